@@ -66,6 +66,10 @@ type Context struct {
 
 	playingPlayers map[*playerImpl]struct{}
 
+	onPlayerStarved func(player *Player)
+
+	adaptiveBuffering bool
+
 	m         sync.Mutex
 	semaphore chan struct{}
 }
@@ -144,9 +148,11 @@ func NewContext(sampleRate int) *Context {
 			}()
 		}
 
-		if err := c.updatePlayers(); err != nil {
+		stats, err := c.updatePlayers()
+		if err != nil {
 			return err
 		}
+		h.SetAudioStats(stats)
 		return nil
 	})
 
@@ -247,7 +253,7 @@ func (c *Context) onResume() error {
 	return nil
 }
 
-func (c *Context) updatePlayers() error {
+func (c *Context) updatePlayers() (hook.AudioStats, error) {
 	// A Context must not call playerImpl's functions with a lock, or this causes a deadlock (#2737).
 	// Copy the playerImpls and iterate them without a lock.
 	var players []*playerImpl
@@ -256,9 +262,12 @@ func (c *Context) updatePlayers() error {
 	for p := range c.playingPlayers {
 		players = append(players, p)
 	}
+	adaptiveBuffering := c.adaptiveBuffering
 	c.m.Unlock()
 
 	var playersToRemove []*playerImpl
+	var starvedPlayers []*Player
+	var stats hook.AudioStats
 
 	// Now reader players cannot call removePlayers from themselves in the current implementation.
 	// Underlying playering can be the pause state after fishing its playing,
@@ -266,21 +275,86 @@ func (c *Context) updatePlayers() error {
 	// Instead, let's check the states proactively every frame.
 	for _, p := range players {
 		if err := p.Err(); err != nil {
-			return err
+			return hook.AudioStats{}, err
 		}
 		p.updatePosition()
 		if !p.IsPlaying() {
 			playersToRemove = append(playersToRemove, p)
+			continue
+		}
+		player, starved := p.checkStarved()
+		if starved && player != nil {
+			starvedPlayers = append(starvedPlayers, player)
+		}
+		p.adaptBufferSize(adaptiveBuffering, starved)
+		d := p.bufferedDuration()
+		if stats.PlayingPlayerCount == 0 || d < stats.MinBufferedDuration {
+			stats.MinBufferedDuration = d
 		}
+		stats.PlayingPlayerCount++
 	}
 
 	c.m.Lock()
 	for _, p := range playersToRemove {
 		delete(c.playingPlayers, p)
 	}
+	onPlayerStarved := c.onPlayerStarved
 	c.m.Unlock()
 
-	return nil
+	for _, p := range playersToRemove {
+		p.releaseOwner()
+	}
+
+	// Call the callback without a lock so that the callback can call any functions of Context and Player.
+	if onPlayerStarved != nil {
+		for _, p := range starvedPlayers {
+			onPlayerStarved(p)
+		}
+	}
+
+	return stats, nil
+}
+
+// OnPlayerStarved sets a callback that is called when a playing player is starved.
+//
+// A player is starved when the player's buffer becomes empty before its source stream ends,
+// e.g. when reading the source is too slow. Then the audio is interrupted until the buffer gets data again.
+// f is called once per starvation with the player, and is not called again until the buffer gets data again.
+//
+// Starvations are detected by polling the players' buffers every tick, and f is called on the game's goroutine
+// before Update. Then, a very short starvation between ticks might not be detected.
+//
+// OnPlayerStarved doesn't report underruns of the platform's audio device, e.g. XRuns of AAudio on Android.
+//
+// If f is nil, the callback is removed.
+func (c *Context) OnPlayerStarved(f func(player *Player)) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.onPlayerStarved = f
+}
+
+// SetAdaptiveBuffering enables or disables the adaptive buffering.
+//
+// With the adaptive buffering, a player's buffer is grown automatically after repeated underruns, and is shrunk
+// again after the player plays stably for a while, but never below the size specified by Player.SetBufferSize
+// or the default size.
+// This trades the latency for the stability depending on the device.
+// The current buffer size can be obtained by Player.BufferSize.
+//
+// When the adaptive buffering is disabled, the players' buffer sizes are restored at the next tick.
+//
+// The adaptive buffering is disabled by default.
+func (c *Context) SetAdaptiveBuffering(enabled bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.adaptiveBuffering = enabled
+}
+
+// IsAdaptiveBuffering reports whether the adaptive buffering is enabled.
+func (c *Context) IsAdaptiveBuffering() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.adaptiveBuffering
 }
 
 // IsReady returns a boolean value indicating whether the audio is ready or not.
@@ -384,7 +458,7 @@ func (p *Player) Close() error {
 
 // Play plays the stream.
 func (p *Player) Play() {
-	p.p.Play()
+	p.p.Play(p)
 }
 
 // IsPlaying returns boolean indicating whether the player is playing.
@@ -456,10 +530,19 @@ func (p *Player) SetBufferSize(bufferSize time.Duration) {
 	p.p.SetBufferSize(bufferSize)
 }
 
+// BufferSize returns the current effective buffer size of this player.
+//
+// BufferSize returns the size specified by SetBufferSize, or the default size if the size is not specified.
+// With the adaptive buffering, the size might be changed automatically. See Context.SetAdaptiveBuffering.
+func (p *Player) BufferSize() time.Duration {
+	return p.p.BufferSize()
+}
+
 type hooker interface {
 	OnSuspendAudio(f func() error)
 	OnResumeAudio(f func() error)
 	AppendHookOnBeforeUpdate(f func() error)
+	SetAudioStats(stats hook.AudioStats)
 }
 
 var hookerForTesting hooker
@@ -485,6 +568,29 @@ func (h *hookerImpl) AppendHookOnBeforeUpdate(f func() error) {
 	hook.AppendHookOnBeforeUpdate(f)
 }
 
+func (h *hookerImpl) SetAudioStats(stats hook.AudioStats) {
+	hook.SetAudioStats(stats)
+}
+
+// ResamplingQuality represents the quality of resampling, which trades CPU usage for fidelity.
+type ResamplingQuality = convert.ResamplingQuality
+
+const (
+	// ResamplingQualityDefault is a windowed sinc interpolation with 17 taps.
+	// This is used when the quality is not specified.
+	ResamplingQualityDefault ResamplingQuality = convert.ResamplingQualityDefault
+
+	// ResamplingQualityLinear is a linear interpolation.
+	// This is roughly 10 times faster than ResamplingQualityDefault, but high frequencies are attenuated
+	// and aliasing might be audible. This is suitable for sound effects.
+	ResamplingQualityLinear ResamplingQuality = convert.ResamplingQualityLinear
+
+	// ResamplingQualityHigh is a windowed sinc interpolation with 65 taps, with a low-pass filter for downsampling
+	// to suppress aliasing.
+	// This costs roughly 3 times as much CPU as ResamplingQualityDefault. This is suitable for music.
+	ResamplingQualityHigh ResamplingQuality = convert.ResamplingQualityHigh
+)
+
 // Resample converts the sample rate of the given stream.
 // size is the length of the source stream in bytes.
 // from is the original sample rate.
@@ -497,3 +603,13 @@ func Resample(source io.ReadSeeker, size int64, from, to int) io.ReadSeeker {
 	}
 	return convert.NewResampling(source, size, from, to)
 }
+
+// ResampleWithQuality converts the sample rate of the given stream with the given quality.
+//
+// ResampleWithQuality is the same as Resample except for the quality.
+func ResampleWithQuality(source io.ReadSeeker, size int64, from, to int, quality ResamplingQuality) io.ReadSeeker {
+	if from == to {
+		return source
+	}
+	return convert.NewResamplingWithQuality(source, size, from, to, quality)
+}
@@ -16,6 +16,7 @@ package audio_test
 
 import (
 	"bytes"
+	"io"
 	"runtime"
 	"testing"
 	"time"
@@ -116,3 +117,104 @@ func TestPauseBeforeInit(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestBufferSize(t *testing.T) {
+	setup()
+	defer teardown()
+
+	p, err := context.NewPlayer(bytes.NewReader(make([]byte, 4)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := p.BufferSize(), time.Second/2; got != want {
+		t.Errorf("BufferSize(): got: %v, want: %v", got, want)
+	}
+
+	p.SetBufferSize(100 * time.Millisecond)
+	if got, want := p.BufferSize(), 100*time.Millisecond; got != want {
+		t.Errorf("BufferSize() after SetBufferSize: got: %v, want: %v", got, want)
+	}
+
+	p.SetBufferSize(0)
+	if got, want := p.BufferSize(), time.Second/2; got != want {
+		t.Errorf("BufferSize() after SetBufferSize(0): got: %v, want: %v", got, want)
+	}
+}
+
+func TestPlayerStarved(t *testing.T) {
+	setup()
+	defer teardown()
+
+	audio.SetReadyForTesting()
+
+	var starved []*audio.Player
+	context.OnPlayerStarved(func(player *audio.Player) {
+		starved = append(starved, player)
+	})
+
+	// Use a pipe so that the source stream doesn't end until the writer is closed.
+	r, w := io.Pipe()
+	p, err := context.NewPlayer(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Play()
+
+	update := func() {
+		t.Helper()
+		if err := audio.UpdateForTesting(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The buffer is empty before being filled first. This is not a starvation.
+	update()
+	if got, want := len(starved), 0; got != want {
+		t.Errorf("len(starved) before buffering: got: %d, want: %d", got, want)
+	}
+
+	p.SetBufferedSizeForTesting(100)
+	update()
+	if got, want := len(starved), 0; got != want {
+		t.Errorf("len(starved) after buffering: got: %d, want: %d", got, want)
+	}
+
+	p.SetBufferedSizeForTesting(0)
+	update()
+	if got, want := len(starved), 1; got != want {
+		t.Fatalf("len(starved) after the buffer becomes empty: got: %d, want: %d", got, want)
+	}
+	if starved[0] != p {
+		t.Errorf("starved[0]: got: %p, want: %p", starved[0], p)
+	}
+
+	// A starvation is reported only once until the buffer gets data again.
+	update()
+	if got, want := len(starved), 1; got != want {
+		t.Errorf("len(starved) while the buffer is empty: got: %d, want: %d", got, want)
+	}
+
+	p.SetBufferedSizeForTesting(100)
+	update()
+	p.SetBufferedSizeForTesting(0)
+	update()
+	if got, want := len(starved), 2; got != want {
+		t.Errorf("len(starved) after the buffer becomes empty again: got: %d, want: %d", got, want)
+	}
+
+	// The empty buffer after the source stream ends is not a starvation.
+	p.SetBufferedSizeForTesting(100)
+	update()
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10 && p.IsPlaying(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	p.SetBufferedSizeForTesting(0)
+	update()
+	if got, want := len(starved), 2; got != want {
+		t.Errorf("len(starved) after the source ends: got: %d, want: %d", got, want)
+	}
+}
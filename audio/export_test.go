@@ -17,15 +17,18 @@ package audio
 import (
 	"io"
 	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
 )
 
 type (
 	dummyContext struct{}
 	dummyPlayer  struct {
-		r       io.Reader
-		playing bool
-		volume  float64
-		m       sync.Mutex
+		r            io.Reader
+		playing      bool
+		volume       float64
+		bufferedSize int
+		m            sync.Mutex
 	}
 )
 
@@ -87,7 +90,9 @@ func (p *dummyPlayer) SetVolume(volume float64) {
 }
 
 func (p *dummyPlayer) BufferedSize() int {
-	return 0
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.bufferedSize
 }
 
 func (p *dummyPlayer) Err() error {
@@ -126,6 +131,9 @@ func (h *dummyHook) AppendHookOnBeforeUpdate(f func() error) {
 	h.updates = append(h.updates, f)
 }
 
+func (h *dummyHook) SetAudioStats(stats hook.AudioStats) {
+}
+
 func init() {
 	hookerForTesting = &dummyHook{}
 }
@@ -154,3 +162,17 @@ func ResetContextForTesting() {
 func (i *InfiniteLoop) SetNoBlendForTesting(value bool) {
 	i.noBlendForTesting = value
 }
+
+func SetReadyForTesting() {
+	CurrentContext().setReady()
+}
+
+func (p *Player) SetBufferedSizeForTesting(size int) {
+	p.p.m.Lock()
+	d := p.p.player.(*dummyPlayer)
+	p.p.m.Unlock()
+
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.bufferedSize = size
+}
@@ -0,0 +1,266 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	stdcontext "context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HTTPStreamOptions represents options for NewHTTPStream.
+type HTTPStreamOptions struct {
+	// Client is the HTTP client to send the request.
+	//
+	// The default (zero) value is nil, which means http.DefaultClient is used.
+	Client *http.Client
+
+	// PrebufferDuration is the duration of the decoded data to buffer before the playback starts or restarts.
+	//
+	// The default (zero) value is 2 seconds.
+	PrebufferDuration time.Duration
+
+	// MaxBufferDuration is the maximum duration of the decoded data to buffer ahead.
+	// If MaxBufferDuration is less than PrebufferDuration, PrebufferDuration is used instead.
+	//
+	// The default (zero) value is 10 seconds.
+	MaxBufferDuration time.Duration
+}
+
+const (
+	defaultPrebufferDuration = 2 * time.Second
+	defaultMaxBufferDuration = 10 * time.Second
+)
+
+// HTTPStream is a stream of decoded audio data from an HTTP source, which can be played by a Player.
+//
+// HTTPStream downloads and decodes the data on another goroutine and keeps the decoded data in a buffer.
+// When the buffer runs out, e.g. due to a network stall, HTTPStream produces silence and rebuffers the data
+// until the prebuffer duration is filled, instead of blocking or returning an error.
+// Then, the player keeps playing without an interruption by an error, and the playback resumes after rebuffering.
+//
+// HTTPStream is not seekable. The Player's SetPosition and Rewind don't work with HTTPStream.
+type HTTPStream struct {
+	prebuffer int
+	maxBuffer int
+
+	buf       []byte
+	buffering bool
+	eof       bool
+	err       error
+	closed    bool
+
+	body   io.Closer
+	cancel func()
+	cond   *sync.Cond
+	m      sync.Mutex
+}
+
+// NewHTTPStream sends a GET request to the URL, and returns a stream of the decoded data.
+//
+// decode is a function to decode the response body, e.g. a function calling mp3.DecodeWithSampleRate with
+// the context's sample rate. The decoded data must be in the same format as the data for NewPlayer,
+// i.e. 16bit little endian, 2 channels (stereo), and the context's sample rate.
+//
+// NewHTTPStream blocks until the response header is received and decode returns.
+// On browsers, it is recommended to call NewHTTPStream on a goroutine other than the game's goroutine.
+// On browsers, the request is sent with the Fetch API, and the response body is read as a stream
+// without waiting for the whole data.
+//
+// If the response status is not 200 OK, NewHTTPStream returns an error.
+// An error after the playback starts, e.g. a disconnection, ends the stream after the buffered data is played,
+// and the error is reported by Err.
+//
+// The request is canceled when ctx is canceled or Close is called.
+//
+// options can be nil.
+func NewHTTPStream(ctx stdcontext.Context, context *Context, url string, decode func(r io.Reader) (io.Reader, error), options *HTTPStreamOptions) (*HTTPStream, error) {
+	if options == nil {
+		options = &HTTPStreamOptions{}
+	}
+
+	prebufferDuration := options.PrebufferDuration
+	if prebufferDuration <= 0 {
+		prebufferDuration = defaultPrebufferDuration
+	}
+	maxBufferDuration := options.MaxBufferDuration
+	if maxBufferDuration <= 0 {
+		maxBufferDuration = defaultMaxBufferDuration
+	}
+	if maxBufferDuration < prebufferDuration {
+		maxBufferDuration = prebufferDuration
+	}
+
+	ctx, cancel := stdcontext.WithCancel(ctx)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	client := options.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		_ = res.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("audio: unexpected HTTP status for %s: %s", url, res.Status)
+	}
+
+	decoded, err := decode(res.Body)
+	if err != nil {
+		_ = res.Body.Close()
+		cancel()
+		return nil, err
+	}
+
+	bytesPerSecond := context.SampleRate() * bytesPerSampleInt16
+	s := &HTTPStream{
+		prebuffer: bytesForDuration(prebufferDuration, bytesPerSecond),
+		maxBuffer: bytesForDuration(maxBufferDuration, bytesPerSecond),
+		buffering: true,
+		body:      res.Body,
+		cancel:    cancel,
+	}
+	s.cond = sync.NewCond(&s.m)
+	go s.loop(decoded)
+	return s, nil
+}
+
+func bytesForDuration(duration time.Duration, bytesPerSecond int) int {
+	n := int(int64(duration) * int64(bytesPerSecond) / int64(time.Second))
+	// Align the size to the frame size.
+	return n / bytesPerSampleInt16 * bytesPerSampleInt16
+}
+
+func (s *HTTPStream) loop(decoded io.Reader) {
+	buf := make([]byte, 16384)
+	for {
+		n, err := decoded.Read(buf)
+
+		s.m.Lock()
+		if s.closed {
+			s.m.Unlock()
+			return
+		}
+		s.buf = append(s.buf, buf[:n]...)
+		if len(s.buf) >= s.prebuffer {
+			s.buffering = false
+		}
+		if err != nil {
+			if err != io.EOF {
+				s.err = err
+			}
+			s.eof = true
+			s.buffering = false
+			s.m.Unlock()
+			return
+		}
+		// Wait until the buffer has a space.
+		for len(s.buf) >= s.maxBuffer && !s.closed {
+			s.cond.Wait()
+		}
+		s.m.Unlock()
+	}
+}
+
+// Read implements io.Reader.
+//
+// While buffering, Read fills buf with silence.
+// Read returns io.EOF after all the data is read, or after Close is called.
+func (s *HTTPStream) Read(buf []byte) (int, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.closed {
+		return 0, io.EOF
+	}
+	if s.eof && len(s.buf) == 0 {
+		return 0, io.EOF
+	}
+
+	if !s.eof && len(s.buf) < bytesPerSampleInt16 {
+		// The buffer runs out. Rebuffer the data.
+		s.buffering = true
+	}
+	if s.buffering {
+		n := len(buf) / bytesPerSampleInt16 * bytesPerSampleInt16
+		for i := range buf[:n] {
+			buf[i] = 0
+		}
+		return n, nil
+	}
+
+	n := len(s.buf)
+	if n > len(buf) {
+		n = len(buf)
+	}
+	// Keep the frames aligned so that silence can be inserted later.
+	if !s.eof {
+		n = n / bytesPerSampleInt16 * bytesPerSampleInt16
+	}
+	copy(buf, s.buf[:n])
+	s.buf = s.buf[n:]
+	s.cond.Broadcast()
+	return n, nil
+}
+
+// IsBuffering reports whether the stream is buffering the data, and produces silence.
+// This is useful to show an indicator to users.
+func (s *HTTPStream) IsBuffering() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.buffering
+}
+
+// BufferedDuration returns the duration of the buffered data that is not read yet.
+func (s *HTTPStream) BufferedDuration(context *Context) time.Duration {
+	s.m.Lock()
+	defer s.m.Unlock()
+	bytesPerSecond := context.SampleRate() * bytesPerSampleInt16
+	return time.Duration(int64(len(s.buf)) * int64(time.Second) / int64(bytesPerSecond))
+}
+
+// Err returns the error that ended the stream, e.g. a disconnection.
+// Err returns nil while the stream is active or when the stream ends successfully.
+func (s *HTTPStream) Err() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.err
+}
+
+// Close cancels the request and closes the stream.
+func (s *HTTPStream) Close() error {
+	s.m.Lock()
+	if s.closed {
+		s.m.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.buf = nil
+	s.cond.Broadcast()
+	s.m.Unlock()
+
+	s.cancel()
+	return s.body.Close()
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio_test
+
+import (
+	"bytes"
+	stdcontext "context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+func TestHTTPStream(t *testing.T) {
+	setup()
+	defer teardown()
+
+	src := make([]byte, 44100*4)
+	for i := range src {
+		src[i] = byte(i)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(src)
+	}))
+	defer server.Close()
+
+	decode := func(r io.Reader) (io.Reader, error) {
+		return r, nil
+	}
+	s, err := audio.NewHTTPStream(stdcontext.Background(), context, server.URL, decode, &audio.HTTPStreamOptions{
+		PrebufferDuration: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = s.Close()
+	}()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for s.IsBuffering() {
+		if time.Now().After(deadline) {
+			t.Fatal("buffering didn't finish")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	var got []byte
+	buf := make([]byte, 4096)
+	for {
+		if time.Now().After(deadline) {
+			t.Fatal("reading didn't finish")
+		}
+		if s.IsBuffering() {
+			time.Sleep(time.Millisecond)
+			continue
+		}
+		n, err := s.Read(buf)
+		got = append(got, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.Equal(got, src) {
+		t.Errorf("got %d bytes, want %d bytes matching the source", len(got), len(src))
+	}
+	if err := s.Err(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHTTPStreamStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	decode := func(r io.Reader) (io.Reader, error) {
+		return r, nil
+	}
+	if _, err := audio.NewHTTPStream(stdcontext.Background(), context, server.URL, decode, nil); err == nil {
+		t.Error("NewHTTPStream must return an error for 404")
+	}
+}
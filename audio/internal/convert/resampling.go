@@ -77,6 +77,20 @@ func sinc01(x float64) float64 {
 	return fastSin01(x) / (x * 2 * math.Pi)
 }
 
+// ResamplingQuality represents the algorithm of resampling.
+type ResamplingQuality int
+
+const (
+	// ResamplingQualityDefault is a windowed sinc interpolation with 17 taps.
+	ResamplingQualityDefault ResamplingQuality = iota
+
+	// ResamplingQualityLinear is a linear interpolation with 2 taps.
+	ResamplingQualityLinear
+
+	// ResamplingQualityHigh is a windowed sinc interpolation with 65 taps and a low-pass filter for downsampling.
+	ResamplingQualityHigh
+)
+
 type Resampling struct {
 	source       io.ReadSeeker
 	size         int64
@@ -87,9 +101,14 @@ type Resampling struct {
 	srcBufL      map[int64][]float64
 	srcBufR      map[int64][]float64
 	lruSrcBlocks []int64
+	quality      ResamplingQuality
 }
 
 func NewResampling(source io.ReadSeeker, size int64, from, to int) *Resampling {
+	return NewResamplingWithQuality(source, size, from, to, ResamplingQualityDefault)
+}
+
+func NewResamplingWithQuality(source io.ReadSeeker, size int64, from, to int, quality ResamplingQuality) *Resampling {
 	r := &Resampling{
 		source:   source,
 		size:     size,
@@ -98,6 +117,7 @@ func NewResampling(source io.ReadSeeker, size int64, from, to int) *Resampling {
 		srcBlock: -1,
 		srcBufL:  map[int64][]float64{},
 		srcBufR:  map[int64][]float64{},
+		quality:  quality,
 	}
 	return r
 }
@@ -174,7 +194,20 @@ func (r *Resampling) src(i int64) (float64, float64, error) {
 }
 
 func (r *Resampling) at(t int64) (float64, float64, error) {
+	if r.quality == ResamplingQualityLinear {
+		return r.atLinear(t)
+	}
+
 	windowSize := 8.0
+	// cutoff is the cutoff frequency relative to the source's Nyquist frequency.
+	cutoff := 1.0
+	if r.quality == ResamplingQualityHigh {
+		windowSize = 32
+		// Cut the frequencies that the destination cannot represent to avoid aliasing.
+		if r.to < r.from {
+			cutoff = float64(r.to) / float64(r.from)
+		}
+	}
 	tInSrc := float64(t) * float64(r.from) / float64(r.to)
 	startN := int64(tInSrc - windowSize)
 	if startN < 0 {
@@ -196,7 +229,7 @@ func (r *Resampling) at(t int64) (float64, float64, error) {
 		}
 		d := tInSrc - float64(n)
 		w := 0.5 + 0.5*fastCos01(d/(windowSize*2+1))
-		s := sinc01(d/2) * w
+		s := cutoff * sinc01(cutoff*d/2) * w
 		lv += srcL * s
 		rv += srcR * s
 	}
@@ -215,6 +248,26 @@ func (r *Resampling) at(t int64) (float64, float64, error) {
 	return lv, rv, nil
 }
 
+func (r *Resampling) atLinear(t int64) (float64, float64, error) {
+	tInSrc := float64(t) * float64(r.from) / float64(r.to)
+	n := int64(tInSrc)
+	rate := tInSrc - float64(n)
+
+	l0, r0, err := r.src(n)
+	if err != nil {
+		return 0, 0, err
+	}
+	// If the next sample is out of range, src returns 0. Use the last sample instead not to fade out.
+	l1, r1 := l0, r0
+	if n+1 < r.size/4 {
+		l1, r1, err = r.src(n + 1)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return l0*(1-rate) + l1*rate, r0*(1-rate) + r1*rate, nil
+}
+
 func (r *Resampling) Read(b []byte) (int, error) {
 	if r.pos == r.Length() {
 		return 0, io.EOF
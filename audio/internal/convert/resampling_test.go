@@ -55,34 +55,62 @@ func newSoundBytes(sampleRate int) []byte {
 
 func TestResampling(t *testing.T) {
 	cases := []struct {
-		In  int
-		Out int
+		In      int
+		Out     int
+		Quality convert.ResamplingQuality
 	}{
 		{
-			In:  44100,
-			Out: 48000,
+			In:      44100,
+			Out:     48000,
+			Quality: convert.ResamplingQualityDefault,
 		},
 		{
-			In:  48000,
-			Out: 44100,
+			In:      48000,
+			Out:     44100,
+			Quality: convert.ResamplingQualityDefault,
+		},
+		{
+			In:      44100,
+			Out:     48000,
+			Quality: convert.ResamplingQualityLinear,
+		},
+		{
+			In:      48000,
+			Out:     44100,
+			Quality: convert.ResamplingQualityLinear,
+		},
+		{
+			In:      44100,
+			Out:     48000,
+			Quality: convert.ResamplingQualityHigh,
+		},
+		{
+			In:      48000,
+			Out:     44100,
+			Quality: convert.ResamplingQualityHigh,
 		},
 	}
 	for _, c := range cases {
 		inB := newSoundBytes(c.In)
-		outS := convert.NewResampling(bytes.NewReader(inB), int64(len(inB)), c.In, c.Out)
+		outS := convert.NewResamplingWithQuality(bytes.NewReader(inB), int64(len(inB)), c.In, c.Out, c.Quality)
 		gotB, err := io.ReadAll(outS)
 		if err != nil {
 			t.Fatal(err)
 		}
 		wantB := newSoundBytes(c.Out)
+		tolerance := 0.025
+		if c.Quality == convert.ResamplingQualityLinear {
+			// The linear interpolation is less accurate especially for high frequencies.
+			tolerance = 0.05
+		}
 		if len(gotB) != len(wantB) {
 			t.Errorf("len(gotB) == %d but len(wantB) == %d", len(gotB), len(wantB))
 		}
 		for i := 0; i < len(gotB)/2; i++ {
 			got := float64(int16(gotB[2*i])|(int16(gotB[2*i+1])<<8)) / (1<<15 - 1)
 			want := float64(int16(wantB[2*i])|(int16(wantB[2*i+1])<<8)) / (1<<15 - 1)
-			if math.Abs(got-want) > 0.025 {
-				t.Errorf("sample rate: %d, index: %d: got: %f, want: %f", c.Out, i, got, want)
+			if math.Abs(got-want) > tolerance {
+				t.Errorf("sample rate: %d, quality: %d, index: %d: got: %f, want: %f", c.Out, c.Quality, i, got, want)
 			}
 		}
 	}
@@ -43,6 +43,20 @@ type context interface {
 	Err() error
 }
 
+const (
+	// defaultBufferSize is the default buffer size of a player, which is the same as the underlying driver's.
+	defaultBufferSize = time.Second / 2
+
+	// maxAdaptiveBufferSize is the maximum buffer size that the adaptive buffering grows a buffer to.
+	maxAdaptiveBufferSize = 2 * time.Second
+
+	// underrunCountToGrowBuffer is the number of underruns to grow a buffer with the adaptive buffering.
+	underrunCountToGrowBuffer = 2
+
+	// stableDurationToShrinkBuffer is the duration without underruns to shrink a buffer with the adaptive buffering.
+	stableDurationToShrinkBuffer = 30 * time.Second
+)
+
 type playerFactory struct {
 	context    context
 	sampleRate int
@@ -63,12 +77,25 @@ func newPlayerFactory(sampleRate int) *playerFactory {
 }
 
 type playerImpl struct {
-	context        *Context
-	player         player
-	src            io.Reader
-	stream         *timeStream
-	factory        *playerFactory
-	initBufferSize int
+	context *Context
+	player  player
+	src     io.Reader
+	stream  *timeStream
+	factory *playerFactory
+
+	// bufferSize is the buffer size in bytes specified by SetBufferSize.
+	// 0 means the default size.
+	bufferSize int
+
+	// adaptiveBufferSize is the buffer size in bytes adjusted by the adaptive buffering.
+	// 0 means the buffer size is not adjusted.
+	adaptiveBufferSize int
+
+	// underrunCount is the number of the underruns since the buffer size was adjusted last time.
+	underrunCount int
+
+	// stableSince is the time when the last underrun happened or the buffer size was adjusted last time.
+	stableSince time.Time
 
 	// adjustedPosition is the player's more accurate position.
 	// The underlying buffer might not be changed even if the player is playing.
@@ -82,6 +109,16 @@ type playerImpl struct {
 	// stopwatch is a stopwatch to measure the time duration during the player position doesn't change while its playing.
 	stopwatch stopwatch
 
+	// owner is the Player wrapping this playerImpl.
+	// owner is held only while the player is playing, in order not to prevent the Player from being GCed.
+	owner *Player
+
+	// buffered reports whether the underlying buffer has had data since the player started playing or seeked.
+	buffered bool
+
+	// starved reports whether the underlying buffer is currently starved.
+	starved bool
+
 	m sync.Mutex
 }
 
@@ -171,15 +208,14 @@ func (p *playerImpl) ensurePlayer() error {
 	}
 	if p.player == nil {
 		p.player = p.factory.context.NewPlayer(p.stream)
-		if p.initBufferSize != 0 {
-			p.player.SetBufferSize(p.initBufferSize)
-			p.initBufferSize = 0
+		if p.bufferSize != 0 {
+			p.player.SetBufferSize(p.bufferSize)
 		}
 	}
 	return nil
 }
 
-func (p *playerImpl) Play() {
+func (p *playerImpl) Play(owner *Player) {
 	p.m.Lock()
 	defer p.m.Unlock()
 
@@ -190,6 +226,9 @@ func (p *playerImpl) Play() {
 	if p.player.IsPlaying() {
 		return
 	}
+	p.owner = owner
+	p.buffered = false
+	p.starved = false
 	p.player.Play()
 	p.context.addPlayingPlayer(p)
 	p.stopwatch.start()
@@ -209,6 +248,7 @@ func (p *playerImpl) Pause() {
 	p.player.Pause()
 	p.context.removePlayingPlayer(p)
 	p.stopwatch.stop()
+	p.owner = nil
 }
 
 func (p *playerImpl) IsPlaying() bool {
@@ -257,6 +297,7 @@ func (p *playerImpl) Close() error {
 		}()
 		p.player.Pause()
 		p.stopwatch.stop()
+		p.owner = nil
 		return p.player.Close()
 	}
 	return nil
@@ -290,6 +331,8 @@ func (p *playerImpl) SetPosition(offset time.Duration) error {
 		return err
 	}
 	p.lastSamples = -1
+	p.buffered = false
+	p.starved = false
 	// Just after setting a position, the buffer size should be 0 as no data is sent.
 	p.adjustedPosition = p.stream.positionInTimeDuration()
 	p.stopwatch.reset()
@@ -313,13 +356,118 @@ func (p *playerImpl) SetBufferSize(bufferSize time.Duration) {
 	p.m.Lock()
 	defer p.m.Unlock()
 
-	bufferSizeInBytes := int(bufferSize * bytesPerSampleInt16 * time.Duration(p.factory.sampleRate) / time.Second)
-	bufferSizeInBytes = bufferSizeInBytes / bytesPerSampleInt16 * bytesPerSampleInt16
+	p.bufferSize = p.durationToBytes(bufferSize)
+	p.adaptiveBufferSize = 0
+	p.underrunCount = 0
+	p.stableSince = time.Time{}
 	if p.player == nil {
-		p.initBufferSize = bufferSizeInBytes
 		return
 	}
-	p.player.SetBufferSize(bufferSizeInBytes)
+	p.player.SetBufferSize(p.bufferSize)
+}
+
+// BufferSize returns the current effective buffer size.
+func (p *playerImpl) BufferSize() time.Duration {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	return p.bytesToDuration(p.effectiveBufferSize())
+}
+
+func (p *playerImpl) durationToBytes(d time.Duration) int {
+	bytes := int(d * bytesPerSampleInt16 * time.Duration(p.factory.sampleRate) / time.Second)
+	return bytes / bytesPerSampleInt16 * bytesPerSampleInt16
+}
+
+func (p *playerImpl) bytesToDuration(bytes int) time.Duration {
+	samples := int64(bytes) / bytesPerSampleInt16
+	return time.Duration(samples) * time.Second / time.Duration(p.factory.sampleRate)
+}
+
+// baseBufferSize returns the buffer size in bytes without the adaptive buffering.
+func (p *playerImpl) baseBufferSize() int {
+	if p.bufferSize != 0 {
+		return p.bufferSize
+	}
+	return p.durationToBytes(defaultBufferSize)
+}
+
+func (p *playerImpl) effectiveBufferSize() int {
+	if p.adaptiveBufferSize != 0 {
+		return p.adaptiveBufferSize
+	}
+	return p.baseBufferSize()
+}
+
+// adaptBufferSize adjusts the buffer size by the underruns.
+// underrun reports whether a new underrun is detected in this tick.
+//
+// The buffer size is doubled after underrunCountToGrowBuffer underruns up to maxAdaptiveBufferSize,
+// and is halved after stableDurationToShrinkBuffer without underruns down to the base size.
+// If enabled is false, the buffer size is restored to the base size.
+func (p *playerImpl) adaptBufferSize(enabled bool, underrun bool) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.player == nil {
+		return
+	}
+
+	if !enabled {
+		if p.adaptiveBufferSize != 0 {
+			p.adaptiveBufferSize = 0
+			p.player.SetBufferSize(p.bufferSize)
+		}
+		p.underrunCount = 0
+		p.stableSince = time.Time{}
+		return
+	}
+
+	now := time.Now()
+	if p.stableSince.IsZero() {
+		p.stableSince = now
+	}
+
+	current := p.effectiveBufferSize()
+
+	if underrun {
+		p.stableSince = now
+		p.underrunCount++
+		if p.underrunCount < underrunCountToGrowBuffer {
+			return
+		}
+		p.underrunCount = 0
+
+		max := p.durationToBytes(maxAdaptiveBufferSize)
+		if current >= max {
+			return
+		}
+		size := 2 * current
+		if size > max {
+			size = max
+		}
+		p.adaptiveBufferSize = size
+		p.player.SetBufferSize(size)
+		return
+	}
+
+	if now.Sub(p.stableSince) < stableDurationToShrinkBuffer {
+		return
+	}
+	p.stableSince = now
+	p.underrunCount = 0
+
+	if p.adaptiveBufferSize == 0 {
+		return
+	}
+	size := current / 2 / bytesPerSampleInt16 * bytesPerSampleInt16
+	if size <= p.baseBufferSize() {
+		p.adaptiveBufferSize = 0
+		p.player.SetBufferSize(p.bufferSize)
+		return
+	}
+	p.adaptiveBufferSize = size
+	p.player.SetBufferSize(size)
 }
 
 func (p *playerImpl) source() io.Reader {
@@ -380,10 +528,61 @@ func (p *playerImpl) updatePosition() {
 	p.adjustedPosition = time.Duration(samples)*time.Second/time.Duration(p.factory.sampleRate) + adjustingTime
 }
 
+// bufferedDuration returns the duration of the data buffered in the underlying player.
+func (p *playerImpl) bufferedDuration() time.Duration {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.player == nil {
+		return 0
+	}
+	return p.bytesToDuration(p.player.BufferedSize())
+}
+
+// releaseOwner releases the reference to the Player after the player is removed from the playing players.
+func (p *playerImpl) releaseOwner() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	// The player might be played again after being removed.
+	if p.isPlaying() {
+		return
+	}
+	p.owner = nil
+}
+
+// checkStarved reports whether the underlying buffer has newly become empty while the source stream is not finished yet.
+// checkStarved also returns the Player wrapping this playerImpl.
+//
+// A starvation is reported only once until the buffer gets data again.
+func (p *playerImpl) checkStarved() (*Player, bool) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.player == nil || !p.player.IsPlaying() || !p.context.IsReady() {
+		return nil, false
+	}
+	if p.stream.isEOF() {
+		return nil, false
+	}
+	if p.player.BufferedSize() > 0 {
+		p.buffered = true
+		p.starved = false
+		return nil, false
+	}
+	// Before the buffer is filled first, the buffer is empty as expected.
+	if !p.buffered || p.starved {
+		return nil, false
+	}
+	p.starved = true
+	return p.owner, true
+}
+
 type timeStream struct {
 	r          io.Reader
 	sampleRate int
 	pos        int64
+	eof        bool
 
 	// m is a mutex for this stream.
 	// All the exported functions are protected by this mutex as Read can be read from a different goroutine than Seek.
@@ -412,6 +611,9 @@ func (s *timeStream) Read(buf []byte) (int, error) {
 
 	n, err := s.r.Read(buf)
 	s.pos += int64(n)
+	if err == io.EOF {
+		s.eof = true
+	}
 	return n, err
 }
 
@@ -430,6 +632,7 @@ func (s *timeStream) Seek(offset int64, whence int) (int64, error) {
 	}
 
 	s.pos = pos
+	s.eof = false
 	return pos, nil
 }
 
@@ -453,6 +656,13 @@ func (s *timeStream) position() int64 {
 	return s.pos
 }
 
+func (s *timeStream) isEOF() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.eof
+}
+
 func (s *timeStream) positionInTimeDuration() time.Duration {
 	s.m.Lock()
 	defer s.m.Unlock()
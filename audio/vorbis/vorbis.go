@@ -16,8 +16,10 @@
 package vorbis
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"io/fs"
 
 	"github.com/jfreymuth/oggvorbis"
 
@@ -188,6 +190,14 @@ func DecodeWithoutResampling(src io.Reader) (*Stream, error) {
 // Resampling can be a very heavy task. Stream has a cache for resampling, but the size is limited.
 // Do not expect that Stream has a resampling cache even after whole data is played.
 func DecodeWithSampleRate(sampleRate int, src io.Reader) (*Stream, error) {
+	return DecodeWithSampleRateAndQuality(sampleRate, src, audio.ResamplingQualityDefault)
+}
+
+// DecodeWithSampleRateAndQuality decodes Ogg/Vorbis data to playable stream with the given resampling quality.
+//
+// DecodeWithSampleRateAndQuality is the same as DecodeWithSampleRate except for the resampling quality.
+// The quality is used only when the stream is resampled. See audio.ResamplingQuality for the CPU cost of each quality.
+func DecodeWithSampleRateAndQuality(sampleRate int, src io.Reader, quality audio.ResamplingQuality) (*Stream, error) {
 	i16Stream, channelCount, origSampleRate, err := decode(src)
 	if err != nil {
 		return nil, err
@@ -200,7 +210,7 @@ func DecodeWithSampleRate(sampleRate int, src io.Reader) (*Stream, error) {
 		length *= 2
 	}
 	if origSampleRate != sampleRate {
-		r := convert.NewResampling(s, length, origSampleRate, sampleRate)
+		r := convert.NewResamplingWithQuality(s, length, origSampleRate, sampleRate, quality)
 		s = r
 		length = r.Length()
 	}
@@ -227,3 +237,22 @@ func DecodeWithSampleRate(sampleRate int, src io.Reader) (*Stream, error) {
 func Decode(context *audio.Context, src io.Reader) (*Stream, error) {
 	return DecodeWithSampleRate(context.SampleRate(), src)
 }
+
+// DecodeFSWithSampleRate decodes the Ogg/Vorbis file name in fsys to a playable stream.
+//
+// fsys can be an embed.FS, which works on any environments including browsers and mobiles.
+// The whole file is read into memory, so the returned Stream's Seek is always available.
+// The returned error includes name.
+//
+// DecodeFSWithSampleRate automatically resamples the stream to fit with sampleRate if necessary.
+func DecodeFSWithSampleRate(sampleRate int, fsys fs.FS, name string) (*Stream, error) {
+	bs, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("vorbis: reading %s failed: %w", name, err)
+	}
+	s, err := DecodeWithSampleRate(sampleRate, bytes.NewReader(bs))
+	if err != nil {
+		return nil, fmt.Errorf("vorbis: decoding %s failed: %w", name, err)
+	}
+	return s, nil
+}
@@ -18,7 +18,9 @@ import (
 	"bytes"
 	_ "embed"
 	"io"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/jfreymuth/oggvorbis"
 
@@ -103,3 +105,25 @@ func TestNonSeeker(t *testing.T) {
 		t.Errorf("s.SampleRate(): got: %d, want: %d", got, want)
 	}
 }
+
+func TestDecodeFSWithSampleRate(t *testing.T) {
+	fsys := fstest.MapFS{
+		"test_mono.ogg": &fstest.MapFile{Data: test_mono_ogg},
+	}
+
+	s, err := vorbis.DecodeFSWithSampleRate(audioContext.SampleRate(), fsys, "test_mono.ogg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, err := vorbis.DecodeWithSampleRate(audioContext.SampleRate(), bytes.NewReader(test_mono_ogg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Length(), s2.Length(); got != want {
+		t.Errorf("s.Length(): got: %d, want: %d", got, want)
+	}
+
+	if _, err := vorbis.DecodeFSWithSampleRate(audioContext.SampleRate(), fsys, "missing.ogg"); err == nil || !strings.Contains(err.Error(), "missing.ogg") {
+		t.Errorf("got: %v, want: an error including the file name", err)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/fs"
 
 	"github.com/hajimehoshi/ebiten/v2/audio"
 	"github.com/hajimehoshi/ebiten/v2/audio/internal/convert"
@@ -144,6 +145,14 @@ func DecodeWithoutResampling(src io.Reader) (*Stream, error) {
 // Resampling can be a very heavy task. Stream has a cache for resampling, but the size is limited.
 // Do not expect that Stream has a resampling cache even after whole data is played.
 func DecodeWithSampleRate(sampleRate int, src io.Reader) (*Stream, error) {
+	return DecodeWithSampleRateAndQuality(sampleRate, src, audio.ResamplingQualityDefault)
+}
+
+// DecodeWithSampleRateAndQuality decodes WAV (RIFF) data to playable stream with the given resampling quality.
+//
+// DecodeWithSampleRateAndQuality is the same as DecodeWithSampleRate except for the resampling quality.
+// The quality is used only when the stream is resampled. See audio.ResamplingQuality for the CPU cost of each quality.
+func DecodeWithSampleRateAndQuality(sampleRate int, src io.Reader, quality audio.ResamplingQuality) (*Stream, error) {
 	s, err := decode(src)
 	if err != nil {
 		return nil, err
@@ -153,7 +162,7 @@ func DecodeWithSampleRate(sampleRate int, src io.Reader) (*Stream, error) {
 		return s, nil
 	}
 
-	r := convert.NewResampling(s.inner, s.size, s.sampleRate, sampleRate)
+	r := convert.NewResamplingWithQuality(s.inner, s.size, s.sampleRate, sampleRate, quality)
 	return &Stream{
 		inner:      r,
 		size:       r.Length(),
@@ -284,3 +293,22 @@ chunks:
 func Decode(context *audio.Context, src io.Reader) (*Stream, error) {
 	return DecodeWithSampleRate(context.SampleRate(), src)
 }
+
+// DecodeFSWithSampleRate decodes the WAV file name in fsys to a playable stream.
+//
+// fsys can be an embed.FS, which works on any environments including browsers and mobiles.
+// The whole file is read into memory, so the returned Stream's Seek is always available.
+// The returned error includes name.
+//
+// DecodeFSWithSampleRate automatically resamples the stream to fit with sampleRate if necessary.
+func DecodeFSWithSampleRate(sampleRate int, fsys fs.FS, name string) (*Stream, error) {
+	bs, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("wav: reading %s failed: %w", name, err)
+	}
+	s, err := DecodeWithSampleRate(sampleRate, bytes.NewReader(bs))
+	if err != nil {
+		return nil, fmt.Errorf("wav: decoding %s failed: %w", name, err)
+	}
+	return s, nil
+}
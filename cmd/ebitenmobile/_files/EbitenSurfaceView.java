@@ -15,6 +15,7 @@
 package {{.JavaPkg}}.{{.PrefixLower}};
 
 import android.content.Context;
+import android.graphics.PixelFormat;
 import android.opengl.GLSurfaceView;
 import android.os.Handler;
 import android.os.Looper;
@@ -79,17 +80,27 @@ class EbitenSurfaceView extends GLSurfaceView implements RenderRequester {
 
     public EbitenSurfaceView(Context context) {
         super(context);
-        initialize();
+        initialize(false);
     }
 
     public EbitenSurfaceView(Context context, AttributeSet attrs) {
         super(context, attrs);
-        initialize();
+        initialize(false);
     }
 
-    private void initialize() {
+    public EbitenSurfaceView(Context context, boolean transparent) {
+        super(context);
+        initialize(transparent);
+    }
+
+    private void initialize(boolean transparent) {
         setEGLContextClientVersion(3);
         setEGLConfigChooser(8, 8, 8, 8, 0, 0);
+        if (transparent) {
+            // A translucent surface must be on top of the window to be composited with the views behind it.
+            getHolder().setFormat(PixelFormat.TRANSLUCENT);
+            setZOrderOnTop(true);
+        }
         setRenderer(new EbitenRenderer());
         setPreserveEGLContextOnPause(true);
         Ebitenmobileview.setRenderRequester(this);
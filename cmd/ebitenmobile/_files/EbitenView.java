@@ -19,8 +19,16 @@ import java.util.Collections;
 import java.util.Comparator;
 import java.util.List;
 
+import android.content.ComponentCallbacks2;
 import android.content.Context;
+import android.content.Intent;
+import android.content.res.Configuration;
+import android.graphics.Rect;
+import android.hardware.display.DisplayManager;
 import android.hardware.input.InputManager;
+import android.net.Uri;
+import android.os.Build;
+import android.os.Bundle;
 import android.os.Handler;
 import android.os.Looper;
 import android.util.AttributeSet;
@@ -30,12 +38,20 @@ import android.view.Display;
 import android.view.KeyEvent;
 import android.view.InputDevice;
 import android.view.MotionEvent;
+import android.view.View;
 import android.view.ViewGroup;
+import android.view.ViewTreeObserver;
+import android.view.WindowInsets;
+import android.view.WindowInsetsController;
 import android.view.WindowManager;
+import android.window.OnBackInvokedCallback;
+import android.window.OnBackInvokedDispatcher;
 
+import {{.JavaPkg}}.ebitenmobileview.BackButtonController;
 import {{.JavaPkg}}.ebitenmobileview.Ebitenmobileview;
+import {{.JavaPkg}}.ebitenmobileview.SystemBarsController;
 
-public class EbitenView extends ViewGroup implements InputManager.InputDeviceListener {
+public class EbitenView extends ViewGroup implements InputManager.InputDeviceListener, SystemBarsController, BackButtonController {
     static class Gamepad {
         public int deviceId;
         public ArrayList<InputDevice.MotionRange> axes;
@@ -102,18 +118,36 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
 
     public EbitenView(Context context) {
         super(context);
-        initialize(context);
+        initialize(context, isTransparent());
     }
 
     public EbitenView(Context context, AttributeSet attrs) {
         super(context, attrs);
-        initialize(context);
+        initialize(context, isTransparent());
     }
 
-    private void initialize(Context context) {
+    // EbitenView with transparent true has a translucent surface, and the views behind it are visible
+    // where the game renders transparent pixels.
+    // The game must be set with ebiten.RunGameOptions' ScreenTransparent true as well.
+    //
+    // The translucent surface is placed on top of the window, so views over this view are hidden.
+    public EbitenView(Context context, boolean transparent) {
+        super(context);
+        initialize(context, transparent);
+    }
+
+    // isTransparent reports whether the view has a translucent surface when the view is created without
+    // the transparent argument, e.g. from a layout XML.
+    // You can make such a view transparent by overriding this method to return true.
+    // Note that this is called from the constructors, so this must not depend on the subclass's fields.
+    protected boolean isTransparent() {
+        return false;
+    }
+
+    private void initialize(Context context, boolean transparent) {
         this.gamepads = new ArrayList<Gamepad>();
 
-        this.ebitenSurfaceView = new EbitenSurfaceView(getContext());
+        this.ebitenSurfaceView = new EbitenSurfaceView(getContext(), transparent);
         LayoutParams params = new LayoutParams(LayoutParams.MATCH_PARENT, LayoutParams.MATCH_PARENT);
         addView(this.ebitenSurfaceView, params);
 
@@ -122,6 +156,272 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
         for (int id : this.inputManager.getInputDeviceIds()) {
             this.onInputDeviceAdded(id);
         }
+
+        this.displayManager = (DisplayManager)context.getSystemService(Context.DISPLAY_SERVICE);
+        this.displayManager.registerDisplayListener(new DisplayManager.DisplayListener() {
+            @Override
+            public void onDisplayAdded(int displayId) {
+                updateExternalDisplay();
+            }
+
+            @Override
+            public void onDisplayChanged(int displayId) {
+                updateExternalDisplay();
+            }
+
+            @Override
+            public void onDisplayRemoved(int displayId) {
+                updateExternalDisplay();
+            }
+        }, null);
+        updateExternalDisplay();
+
+        context.registerComponentCallbacks(new ComponentCallbacks2() {
+            @Override
+            public void onTrimMemory(int level) {
+                // TRIM_MEMORY_UI_HIDDEN just means the UI is hidden, and the memory is not low.
+                if (level == ComponentCallbacks2.TRIM_MEMORY_UI_HIDDEN) {
+                    return;
+                }
+                if (level < ComponentCallbacks2.TRIM_MEMORY_RUNNING_LOW) {
+                    return;
+                }
+                Ebitenmobileview.onLowMemory();
+            }
+
+            @Override
+            public void onLowMemory() {
+                Ebitenmobileview.onLowMemory();
+            }
+
+            @Override
+            public void onConfigurationChanged(Configuration newConfig) {
+                // Do nothing.
+            }
+        });
+
+        setOnApplyWindowInsetsListener(new View.OnApplyWindowInsetsListener() {
+            @Override
+            public WindowInsets onApplyWindowInsets(View v, WindowInsets insets) {
+                onWindowInsetsChanged(insets);
+                return v.onApplyWindowInsets(insets);
+            }
+        });
+        setOnSystemUiVisibilityChangeListener(new View.OnSystemUiVisibilityChangeListener() {
+            @Override
+            public void onSystemUiVisibilityChange(int visibility) {
+                // In the immersive mode, the flags are cleared when e.g. the software keyboard is shown,
+                // and they are not restored automatically after the keyboard is closed.
+                // Restore them unless the bars are shown transiently.
+                if (Build.VERSION.SDK_INT >= Build.VERSION_CODES.R) {
+                    return;
+                }
+                if (!EbitenView.this.immersiveMode) {
+                    return;
+                }
+                if ((visibility & View.SYSTEM_UI_FLAG_FULLSCREEN) != 0) {
+                    return;
+                }
+                applySystemBars();
+            }
+        });
+        getViewTreeObserver().addOnGlobalLayoutListener(new ViewTreeObserver.OnGlobalLayoutListener() {
+            @Override
+            public void onGlobalLayout() {
+                // On Android 11 or later, the keyboard height is calculated from the window insets.
+                if (Build.VERSION.SDK_INT >= Build.VERSION_CODES.R) {
+                    return;
+                }
+                // The visible display frame excludes the area covered by the software keyboard.
+                Rect visible = new Rect();
+                getWindowVisibleDisplayFrame(visible);
+                int[] location = new int[2];
+                getLocationOnScreen(location);
+                updateVirtualKeyboardHeight(location[1] + getHeight() - visible.bottom);
+            }
+        });
+        Ebitenmobileview.setSystemBarsController(this);
+        Ebitenmobileview.setBackButtonController(this);
+    }
+
+    // updateExternalDisplay notifies the game of the first external display suitable for presentations,
+    // e.g. one connected via HDMI or a wireless display.
+    @SuppressWarnings("deprecation")
+    private void updateExternalDisplay() {
+        for (Display display : this.displayManager.getDisplays(DisplayManager.DISPLAY_CATEGORY_PRESENTATION)) {
+            if (display.getDisplayId() == Display.DEFAULT_DISPLAY) {
+                continue;
+            }
+            DisplayMetrics metrics = new DisplayMetrics();
+            display.getRealMetrics(metrics);
+            Ebitenmobileview.setExternalDisplay(true, metrics.widthPixels / metrics.density, metrics.heightPixels / metrics.density, metrics.density);
+            return;
+        }
+        Ebitenmobileview.setExternalDisplay(false, 0, 0, 0);
+    }
+
+    // setSystemBarsState is called from the game via ebiten.SetSystemBarsVisible and ebiten.SetImmersiveMode.
+    // This can be called from any thread.
+    @Override
+    public void setSystemBarsState(final boolean visible, final boolean immersive) {
+        post(new Runnable() {
+            @Override
+            public void run() {
+                EbitenView.this.systemBarsVisible = visible;
+                EbitenView.this.immersiveMode = immersive;
+                if (!visible || immersive) {
+                    EbitenView.this.systemBarsControlled = true;
+                }
+                applySystemBars();
+            }
+        });
+    }
+
+    // setBackButtonHandled is called from the game via ebiten.SetBackButtonHandled.
+    // This can be called from any thread.
+    @Override
+    public void setBackButtonHandled(final boolean handled) {
+        post(new Runnable() {
+            @Override
+            public void run() {
+                applyBackButtonHandled(handled);
+            }
+        });
+    }
+
+    private void applyBackButtonHandled(boolean handled) {
+        this.backButtonHandled = handled;
+        if (handled) {
+            // The back key events are delivered to the focused view.
+            setFocusable(true);
+            setFocusableInTouchMode(true);
+            requestFocus();
+        }
+
+        // On Android 13 or later, the back events might be delivered via OnBackInvokedDispatcher instead of the key events,
+        // e.g. when the predictive back gesture is enabled.
+        if (Build.VERSION.SDK_INT < Build.VERSION_CODES.TIRAMISU) {
+            return;
+        }
+        OnBackInvokedDispatcher dispatcher = findOnBackInvokedDispatcher();
+        if (dispatcher == null) {
+            return;
+        }
+        if (handled && this.backInvokedCallback == null) {
+            OnBackInvokedCallback callback = new OnBackInvokedCallback() {
+                @Override
+                public void onBackInvoked() {
+                    pressBackKey();
+                }
+            };
+            dispatcher.registerOnBackInvokedCallback(OnBackInvokedDispatcher.PRIORITY_DEFAULT, callback);
+            this.backInvokedCallback = callback;
+        } else if (!handled && this.backInvokedCallback != null) {
+            dispatcher.unregisterOnBackInvokedCallback((OnBackInvokedCallback)this.backInvokedCallback);
+            this.backInvokedCallback = null;
+        }
+    }
+
+    // pressBackKey emulates a back key press and release.
+    // The release is delayed so that the game can observe the key being pressed at least for one tick.
+    private void pressBackKey() {
+        Ebitenmobileview.onKeyDownOnAndroid(KeyEvent.KEYCODE_BACK, 0, InputDevice.SOURCE_KEYBOARD, -1);
+        postDelayed(new Runnable() {
+            @Override
+            public void run() {
+                Ebitenmobileview.onKeyUpOnAndroid(KeyEvent.KEYCODE_BACK, InputDevice.SOURCE_KEYBOARD, -1);
+            }
+        }, 100);
+    }
+
+    @SuppressWarnings("deprecation")
+    private void applySystemBars() {
+        // Don't touch the system bars until the game hides them, so that the application can control them by itself.
+        if (!this.systemBarsControlled) {
+            return;
+        }
+        boolean hidden = this.immersiveMode || !this.systemBarsVisible;
+        if (Build.VERSION.SDK_INT >= Build.VERSION_CODES.R) {
+            WindowInsetsController controller = getWindowInsetsController();
+            if (controller == null) {
+                return;
+            }
+            if (!hidden) {
+                controller.show(WindowInsets.Type.systemBars());
+                return;
+            }
+            if (this.immersiveMode) {
+                controller.setSystemBarsBehavior(WindowInsetsController.BEHAVIOR_SHOW_TRANSIENT_BARS_BY_SWIPE);
+            } else {
+                controller.setSystemBarsBehavior(WindowInsetsController.BEHAVIOR_SHOW_BARS_BY_SWIPE);
+            }
+            controller.hide(WindowInsets.Type.systemBars());
+            return;
+        }
+
+        int flags = 0;
+        if (hidden) {
+            flags = View.SYSTEM_UI_FLAG_LAYOUT_STABLE |
+                View.SYSTEM_UI_FLAG_LAYOUT_HIDE_NAVIGATION |
+                View.SYSTEM_UI_FLAG_LAYOUT_FULLSCREEN |
+                View.SYSTEM_UI_FLAG_HIDE_NAVIGATION |
+                View.SYSTEM_UI_FLAG_FULLSCREEN;
+            if (this.immersiveMode) {
+                flags |= View.SYSTEM_UI_FLAG_IMMERSIVE_STICKY;
+            }
+        }
+        setSystemUiVisibility(flags);
+    }
+
+    @SuppressWarnings("deprecation")
+    private void onWindowInsetsChanged(WindowInsets insets) {
+        int left, top, right, bottom;
+        if (Build.VERSION.SDK_INT >= Build.VERSION_CODES.R) {
+            android.graphics.Insets safeInsets = insets.getInsets(WindowInsets.Type.systemBars() | WindowInsets.Type.displayCutout());
+            left = safeInsets.left;
+            top = safeInsets.top;
+            right = safeInsets.right;
+            bottom = safeInsets.bottom;
+
+            // When the software keyboard is closed, the hidden bars might be shown. Hide them again.
+            boolean imeVisible = insets.isVisible(WindowInsets.Type.ime());
+            if (this.imeVisible && !imeVisible) {
+                applySystemBars();
+            }
+            this.imeVisible = imeVisible;
+
+            // The IME insets are relative to the window.
+            int imeTop = getRootView().getHeight() - insets.getInsets(WindowInsets.Type.ime()).bottom;
+            int[] location = new int[2];
+            getLocationInWindow(location);
+            updateVirtualKeyboardHeight(location[1] + getHeight() - imeTop);
+        } else {
+            left = insets.getSystemWindowInsetLeft();
+            top = insets.getSystemWindowInsetTop();
+            right = insets.getSystemWindowInsetRight();
+            bottom = insets.getSystemWindowInsetBottom();
+        }
+        Ebitenmobileview.setSafeAreaInsets(pxToDp(left), pxToDp(top), pxToDp(right), pxToDp(bottom));
+    }
+
+    // updateVirtualKeyboardHeight notifies the game of the height of the view covered by the software keyboard.
+    private void updateVirtualKeyboardHeight(int heightInPx) {
+        if (heightInPx < 0) {
+            heightInPx = 0;
+        }
+        if (heightInPx > getHeight()) {
+            heightInPx = getHeight();
+        }
+        Ebitenmobileview.setVirtualKeyboardHeight(pxToDp(heightInPx));
+    }
+
+    @Override
+    public void onWindowFocusChanged(boolean hasWindowFocus) {
+        super.onWindowFocusChanged(hasWindowFocus);
+        // The system bars might be shown by e.g. a dialog or the software keyboard. Hide them again.
+        if (hasWindowFocus) {
+            applySystemBars();
+        }
     }
 
     @Override
@@ -134,12 +434,19 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
 
     @Override
     public boolean onKeyDown(int keyCode, KeyEvent event) {
+        // Let the system handle the back key, e.g. to finish the activity, unless the game handles it.
+        if (keyCode == KeyEvent.KEYCODE_BACK && !this.backButtonHandled) {
+            return super.onKeyDown(keyCode, event);
+        }
         Ebitenmobileview.onKeyDownOnAndroid(keyCode, event.getUnicodeChar(), event.getSource(), event.getDeviceId());
         return true;
     }
 
     @Override
     public boolean onKeyUp(int keyCode, KeyEvent event) {
+        if (keyCode == KeyEvent.KEYCODE_BACK && !this.backButtonHandled) {
+            return super.onKeyUp(keyCode, event);
+        }
         Ebitenmobileview.onKeyUpOnAndroid(keyCode, event.getSource(), event.getDeviceId());
         return true;
     }
@@ -155,7 +462,11 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
             int x = (int)e.getX(i);
             int y = (int)e.getY(i);
             int action = (i == touchIndex) ? e.getActionMasked() : MotionEvent.ACTION_MOVE;
-            Ebitenmobileview.updateTouchesOnAndroid(action, id, (int)pxToDp(x), (int)pxToDp(y));
+            float pressure = e.getPressure(i);
+            int toolType = e.getToolType(i);
+            float tilt = e.getAxisValue(MotionEvent.AXIS_TILT, i);
+            float orientation = e.getOrientation(i);
+            Ebitenmobileview.updateTouchesOnAndroid(action, id, (int)pxToDp(x), (int)pxToDp(y), pressure, toolType, tilt, orientation);
         }
         return true;
     }
@@ -430,6 +741,35 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
         }
     }
 
+    // setLaunchParameters delivers the intent's extras and data to the game as one set of launch parameters.
+    // The game can receive them with mobile.NextLaunchParameters.
+    // It is recommended to call this when Activity's onCreate and onNewIntent are called.
+    //
+    // Each extra is converted to a string. If the intent has a data URI like a deep link,
+    // the URI is added as "url", and the URI's query parameters are added as well.
+    public static void setLaunchParameters(Intent intent) {
+        if (intent == null) {
+            return;
+        }
+        Bundle extras = intent.getExtras();
+        if (extras != null) {
+            for (String key : extras.keySet()) {
+                Ebitenmobileview.addLaunchParameter(key, String.valueOf(extras.get(key)));
+            }
+        }
+        Uri uri = intent.getData();
+        if (uri != null) {
+            if (uri.isHierarchical()) {
+                for (String key : uri.getQueryParameterNames()) {
+                    String value = uri.getQueryParameter(key);
+                    Ebitenmobileview.addLaunchParameter(key, value != null ? value : "");
+                }
+            }
+            Ebitenmobileview.addLaunchParameter("url", uri.toString());
+        }
+        Ebitenmobileview.commitLaunchParameters();
+    }
+
     // onErrorOnGameUpdate is called on the main thread when an error happens when updating a game.
     // You can define your own error handler, e.g., using Crashlytics, by overriding this method.
     protected void onErrorOnGameUpdate(Exception e) {
@@ -438,5 +778,13 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
 
     private EbitenSurfaceView ebitenSurfaceView;
     private InputManager inputManager;
+    private DisplayManager displayManager;
     private ArrayList<Gamepad> gamepads;
+    private boolean systemBarsVisible = true;
+    private boolean immersiveMode = false;
+    private boolean systemBarsControlled = false;
+    private boolean imeVisible = false;
+    private boolean backButtonHandled = false;
+    // backInvokedCallback is an OnBackInvokedCallback, which is available on Android 13 or later.
+    private Object backInvokedCallback;
 }
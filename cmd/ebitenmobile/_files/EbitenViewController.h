@@ -16,6 +16,13 @@
 
 @interface {{.PrefixUpper}}EbitenViewController : UIViewController
 
+// transparent indicates whether the view is transparent, and the views behind it are visible
+// where the game renders transparent pixels.
+// The game must be set with ebiten.RunGameOptions' ScreenTransparent true as well.
+// transparent must be set before the view is loaded.
+// The default value is NO.
+@property (nonatomic) BOOL transparent;
+
 // onErrorOnGameUpdate is called on the main thread when an error happens when updating a game.
 // You can define your own error handler, e.g., using Crashlytics, by overwriting this method.
 - (void)onErrorOnGameUpdate:(NSError*)err;
@@ -30,4 +37,14 @@
 // UIApplicationDelegate's applicationDidBecomeActive is called.
 - (void)resumeGame;
 
+// setLaunchParameters: delivers the parameters to the game as one set of launch parameters.
+// The game can receive them with mobile.NextLaunchParameters.
+- (void)setLaunchParameters:(NSDictionary<NSString*, NSString*>*)parameters;
+
+// setLaunchURL: delivers the URL like a deep link to the game as one set of launch parameters.
+// The URL is added as "url", and the URL's query items are added as well.
+// It is recommended to call this when UIApplicationDelegate's application:openURL:options: is called, and
+// with the user activity's webpageURL when application:continueUserActivity:restorationHandler: is called.
+- (void)setLaunchURL:(NSURL*)url;
+
 @end
@@ -75,6 +75,14 @@
 - (void)viewDidLoad {
   [super viewDidLoad];
 
+  NSNotificationCenter* center = [NSNotificationCenter defaultCenter];
+  [center addObserver:self selector:@selector(updateExternalDisplay) name:UIScreenDidConnectNotification object:nil];
+  [center addObserver:self selector:@selector(updateExternalDisplay) name:UIScreenDidDisconnectNotification object:nil];
+  [center addObserver:self selector:@selector(updateExternalDisplay) name:UIScreenModeDidChangeNotification object:nil];
+  [self updateExternalDisplay];
+  [center addObserver:self selector:@selector(keyboardWillChangeFrame:) name:UIKeyboardWillChangeFrameNotification object:nil];
+  [center addObserver:self selector:@selector(keyboardWillHide:) name:UIKeyboardWillHideNotification object:nil];
+
   viewDidLoad_ = true;
   if (viewDidLoad_ && gameSet_) {
     [self initView];
@@ -105,6 +113,14 @@
     return;
   }
 
+  if (self.transparent) {
+    self.view.opaque = NO;
+    self.view.backgroundColor = [UIColor clearColor];
+    UIView* view = isGL ? self.glkView : self.metalView;
+    view.opaque = NO;
+    view.backgroundColor = [UIColor clearColor];
+  }
+
   if (isGL) {
     self.glkView.delegate = (id<GLKViewDelegate>)(self);
     [self.view addSubview: self.glkView];
@@ -171,7 +187,9 @@
     return;
   }
 
-  CGRect viewRect = [[self view] frame];
+  // Use the bounds instead of the frame, as the frame's origin is in the superview's coordinate
+  // and is not zero when this view is embedded as a subview.
+  CGRect viewRect = [[self view] bounds];
   if (isGL) {
     [[self glkView] setFrame:viewRect];
   } else {
@@ -186,15 +204,57 @@
     return;
   }
 
-  CGRect viewRect = [[self view] frame];
+  CGRect viewRect = [[self view] bounds];
 
   EbitenmobileviewLayout(viewRect.size.width, viewRect.size.height);
+  [self updateSafeAreaInsets];
+}
+
+- (void)viewSafeAreaInsetsDidChange {
+  [super viewSafeAreaInsetsDidChange];
+  [self updateSafeAreaInsets];
+}
+
+- (void)updateSafeAreaInsets {
+  if (@available(iOS 11.0, *)) {
+    UIEdgeInsets insets = [[self view] safeAreaInsets];
+    EbitenmobileviewSetSafeAreaInsets(insets.left, insets.top, insets.right, insets.bottom);
+  }
+}
+
+// updateExternalDisplay notifies the game of an external display connected via e.g. AirPlay or HDMI.
+- (void)updateExternalDisplay {
+  for (UIScreen* screen in [UIScreen screens]) {
+    if (screen == [UIScreen mainScreen]) {
+      continue;
+    }
+    CGSize size = [screen bounds].size;
+    EbitenmobileviewSetExternalDisplay(YES, size.width, size.height, [screen scale]);
+    return;
+  }
+  EbitenmobileviewSetExternalDisplay(NO, 0, 0, 0);
+}
+
+// keyboardWillChangeFrame: notifies the game of the height of the view covered by the software keyboard.
+- (void)keyboardWillChangeFrame:(NSNotification*)notification {
+  CGRect frame = [[[notification userInfo] objectForKey:UIKeyboardFrameEndUserInfoKey] CGRectValue];
+  // The frame is in the screen coordinate.
+  CGRect frameInView = [[self view] convertRect:frame fromCoordinateSpace:[[[self view] window] screen].coordinateSpace];
+  CGRect covered = CGRectIntersection([[self view] bounds], frameInView);
+  if (CGRectIsNull(covered)) {
+    EbitenmobileviewSetVirtualKeyboardHeight(0);
+    return;
+  }
+  EbitenmobileviewSetVirtualKeyboardHeight(covered.size.height);
+}
+
+- (void)keyboardWillHide:(NSNotification*)notification {
+  EbitenmobileviewSetVirtualKeyboardHeight(0);
 }
 
 - (void)didReceiveMemoryWarning {
   [super didReceiveMemoryWarning];
-  // Dispose of any resources that can be recreated.
-  // TODO: Notify this to Go world?
+  EbitenmobileviewOnLowMemory();
 }
 
 - (void)drawFrame{
@@ -286,7 +346,22 @@
       }
     }
     CGPoint location = [touch locationInView:touch.view];
-    EbitenmobileviewUpdateTouchesOnIOS(touch.phase, (uintptr_t)touch, location.x, location.y);
+    double pressure = 0;
+    BOOL pressureAvailable = NO;
+    if (touch.maximumPossibleForce > 0 &&
+        (touch.type == UITouchTypePencil ||
+         self.traitCollection.forceTouchCapability == UIForceTouchCapabilityAvailable)) {
+      pressure = touch.force / touch.maximumPossibleForce;
+      pressureAvailable = YES;
+    }
+    BOOL stylus = touch.type == UITouchTypePencil;
+    double altitude = 0;
+    double azimuth = 0;
+    if (stylus) {
+      altitude = touch.altitudeAngle;
+      azimuth = [touch azimuthAngleInView:touch.view];
+    }
+    EbitenmobileviewUpdateTouchesOnIOS(touch.phase, (uintptr_t)touch, location.x, location.y, pressure, pressureAvailable, stylus, altitude, azimuth);
   }
 }
 
@@ -364,6 +439,26 @@
   }
 }
 
+- (void)setLaunchParameters:(NSDictionary<NSString*, NSString*>*)parameters {
+  for (NSString* key in parameters) {
+    EbitenmobileviewAddLaunchParameter(key, parameters[key]);
+  }
+  EbitenmobileviewCommitLaunchParameters();
+}
+
+- (void)setLaunchURL:(NSURL*)url {
+  if (url == nil) {
+    return;
+  }
+  NSMutableDictionary<NSString*, NSString*>* parameters = [[NSMutableDictionary alloc] init];
+  NSURLComponents* components = [NSURLComponents componentsWithURL:url resolvingAgainstBaseURL:NO];
+  for (NSURLQueryItem* item in components.queryItems) {
+    parameters[item.name] = item.value != nil ? item.value : @"";
+  }
+  parameters[@"url"] = url.absoluteString;
+  [self setLaunchParameters:parameters];
+}
+
 - (void)setExplicitRenderingMode:(BOOL)explicitRendering {
   @synchronized(self) {
     explicitRendering_ = explicitRendering;
@@ -68,19 +68,19 @@ func invokeOriginalGobind(lang string) (pkgName string, err error) {
 		return "", err
 	}
 
-	cfgtags := strings.Join(strings.Split(*tags, ","), " ")
+	// This file is built alone, so buildTagsForOS in main.go is not available.
+	cfgtags := strings.FieldsFunc(*tags, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
 	cfg := &packages.Config{}
 	switch lang {
 	case "java":
-		cfg.Env = append(os.Environ(), "GOOS=android")
+		cfg.Env = append(os.Environ(), "GOOS=android", "CGO_ENABLED=1")
 	case "objc":
-		cfg.Env = append(os.Environ(), "GOOS=darwin")
-		if cfgtags != "" {
-			cfgtags += " "
-		}
-		cfgtags += "ios"
+		cfg.Env = append(os.Environ(), "GOOS=darwin", "CGO_ENABLED=1")
+		cfgtags = append(cfgtags, "ios")
 	}
-	cfg.BuildFlags = []string{"-tags", cfgtags}
+	cfg.BuildFlags = []string{"-tags", strings.Join(cfgtags, ",")}
 	pkgs, err := packages.Load(cfg, flag.Args()[0])
 	if err != nil {
 		return "", err
@@ -23,6 +23,7 @@ package main
 
 import (
 	_ "embed"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -120,13 +121,21 @@ func main() {
 	flagset.StringVar(&bindClasspath, "classpath", "", "")
 	flagset.StringVar(&bindBootClasspath, "bootclasspath", "", "")
 
-	_ = flagset.Parse(args[1:])
+	flagset.Usage = flag.Usage
+	if err := flagset.Parse(args[1:]); err != nil {
+		log.Fatal(err)
+	}
 
 	buildTarget, err := osFromBuildTarget(buildTarget)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if err := checkOutputName(buildTarget, buildO); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
 	// Add ldflags to suppress linker errors (#932).
 	// See https://github.com/golang/go/issues/17807
 	if buildTarget == "android" {
@@ -150,23 +159,70 @@ func main() {
 		log.Fatal(err)
 	}
 
-	// If args doesn't include '-androidapi', set it to args explicitly.
-	// It's because ebitenmobile's default API level is different from gomobile's one.
-	if buildTarget == "android" && buildAndroidAPI == minAndroidAPI {
-		var found bool
-		flag.Visit(func(f *flag.Flag) {
-			if f.Name == "androidapi" {
-				found = true
+	if err := doBind(gomobileArgs(&flagset, buildTarget), &flagset, buildTarget); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// gomobileArgs returns the arguments for gomobile from the parsed flags.
+//
+// The flags specified explicitly are passed through to gomobile.
+// -ldflags is passed with the modification by ebitenmobile, and -androidapi is always passed for Android,
+// as ebitenmobile's default API level is different from gomobile's one.
+func gomobileArgs(flagset *flag.FlagSet, buildOS string) []string {
+	set := map[string]struct{}{}
+	flagset.Visit(func(f *flag.Flag) {
+		set[f.Name] = struct{}{}
+	})
+
+	args := []string{"bind"}
+	flagset.VisitAll(func(f *flag.Flag) {
+		switch f.Name {
+		case "ldflags":
+			if buildLdflags != "" {
+				args = append(args, "-ldflags="+buildLdflags)
+			}
+		case "androidapi":
+			if buildOS == "android" {
+				args = append(args, fmt.Sprintf("-androidapi=%d", buildAndroidAPI))
+			}
+		default:
+			if _, ok := set[f.Name]; ok {
+				args = append(args, "-"+f.Name+"="+f.Value.String())
 			}
-		})
-		if !found {
-			args = append([]string{args[0], "-androidapi", fmt.Sprintf("%d", minAndroidAPI)}, args[1:]...)
+		}
+	})
+	return append(args, flagset.Args()...)
+}
+
+// checkOutputName checks the output name given by -o.
+// gomobile decides the output format by the extension.
+func checkOutputName(buildOS string, output string) error {
+	if output == "" {
+		return fmt.Errorf("ebitenmobile: -o must be specified")
+	}
+	switch buildOS {
+	case "android":
+		if filepath.Ext(output) != ".aar" {
+			return fmt.Errorf("ebitenmobile: -o must have the .aar extension for Android: %s", output)
+		}
+	case "darwin":
+		if filepath.Ext(output) != ".xcframework" {
+			return fmt.Errorf("ebitenmobile: -o must have the .xcframework extension for iOS: %s", output)
 		}
 	}
+	return nil
+}
 
-	if err := doBind(args, &flagset, buildTarget); err != nil {
-		log.Fatal(err)
+// buildTagsForOS returns the build tags in the comma-separated form for the given OS.
+func buildTagsForOS(tags string, buildOS string) string {
+	ts := strings.FieldsFunc(tags, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	if buildOS == "darwin" {
+		ts = append(ts, "ios")
 	}
+	return strings.Join(ts, ",")
 }
 
 func osFromBuildTarget(buildTarget string) (string, error) {
@@ -187,16 +243,9 @@ func osFromBuildTarget(buildTarget string) (string, error) {
 }
 
 func doBind(args []string, flagset *flag.FlagSet, buildOS string) error {
-	tags := buildTags
 	cfg := &packages.Config{}
-	cfg.Env = append(os.Environ(), "GOOS="+buildOS)
-	if buildOS == "darwin" {
-		if tags != "" {
-			tags += " "
-		}
-		tags += "ios"
-	}
-	cfg.BuildFlags = []string{"-tags", tags}
+	cfg.Env = append(os.Environ(), "GOOS="+buildOS, "CGO_ENABLED=1")
+	cfg.BuildFlags = []string{"-tags", buildTagsForOS(buildTags, buildOS)}
 
 	flagsetArgs := flagset.Args()
 	if len(flagsetArgs) == 0 {
@@ -206,17 +255,17 @@ func doBind(args []string, flagset *flag.FlagSet, buildOS string) error {
 	if err != nil {
 		return err
 	}
+	if len(pkgs) == 0 {
+		return fmt.Errorf("ebitenmobile: no package found: %s", flagsetArgs[0])
+	}
+	if len(pkgs[0].Errors) > 0 {
+		return pkgs[0].Errors[0]
+	}
 	prefixLower := bindPrefix + pkgs[0].Name
 	prefixUpper := strings.Title(bindPrefix) + strings.Title(pkgs[0].Name)
 
 	args = append(args, "github.com/hajimehoshi/ebiten/v2/mobile/ebitenmobileview")
 
-	if buildO == "" {
-		fmt.Fprintln(os.Stderr, "-o must be specified.")
-		os.Exit(2)
-		return nil
-	}
-
 	if buildN {
 		fmt.Print("gomobile")
 		for _, arg := range args {
@@ -230,8 +279,12 @@ func doBind(args []string, flagset *flag.FlagSet, buildOS string) error {
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
-		os.Exit(err.(*exec.ExitError).ExitCode())
-		return nil
+		// gomobile's output is already shown on the standard error. Exit with the same code.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		return fmt.Errorf("ebitenmobile: running gomobile failed: %w", err)
 	}
 
 	replacePrefixes := func(content string) string {
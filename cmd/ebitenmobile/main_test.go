@@ -74,3 +74,76 @@ func TestJavaPackageName(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckOutputName(t *testing.T) {
+	testCases := []struct {
+		os     string
+		output string
+		ok     bool
+	}{
+		{
+			os:     "android",
+			output: "mygame.aar",
+			ok:     true,
+		},
+		{
+			os:     "android",
+			output: "mygame.xcframework",
+			ok:     false,
+		},
+		{
+			os:     "darwin",
+			output: "Mygame.xcframework",
+			ok:     true,
+		},
+		{
+			os:     "darwin",
+			output: "mygame",
+			ok:     false,
+		},
+		{
+			os:     "android",
+			output: "",
+			ok:     false,
+		},
+	}
+	for _, tc := range testCases {
+		if got, want := checkOutputName(tc.os, tc.output) == nil, tc.ok; got != want {
+			t.Errorf("checkOutputName(%q, %q) == nil = %v; want %v", tc.os, tc.output, got, want)
+		}
+	}
+}
+
+func TestBuildTagsForOS(t *testing.T) {
+	testCases := []struct {
+		tags string
+		os   string
+		out  string
+	}{
+		{
+			tags: "",
+			os:   "android",
+			out:  "",
+		},
+		{
+			tags: "",
+			os:   "darwin",
+			out:  "ios",
+		},
+		{
+			tags: "demo,foo",
+			os:   "darwin",
+			out:  "demo,foo,ios",
+		},
+		{
+			tags: "demo foo",
+			os:   "android",
+			out:  "demo,foo",
+		},
+	}
+	for _, tc := range testCases {
+		if got, want := buildTagsForOS(tc.tags, tc.os), tc.out; got != want {
+			t.Errorf("buildTagsForOS(%q, %q) = %q; want %q", tc.tags, tc.os, got, want)
+		}
+	}
+}
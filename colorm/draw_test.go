@@ -16,6 +16,7 @@ package colorm_test
 
 import (
 	"fmt"
+	"image"
 	"image/color"
 	"math"
 	"testing"
@@ -299,3 +300,63 @@ func TestColorMCopy(t *testing.T) {
 		}
 	}
 }
+
+func TestApplyToImage(t *testing.T) {
+	const w, h = 16, 16
+	src := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			src.SetNRGBA(i, j, color.NRGBA{
+				R: uint8(17 * i),
+				G: uint8(17 * j),
+				B: uint8(255 - 17*i),
+				A: uint8(17 * ((i + j) % 16)),
+			})
+		}
+	}
+	srcImg := ebiten.NewImageFromImage(src)
+
+	var cms []colorm.ColorM
+	{
+		var cm colorm.ColorM
+		cms = append(cms, cm)
+	}
+	{
+		var cm colorm.ColorM
+		cm.Scale(0.5, 0.75, 1.5, 0.8)
+		cms = append(cms, cm)
+	}
+	{
+		var cm colorm.ColorM
+		cm.Translate(0.25, -0.25, 0.5, 0.25)
+		cms = append(cms, cm)
+	}
+	{
+		var cm colorm.ColorM
+		cm.ChangeHSV(math.Pi/3, 0.5, 1.25)
+		cms = append(cms, cm)
+	}
+	{
+		var cm colorm.ColorM
+		cm.Scale(-1, -1, -1, 1)
+		cm.Translate(1, 1, 1, 0)
+		cms = append(cms, cm)
+	}
+
+	for _, cm := range cms {
+		dst := ebiten.NewImage(w, h)
+		op := &colorm.DrawImageOptions{}
+		op.Blend = ebiten.BlendCopy
+		colorm.DrawImage(dst, srcImg, cm, op)
+
+		got := colorm.ApplyToImage(src, cm)
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				want := dst.At(i, j).(color.RGBA)
+				if !sameColors(got.RGBAAt(i, j), want, 1) {
+					t.Errorf("%s: At(%d, %d): got: %v, want: %v", cm.String(), i, j, got.RGBAAt(i, j), want)
+				}
+			}
+		}
+	}
+}
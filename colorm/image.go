@@ -0,0 +1,130 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colorm
+
+import (
+	"image"
+	"image/draw"
+	"runtime"
+	"sync"
+)
+
+// ApplyToImage returns a new image with the color matrix c applied to src's pixels on the CPU.
+//
+// The result is the same as DrawImage with c and ebiten.BlendCopy within an error of 1/255 for each channel,
+// i.e. the colors are un-premultiplied, transformed by c, premultiplied again, and then clamped.
+// This is useful to bake colored images at loading time with the same ColorM values used at runtime.
+//
+// The returned image has the same bounds as src.
+func ApplyToImage(src image.Image, c ColorM) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(b)
+	draw.Draw(dst, b, src, b.Min, draw.Src)
+	ApplyToRGBA(dst, c)
+	return dst
+}
+
+// minRowsPerGoroutine is the minimum number of rows processed in one goroutine at ApplyToRGBA.
+const minRowsPerGoroutine = 64
+
+// ApplyToRGBA applies the color matrix c to img's pixels in place on the CPU.
+//
+// The result is the same as ApplyToImage.
+// The rows are processed in parallel for a big image.
+func ApplyToRGBA(img *image.RGBA, c ColorM) {
+	var body [16]float32
+	var translation [4]float32
+	c.affineColorM().Elements(body[:], translation[:])
+
+	b := img.Bounds()
+	h := b.Dy()
+	if h <= 0 || b.Dx() <= 0 {
+		return
+	}
+
+	// Split the rows into chunks and process them in parallel.
+	n := runtime.GOMAXPROCS(0)
+	if m := (h + minRowsPerGoroutine - 1) / minRowsPerGoroutine; n > m {
+		n = m
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		y0 := b.Min.Y + h*i/n
+		y1 := b.Min.Y + h*(i+1)/n
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := y0; y < y1; y++ {
+				idx := img.PixOffset(b.Min.X, y)
+				applyToPixels(img.Pix[idx:idx+4*b.Dx()], &body, &translation)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// applyToPixels applies the color matrix to the premultiplied-alpha RGBA pixels.
+// This must be the same as the built-in shader with a color matrix.
+func applyToPixels(pix []byte, body *[16]float32, translation *[4]float32) {
+	for i := 0; i < len(pix); i += 4 {
+		p := pix[i : i+4 : i+4]
+		a := float32(p[3]) / 0xff
+
+		// Un-premultiply alpha. When the alpha is 0, the colors are kept as they are.
+		// p[0] / 0xff / a is the same as p[0] / p[3].
+		d := float32(0xff)
+		if p[3] != 0 {
+			d = float32(p[3])
+		}
+		r := float32(p[0]) / d
+		g := float32(p[1]) / d
+		b := float32(p[2]) / d
+
+		r2 := body[0]*r + body[4]*g + body[8]*b + body[12]*a + translation[0]
+		g2 := body[1]*r + body[5]*g + body[9]*b + body[13]*a + translation[1]
+		b2 := body[2]*r + body[6]*g + body[10]*b + body[14]*a + translation[2]
+		a2 := body[3]*r + body[7]*g + body[11]*b + body[15]*a + translation[3]
+
+		// Premultiply alpha, and clamp the colors by the alpha.
+		r2 *= a2
+		g2 *= a2
+		b2 *= a2
+		if r2 > a2 {
+			r2 = a2
+		}
+		if g2 > a2 {
+			g2 = a2
+		}
+		if b2 > a2 {
+			b2 = a2
+		}
+
+		p[0] = toByte(r2)
+		p[1] = toByte(g2)
+		p[2] = toByte(b2)
+		p[3] = toByte(a2)
+	}
+}
+
+// toByte converts a color value to a byte with clamping, in the same way as a GPU writes a value to an 8-bit channel.
+func toByte(v float32) byte {
+	if v <= 0 {
+		return 0
+	}
+	if v >= 1 {
+		return 0xff
+	}
+	return byte(v*0xff + 0.5)
+}
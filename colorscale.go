@@ -22,6 +22,11 @@ import (
 // ColorScale represents a scale of RGBA color.
 // ColorScale is intended to be applied to a premultiplied-alpha color value.
 //
+// The values of ColorScale are premultiplied-alpha values as well as color.Color's RGBA.
+// For example, a half-transparent red is (0.5, 0, 0, 0.5), not (1, 0, 0, 0.5).
+// Use ColorScaleFromColor to convert a color.Color like color.RGBA or color.NRGBA,
+// and ColorScaleFromStraightAlpha to convert straight-alpha (non-premultiplied) values.
+//
 // The initial (zero) value of ColorScale is an identity scale (1, 1, 1, 1).
 type ColorScale struct {
 	// These values are adjusted by -1 from the actual values.
@@ -29,6 +34,26 @@ type ColorScale struct {
 	r_1, g_1, b_1, a_1 float32
 }
 
+// ColorScaleFromColor returns a ColorScale that scales colors with the given color.
+//
+// As color.Color's RGBA returns premultiplied-alpha values, the returned values are premultiplied-alpha values.
+// For example, both color.RGBA{0x80, 0, 0, 0x80} and color.NRGBA{0xff, 0, 0, 0x80} result in about (0.5, 0, 0, 0.5).
+func ColorScaleFromColor(clr color.Color) ColorScale {
+	var c ColorScale
+	c.ScaleWithColor(clr)
+	return c
+}
+
+// ColorScaleFromStraightAlpha returns a ColorScale with the given straight-alpha (non-premultiplied) values.
+//
+// The color values are multiplied by the alpha value.
+// For example, ColorScaleFromStraightAlpha(1, 0, 0, 0.5) returns (0.5, 0, 0, 0.5).
+func ColorScaleFromStraightAlpha(r, g, b, a float32) ColorScale {
+	var c ColorScale
+	c.Scale(r*a, g*a, b*a, a)
+	return c
+}
+
 // String returns a string representing the color scale.
 func (c *ColorScale) String() string {
 	return fmt.Sprintf("(%f,%f,%f,%f)", c.r_1+1, c.g_1+1, c.b_1+1, c.a_1+1)
@@ -62,6 +87,49 @@ func (c *ColorScale) A() float32 {
 	return c.a_1 + 1
 }
 
+// StraightAlpha returns the straight-alpha (non-premultiplied) values of the color scale.
+//
+// The color values are divided by the alpha value.
+// If the alpha value is 0, StraightAlpha returns 0 for all the values.
+func (c *ColorScale) StraightAlpha() (r, g, b, a float32) {
+	r, g, b, a = c.elements()
+	if a == 0 {
+		return 0, 0, 0, 0
+	}
+	return r / a, g / a, b / a, a
+}
+
+// Color returns a premultiplied-alpha color of the color scale.
+//
+// The values are clamped to [0, 1], and the color values are clamped to the alpha value.
+func (c *ColorScale) Color() color.RGBA64 {
+	r, g, b, a := c.elements()
+	a = clamp01(a)
+	r = clamp(r, 0, a)
+	g = clamp(g, 0, a)
+	b = clamp(b, 0, a)
+	return color.RGBA64{
+		R: uint16(r*0xffff + 0.5),
+		G: uint16(g*0xffff + 0.5),
+		B: uint16(b*0xffff + 0.5),
+		A: uint16(a*0xffff + 0.5),
+	}
+}
+
+func clamp01(x float32) float32 {
+	return clamp(x, 0, 1)
+}
+
+func clamp(x, min, max float32) float32 {
+	if x < min {
+		return min
+	}
+	if x > max {
+		return max
+	}
+	return x
+}
+
 func (c *ColorScale) elements() (float32, float32, float32, float32) {
 	return c.r_1 + 1, c.g_1 + 1, c.b_1 + 1, c.a_1 + 1
 }
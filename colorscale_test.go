@@ -0,0 +1,115 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func colorScaleElements(c ebiten.ColorScale) [4]float32 {
+	return [4]float32{c.R(), c.G(), c.B(), c.A()}
+}
+
+func nearlyEqualColorScales(a, b [4]float32) bool {
+	for i := range a {
+		if math.Abs(float64(a[i]-b[i])) > 1.0/256 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestColorScaleFromColor(t *testing.T) {
+	testCases := []struct {
+		In  color.Color
+		Out [4]float32
+	}{
+		{
+			In:  color.White,
+			Out: [4]float32{1, 1, 1, 1},
+		},
+		{
+			In:  color.Transparent,
+			Out: [4]float32{0, 0, 0, 0},
+		},
+		{
+			In:  color.RGBA{0x80, 0, 0, 0x80},
+			Out: [4]float32{0.5, 0, 0, 0.5},
+		},
+		{
+			In:  color.NRGBA{0xff, 0, 0, 0x80},
+			Out: [4]float32{0.5, 0, 0, 0.5},
+		},
+	}
+	for _, tc := range testCases {
+		if got, want := colorScaleElements(ebiten.ColorScaleFromColor(tc.In)), tc.Out; !nearlyEqualColorScales(got, want) {
+			t.Errorf("ColorScaleFromColor(%v): got: %v, want: %v", tc.In, got, want)
+		}
+	}
+}
+
+func TestColorScaleStraightAlpha(t *testing.T) {
+	c := ebiten.ColorScaleFromStraightAlpha(1, 0.5, 0, 0.5)
+	if got, want := colorScaleElements(c), [4]float32{0.5, 0.25, 0, 0.5}; got != want {
+		t.Errorf("ColorScaleFromStraightAlpha: got: %v, want: %v", got, want)
+	}
+	r, g, b, a := c.StraightAlpha()
+	if got, want := [4]float32{r, g, b, a}, [4]float32{1, 0.5, 0, 0.5}; got != want {
+		t.Errorf("StraightAlpha: got: %v, want: %v", got, want)
+	}
+
+	c = ebiten.ColorScaleFromStraightAlpha(1, 1, 1, 0)
+	r, g, b, a = c.StraightAlpha()
+	if got, want := [4]float32{r, g, b, a}, [4]float32{0, 0, 0, 0}; got != want {
+		t.Errorf("StraightAlpha: got: %v, want: %v", got, want)
+	}
+}
+
+func TestColorScaleColor(t *testing.T) {
+	testCases := []struct {
+		In  ebiten.ColorScale
+		Out color.RGBA64
+	}{
+		{
+			In:  ebiten.ColorScale{},
+			Out: color.RGBA64{0xffff, 0xffff, 0xffff, 0xffff},
+		},
+		{
+			In:  ebiten.ColorScaleFromStraightAlpha(1, 0, 0, 0.5),
+			Out: color.RGBA64{0x8000, 0, 0, 0x8000},
+		},
+		{
+			In:  ebiten.ColorScaleFromStraightAlpha(2, -1, 1, 1),
+			Out: color.RGBA64{0xffff, 0, 0xffff, 0xffff},
+		},
+	}
+	for _, tc := range testCases {
+		if got, want := tc.In.Color(), tc.Out; got != want {
+			t.Errorf("%v.Color(): got: %v, want: %v", &tc.In, got, want)
+		}
+	}
+}
+
+func TestImageFillWithColorScale(t *testing.T) {
+	dst := ebiten.NewImage(16, 16)
+	dst.FillWithColorScale(ebiten.ColorScaleFromColor(color.NRGBA{0xff, 0, 0, 0x80}))
+	if got, want := dst.At(0, 0).(color.RGBA), (color.RGBA{0x80, 0, 0, 0x80}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// Atlas represents an internal image, i.e. a texture on GPU, that might be shared by multiple ebiten.Images.
+type Atlas struct {
+	// Image is the pixels of the internal image.
+	Image *image.RGBA
+
+	// Shared reports whether multiple images can share the internal image as a texture atlas.
+	// If Shared is false, the internal image is dedicated to one image, e.g. a big image or an unmanaged image.
+	Shared bool
+
+	// Regions is the images on the internal image, sorted by their positions.
+	Regions []AtlasRegion
+}
+
+// AtlasRegion represents an image on an Atlas.
+type AtlasRegion struct {
+	// Bounds is the region of the image in the atlas, excluding the padding.
+	Bounds image.Rectangle
+
+	// Padding is the size of the padding on the right and bottom edges of the image in pixels.
+	// A padding is kept transparent to prevent the adjacent images from bleeding into the image.
+	Padding int
+
+	// ImageID identifies the ebiten.Image of the region.
+	// ImageID is the same as the ID reported by DumpLiveImages, which shows where the image was created.
+	//
+	// ImageID is 0 when the image was created while the image tracing was disabled,
+	// or when the region is for an internal image like a mipmap.
+	ImageID uint64
+
+	// RenderTarget reports whether the image is on an atlas for rendering destinations.
+	// An image used as a rendering destination is moved to such an atlas, and might be moved back to an atlas for
+	// rendering sources after the image is used only as a rendering source for a while.
+	RenderTarget bool
+}
+
+// Atlases returns all the current internal images except for the screen with the information of the images on them.
+//
+// The buffered pixels of ebiten.Image by Set are rendered before reading the internal images.
+//
+// Atlases must be called after the game starts, e.g. in Update or Draw. Otherwise, Atlases panics.
+// Atlases reads pixels from GPU, which is very slow. Do not call Atlases every frame.
+func Atlases() ([]*Atlas, error) {
+	infos, err := ui.Get().ReadAtlases()
+	if err != nil {
+		return nil, err
+	}
+
+	atlases := make([]*Atlas, 0, len(infos))
+	for _, info := range infos {
+		a := &Atlas{
+			Image: &image.RGBA{
+				Pix:    info.Pixels,
+				Stride: 4 * info.Width,
+				Rect:   image.Rect(0, 0, info.Width, info.Height),
+			},
+			Shared:  info.Shared,
+			Regions: make([]AtlasRegion, 0, len(info.Regions)),
+		}
+		for _, r := range info.Regions {
+			a.Regions = append(a.Regions, AtlasRegion{
+				Bounds:       r.Bounds,
+				Padding:      r.Padding,
+				ImageID:      r.TraceID,
+				RenderTarget: !info.Source,
+			})
+		}
+		atlases = append(atlases, a)
+	}
+	return atlases, nil
+}
+
+// DumpAtlases writes all the current internal images as PNG files to the directory dir,
+// with a text file atlases.txt describing the images on them.
+//
+// dir is created if it doesn't exist. The existing files with the same names are overwritten.
+//
+// The restrictions are the same as Atlases.
+// DumpAtlases doesn't work on browsers as there is no file system.
+func DumpAtlases(dir string) error {
+	atlases, err := Atlases()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(dir, "atlases.txt"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	w := bufio.NewWriter(f)
+	for i, a := range atlases {
+		name := fmt.Sprintf("atlas_%d.png", i)
+		if err := writePNG(filepath.Join(dir, name), a.Image); err != nil {
+			return err
+		}
+
+		kind := "dedicated"
+		if a.Shared {
+			kind = "shared"
+		}
+		fmt.Fprintf(w, "%s: %dx%d, %s, %d image(s)\n", name, a.Image.Rect.Dx(), a.Image.Rect.Dy(), kind, len(a.Regions))
+		for _, r := range a.Regions {
+			fmt.Fprintf(w, "\t%v: %dx%d, padding: %d, render target: %t", r.Bounds, r.Bounds.Dx(), r.Bounds.Dy(), r.Padding, r.RenderTarget)
+			if r.ImageID != 0 {
+				fmt.Fprintf(w, ", image ID: %d", r.ImageID)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	w := bufio.NewWriter(f)
+	if err := png.Encode(w, img); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return f.Close()
+}
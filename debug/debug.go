@@ -0,0 +1,80 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package debug provides functions to investigate the resource usage of Ebitengine.
+package debug
+
+import (
+	"io"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
+	"github.com/hajimehoshi/ebiten/v2/internal/imagetrace"
+)
+
+// ImageCount returns the number of the current internal images, i.e. textures on GPU.
+//
+// Ebitengine puts small images on a shared internal texture atlas automatically,
+// so ImageCount is not the same as the number of the live ebiten.Images.
+// Internal images used by Ebitengine itself like the screen are also counted.
+//
+// ImageCount is updated when the rendering commands are issued, not when the GPU actually allocates or releases textures.
+//
+// ImageCount is concurrent-safe.
+func ImageCount() int {
+	return graphicscommand.LastFrameStats().ImageCount
+}
+
+// TotalImageBytes returns the estimated total size of the current internal images in bytes.
+//
+// TotalImageBytes includes the overhead of the internal texture atlases, like unused regions and paddings,
+// and the sizes of the textures rounded up to powers of 2.
+// The actual GPU memory usage depends on the graphics driver.
+//
+// TotalImageBytes is concurrent-safe.
+func TotalImageBytes() int64 {
+	return graphicscommand.LastFrameStats().ImageBytes
+}
+
+// SetImageTracingEnabled enables or disables the image tracing.
+//
+// While the image tracing is enabled, a stack trace is recorded for each ebiten.Image creation,
+// which can be dumped by DumpLiveImages.
+// Only the images created while the image tracing is enabled are recorded.
+// Disabling the image tracing doesn't remove the existing records.
+//
+// The image tracing is disabled by default. When the image tracing is disabled, there is no overhead to record stack traces.
+//
+// SetImageTracingEnabled is concurrent-safe.
+func SetImageTracingEnabled(enabled bool) {
+	imagetrace.SetEnabled(enabled)
+}
+
+// IsImageTracingEnabled reports whether the image tracing is enabled.
+//
+// IsImageTracingEnabled is concurrent-safe.
+func IsImageTracingEnabled() bool {
+	return imagetrace.IsEnabled()
+}
+
+// DumpLiveImages writes the report of the live ebiten.Images recorded by the image tracing to w.
+//
+// The images are grouped by their creation sites, and the sites are sorted by the total bytes in descending order.
+// Each site lists the IDs of its images, which are the same as AtlasRegion's ImageID.
+// An image is live until it is deallocated by Deallocate or is garbage-collected.
+// A site with a growing number of images is a candidate of a leak.
+//
+// DumpLiveImages is concurrent-safe.
+func DumpLiveImages(w io.Writer) error {
+	return imagetrace.Dump(w, "github.com/hajimehoshi/ebiten/v2.")
+}
@@ -0,0 +1,309 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image/color"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+var debugDrawEnabled atomic.Bool
+
+// SetDebugDrawEnabled enables or disables the debug drawing by DebugLine, DebugCircle, DebugRect and DebugText.
+//
+// When the debug drawing is disabled, the debug drawing functions do nothing and are cheap enough to be left in code.
+// Disabling the debug drawing discards the queued primitives.
+//
+// The debug drawing is disabled by default.
+//
+// SetDebugDrawEnabled is concurrent-safe.
+func SetDebugDrawEnabled(enabled bool) {
+	debugDrawEnabled.Store(enabled)
+	if !enabled {
+		theDebugDrawer.clear()
+	}
+}
+
+// IsDebugDrawEnabled reports whether the debug drawing is enabled.
+//
+// IsDebugDrawEnabled is concurrent-safe.
+func IsDebugDrawEnabled() bool {
+	return debugDrawEnabled.Load()
+}
+
+// DebugDrawOptions represents options for the debug drawing functions.
+type DebugDrawOptions struct {
+	// Color is the color of the primitive.
+	// The default (nil) value is white.
+	Color color.Color
+
+	// Frames is the number of the frames in which the primitive is rendered.
+	// The default (zero) value is 1, which means the primitive is rendered only in the next rendered frame.
+	Frames int
+}
+
+// DebugLine queues a line from (x0, y0) to (x1, y1) for the debug drawing.
+//
+// The debug primitives are rendered onto the final screen after the game's screen is rendered on it,
+// in the coordinate of the screen image given to Draw.
+// The debug primitives are not rendered onto the image passed to Draw, so they never persist in the image.
+// The lines are always 1 pixel wide on the final screen regardless of the screen scale.
+// The debug primitives are rendered only when the debug drawing is enabled by SetDebugDrawEnabled.
+//
+// DebugLine is concurrent-safe.
+func DebugLine(x0, y0, x1, y1 float32, options *DebugDrawOptions) {
+	if !debugDrawEnabled.Load() {
+		return
+	}
+	theDebugDrawer.add(debugPrimitive{
+		kind: debugPrimitiveLine,
+		x0:   x0,
+		y0:   y0,
+		x1:   x1,
+		y1:   y1,
+	}, options)
+}
+
+// DebugCircle queues an outline of a circle at the center (cx, cy) with the radius r for the debug drawing.
+//
+// See DebugLine for the details of the debug drawing.
+//
+// DebugCircle is concurrent-safe.
+func DebugCircle(cx, cy, r float32, options *DebugDrawOptions) {
+	if !debugDrawEnabled.Load() {
+		return
+	}
+	theDebugDrawer.add(debugPrimitive{
+		kind: debugPrimitiveCircle,
+		x0:   cx,
+		y0:   cy,
+		x1:   r,
+	}, options)
+}
+
+// DebugRect queues an outline of a rectangle at (x, y) with the given size for the debug drawing.
+//
+// See DebugLine for the details of the debug drawing.
+//
+// DebugRect is concurrent-safe.
+func DebugRect(x, y, width, height float32, options *DebugDrawOptions) {
+	if !debugDrawEnabled.Load() {
+		return
+	}
+	theDebugDrawer.add(debugPrimitive{
+		kind: debugPrimitiveRect,
+		x0:   x,
+		y0:   y,
+		x1:   x + width,
+		y1:   y + height,
+	}, options)
+}
+
+// DebugText queues a text at (x, y) as the upper-left position for the debug drawing.
+// The text is rendered with the same font as ebitenutil.DebugPrint.
+//
+// See DebugLine for the details of the debug drawing.
+//
+// DebugText is concurrent-safe.
+func DebugText(str string, x, y float32, options *DebugDrawOptions) {
+	if !debugDrawEnabled.Load() {
+		return
+	}
+	theDebugDrawer.add(debugPrimitive{
+		kind: debugPrimitiveText,
+		x0:   x,
+		y0:   y,
+		text: str,
+	}, options)
+}
+
+type debugPrimitiveKind int
+
+const (
+	debugPrimitiveLine debugPrimitiveKind = iota
+	debugPrimitiveCircle
+	debugPrimitiveRect
+	debugPrimitiveText
+)
+
+type debugPrimitive struct {
+	kind   debugPrimitiveKind
+	x0     float32
+	y0     float32
+	x1     float32
+	y1     float32
+	text   string
+	r      float32
+	g      float32
+	b      float32
+	a      float32
+	frames int
+}
+
+// debugCircleSegmentCount is the number of the line segments to render a circle.
+const debugCircleSegmentCount = 32
+
+type debugDrawer struct {
+	primitives []debugPrimitive
+	m          sync.Mutex
+
+	// tmpPrimitives, vertices and indices are used only at draw.
+	tmpPrimitives []debugPrimitive
+	vertices      []Vertex
+	indices       []uint16
+}
+
+var theDebugDrawer debugDrawer
+
+func (d *debugDrawer) add(p debugPrimitive, options *DebugDrawOptions) {
+	p.r, p.g, p.b, p.a = 1, 1, 1, 1
+	p.frames = 1
+	if options != nil {
+		if options.Color != nil {
+			r, g, b, a := options.Color.RGBA()
+			p.r, p.g, p.b, p.a = float32(r)/0xffff, float32(g)/0xffff, float32(b)/0xffff, float32(a)/0xffff
+		}
+		if options.Frames > 1 {
+			p.frames = options.Frames
+		}
+	}
+
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.primitives = append(d.primitives, p)
+}
+
+func (d *debugDrawer) clear() {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.primitives = d.primitives[:0]
+}
+
+// draw renders the queued primitives onto screen with geoM, and removes the expired primitives.
+// geoM is a transformation from the game's screen coordinate to the screen coordinate.
+func (d *debugDrawer) draw(screen *Image, geoM *GeoM) {
+	d.m.Lock()
+	d.tmpPrimitives = append(d.tmpPrimitives[:0], d.primitives...)
+	n := 0
+	for _, p := range d.primitives {
+		p.frames--
+		if p.frames > 0 {
+			d.primitives[n] = p
+			n++
+		}
+	}
+	d.primitives = d.primitives[:n]
+	d.m.Unlock()
+
+	if len(d.tmpPrimitives) == 0 {
+		return
+	}
+
+	d.vertices = d.vertices[:0]
+	d.indices = d.indices[:0]
+	for _, p := range d.tmpPrimitives {
+		switch p.kind {
+		case debugPrimitiveLine:
+			d.appendLine(screen, geoM, p.x0, p.y0, p.x1, p.y1, &p)
+		case debugPrimitiveCircle:
+			for i := 0; i < debugCircleSegmentCount; i++ {
+				t0 := 2 * math.Pi * float64(i) / debugCircleSegmentCount
+				t1 := 2 * math.Pi * float64(i+1) / debugCircleSegmentCount
+				x0 := p.x0 + p.x1*float32(math.Cos(t0))
+				y0 := p.y0 + p.x1*float32(math.Sin(t0))
+				x1 := p.x0 + p.x1*float32(math.Cos(t1))
+				y1 := p.y0 + p.x1*float32(math.Sin(t1))
+				d.appendLine(screen, geoM, x0, y0, x1, y1, &p)
+			}
+		case debugPrimitiveRect:
+			d.appendLine(screen, geoM, p.x0, p.y0, p.x1, p.y0, &p)
+			d.appendLine(screen, geoM, p.x1, p.y0, p.x1, p.y1, &p)
+			d.appendLine(screen, geoM, p.x1, p.y1, p.x0, p.y1, &p)
+			d.appendLine(screen, geoM, p.x0, p.y1, p.x0, p.y0, &p)
+		}
+	}
+	d.flushLines(screen)
+
+	for _, p := range d.tmpPrimitives {
+		if p.kind != debugPrimitiveText {
+			continue
+		}
+		var colorScale ColorScale
+		colorScale.Scale(p.r, p.g, p.b, p.a)
+		theDebugImages.drawText(screen, p.text, float64(p.x0), float64(p.y0), geoM, &colorScale)
+	}
+}
+
+// appendLine appends a line with 1-pixel width as a quad.
+// The end points are transformed by geoM, but the width is not.
+func (d *debugDrawer) appendLine(screen *Image, geoM *GeoM, x0, y0, x1, y1 float32, p *debugPrimitive) {
+	x0, y0 = applyGeoMF32(geoM, x0, y0)
+	x1, y1 = applyGeoMF32(geoM, x1, y1)
+	dx, dy := x1-x0, y1-y0
+	l := float32(math.Hypot(float64(dx), float64(dy)))
+	if l == 0 {
+		return
+	}
+	// nx and ny is a normal vector with the half length of the line width.
+	nx, ny := -dy/l/2, dx/l/2
+
+	if len(d.vertices)+4 > MaxVertexCount {
+		d.flushLines(screen)
+	}
+
+	d.indices = AppendRectIndices(d.indices, uint16(len(d.vertices)))
+	for _, v := range [][2]float32{
+		{x0 + nx, y0 + ny},
+		{x1 + nx, y1 + ny},
+		{x0 - nx, y0 - ny},
+		{x1 - nx, y1 - ny},
+	} {
+		d.vertices = append(d.vertices, Vertex{
+			DstX:   v[0],
+			DstY:   v[1],
+			SrcX:   0.5,
+			SrcY:   0.5,
+			ColorR: p.r,
+			ColorG: p.g,
+			ColorB: p.b,
+			ColorA: p.a,
+		})
+	}
+}
+
+func applyGeoMF32(geoM *GeoM, x, y float32) (float32, float32) {
+	tx, ty := geoM.Apply(float64(x), float64(y))
+	return float32(tx), float32(ty)
+}
+
+func (d *debugDrawer) flushLines(screen *Image) {
+	if len(d.indices) == 0 {
+		return
+	}
+	white := theDebugImages.white()
+	b := white.Bounds()
+	for i := range d.vertices {
+		d.vertices[i].SrcX += float32(b.Min.X)
+		d.vertices[i].SrcY += float32(b.Min.Y)
+	}
+	op := &DrawTrianglesOptions{}
+	op.ColorScaleMode = ColorScaleModePremultipliedAlpha
+	op.AntiAlias = true
+	screen.DrawTriangles(d.vertices, d.indices, white, op)
+	d.vertices = d.vertices[:0]
+	d.indices = d.indices[:0]
+}
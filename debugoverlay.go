@@ -0,0 +1,249 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"runtime/debug"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/debugfont"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
+)
+
+const (
+	debugOverlaySampleCount    = 80
+	debugOverlaySampleInterval = 100 * time.Millisecond
+	debugOverlayPadding        = 4
+	debugOverlayBarWidth       = 2
+	debugOverlayGraphHeight    = 24
+)
+
+var debugOverlayEnabled atomic.Bool
+
+// SetDebugOverlayEnabled enables or disables the debug overlay.
+//
+// The debug overlay shows the FPS and TPS with their graphs, the number of draw calls and images,
+// the GC statistics, and the audio buffer statistics at the upper-left corner of the screen.
+// The debug overlay is rendered onto the final screen after Draw and FinalScreenDrawer's DrawFinalScreen,
+// so the game's rendering never covers the overlay.
+// The debug overlay is not included in screenshots taken by EBITENGINE_SCREENSHOT_KEY.
+//
+// The debug overlay is disabled by default.
+// The debug overlay can also be toggled by a key specified by the environment variable EBITENGINE_DEBUG_OVERLAY_KEY.
+//
+// SetDebugOverlayEnabled is concurrent-safe.
+func SetDebugOverlayEnabled(enabled bool) {
+	debugOverlayEnabled.Store(enabled)
+}
+
+// IsDebugOverlayEnabled reports whether the debug overlay is enabled.
+//
+// IsDebugOverlayEnabled is concurrent-safe.
+func IsDebugOverlayEnabled() bool {
+	return debugOverlayEnabled.Load()
+}
+
+type debugOverlay struct {
+	initialized  bool
+	hasToggleKey bool
+	toggleKey    Key
+	keyState     int
+
+	fpsSamples     [debugOverlaySampleCount]float64
+	tpsSamples     [debugOverlaySampleCount]float64
+	sampleCount    int
+	lastSampleTime time.Time
+	gcStats        debug.GCStats
+}
+
+func envDebugOverlayKey() string {
+	return os.Getenv("EBITENGINE_DEBUG_OVERLAY_KEY")
+}
+
+func (d *debugOverlay) update() {
+	if !d.initialized {
+		d.initialized = true
+		if keyname := envDebugOverlayKey(); keyname != "" {
+			if key, ok := keyNameToKeyCode(keyname); ok {
+				d.hasToggleKey = true
+				d.toggleKey = key
+			}
+		}
+	}
+
+	if !d.hasToggleKey {
+		return
+	}
+	if IsKeyPressed(d.toggleKey) {
+		d.keyState++
+		if d.keyState == 1 {
+			debugOverlayEnabled.Store(!debugOverlayEnabled.Load())
+		}
+	} else {
+		d.keyState = 0
+	}
+}
+
+func (d *debugOverlay) draw(screen *Image) {
+	if now := time.Now(); d.lastSampleTime.IsZero() || now.Sub(d.lastSampleTime) >= debugOverlaySampleInterval {
+		d.lastSampleTime = now
+		d.fpsSamples[d.sampleCount%debugOverlaySampleCount] = ActualFPS()
+		d.tpsSamples[d.sampleCount%debugOverlaySampleCount] = ActualTPS()
+		d.sampleCount++
+		debug.ReadGCStats(&d.gcStats)
+	}
+
+	frameStats := graphicscommand.LastFrameStats()
+	audioStats := hook.CurrentAudioStats()
+
+	var gcPause time.Duration
+	if len(d.gcStats.Pause) > 0 {
+		gcPause = d.gcStats.Pause[0]
+	}
+
+	var audio string
+	if audioStats.PlayingPlayerCount > 0 {
+		audio = fmt.Sprintf("Audio: %d playing, Min buffer: %d ms", audioStats.PlayingPlayerCount, audioStats.MinBufferedDuration.Milliseconds())
+	} else {
+		audio = "Audio: No players"
+	}
+
+	header := fmt.Sprintf("FPS: %0.2f, TPS: %0.2f", ActualFPS(), ActualTPS())
+	footer := strings.Join([]string{
+		fmt.Sprintf("Draw calls: %d, Images: %d", frameStats.DrawCallCount, frameStats.ImageCount),
+		fmt.Sprintf("GC: %d, Last pause: %0.2f ms", d.gcStats.NumGC, float64(gcPause)/float64(time.Millisecond)),
+		audio,
+	}, "\n")
+
+	width := debugOverlaySampleCount * debugOverlayBarWidth
+	for _, line := range strings.Split(header+"\n"+footer, "\n") {
+		if w := len(line) * debugfont.CharWidth; width < w {
+			width = w
+		}
+	}
+	height := debugfont.LineHeight + 2*(debugOverlayGraphHeight+debugOverlayPadding) + 3*debugfont.LineHeight
+
+	// Enlarge the overlay on a high-resolution screen so that the text is readable.
+	scale := float64(1)
+	if s := screen.Bounds().Dy() / 1000; s > 1 {
+		scale = float64(s)
+	}
+
+	d.drawRect(screen, 0, 0, float64(width+2*debugOverlayPadding), float64(height+2*debugOverlayPadding), 0, 0, 0, 0.5, scale)
+
+	y := debugOverlayPadding
+	d.drawText(screen, header, debugOverlayPadding, y, scale)
+	y += debugfont.LineHeight
+	d.drawGraph(screen, &d.fpsSamples, debugOverlayPadding, y, 0.25, 1, 0.25, scale)
+	y += debugOverlayGraphHeight + debugOverlayPadding
+	d.drawGraph(screen, &d.tpsSamples, debugOverlayPadding, y, 0.25, 0.75, 1, scale)
+	y += debugOverlayGraphHeight + debugOverlayPadding
+	d.drawText(screen, footer, debugOverlayPadding, y, scale)
+}
+
+func (d *debugOverlay) drawRect(screen *Image, x, y, width, height float64, r, g, b, a float32, scale float64) {
+	op := &DrawImageOptions{}
+	op.GeoM.Scale(width, height)
+	op.GeoM.Translate(x, y)
+	op.GeoM.Scale(scale, scale)
+	op.ColorScale.Scale(r*a, g*a, b*a, a)
+	screen.DrawImage(theDebugImages.white(), op)
+}
+
+func (d *debugOverlay) drawGraph(screen *Image, samples *[debugOverlaySampleCount]float64, x, y int, r, g, b float32, scale float64) {
+	maxValue := 1.0
+	for _, v := range samples {
+		if maxValue < v {
+			maxValue = v
+		}
+	}
+
+	d.drawRect(screen, float64(x), float64(y), debugOverlaySampleCount*debugOverlayBarWidth, debugOverlayGraphHeight, 0, 0, 0, 0.5, scale)
+
+	// Draw the samples from the oldest one.
+	for i := 0; i < debugOverlaySampleCount; i++ {
+		v := samples[(d.sampleCount+i)%debugOverlaySampleCount]
+		h := v / maxValue * debugOverlayGraphHeight
+		d.drawRect(screen, float64(x+i*debugOverlayBarWidth), float64(y)+debugOverlayGraphHeight-h, debugOverlayBarWidth, h, r, g, b, 1, scale)
+	}
+}
+
+func (d *debugOverlay) drawText(screen *Image, str string, x, y int, scale float64) {
+	var geoM GeoM
+	geoM.Scale(scale, scale)
+	theDebugImages.drawText(screen, str, float64(x), float64(y), &geoM, nil)
+}
+
+// debugImages is a set of images shared by the debug overlay and the debug drawing.
+type debugImages struct {
+	textImage   *Image
+	glyphImages map[rune]*Image
+	whiteImage  *Image
+}
+
+var theDebugImages debugImages
+
+func (d *debugImages) ensureImages() {
+	if d.textImage != nil {
+		return
+	}
+	d.textImage = NewImageFromImage(debugfont.Image())
+	d.glyphImages = map[rune]*Image{}
+	img := NewImage(3, 3)
+	img.Fill(color.White)
+	d.whiteImage = img.SubImage(image.Rect(1, 1, 2, 2)).(*Image)
+}
+
+// white returns a 1x1 white image.
+func (d *debugImages) white() *Image {
+	d.ensureImages()
+	return d.whiteImage
+}
+
+// drawText draws str at (x, y) with the debug font, and then applies geoM.
+// If colorScale is nil, the text is rendered as it is.
+func (d *debugImages) drawText(screen *Image, str string, x, y float64, geoM *GeoM, colorScale *ColorScale) {
+	d.ensureImages()
+
+	op := &DrawImageOptions{}
+	if colorScale != nil {
+		op.ColorScale = *colorScale
+	}
+	ox := x
+	for _, c := range str {
+		if c == '\n' {
+			x = ox
+			y += debugfont.LineHeight
+			continue
+		}
+		img, ok := d.glyphImages[c]
+		if !ok {
+			img = d.textImage.SubImage(debugfont.GlyphRect(c)).(*Image)
+			d.glyphImages[c] = img
+		}
+		op.GeoM.Reset()
+		op.GeoM.Translate(x, y)
+		op.GeoM.Concat(*geoM)
+		screen.DrawImage(img, op)
+		x += debugfont.CharWidth
+	}
+}
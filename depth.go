@@ -0,0 +1,248 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sync"
+)
+
+// depthBuffer is a depth buffer emulated with images.
+//
+// A depth value is encoded into 24 bits in the RGB channels.
+// As a shader cannot read its destination, a write to the depth buffer is done in two steps:
+// the new values are rendered to tmp with the current values, and then they are copied back to current.
+type depthBuffer struct {
+	current *Image
+	tmp     *Image
+}
+
+func newDepthBuffer(bounds image.Rectangle) *depthBuffer {
+	return &depthBuffer{
+		current: NewImageWithOptions(bounds, nil),
+		tmp:     NewImageWithOptions(bounds, nil),
+	}
+}
+
+func (d *depthBuffer) deallocate() {
+	d.current.Deallocate()
+	d.tmp.Deallocate()
+}
+
+// encodeDepth encodes the depth value in [0, 1] into 24 bits of RGB values in [0, 1].
+func encodeDepth(depth float32) [3]float32 {
+	if depth < 0 || math.IsNaN(float64(depth)) {
+		depth = 0
+	}
+	if depth > 1 {
+		depth = 1
+	}
+	v := uint32(math.Round(float64(depth) * (1<<24 - 1)))
+	return [3]float32{
+		float32(v>>16) / 0xff,
+		float32((v>>8)&0xff) / 0xff,
+		float32(v&0xff) / 0xff,
+	}
+}
+
+const depthShaderFuncs = `
+func decodeDepth(c vec3) float {
+	return dot(floor(c*255+0.5), vec3(65536, 256, 1))
+}
+
+// depthAt returns the encoded depth at the destination position.
+// The depth image must be the 1st source image.
+func depthAt(dstPos vec4) vec3 {
+	// imageSrc1UnsafeAt takes a position in the 0th image's coordinate.
+	return imageSrc1UnsafeAt(dstPos.xy - imageDstOrigin() + imageSrc0Origin()).rgb
+}
+
+func sourceColorAt(srcPos vec2) vec4 {
+	if Filter == 0 {
+		return imageSrc0At(srcPos)
+	}
+	p0 := srcPos - 1/2.0
+	p1 := srcPos + 1/2.0
+	c0 := imageSrc0At(p0)
+	c1 := imageSrc0At(vec2(p1.x, p0.y))
+	c2 := imageSrc0At(vec2(p0.x, p1.y))
+	c3 := imageSrc0At(p1)
+	rate := fract(p1)
+	return mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)
+}
+`
+
+var depthColorShaderSrc = []byte(`//kage:unit pixels
+
+package main
+
+var Depth vec3
+var Test int
+var Filter int
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	if Test != 0 && decodeDepth(Depth) < decodeDepth(depthAt(dstPos)) {
+		discard()
+	}
+	return sourceColorAt(srcPos) * color
+}
+` + depthShaderFuncs)
+
+var depthWriteShaderSrc = []byte(`//kage:unit pixels
+
+package main
+
+var Depth vec3
+var Test int
+var Filter int
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	current := depthAt(dstPos)
+	if sourceColorAt(srcPos).a == 0 {
+		return vec4(current, 1)
+	}
+	if Test != 0 && decodeDepth(Depth) < decodeDepth(current) {
+		return vec4(current, 1)
+	}
+	return vec4(Depth, 1)
+}
+` + depthShaderFuncs)
+
+var depthCopyShaderSrc = []byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return imageSrc0UnsafeAt(dstPos.xy - imageDstOrigin() + imageSrc0Origin())
+}
+`)
+
+var (
+	depthColorShader     *Shader
+	depthColorShaderOnce sync.Once
+	depthWriteShader     *Shader
+	depthWriteShaderOnce sync.Once
+	depthCopyShader      *Shader
+	depthCopyShaderOnce  sync.Once
+)
+
+func ensureDepthShader(shader **Shader, once *sync.Once, src []byte) *Shader {
+	once.Do(func() {
+		s, err := NewShader(src)
+		if err != nil {
+			panic(fmt.Sprintf("ebiten: NewShader for the depth buffer failed: %v", err))
+		}
+		*shader = s
+	})
+	return *shader
+}
+
+// drawImageWithDepth draws img with the depth test and/or the depth write.
+// geoM must include the flips.
+//
+// drawImageWithDepth panics if options.ColorM is not a scale-only matrix or options.Filter is neither
+// FilterNearest nor FilterLinear, as the depth shaders don't support them.
+func (i *Image) drawImageWithDepth(img *Image, geoM GeoM, options *DrawImageOptions) {
+	if options.Filter != FilterNearest && options.Filter != FilterLinear {
+		panic(fmt.Sprintf("ebiten: the filter %d is not available with the depth test or the depth write", options.Filter))
+	}
+	colorm, cr, cg, cb, ca := colorMToScale(options.ColorM.affineColorM())
+	if !colorm.IsIdentity() {
+		panic("ebiten: DrawImageOptions.ColorM other than scaling is not available with the depth test or the depth write")
+	}
+
+	bounds := img.Bounds()
+	sx0, sy0 := float32(bounds.Min.X), float32(bounds.Min.Y)
+	w, h := float64(bounds.Dx()), float64(bounds.Dy())
+
+	cr, cg, cb, ca = options.ColorScale.apply(cr, cg, cb, ca)
+	var vs [4]Vertex
+	for idx, p := range [4][2]float64{{0, 0}, {w, 0}, {0, h}, {w, h}} {
+		x, y := geoM.Apply(p[0], p[1])
+		vs[idx] = Vertex{
+			DstX:   float32(x),
+			DstY:   float32(y),
+			SrcX:   sx0 + float32(p[0]),
+			SrcY:   sy0 + float32(p[1]),
+			ColorR: cr,
+			ColorG: cg,
+			ColorB: cb,
+			ColorA: ca,
+		}
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+
+	var test, filter int
+	if options.DepthTest {
+		test = 1
+	}
+	if options.Filter == FilterLinear {
+		filter = 1
+	}
+	uniforms := map[string]any{
+		"Depth":  encodeDepth(options.DepthValue),
+		"Test":   test,
+		"Filter": filter,
+	}
+
+	current := i.depth.current.SubImage(i.Bounds()).(*Image)
+	tmp := i.depth.tmp.SubImage(i.Bounds()).(*Image)
+
+	// Render the colors with the current depth values.
+	op := &DrawTrianglesShaderOptions{}
+	op.Uniforms = uniforms
+	op.Images[0] = img
+	op.Images[1] = current
+	if options.CompositeMode == CompositeModeCustom {
+		op.Blend = options.Blend
+	} else {
+		op.Blend = options.CompositeMode.blend()
+	}
+	i.DrawTrianglesShader(vs[:], is, ensureDepthShader(&depthColorShader, &depthColorShaderOnce, depthColorShaderSrc), op)
+
+	if !options.DepthWrite {
+		return
+	}
+
+	// Render the new depth values to the temporary buffer, and copy them back to the current buffer.
+	// The same triangles are used so that exactly the same pixels are updated.
+	op.Blend = BlendCopy
+	tmp.DrawTrianglesShader(vs[:], is, ensureDepthShader(&depthWriteShader, &depthWriteShaderOnce, depthWriteShaderSrc), op)
+
+	op.Uniforms = nil
+	op.Images[0] = tmp
+	op.Images[1] = nil
+	current.DrawTrianglesShader(vs[:], is, ensureDepthShader(&depthCopyShader, &depthCopyShaderOnce, depthCopyShaderSrc), op)
+}
+
+// ClearDepth clears the depth buffer of the image.
+// All the depth values are reset to 0, which is the farthest.
+//
+// If the image doesn't have a depth buffer, ClearDepth does nothing.
+//
+// When the image is disposed, ClearDepth does nothing.
+func (i *Image) ClearDepth() {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return
+	}
+	if i.depth == nil {
+		return
+	}
+	i.depth.current.SubImage(i.Bounds()).(*Image).Clear()
+}
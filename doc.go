@@ -54,6 +54,20 @@
 // DeviceScaleFactor) that must be called on the main thread under some conditions (typically, before ebiten.RunGame
 // is called).
 //
+// # Context lost
+//
+// Ebitengine doesn't restore images when the graphics context is lost,
+// and there is no callback to notify the context lost and the restoration.
+// How a lost context is handled depends on the platform:
+//
+//   - On browsers, when the WebGL context is lost, Ebitengine reloads the page, and the game starts from the beginning.
+//   - On Android, Ebitengine preserves the GL context while the application is paused.
+//     If the context is lost anyway, Ebitengine kills the application process by Runtime.exit(0)
+//     without calling any game functions. Save data at Suspender's OnSuspend in order not to lose it in this case.
+//   - On the other platforms, the graphics context is not lost in usual cases.
+//
+// Thus, there is no need to mark images to be regenerated by the game itself.
+//
 // # Environment variables
 //
 // `EBITENGINE_SCREENSHOT_KEY` environment variable specifies the key
@@ -61,6 +75,9 @@
 // `EBITENGINE_SCREENSHOT_KEY=q`, you can take a game screen's screenshot
 // by pressing Q key. This works only on desktops and browsers.
 //
+// `EBITENGINE_DEBUG_OVERLAY_KEY` environment variable specifies the key
+// to toggle the debug overlay. See SetDebugOverlayEnabled for the details.
+//
 // `EBITENGINE_INTERNAL_IMAGES_KEY` environment variable specifies the key
 // to dump all the internal images. This is valid only when the build tag
 // 'ebitenginedebug' is specified. This works only on desktops and browsers.
@@ -68,6 +85,7 @@
 // `EBITENGINE_GRAPHICS_LIBRARY` environment variable specifies the graphics library.
 // If the specified graphics library is not available, RunGame returns an error.
 // This environment variable works when RunGame is called or RunGameWithOptions is called with GraphicsLibraryAuto.
+// If RunGameOptions.GraphicsLibraries includes GraphicsLibraryAuto, the entry is replaced with the specified graphics library.
 // This can take one of the following value:
 //
 //	"auto":         Ebitengine chooses the graphics library automatically. This is the default value.
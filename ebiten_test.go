@@ -15,6 +15,7 @@
 package ebiten_test
 
 import (
+	"image/color"
 	"testing"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -30,3 +31,61 @@ func TestScreenSizeInFullscreen(t *testing.T) {
 		t.Errorf("h must be positive but not: %d", h)
 	}
 }
+
+func TestDebugDraw(t *testing.T) {
+	ebiten.SetDebugDrawEnabled(true)
+	defer ebiten.SetDebugDrawEnabled(false)
+
+	dst := ebiten.NewImage(16, 16)
+	ebiten.DebugLine(0, 8, 16, 8, &ebiten.DebugDrawOptions{
+		Color:  color.RGBA{R: 0xff, A: 0xff},
+		Frames: 2,
+	})
+
+	// The line should persist in two frames.
+	for i := 0; i < 3; i++ {
+		dst.Clear()
+		ebiten.DrawDebugPrimitivesForTesting(dst, ebiten.GeoM{})
+		got := dst.At(8, 8).(color.RGBA)
+		if i < 2 {
+			if got.R == 0 {
+				t.Errorf("frame %d: dst.At(8, 8): got: %v, want: red", i, got)
+			}
+		} else {
+			if want := (color.RGBA{}); got != want {
+				t.Errorf("frame %d: dst.At(8, 8): got: %v, want: %v", i, got, want)
+			}
+		}
+	}
+
+	// A disabled debug drawing doesn't queue primitives.
+	ebiten.SetDebugDrawEnabled(false)
+	ebiten.DebugRect(0, 0, 16, 16, nil)
+	dst.Clear()
+	ebiten.DrawDebugPrimitivesForTesting(dst, ebiten.GeoM{})
+	if got, want := dst.At(0, 0).(color.RGBA), (color.RGBA{}); got != want {
+		t.Errorf("dst.At(0, 0): got: %v, want: %v", got, want)
+	}
+}
+
+func TestDebugDrawWithGeoM(t *testing.T) {
+	ebiten.SetDebugDrawEnabled(true)
+	defer ebiten.SetDebugDrawEnabled(false)
+
+	// The primitives are in the game's screen coordinate, and are transformed to the final screen.
+	dst := ebiten.NewImage(32, 32)
+	ebiten.DebugLine(0, 4, 16, 4, &ebiten.DebugDrawOptions{
+		Color: color.RGBA{R: 0xff, A: 0xff},
+	})
+	var geoM ebiten.GeoM
+	geoM.Scale(2, 2)
+	geoM.Translate(0, 8)
+	ebiten.DrawDebugPrimitivesForTesting(dst, geoM)
+
+	if got := dst.At(16, 16).(color.RGBA); got.R == 0 {
+		t.Errorf("dst.At(16, 16): got: %v, want: red", got)
+	}
+	if got, want := dst.At(16, 4).(color.RGBA), (color.RGBA{}); got != want {
+		t.Errorf("dst.At(16, 4): got: %v, want: %v", got, want)
+	}
+}
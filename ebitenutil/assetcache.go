@@ -0,0 +1,158 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type assetCacheEntry[T any] struct {
+	asset T
+	refs  int
+}
+
+// AssetCache is a cache of assets like images, audio data, and fonts, keyed by paths.
+//
+// AssetCache loads an asset once, and shares it among the users with reference counting.
+// When the last reference is released, the asset is released by the release function and removed from the cache.
+// NewImageCache creates an AssetCache for images.
+//
+// For example, an AssetCache for audio data can be created with a loader decoding a file into bytes:
+//
+//	cache := ebitenutil.NewAssetCache(func(path string) ([]byte, error) {
+//		f, err := assets.Open(path)
+//		if err != nil {
+//			return nil, err
+//		}
+//		defer f.Close()
+//		s, err := vorbis.DecodeWithSampleRate(sampleRate, f)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return io.ReadAll(s)
+//	}, nil)
+//
+// Similarly, an AssetCache for font sources of the text/v2 package can be created with text.NewGoTextFaceSourceFromFS.
+// A font source doesn't have to be released explicitly, as it is garbage-collected:
+//
+//	cache := ebitenutil.NewAssetCache(func(path string) (*text.GoTextFaceSource, error) {
+//		return text.NewGoTextFaceSourceFromFS(assets, path)
+//	}, nil)
+//
+// AssetCache is concurrent-safe.
+type AssetCache[T any] struct {
+	load    func(path string) (T, error)
+	release func(asset T)
+	entries map[string]*assetCacheEntry[T]
+	m       sync.Mutex
+}
+
+// NewAssetCache creates a new AssetCache.
+//
+// load is called to load an asset that is not in the cache.
+// release is called when the last reference to an asset is released. release can be nil.
+//
+// load and release are called with the cache locked, so they must not call the same cache's functions.
+func NewAssetCache[T any](load func(path string) (T, error), release func(asset T)) *AssetCache[T] {
+	return &AssetCache[T]{
+		load:    load,
+		release: release,
+		entries: map[string]*assetCacheEntry[T]{},
+	}
+}
+
+// Acquire returns the asset for the path, and increments its reference count.
+// If the asset is not in the cache, Acquire loads it.
+//
+// If loading fails, Acquire returns the error and the reference count is not incremented.
+// The failure is not cached, and the next Acquire tries loading again.
+//
+// Each successful Acquire call must be paired with a Release call.
+func (c *AssetCache[T]) Acquire(path string) (T, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if e, ok := c.entries[path]; ok {
+		e.refs++
+		return e.asset, nil
+	}
+
+	asset, err := c.load(path)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	c.entries[path] = &assetCacheEntry[T]{
+		asset: asset,
+		refs:  1,
+	}
+	return asset, nil
+}
+
+// Release decrements the reference count of the asset for the path.
+// When the reference count reaches zero, the asset is released and removed from the cache.
+//
+// If the asset for the path is not acquired, Release panics.
+func (c *AssetCache[T]) Release(path string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	e, ok := c.entries[path]
+	if !ok {
+		panic(fmt.Sprintf("ebitenutil: the asset %q is not acquired", path))
+	}
+	e.refs--
+	if e.refs > 0 {
+		return
+	}
+	delete(c.entries, path)
+	if c.release != nil {
+		c.release(e.asset)
+	}
+}
+
+// RefCount returns the reference count of the asset for the path.
+// If the asset is not in the cache, RefCount returns 0.
+func (c *AssetCache[T]) RefCount(path string) int {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if e, ok := c.entries[path]; ok {
+		return e.refs
+	}
+	return 0
+}
+
+// Len returns the number of the assets in the cache.
+func (c *AssetCache[T]) Len() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return len(c.entries)
+}
+
+// NewImageCache creates a new AssetCache for images loaded from fsys by NewImageFromFS.
+// An image is deallocated when the last reference is released.
+func NewImageCache(fsys fs.FS) *AssetCache[*ebiten.Image] {
+	return NewAssetCache(func(path string) (*ebiten.Image, error) {
+		img, _, err := NewImageFromFS(fsys, path)
+		return img, err
+	}, func(img *ebiten.Image) {
+		img.Deallocate()
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func TestAssetCache(t *testing.T) {
+	var loaded, released []string
+	cache := ebitenutil.NewAssetCache(func(path string) (string, error) {
+		if path == "missing" {
+			return "", errors.New("not found")
+		}
+		loaded = append(loaded, path)
+		return "asset:" + path, nil
+	}, func(asset string) {
+		released = append(released, asset)
+	})
+
+	for i := 0; i < 2; i++ {
+		a, err := cache.Acquire("a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := a, "asset:a"; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+	}
+	if got, want := len(loaded), 1; got != want {
+		t.Errorf("the number of loads: got: %d, want: %d", got, want)
+	}
+	if got, want := cache.RefCount("a"), 2; got != want {
+		t.Errorf("RefCount: got: %d, want: %d", got, want)
+	}
+
+	if _, err := cache.Acquire("missing"); err == nil {
+		t.Errorf("Acquire must return an error for a missing asset")
+	}
+	if got, want := cache.Len(), 1; got != want {
+		t.Errorf("Len: got: %d, want: %d", got, want)
+	}
+
+	cache.Release("a")
+	if len(released) != 0 {
+		t.Errorf("the asset must not be released while it is referenced")
+	}
+	cache.Release("a")
+	if got, want := released, []string{"asset:a"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("released: got: %q, want: %q", got, want)
+	}
+	if got, want := cache.Len(), 0; got != want {
+		t.Errorf("Len: got: %d, want: %d", got, want)
+	}
+
+	// The asset is loaded again after it is released.
+	if _, err := cache.Acquire("a"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(loaded), 2; got != want {
+		t.Errorf("the number of loads: got: %d, want: %d", got, want)
+	}
+}
+
+func TestImageCache(t *testing.T) {
+	cache := ebitenutil.NewImageCache(images)
+	img0, err := cache.Acquire("text.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	img1, err := cache.Acquire("text.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img0 != img1 {
+		t.Errorf("the same image must be shared")
+	}
+	cache.Release("text.png")
+	cache.Release("text.png")
+	if got, want := cache.Len(), 0; got != want {
+		t.Errorf("Len: got: %d, want: %d", got, want)
+	}
+}
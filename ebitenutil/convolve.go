@@ -0,0 +1,330 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// maxBlurTaps is the maximum number of the one-side taps of the blur shader.
+	maxBlurTaps = 32
+
+	// MaxKernelSize is the maximum width and height of a kernel for Convolve.
+	MaxKernelSize = 9
+)
+
+var blurShaderSrc = []byte(fmt.Sprintf(`//kage:unit pixels
+
+package main
+
+var Direction vec2
+var Taps int
+var Weights [%[1]d]float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+	minPos := origin + 0.5
+	maxPos := origin + size - 0.5
+
+	clr := imageSrc0UnsafeAt(srcPos) * Weights[0]
+	for i := 1; i < %[1]d; i++ {
+		if i > Taps {
+			break
+		}
+		d := Direction * float(i)
+		clr += imageSrc0UnsafeAt(clamp(srcPos+d, minPos, maxPos)) * Weights[i]
+		clr += imageSrc0UnsafeAt(clamp(srcPos-d, minPos, maxPos)) * Weights[i]
+	}
+	return clr
+}
+`, maxBlurTaps+1))
+
+var convolveShaderSrc = []byte(fmt.Sprintf(`//kage:unit pixels
+
+package main
+
+var KernelWidth int
+var KernelHeight int
+var Kernel [%[2]d]float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+	minPos := origin + 0.5
+	maxPos := origin + size - 0.5
+
+	clr := vec4(0)
+	for j := 0; j < %[1]d; j++ {
+		if j >= KernelHeight {
+			break
+		}
+		for i := 0; i < %[1]d; i++ {
+			if i >= KernelWidth {
+				break
+			}
+			d := vec2(float(i-KernelWidth/2), float(j-KernelHeight/2))
+			clr += imageSrc0UnsafeAt(clamp(srcPos+d, minPos, maxPos)) * Kernel[j*%[1]d+i]
+		}
+	}
+	return clr
+}
+`, MaxKernelSize, MaxKernelSize*MaxKernelSize))
+
+// resampleShaderSrc is a shader for bilinear sampling with clamping positions to the edges.
+var resampleShaderSrc = []byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+	minPos := origin + 0.5
+	maxPos := origin + size - 0.5
+
+	p := srcPos - 0.5
+	p0 := floor(p) + 0.5
+	p1 := p0 + 1
+	rate := fract(p)
+	c0 := imageSrc0UnsafeAt(clamp(p0, minPos, maxPos))
+	c1 := imageSrc0UnsafeAt(clamp(vec2(p1.x, p0.y), minPos, maxPos))
+	c2 := imageSrc0UnsafeAt(clamp(vec2(p0.x, p1.y), minPos, maxPos))
+	c3 := imageSrc0UnsafeAt(clamp(p1, minPos, maxPos))
+	return mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)
+}
+`)
+
+var (
+	blurShader     *ebiten.Shader
+	blurShaderOnce sync.Once
+
+	convolveShader     *ebiten.Shader
+	convolveShaderOnce sync.Once
+
+	resampleShader     *ebiten.Shader
+	resampleShaderOnce sync.Once
+)
+
+func ensureBlurShader() *ebiten.Shader {
+	blurShaderOnce.Do(func() {
+		s, err := ebiten.NewShader(blurShaderSrc)
+		if err != nil {
+			panic(fmt.Sprintf("ebitenutil: NewShader for the blur shader failed: %v", err))
+		}
+		blurShader = s
+	})
+	return blurShader
+}
+
+func ensureConvolveShader() *ebiten.Shader {
+	convolveShaderOnce.Do(func() {
+		s, err := ebiten.NewShader(convolveShaderSrc)
+		if err != nil {
+			panic(fmt.Sprintf("ebitenutil: NewShader for the convolution shader failed: %v", err))
+		}
+		convolveShader = s
+	})
+	return convolveShader
+}
+
+func ensureResampleShader() *ebiten.Shader {
+	resampleShaderOnce.Do(func() {
+		s, err := ebiten.NewShader(resampleShaderSrc)
+		if err != nil {
+			panic(fmt.Sprintf("ebitenutil: NewShader for the resampling shader failed: %v", err))
+		}
+		resampleShader = s
+	})
+	return resampleShader
+}
+
+// resample draws src onto the whole region of dst with bilinear filtering.
+// Unlike DrawImage with FilterLinear, resample never picks pixels outside of src.
+func resample(dst, src *ebiten.Image) {
+	sb := src.Bounds()
+	dw, dh := float32(dst.Bounds().Dx()), float32(dst.Bounds().Dy())
+	sx0, sy0 := float32(sb.Min.X), float32(sb.Min.Y)
+	sx1, sy1 := float32(sb.Max.X), float32(sb.Max.Y)
+	vs := []ebiten.Vertex{
+		{DstX: 0, DstY: 0, SrcX: sx0, SrcY: sy0},
+		{DstX: dw, DstY: 0, SrcX: sx1, SrcY: sy0},
+		{DstX: 0, DstY: dh, SrcX: sx0, SrcY: sy1},
+		{DstX: dw, DstY: dh, SrcX: sx1, SrcY: sy1},
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	op.Images[0] = src
+	op.Blend = ebiten.BlendCopy
+	dst.DrawTrianglesShader(vs, is, ensureResampleShader(), op)
+}
+
+// Blur returns a new image with the Gaussian blur applied to src.
+//
+// radius is the standard deviation of the Gaussian function in pixels.
+// If radius is not a positive number, Blur returns a copy of src.
+//
+// Blur is implemented as a separable two-pass shader.
+// For a large radius, src is downsampled before blurring and then upsampled, so that the cost doesn't grow much.
+// The pixels outside of src are treated as the nearest edge pixels of src.
+//
+// Blur creates new images every call. If the result doesn't change, cache the result instead of calling Blur
+// every frame.
+func Blur(src *ebiten.Image, radius float64) *ebiten.Image {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := ebiten.NewImage(w, h)
+	if radius <= 0 {
+		dst.DrawImage(src, nil)
+		return dst
+	}
+
+	taps := int(math.Ceil(radius * 3))
+	if taps <= maxBlurTaps {
+		blur(dst, src, radius, taps)
+		return dst
+	}
+
+	// Downsample the source by halves so that the number of taps fits with the shader.
+	// Each halving is a box filter, which keeps the result smooth.
+	var smalls []*ebiten.Image
+	defer func() {
+		for _, img := range smalls {
+			img.Deallocate()
+		}
+	}()
+	small := src
+	r := radius
+	for taps > maxBlurTaps {
+		sw := (small.Bounds().Dx() + 1) / 2
+		sh := (small.Bounds().Dy() + 1) / 2
+		img := ebiten.NewImage(sw, sh)
+		smalls = append(smalls, img)
+		resample(img, small)
+		small = img
+		r /= 2
+		taps = int(math.Ceil(r * 3))
+	}
+
+	blurred := ebiten.NewImage(small.Bounds().Dx(), small.Bounds().Dy())
+	defer blurred.Deallocate()
+	blur(blurred, small, r, taps)
+	resample(dst, blurred)
+	return dst
+}
+
+func blur(dst, src *ebiten.Image, sigma float64, taps int) {
+	if taps > maxBlurTaps {
+		taps = maxBlurTaps
+	}
+
+	weights := make([]float32, maxBlurTaps+1)
+	var sum float64
+	for i := 0; i <= taps; i++ {
+		v := math.Exp(-float64(i*i) / (2 * sigma * sigma))
+		weights[i] = float32(v)
+		if i == 0 {
+			sum += v
+		} else {
+			sum += 2 * v
+		}
+	}
+	for i := range weights {
+		weights[i] /= float32(sum)
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	tmp := ebiten.NewImage(w, h)
+	defer tmp.Deallocate()
+
+	s := ensureBlurShader()
+
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = src
+	op.Uniforms = map[string]any{
+		"Direction": []float32{1, 0},
+		"Taps":      taps,
+		"Weights":   weights,
+	}
+	op.Blend = ebiten.BlendCopy
+	tmp.DrawRectShader(w, h, s, op)
+
+	op = &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = tmp
+	op.Uniforms = map[string]any{
+		"Direction": []float32{0, 1},
+		"Taps":      taps,
+		"Weights":   weights,
+	}
+	op.Blend = ebiten.BlendCopy
+	dst.DrawRectShader(w, h, s, op)
+}
+
+// Convolve returns a new image with the convolution by kernel applied to src.
+//
+// kernel is a matrix indexed by [y][x]. The center of kernel corresponds to the target pixel.
+// The width and the height of kernel must be odd numbers and must not be more than MaxKernelSize.
+// All the rows of kernel must have the same length. Otherwise, Convolve panics.
+//
+// For example, a sharpening kernel is:
+//
+//	[][]float64{
+//	    {0, -1, 0},
+//	    {-1, 5, -1},
+//	    {0, -1, 0},
+//	}
+//
+// The kernel is applied to premultiplied-alpha colors.
+// The pixels outside of src are treated as the nearest edge pixels of src.
+//
+// Convolve creates a new image every call. If the result doesn't change, cache the result instead of calling
+// Convolve every frame.
+func Convolve(src *ebiten.Image, kernel [][]float64) *ebiten.Image {
+	kh := len(kernel)
+	if kh == 0 || kh%2 == 0 || kh > MaxKernelSize {
+		panic(fmt.Sprintf("ebitenutil: the kernel height must be an odd number <= %d but %d", MaxKernelSize, kh))
+	}
+	kw := len(kernel[0])
+	if kw == 0 || kw%2 == 0 || kw > MaxKernelSize {
+		panic(fmt.Sprintf("ebitenutil: the kernel width must be an odd number <= %d but %d", MaxKernelSize, kw))
+	}
+
+	values := make([]float32, MaxKernelSize*MaxKernelSize)
+	for j, row := range kernel {
+		if len(row) != kw {
+			panic(fmt.Sprintf("ebitenutil: all the kernel rows must have the same length %d but the row %d has %d", kw, j, len(row)))
+		}
+		for i, v := range row {
+			values[j*MaxKernelSize+i] = float32(v)
+		}
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := ebiten.NewImage(w, h)
+
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = src
+	op.Uniforms = map[string]any{
+		"KernelWidth":  kw,
+		"KernelHeight": kh,
+		"Kernel":       values,
+	}
+	op.Blend = ebiten.BlendCopy
+	dst.DrawRectShader(w, h, ensureConvolveShader(), op)
+	return dst
+}
@@ -0,0 +1,162 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
+)
+
+func TestMain(m *testing.M) {
+	t.MainWithRunLoop(m)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func sameColors(c0, c1 color.RGBA, delta int) bool {
+	if abs(int(c0.R)-int(c1.R)) > delta {
+		return false
+	}
+	if abs(int(c0.G)-int(c1.G)) > delta {
+		return false
+	}
+	if abs(int(c0.B)-int(c1.B)) > delta {
+		return false
+	}
+	if abs(int(c0.A)-int(c1.A)) > delta {
+		return false
+	}
+	return true
+}
+
+func TestBlurSolidColor(t *testing.T) {
+	const w, h = 64, 48
+	clr := color.RGBA{R: 0x80, G: 0x40, B: 0x20, A: 0xff}
+	src := ebiten.NewImage(w, h)
+	src.Fill(clr)
+
+	for _, radius := range []float64{0, 1, 4, 50} {
+		dst := ebitenutil.Blur(src, radius)
+		if got, want := dst.Bounds().Size(), src.Bounds().Size(); got != want {
+			t.Errorf("radius: %f, size: got: %v, want: %v", radius, got, want)
+		}
+		// As the edges are clamped, a solid color image should keep the color.
+		for j := 0; j < h; j += 7 {
+			for i := 0; i < w; i += 7 {
+				got := dst.At(i, j).(color.RGBA)
+				if !sameColors(got, clr, 1) {
+					t.Errorf("radius: %f, dst.At(%d, %d): got: %v, want: %v", radius, i, j, got, clr)
+				}
+			}
+		}
+	}
+}
+
+func TestBlurSpreads(t *testing.T) {
+	const w, h = 32, 32
+	src := ebiten.NewImage(w, h)
+	src.Set(w/2, h/2, color.White)
+
+	dst := ebitenutil.Blur(src, 2)
+	center := dst.At(w/2, h/2).(color.RGBA)
+	neighbor := dst.At(w/2+1, h/2).(color.RGBA)
+	far := dst.At(0, 0).(color.RGBA)
+	if center.A == 0 || center.A == 0xff {
+		t.Errorf("center: got: %v, want: a blurred color", center)
+	}
+	if neighbor.A == 0 || neighbor.A > center.A {
+		t.Errorf("neighbor: got: %v, want: a blurred color darker than the center %v", neighbor, center)
+	}
+	if far.A != 0 {
+		t.Errorf("far: got: %v, want: transparent", far)
+	}
+}
+
+func TestConvolveIdentity(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			src.Set(i, j, color.RGBA{R: byte(i * 16), G: byte(j * 16), A: 0xff})
+		}
+	}
+
+	dst := ebitenutil.Convolve(src, [][]float64{
+		{0, 0, 0},
+		{0, 1, 0},
+		{0, 0, 0},
+	})
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := src.At(i, j).(color.RGBA)
+			if !sameColors(got, want, 1) {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestConvolveShift(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			src.Set(i, j, color.RGBA{R: byte(i * 16), G: byte(j * 16), A: 0xff})
+		}
+	}
+
+	// This kernel picks the right pixel.
+	dst := ebitenutil.Convolve(src, [][]float64{
+		{0, 0, 0},
+		{0, 0, 1},
+		{0, 0, 0},
+	})
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			si := i + 1
+			if si >= w {
+				si = w - 1
+			}
+			want := src.At(si, j).(color.RGBA)
+			if !sameColors(got, want, 1) {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestConvolveInvalidKernel(t *testing.T) {
+	defer func() {
+		if e := recover(); e == nil {
+			t.Errorf("Convolve with an even-sized kernel must panic")
+		}
+	}()
+	src := ebiten.NewImage(16, 16)
+	ebitenutil.Convolve(src, [][]float64{
+		{0, 1},
+		{1, 0},
+	})
+}
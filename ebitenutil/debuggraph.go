@@ -0,0 +1,194 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DebugGraph is a bar graph of the latest values, which is useful for profiling.
+//
+// DebugGraph keeps a fixed number of values in a ring buffer.
+// Pushing and drawing don't allocate memory, so it is fine to push and draw values every frame.
+type DebugGraph struct {
+	label  string
+	values []float64
+	next   int
+	count  int
+
+	vertices []ebiten.Vertex
+	indices  []uint16
+	text     []byte
+	textStr  string
+}
+
+// NewDebugGraph returns a new DebugGraph that keeps the latest size values.
+// label is shown with the statistics of the values.
+//
+// If size is not positive, NewDebugGraph panics.
+func NewDebugGraph(label string, size int) *DebugGraph {
+	if size <= 0 {
+		panic(fmt.Sprintf("ebitenutil: size at NewDebugGraph must be positive but %d", size))
+	}
+	return &DebugGraph{
+		label:  label,
+		values: make([]float64, size),
+	}
+}
+
+// Push adds a value to the graph.
+// If the graph is full, the oldest value is discarded.
+func (g *DebugGraph) Push(value float64) {
+	g.values[g.next] = value
+	g.next = (g.next + 1) % len(g.values)
+	if g.count < len(g.values) {
+		g.count++
+	}
+}
+
+// Len returns the number of the values in the graph.
+func (g *DebugGraph) Len() int {
+	return g.count
+}
+
+// At returns the i-th value from the oldest one.
+//
+// If i is out of range, At panics.
+func (g *DebugGraph) At(i int) float64 {
+	if i < 0 || i >= g.count {
+		panic(fmt.Sprintf("ebitenutil: index at At is out of range: %d", i))
+	}
+	return g.values[(g.next-g.count+i+len(g.values))%len(g.values)]
+}
+
+// Stats returns the minimum, the average, and the maximum of the values.
+// If the graph is empty, Stats returns zeros.
+func (g *DebugGraph) Stats() (min, avg, max float64) {
+	if g.count == 0 {
+		return 0, 0, 0
+	}
+	min = math.Inf(1)
+	max = math.Inf(-1)
+	var sum float64
+	for i := 0; i < g.count; i++ {
+		v := g.At(i)
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+		sum += v
+	}
+	return min, sum / float64(g.count), max
+}
+
+// Draw draws the graph in the rectangle (x, y)-(x+width, y+height) on dst.
+//
+// Each value is drawn as a bar from the bottom, where the top of the rectangle corresponds to the maximum value.
+// The average is drawn as a horizontal line, and the label, the minimum, the average, and the maximum are printed
+// at the top-left corner.
+func (g *DebugGraph) Draw(dst *ebiten.Image, x, y, width, height int) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	min, avg, max := g.Stats()
+
+	g.vertices = g.vertices[:0]
+	g.indices = g.indices[:0]
+	fx, fy, fw, fh := float32(x), float32(y), float32(width), float32(height)
+	g.appendRect(fx, fy, fw, fh, 0, 0, 0, 0.5)
+	if max > 0 {
+		barWidth := fw / float32(len(g.values))
+		for i := 0; i < g.count; i++ {
+			v := g.At(i)
+			if v <= 0 {
+				continue
+			}
+			h := float32(v/max) * fh
+			g.appendRect(fx+float32(i)*barWidth, fy+fh-h, barWidth, h, 0, 0.75, 0.25, 1)
+		}
+		g.appendRect(fx, fy+fh-float32(avg/max)*fh, fw, 1, 1, 1, 0, 1)
+	}
+
+	op := &ebiten.DrawTrianglesOptions{}
+	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	dst.DrawTriangles(g.vertices, g.indices, shapeWhiteSubImage, op)
+
+	g.text = append(g.text[:0], g.label...)
+	g.text = append(g.text, "\nmin "...)
+	g.text = strconv.AppendFloat(g.text, min, 'f', 2, 64)
+	g.text = append(g.text, "\navg "...)
+	g.text = strconv.AppendFloat(g.text, avg, 'f', 2, 64)
+	g.text = append(g.text, "\nmax "...)
+	g.text = strconv.AppendFloat(g.text, max, 'f', 2, 64)
+	// Comparing a string converted from bytes doesn't allocate memory.
+	if string(g.text) != g.textStr {
+		g.textStr = string(g.text)
+	}
+	drawDebugText(dst, g.textStr, x, y, nil)
+}
+
+// appendRect appends a rectangle with the premultiplied-alpha color.
+func (g *DebugGraph) appendRect(x, y, width, height float32, r, gr, b, a float32) {
+	idx := uint16(len(g.vertices))
+	for _, p := range [4][2]float32{{x, y}, {x + width, y}, {x, y + height}, {x + width, y + height}} {
+		g.vertices = append(g.vertices, ebiten.Vertex{
+			DstX:   p[0],
+			DstY:   p[1],
+			SrcX:   1,
+			SrcY:   1,
+			ColorR: r * a,
+			ColorG: gr * a,
+			ColorB: b * a,
+			ColorA: a,
+		})
+	}
+	g.indices = append(g.indices, idx, idx+1, idx+2, idx+1, idx+3, idx+2)
+}
+
+var (
+	fpsGraph     *DebugGraph
+	fpsGraphLast time.Time
+	fpsGraphM    sync.Mutex
+)
+
+// fpsGraphSize is the number of frames shown in the graph of DrawFPSGraph.
+const fpsGraphSize = 120
+
+// DrawFPSGraph draws a graph of the frame times in milliseconds in the rectangle (x, y)-(x+width, y+height) on dst.
+//
+// DrawFPSGraph measures the time since the last call as a frame time, so call DrawFPSGraph exactly once per frame,
+// typically at the end of Draw.
+// A rolling graph of frame times reveals stutters that an average like ActualFPS hides.
+//
+// To draw a graph of arbitrary values, use DebugGraph.
+func DrawFPSGraph(dst *ebiten.Image, x, y, width, height int) {
+	fpsGraphM.Lock()
+	defer fpsGraphM.Unlock()
+
+	if fpsGraph == nil {
+		fpsGraph = NewDebugGraph("Frame time (ms)", fpsGraphSize)
+	}
+	now := time.Now()
+	if !fpsGraphLast.IsZero() {
+		fpsGraph.Push(float64(now.Sub(fpsGraphLast)) / float64(time.Millisecond))
+	}
+	fpsGraphLast = now
+	fpsGraph.Draw(dst, x, y, width, height)
+}
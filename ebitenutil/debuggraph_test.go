@@ -0,0 +1,61 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func TestDebugGraphRingBuffer(t *testing.T) {
+	g := ebitenutil.NewDebugGraph("test", 4)
+	for i := 1; i <= 6; i++ {
+		g.Push(float64(i))
+	}
+	if got, want := g.Len(), 4; got != want {
+		t.Fatalf("Len(): got: %d, want: %d", got, want)
+	}
+	for i := 0; i < g.Len(); i++ {
+		if got, want := g.At(i), float64(i+3); got != want {
+			t.Errorf("At(%d): got: %v, want: %v", i, got, want)
+		}
+	}
+	min, avg, max := g.Stats()
+	if min != 3 || avg != 4.5 || max != 6 {
+		t.Errorf("Stats(): got: (%v, %v, %v), want: (3, 4.5, 6)", min, avg, max)
+	}
+}
+
+func TestDebugGraphDraw(t *testing.T) {
+	const w, h = 64, 128
+	dst := ebiten.NewImage(w, h)
+
+	g := ebitenutil.NewDebugGraph("", 2)
+	g.Push(0)
+	g.Push(1)
+	g.Draw(dst, 0, 0, w, h)
+
+	// The bar of the maximum value reaches the top.
+	if got := dst.At(w-1, 1).(color.RGBA); got.G == 0 {
+		t.Errorf("dst.At(%d, 1): got: %v, want: a bar color", w-1, got)
+	}
+	// The zero value doesn't have a bar but only the background. The texts are at the upper side.
+	if got := dst.At(1, h-1).(color.RGBA); got.G != 0 || got.A == 0 {
+		t.Errorf("dst.At(1, %d): got: %v, want: the background color", h-1, got)
+	}
+}
@@ -12,58 +12,162 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:generate go run gen.go
-
 package ebitenutil
 
 import (
-	"bytes"
-	_ "embed"
-	"image"
-	_ "image/png"
+	"image/color"
+	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/internal/debugfont"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
-//go:embed text.png
-var text_png []byte
-
 var (
 	debugPrintTextImage     *ebiten.Image
 	debugPrintTextSubImages = map[rune]*ebiten.Image{}
+
+	debugPrintFace  DebugFont
+	debugPrintFaceM sync.Mutex
 )
 
 func init() {
-	img, _, err := image.Decode(bytes.NewReader(text_png))
-	if err != nil {
-		panic(err)
-	}
-	debugPrintTextImage = ebiten.NewImageFromImage(img)
+	debugPrintTextImage = ebiten.NewImageFromImage(debugfont.Image())
+}
+
+// DebugFont is a font used by DebugPrint, DebugPrintAt, and DebugPrintAtWithOptions instead of the built-in bitmap font.
+//
+// The text/v2 package's DebugFont implements DebugFont with a text/v2 face.
+type DebugFont interface {
+	// Glyph returns the image of the glyph for r, the position of the image relative to the pen position on the top of the line,
+	// and the advance in pixels.
+	// If the image is nil, nothing is rendered for r but the pen position is advanced.
+	Glyph(r rune) (img *ebiten.Image, x, y, advance float64)
+
+	// LineHeight returns the height of a line in pixels.
+	LineHeight() float64
+}
+
+// SetDebugFont sets the font used by DebugPrint, DebugPrintAt, and DebugPrintAtWithOptions.
+//
+// With a custom font, any runes the font supports are available, e.g. player names or localized strings.
+// The line height is the font's LineHeight instead of the built-in bitmap font's.
+//
+// For example, to use a text/v2 face:
+//
+//	ebitenutil.SetDebugFont(text.NewDebugFont(face))
+//
+// If face is nil, the built-in bitmap font is used, which is the default.
+//
+// SetDebugFont is concurrent-safe.
+func SetDebugFont(face DebugFont) {
+	debugPrintFaceM.Lock()
+	defer debugPrintFaceM.Unlock()
+	debugPrintFace = face
+}
+
+func currentDebugFont() DebugFont {
+	debugPrintFaceM.Lock()
+	defer debugPrintFaceM.Unlock()
+	return debugPrintFace
 }
 
 // DebugPrint draws the string str on the image on left top corner.
 //
-// The available runes are in U+0000 to U+00FF, which is C0 Controls and Basic Latin and C1 Controls and Latin-1 Supplement.
+// The available runes are in U+0000 to U+00FF, which is C0 Controls and Basic Latin and C1 Controls and Latin-1 Supplement,
+// unless a font face is set by SetDebugFont.
 func DebugPrint(image *ebiten.Image, str string) {
 	DebugPrintAt(image, str, 0, 0)
 }
 
 // DebugPrintAt draws the string str on the image at (x, y) position.
 //
-// The available runes are in U+0000 to U+00FF, which is C0 Controls and Basic Latin and C1 Controls and Latin-1 Supplement.
+// The available runes are in U+0000 to U+00FF, which is C0 Controls and Basic Latin and C1 Controls and Latin-1 Supplement,
+// unless a font face is set by SetDebugFont.
 func DebugPrintAt(image *ebiten.Image, str string, x, y int) {
-	drawDebugText(image, str, x, y)
+	drawDebugText(image, str, x, y, nil)
+}
+
+// DebugPrintOptions represents options for DebugPrintAtWithOptions.
+type DebugPrintOptions struct {
+	// Color is the text color.
+	//
+	// The default (zero) value is nil, which means white.
+	Color color.Color
+
+	// BackgroundColor is the color of a box rendered behind the text.
+	// The box fits the text, including multiple lines.
+	// A semi-transparent color is useful to keep the text readable over bright scenes.
+	//
+	// The default (zero) value is nil, which means no box is rendered.
+	BackgroundColor color.Color
+
+	// Scale is the integer scale factor of the text.
+	//
+	// The default (zero) value is 0, which means 1.
+	Scale int
+}
+
+// DebugPrintAtWithOptions draws the string str on the image at (x, y) position with the given options.
+//
+// The available runes are in U+0000 to U+00FF, which is C0 Controls and Basic Latin and C1 Controls and Latin-1 Supplement,
+// unless a font face is set by SetDebugFont.
+func DebugPrintAtWithOptions(image *ebiten.Image, str string, x, y int, options *DebugPrintOptions) {
+	drawDebugText(image, str, x, y, options)
 }
 
-func drawDebugText(rt *ebiten.Image, str string, ox, oy int) {
+const (
+	debugPrintCharWidth  = debugfont.CharWidth
+	debugPrintCharHeight = debugfont.LineHeight
+)
+
+// debugTextSize returns the size of the text in the number of characters.
+func debugTextSize(str string) (int, int) {
+	w, h := 0, 1
+	var x int
+	for _, c := range str {
+		if c == '\n' {
+			x = 0
+			h++
+			continue
+		}
+		x++
+		if w < x {
+			w = x
+		}
+	}
+	return w, h
+}
+
+func drawDebugText(rt *ebiten.Image, str string, ox, oy int, options *DebugPrintOptions) {
+	scale := 1
+	if options != nil && options.Scale > 0 {
+		scale = options.Scale
+	}
+
+	if face := currentDebugFont(); face != nil {
+		drawDebugTextWithFace(rt, str, ox, oy, scale, face, options)
+		return
+	}
+
+	if options != nil && options.BackgroundColor != nil && str != "" {
+		cols, rows := debugTextSize(str)
+		// Add one pixel on both sides as the text is rendered with one pixel offset.
+		w := (cols*debugPrintCharWidth + 2) * scale
+		h := rows * debugPrintCharHeight * scale
+		vector.DrawFilledRect(rt, float32(ox), float32(oy), float32(w), float32(h), options.BackgroundColor, false)
+	}
+
 	op := &ebiten.DrawImageOptions{}
+	if options != nil && options.Color != nil {
+		op.ColorScale.ScaleWithColor(options.Color)
+	}
 	x := 0
 	y := 0
-	w := debugPrintTextImage.Bounds().Dx()
 	for _, c := range str {
 		const (
-			cw = 6
-			ch = 16
+			cw = debugPrintCharWidth
+			ch = debugPrintCharHeight
 		)
 		if c == '\n' {
 			x = 0
@@ -72,16 +176,59 @@ func drawDebugText(rt *ebiten.Image, str string, ox, oy int) {
 		}
 		s, ok := debugPrintTextSubImages[c]
 		if !ok {
-			n := w / cw
-			sx := (int(c) % n) * cw
-			sy := (int(c) / n) * ch
-			s = debugPrintTextImage.SubImage(image.Rect(sx, sy, sx+cw, sy+ch)).(*ebiten.Image)
+			s = debugPrintTextImage.SubImage(debugfont.GlyphRect(c)).(*ebiten.Image)
 			debugPrintTextSubImages[c] = s
 		}
 		op.GeoM.Reset()
-		op.GeoM.Translate(float64(x), float64(y))
-		op.GeoM.Translate(float64(ox+1), float64(oy))
+		op.GeoM.Translate(float64(x+1), float64(y))
+		op.GeoM.Scale(float64(scale), float64(scale))
+		op.GeoM.Translate(float64(ox), float64(oy))
 		rt.DrawImage(s, op)
 		x += cw
 	}
 }
+
+func drawDebugTextWithFace(rt *ebiten.Image, str string, ox, oy int, scale int, face DebugFont, options *DebugPrintOptions) {
+	lineHeight := face.LineHeight()
+
+	if options != nil && options.BackgroundColor != nil && str != "" {
+		var w, x float64
+		h := lineHeight
+		for _, c := range str {
+			if c == '\n' {
+				x = 0
+				h += lineHeight
+				continue
+			}
+			_, _, _, a := face.Glyph(c)
+			x += a
+			if w < x {
+				w = x
+			}
+		}
+		// Add one pixel on both sides as the text is rendered with one pixel offset.
+		vector.DrawFilledRect(rt, float32(ox), float32(oy), float32((w+2)*float64(scale)), float32(h*float64(scale)), options.BackgroundColor, false)
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	if options != nil && options.Color != nil {
+		op.ColorScale.ScaleWithColor(options.Color)
+	}
+	var x, y float64
+	for _, c := range str {
+		if c == '\n' {
+			x = 0
+			y += lineHeight
+			continue
+		}
+		img, gx, gy, a := face.Glyph(c)
+		if img != nil {
+			op.GeoM.Reset()
+			op.GeoM.Translate(x+gx+1, y+gy)
+			op.GeoM.Scale(float64(scale), float64(scale))
+			op.GeoM.Translate(float64(ox), float64(oy))
+			rt.DrawImage(img, op)
+		}
+		x += a
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+func TestDebugPrintAtWithOptionsBackground(t *testing.T) {
+	dst := ebiten.NewImage(64, 80)
+	blue := color.RGBA{B: 0xff, A: 0xff}
+	ebitenutil.DebugPrintAtWithOptions(dst, "a\nbbb", 0, 0, &ebitenutil.DebugPrintOptions{
+		Color:           color.RGBA{R: 0xff, A: 0xff},
+		BackgroundColor: blue,
+		Scale:           2,
+	})
+
+	// The box is (3 characters * 6 pixels + 2 pixels) * 2 wide and 2 lines * 16 pixels * 2 high.
+	testCases := []struct {
+		X    int
+		Y    int
+		Want color.RGBA
+	}{
+		{X: 0, Y: 0, Want: blue},
+		{X: 39, Y: 0, Want: blue},
+		{X: 0, Y: 63, Want: blue},
+		{X: 40, Y: 0, Want: color.RGBA{}},
+		{X: 0, Y: 64, Want: color.RGBA{}},
+	}
+	for _, tc := range testCases {
+		if got := dst.At(tc.X, tc.Y).(color.RGBA); got != tc.Want {
+			t.Errorf("dst.At(%d, %d): got: %v, want: %v", tc.X, tc.Y, got, tc.Want)
+		}
+	}
+
+	// The text is rendered with the color. No white pixels should exist.
+	var red bool
+	for j := 0; j < 64; j++ {
+		for i := 0; i < 40; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			if got == (color.RGBA{R: 0xff, A: 0xff}) {
+				red = true
+			}
+			if got.G != 0 {
+				t.Errorf("dst.At(%d, %d): got: %v, want: no green", i, j, got)
+			}
+		}
+	}
+	if !red {
+		t.Errorf("no red pixels")
+	}
+}
+
+func TestSetDebugFont(t *testing.T) {
+	const w, h = 64, 32
+	const str = "Ab"
+
+	before := ebiten.NewImage(w, h)
+	ebitenutil.DebugPrint(before, str)
+
+	src, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ebitenutil.SetDebugFont(text.NewDebugFont(&text.GoTextFace{
+		Source: src,
+		Size:   24,
+	}))
+	custom := ebiten.NewImage(w, h)
+	ebitenutil.DebugPrint(custom, str)
+
+	ebitenutil.SetDebugFont(nil)
+	after := ebiten.NewImage(w, h)
+	ebitenutil.DebugPrint(after, str)
+
+	var diff bool
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			if before.At(i, j) != custom.At(i, j) {
+				diff = true
+			}
+			// Resetting the face must restore the built-in bitmap font.
+			if got, want := after.At(i, j), before.At(i, j); got != want {
+				t.Errorf("after.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+	if !diff {
+		t.Errorf("the custom face must change the rendering result")
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type drawContextState struct {
+	geoM       ebiten.GeoM
+	colorScale ebiten.ColorScale
+}
+
+// DrawContext is a wrapper of a destination image with a current transform and a current color scale.
+//
+// DrawContext has a stack of the states. A library can push the state, change the transform and the color scale,
+// draw things relative to the current transform, and then pop the state to restore the caller's state.
+// This is similar to the save and restore of the canvas API in browsers.
+//
+// For example:
+//
+//	func drawCharacter(ctx *ebitenutil.DrawContext, c *Character) {
+//		ctx.Push()
+//		defer ctx.Pop()
+//
+//		ctx.Translate(c.X, c.Y)
+//		ctx.DrawImage(c.Body, nil)
+//		ctx.Translate(0, -16)
+//		ctx.DrawImage(c.Head, nil)
+//	}
+//
+// The transform and the color scale are applied to DrawImage and DrawTriangles.
+// For other drawing functions, use GeoM and ColorScale to get the current state.
+type DrawContext struct {
+	dst   *ebiten.Image
+	state drawContextState
+	stack []drawContextState
+
+	vertices []ebiten.Vertex
+}
+
+// NewDrawContext creates a new DrawContext for the destination image dst.
+// The initial transform is the identity, and the initial color scale is the identity.
+func NewDrawContext(dst *ebiten.Image) *DrawContext {
+	return &DrawContext{
+		dst: dst,
+	}
+}
+
+// Image returns the destination image.
+func (c *DrawContext) Image() *ebiten.Image {
+	return c.dst
+}
+
+// Push saves the current state to the stack.
+func (c *DrawContext) Push() {
+	c.stack = append(c.stack, c.state)
+}
+
+// Pop restores the state saved by the last Push.
+//
+// If the stack is empty, Pop panics.
+func (c *DrawContext) Pop() {
+	if len(c.stack) == 0 {
+		panic("ebitenutil: Pop is called without Push")
+	}
+	c.state = c.stack[len(c.stack)-1]
+	c.stack = c.stack[:len(c.stack)-1]
+}
+
+// Depth returns the number of the states in the stack.
+func (c *DrawContext) Depth() int {
+	return len(c.stack)
+}
+
+// Reset resets the current state to the identity and clears the stack.
+func (c *DrawContext) Reset() {
+	c.state = drawContextState{}
+	c.stack = c.stack[:0]
+}
+
+// GeoM returns the current transform.
+func (c *DrawContext) GeoM() ebiten.GeoM {
+	return c.state.geoM
+}
+
+// ColorScale returns the current color scale.
+func (c *DrawContext) ColorScale() ebiten.ColorScale {
+	return c.state.colorScale
+}
+
+// concatLocal applies m in the local coordinate, i.e. m is applied before the current transform.
+func (c *DrawContext) concatLocal(m ebiten.GeoM) {
+	m.Concat(c.state.geoM)
+	c.state.geoM = m
+}
+
+// Translate translates the local coordinate by (tx, ty).
+func (c *DrawContext) Translate(tx, ty float64) {
+	var m ebiten.GeoM
+	m.Translate(tx, ty)
+	c.concatLocal(m)
+}
+
+// Scale scales the local coordinate by (x, y).
+func (c *DrawContext) Scale(x, y float64) {
+	var m ebiten.GeoM
+	m.Scale(x, y)
+	c.concatLocal(m)
+}
+
+// Rotate rotates the local coordinate by theta in radians.
+func (c *DrawContext) Rotate(theta float64) {
+	var m ebiten.GeoM
+	m.Rotate(theta)
+	c.concatLocal(m)
+}
+
+// Concat applies the transform geoM in the local coordinate.
+// geoM is applied before the current transform.
+func (c *DrawContext) Concat(geoM ebiten.GeoM) {
+	c.concatLocal(geoM)
+}
+
+// ScaleColor multiplies the current color scale by the premultiplied-alpha color scale (r, g, b, a).
+func (c *DrawContext) ScaleColor(r, g, b, a float32) {
+	c.state.colorScale.Scale(r, g, b, a)
+}
+
+// ScaleAlpha multiplies the current color scale by the alpha a.
+func (c *DrawContext) ScaleAlpha(a float32) {
+	c.state.colorScale.ScaleAlpha(a)
+}
+
+// DrawImage draws img on the destination image with the current state.
+//
+// options.GeoM is applied before the current transform, and options.ColorScale is multiplied by the current color scale.
+// options is not modified.
+//
+// options can be nil.
+func (c *DrawContext) DrawImage(img *ebiten.Image, options *ebiten.DrawImageOptions) {
+	var op ebiten.DrawImageOptions
+	if options != nil {
+		op = *options
+	}
+	op.GeoM.Concat(c.state.geoM)
+	op.ColorScale.ScaleWithColorScale(c.state.colorScale)
+	c.dst.DrawImage(img, &op)
+}
+
+// DrawTriangles draws triangles on the destination image with the current state.
+//
+// The vertices' destination positions are transformed by the current transform,
+// and the vertices' colors are multiplied by the current color scale.
+// vertices and options are not modified.
+//
+// options can be nil.
+func (c *DrawContext) DrawTriangles(vertices []ebiten.Vertex, indices []uint16, img *ebiten.Image, options *ebiten.DrawTrianglesOptions) {
+	premultiplied := options != nil && options.ColorScaleMode == ebiten.ColorScaleModePremultipliedAlpha
+	var sr, sg, sb, sa float32
+	if premultiplied {
+		sr, sg, sb, sa = c.state.colorScale.R(), c.state.colorScale.G(), c.state.colorScale.B(), c.state.colorScale.A()
+	} else {
+		sr, sg, sb, sa = c.state.colorScale.StraightAlpha()
+	}
+
+	c.vertices = append(c.vertices[:0], vertices...)
+	for i := range c.vertices {
+		v := &c.vertices[i]
+		x, y := c.state.geoM.Apply(float64(v.DstX), float64(v.DstY))
+		v.DstX = float32(x)
+		v.DstY = float32(y)
+		v.ColorR *= sr
+		v.ColorG *= sg
+		v.ColorB *= sb
+		v.ColorA *= sa
+	}
+	c.dst.DrawTriangles(c.vertices, indices, img, options)
+}
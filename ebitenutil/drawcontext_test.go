@@ -0,0 +1,75 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func TestDrawContext(t *testing.T) {
+	const w, h = 16, 16
+	dst := ebiten.NewImage(w, h)
+	src := ebiten.NewImage(2, 2)
+	src.Fill(color.White)
+
+	ctx := ebitenutil.NewDrawContext(dst)
+	ctx.Translate(4, 4)
+
+	ctx.Push()
+	ctx.Scale(2, 2)
+	ctx.ScaleAlpha(0.5)
+	// The translation is applied in the scaled local coordinate, i.e. (4, 4) + (2, 2) * 2.
+	ctx.Translate(2, 2)
+	ctx.DrawImage(src, nil)
+	if got, want := ctx.Depth(), 1; got != want {
+		t.Errorf("Depth(): got: %d, want: %d", got, want)
+	}
+	ctx.Pop()
+
+	if got, want := ctx.Depth(), 0; got != want {
+		t.Errorf("Depth(): got: %d, want: %d", got, want)
+	}
+	ctx.DrawImage(src, nil)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			var want color.RGBA
+			switch {
+			case 4 <= i && i < 6 && 4 <= j && j < 6:
+				want = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+			case 8 <= i && i < 12 && 8 <= j && j < 12:
+				want = color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0x80}
+			}
+			if !sameColors(got, want, 1) {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDrawContextPopWithoutPush(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Pop without Push must panic")
+		}
+	}()
+	ctx := ebitenutil.NewDrawContext(ebiten.NewImage(1, 1))
+	ctx.Pop()
+}
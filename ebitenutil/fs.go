@@ -15,7 +15,12 @@
 package ebitenutil
 
 import (
+	"fmt"
 	"image"
+	// Register the standard image decoders for NewImageFromFS.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
 	"io/fs"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -23,8 +28,10 @@ import (
 
 // NewImageFromFileSystem create an image from the specified file system.
 //
-// Image decoders must be imported when using NewImageFromReader. For example,
-// if you want to load a PNG image, you'd need to add `_ "image/png"` to the import section.
+// As NewImageFromFS, the standard decoders for PNG, JPEG, and GIF are registered by ebitenutil.
+// For other formats, the decoders must be imported.
+//
+// Deprecated: as of v2.8. Use NewImageFromFS instead.
 func NewImageFromFileSystem(fs fs.FS, path string) (*ebiten.Image, image.Image, error) {
 	file, err := fs.Open(path)
 	if err != nil {
@@ -40,3 +47,25 @@ func NewImageFromFileSystem(fs fs.FS, path string) (*ebiten.Image, image.Image,
 	img2 := ebiten.NewImageFromImage(img)
 	return img2, img, nil
 }
+
+// NewImageFromFS loads the file name from fsys and returns ebiten.Image and image.Image.
+//
+// fsys can be an embed.FS, which works on any environments including browsers and mobiles.
+// The standard decoders for PNG, JPEG, and GIF are registered by ebitenutil.
+// For other formats, the decoders must be imported.
+//
+// The returned error includes name.
+func NewImageFromFS(fsys fs.FS, name string) (*ebiten.Image, image.Image, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ebitenutil: opening %s failed: %w", name, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ebitenutil: decoding %s failed: %w", name, err)
+	}
+	return ebiten.NewImageFromImage(img), img, nil
+}
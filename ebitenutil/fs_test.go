@@ -15,17 +15,23 @@
 package ebitenutil_test
 
 import (
-	"embed"
+	"errors"
 	"image"
-	// `NewImageFromFileSystem` works without this importing, but this is not an expected thing (#2336).
-	_ "image/png"
+	"io"
+	"io/fs"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/internal/debugfont"
 )
 
-//go:embed *.png
-var images embed.FS
+var images = fstest.MapFS{
+	"text.png": &fstest.MapFile{
+		Data: debugfont.PNG(),
+	},
+}
 
 func TestNewImageFromFileSystem(t *testing.T) {
 	img, _, err := ebitenutil.NewImageFromFileSystem(images, "text.png")
@@ -36,3 +42,56 @@ func TestNewImageFromFileSystem(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestDecodeImages(t *testing.T) {
+	var sources []io.Reader
+	for i := 0; i < 8; i++ {
+		f, err := images.Open("text.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		sources = append(sources, f)
+	}
+
+	imgs, err := ebitenutil.DecodeImages(sources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(imgs), len(sources); got != want {
+		t.Fatalf("len(imgs): got: %d, want: %d", got, want)
+	}
+	for i, img := range imgs {
+		if got, want := img.Bounds().Size(), image.Pt(192, 128); got != want {
+			t.Errorf("imgs[%d].Bounds().Size(): got: %v, want: %v", i, got, want)
+		}
+	}
+}
+
+func TestDecodeImagesError(t *testing.T) {
+	f, err := images.Open("text.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	sources := []io.Reader{f, strings.NewReader("not an image")}
+	if _, err := ebitenutil.DecodeImages(sources); err == nil || !strings.Contains(err.Error(), "index 1") {
+		t.Errorf("DecodeImages must return an error for the source at index 1 but not: %v", err)
+	}
+}
+
+func TestNewImageFromFS(t *testing.T) {
+	img, _, err := ebitenutil.NewImageFromFS(images, "text.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := img.Bounds().Size(), image.Pt(192, 128); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	_, _, err = ebitenutil.NewImageFromFS(images, "missing.png")
+	if !errors.Is(err, fs.ErrNotExist) || !strings.Contains(err.Error(), "missing.png") {
+		t.Errorf("got: %v, want: fs.ErrNotExist including the file name", err)
+	}
+}
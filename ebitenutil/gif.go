@@ -0,0 +1,224 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// GIFAnimation is a player of an animated GIF.
+//
+// All the frames are composited and converted to ebiten.Images at NewGIFAnimation,
+// so drawing a frame is as cheap as drawing a regular image.
+// Note that this consumes the memory for all the frames of the GIF's logical screen size.
+type GIFAnimation struct {
+	frames    []*ebiten.Image
+	delays    []time.Duration
+	loopCount int
+
+	index    int
+	elapsed  time.Duration
+	loop     int
+	finished bool
+}
+
+// A frame with a delay less than minGIFDelay is shown for defaultGIFDelay.
+// Browsers behave in the same way, and some GIFs with zero delays expect this behavior.
+const (
+	minGIFDelay     = 20 * time.Millisecond
+	defaultGIFDelay = 100 * time.Millisecond
+)
+
+// NewGIFAnimation decodes an animated GIF from r and returns a GIFAnimation.
+//
+// Each frame is composited on the previous frames with its disposal method,
+// and the transparent pixels of a frame show the previous frames.
+// A frame's local palette is used when the frame has one.
+// The GIF's background color is ignored and treated as transparent, as browsers do.
+func NewGIFAnimation(r io.Reader) (*GIFAnimation, error) {
+	g, err := gif.DecodeAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewGIFAnimationFromGIF(g), nil
+}
+
+// NewGIFAnimationFromGIF returns a GIFAnimation from the decoded GIF g.
+//
+// If g has no frames, NewGIFAnimationFromGIF panics.
+func NewGIFAnimationFromGIF(g *gif.GIF) *GIFAnimation {
+	if len(g.Image) == 0 {
+		panic("ebitenutil: the GIF at NewGIFAnimationFromGIF must have at least one frame")
+	}
+
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() {
+		// The logical screen size might be missing. Use the union of the frames instead.
+		for _, f := range g.Image {
+			bounds = bounds.Union(f.Bounds())
+		}
+	}
+
+	a := &GIFAnimation{
+		frames:    make([]*ebiten.Image, len(g.Image)),
+		delays:    make([]time.Duration, len(g.Image)),
+		loopCount: g.LoopCount,
+	}
+
+	canvas := image.NewRGBA(bounds)
+	var prev *image.RGBA
+	for i, f := range g.Image {
+		var disposal byte
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		if disposal == gif.DisposalPrevious {
+			if prev == nil {
+				prev = image.NewRGBA(bounds)
+			}
+			copy(prev.Pix, canvas.Pix)
+		}
+
+		// Paletted's At returns the color in the frame's palette, which is the local palette if the frame has one.
+		// The transparent index has a zero alpha in the palette, then draw.Over keeps the previous pixels.
+		draw.Draw(canvas, f.Bounds(), f, f.Bounds().Min, draw.Over)
+		a.frames[i] = ebiten.NewImageFromImage(canvas)
+
+		delay := defaultGIFDelay
+		if i < len(g.Delay) {
+			if d := time.Duration(g.Delay[i]) * 10 * time.Millisecond; d >= minGIFDelay {
+				delay = d
+			}
+		}
+		a.delays[i] = delay
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, f.Bounds(), image.NewUniform(color.Transparent), image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			copy(canvas.Pix, prev.Pix)
+		}
+	}
+
+	return a
+}
+
+// Update advances the animation by one tick.
+//
+// Update is intended to be called in the game's Update.
+// If the TPS is not fixed, e.g. SyncWithFPS, use Advance with the actual elapsed time instead.
+func (a *GIFAnimation) Update() {
+	tps := ebiten.TPS()
+	if tps <= 0 {
+		tps = ebiten.DefaultTPS
+	}
+	a.Advance(time.Second / time.Duration(tps))
+}
+
+// Advance advances the animation by the duration d.
+func (a *GIFAnimation) Advance(d time.Duration) {
+	if a.finished || d <= 0 {
+		return
+	}
+	a.elapsed += d
+	for a.elapsed >= a.delays[a.index] {
+		a.elapsed -= a.delays[a.index]
+		if a.index < len(a.frames)-1 {
+			a.index++
+			continue
+		}
+		// The last frame ends.
+		if !a.nextLoop() {
+			a.finished = true
+			a.elapsed = 0
+			return
+		}
+		a.index = 0
+	}
+}
+
+// nextLoop reports whether the animation restarts after the last frame.
+func (a *GIFAnimation) nextLoop() bool {
+	switch {
+	case a.loopCount == 0:
+		return true
+	case a.loopCount < 0:
+		return false
+	}
+	// The animation is looped LoopCount+1 times.
+	if a.loop >= a.loopCount {
+		return false
+	}
+	a.loop++
+	return true
+}
+
+// Frame returns the image of the current frame.
+func (a *GIFAnimation) Frame() *ebiten.Image {
+	return a.frames[a.index]
+}
+
+// FrameIndex returns the index of the current frame.
+func (a *GIFAnimation) FrameIndex() int {
+	return a.index
+}
+
+// FrameCount returns the number of the frames.
+func (a *GIFAnimation) FrameCount() int {
+	return len(a.frames)
+}
+
+// FrameAt returns the image of the i-th frame.
+//
+// If i is out of range, FrameAt panics.
+func (a *GIFAnimation) FrameAt(i int) *ebiten.Image {
+	return a.frames[i]
+}
+
+// Delay returns the duration of the i-th frame.
+//
+// If i is out of range, Delay panics.
+func (a *GIFAnimation) Delay(i int) time.Duration {
+	return a.delays[i]
+}
+
+// IsFinished reports whether the animation is finished.
+// An animation that loops forever never finishes.
+func (a *GIFAnimation) IsFinished() bool {
+	return a.finished
+}
+
+// Reset rewinds the animation to the first frame.
+func (a *GIFAnimation) Reset() {
+	a.index = 0
+	a.elapsed = 0
+	a.loop = 0
+	a.finished = false
+}
+
+// Deallocate deallocates the images of all the frames.
+// The animation must not be used after Deallocate is called.
+func (a *GIFAnimation) Deallocate() {
+	for _, f := range a.frames {
+		f.Deallocate()
+	}
+}
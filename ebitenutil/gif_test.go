@@ -0,0 +1,134 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func TestGIFAnimation(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	green := color.RGBA{G: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+
+	// Each frame has a different palette, which is encoded as a local palette.
+	f0 := image.NewPaletted(image.Rect(0, 0, 4, 4), color.Palette{red, color.RGBA{}})
+	f0.SetColorIndex(0, 0, 1)
+	f1 := image.NewPaletted(image.Rect(2, 2, 4, 4), color.Palette{green, color.RGBA{}})
+	f1.SetColorIndex(2, 2, 1)
+	f2 := image.NewPaletted(image.Rect(0, 0, 1, 1), color.Palette{blue})
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, &gif.GIF{
+		Image:     []*image.Paletted{f0, f1, f2},
+		Delay:     []int{0, 5, 1},
+		Disposal:  []byte{gif.DisposalNone, gif.DisposalBackground, gif.DisposalNone},
+		LoopCount: -1,
+		Config: image.Config{
+			Width:  4,
+			Height: 4,
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := ebitenutil.NewGIFAnimation(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := a.FrameCount(), 3; got != want {
+		t.Fatalf("FrameCount(): got: %d, want: %d", got, want)
+	}
+
+	wants := []func(x, y int) color.RGBA{
+		func(x, y int) color.RGBA {
+			if x == 0 && y == 0 {
+				return color.RGBA{}
+			}
+			return red
+		},
+		func(x, y int) color.RGBA {
+			switch {
+			case x == 0 && y == 0:
+				return color.RGBA{}
+			case x == 2 && y == 2:
+				// The transparent pixel shows the previous frame.
+				return red
+			case x >= 2 && y >= 2:
+				return green
+			}
+			return red
+		},
+		func(x, y int) color.RGBA {
+			switch {
+			case x == 0 && y == 0:
+				return blue
+			case x >= 2 && y >= 2:
+				// The previous frame's area is cleared by the disposal.
+				return color.RGBA{}
+			}
+			return red
+		},
+	}
+	for i, want := range wants {
+		frame := a.FrameAt(i)
+		for y := 0; y < 4; y++ {
+			for x := 0; x < 4; x++ {
+				if got, want := frame.At(x, y).(color.RGBA), want(x, y); got != want {
+					t.Errorf("frame %d: At(%d, %d): got: %v, want: %v", i, x, y, got, want)
+				}
+			}
+		}
+	}
+
+	if got, want := a.Delay(0), 100*time.Millisecond; got != want {
+		t.Errorf("Delay(0): got: %v, want: %v", got, want)
+	}
+	if got, want := a.Delay(1), 50*time.Millisecond; got != want {
+		t.Errorf("Delay(1): got: %v, want: %v", got, want)
+	}
+
+	a.Advance(99 * time.Millisecond)
+	if got, want := a.FrameIndex(), 0; got != want {
+		t.Errorf("FrameIndex(): got: %d, want: %d", got, want)
+	}
+	a.Advance(time.Millisecond)
+	if got, want := a.FrameIndex(), 1; got != want {
+		t.Errorf("FrameIndex(): got: %d, want: %d", got, want)
+	}
+	a.Advance(50 * time.Millisecond)
+	if got, want := a.FrameIndex(), 2; got != want {
+		t.Errorf("FrameIndex(): got: %d, want: %d", got, want)
+	}
+	a.Advance(100 * time.Millisecond)
+	if !a.IsFinished() {
+		t.Errorf("IsFinished(): got: false, want: true")
+	}
+	if got, want := a.FrameIndex(), 2; got != want {
+		t.Errorf("FrameIndex(): got: %d, want: %d", got, want)
+	}
+
+	a.Reset()
+	if a.IsFinished() || a.FrameIndex() != 0 {
+		t.Errorf("Reset must rewind the animation")
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// IDBufferMaxID is the maximum ID that IDBuffer can hold.
+const IDBufferMaxID = 1<<24 - 1
+
+// idShaderSrc is a shader to render an ID color where the source image is not transparent.
+var idShaderSrc = []byte(`//kage:unit pixels
+
+package main
+
+var ID vec3
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	if imageSrc0UnsafeAt(srcPos).a < 0.5 {
+		discard()
+	}
+	return vec4(ID, 1)
+}
+`)
+
+var (
+	idShader     *ebiten.Shader
+	idShaderOnce sync.Once
+)
+
+func ensureIDShader() *ebiten.Shader {
+	idShaderOnce.Do(func() {
+		s, err := ebiten.NewShader(idShaderSrc)
+		if err != nil {
+			panic(fmt.Sprintf("ebitenutil: NewShader for the ID shader failed: %v", err))
+		}
+		idShader = s
+	})
+	return idShader
+}
+
+// IDBuffer is an offscreen buffer to render objects' IDs as colors, which is useful to pick an object under the cursor.
+//
+// An ID is encoded into the RGB channels of a pixel with the alpha value 1.
+// As the alpha value is always 1 or 0, the premultiplied alpha doesn't change the encoded values,
+// and an ID is read back exactly.
+type IDBuffer struct {
+	image *ebiten.Image
+	pixel [4]byte
+}
+
+// NewIDBuffer returns a new IDBuffer with the given size.
+// The size is usually the same as the screen size.
+func NewIDBuffer(width, height int) *IDBuffer {
+	return &IDBuffer{
+		image: ebiten.NewImage(width, height),
+	}
+}
+
+// Image returns the underlying image, which is useful for debugging.
+func (b *IDBuffer) Image() *ebiten.Image {
+	return b.image
+}
+
+// Clear clears all the IDs in the buffer.
+func (b *IDBuffer) Clear() {
+	b.image.Clear()
+}
+
+// Render clears the buffer and calls draw to render objects' IDs.
+// In draw, call DrawImage or DrawTriangles for each object in the same order as drawing the objects on the screen.
+func (b *IDBuffer) Render(draw func(buffer *IDBuffer)) {
+	b.Clear()
+	draw(b)
+}
+
+func idUniforms(id int) map[string]any {
+	if id < 0 || id > IDBufferMaxID {
+		panic(fmt.Sprintf("ebitenutil: id must be in [0, %d] but %d", IDBufferMaxID, id))
+	}
+	return map[string]any{
+		"ID": []float32{
+			float32((id>>16)&0xff) / 0xff,
+			float32((id>>8)&0xff) / 0xff,
+			float32(id&0xff) / 0xff,
+		},
+	}
+}
+
+// DrawImage renders the ID where img is not transparent, with img transformed by geoM.
+// A pixel whose alpha value is less than 0.5 is treated as transparent.
+// A later call overwrites the IDs of the earlier calls.
+//
+// If id is negative or more than IDBufferMaxID, DrawImage panics.
+func (b *IDBuffer) DrawImage(img *ebiten.Image, id int, geoM ebiten.GeoM) {
+	op := &ebiten.DrawRectShaderOptions{}
+	op.GeoM = geoM
+	op.Images[0] = img
+	op.Uniforms = idUniforms(id)
+	op.Blend = ebiten.BlendCopy
+	bounds := img.Bounds()
+	b.image.DrawRectShader(bounds.Dx(), bounds.Dy(), ensureIDShader(), op)
+}
+
+// DrawTriangles renders the ID on the triangles.
+// The vertices' colors are ignored, and the triangles are treated as transparent where img is transparent.
+// If img is nil, the whole triangles are rendered, which is useful for shapes.
+//
+// If id is negative or more than IDBufferMaxID, DrawTriangles panics.
+func (b *IDBuffer) DrawTriangles(vertices []ebiten.Vertex, indices []uint16, img *ebiten.Image, id int) {
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	if img == nil {
+		img = shapeWhiteSubImage
+		// The source positions must be in the white image.
+		vs := make([]ebiten.Vertex, len(vertices))
+		copy(vs, vertices)
+		for i := range vs {
+			vs[i].SrcX = 1
+			vs[i].SrcY = 1
+		}
+		vertices = vs
+	}
+	op.Images[0] = img
+	op.Uniforms = idUniforms(id)
+	op.Blend = ebiten.BlendCopy
+	b.image.DrawTrianglesShader(vertices, indices, ensureIDShader(), op)
+}
+
+// IDAt returns the ID at (x, y).
+// If no ID is rendered at (x, y) or (x, y) is out of the buffer, IDAt returns false as ok.
+//
+// IDAt reads a pixel from GPU, and waits for the rendering commands so far to finish.
+// Call IDAt only when needed, e.g. once when the mouse button is pressed.
+func (b *IDBuffer) IDAt(x, y int) (id int, ok bool) {
+	if !image.Pt(x, y).In(b.image.Bounds()) {
+		return 0, false
+	}
+	b.image.SubImage(image.Rect(x, y, x+1, y+1)).(*ebiten.Image).ReadPixels(b.pixel[:])
+	if b.pixel[3] == 0 {
+		return 0, false
+	}
+	return int(b.pixel[0])<<16 | int(b.pixel[1])<<8 | int(b.pixel[2]), true
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func TestIDBuffer(t *testing.T) {
+	const w, h = 16, 16
+	b := ebitenutil.NewIDBuffer(w, h)
+
+	// A sprite whose left half is transparent.
+	sprite := ebiten.NewImage(4, 4)
+	for j := 0; j < 4; j++ {
+		for i := 2; i < 4; i++ {
+			sprite.Set(i, j, color.RGBA{R: 0x80, A: 0x80})
+		}
+	}
+
+	ids := []int{0, 0x123456, ebitenutil.IDBufferMaxID}
+	b.Render(func(buffer *ebitenutil.IDBuffer) {
+		for i, id := range ids {
+			var geoM ebiten.GeoM
+			geoM.Translate(float64(4*i), 0)
+			buffer.DrawImage(sprite, id, geoM)
+		}
+		// A shape overlapping the last sprite overwrites its ID.
+		vs := []ebiten.Vertex{
+			{DstX: 8, DstY: 2}, {DstX: 12, DstY: 2}, {DstX: 8, DstY: 4}, {DstX: 12, DstY: 4},
+		}
+		buffer.DrawTriangles(vs, []uint16{0, 1, 2, 1, 2, 3}, nil, 42)
+	})
+
+	testCases := []struct {
+		X, Y int
+		ID   int
+		OK   bool
+	}{
+		{X: 0, Y: 0, OK: false},
+		{X: 2, Y: 0, ID: 0, OK: true},
+		{X: 6, Y: 1, ID: 0x123456, OK: true},
+		{X: 10, Y: 1, ID: ebitenutil.IDBufferMaxID, OK: true},
+		{X: 10, Y: 3, ID: 42, OK: true},
+		{X: 9, Y: 3, ID: 42, OK: true},
+		{X: 12, Y: 8, OK: false},
+		{X: -1, Y: 0, OK: false},
+	}
+	for _, tc := range testCases {
+		id, ok := b.IDAt(tc.X, tc.Y)
+		if id != tc.ID || ok != tc.OK {
+			t.Errorf("IDAt(%d, %d): got: (%d, %t), want: (%d, %t)", tc.X, tc.Y, id, ok, tc.ID, tc.OK)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"image"
+	"image/draw"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// imageDataPool is a transient texture and a pixel buffer reused by DrawImageData.
+type imageDataPool struct {
+	image *ebiten.Image
+	rgba  *image.RGBA
+	m     sync.Mutex
+}
+
+var theImageDataPool imageDataPool
+
+func nextPowerOf2(x int) int {
+	p := 1
+	for p < x {
+		p *= 2
+	}
+	return p
+}
+
+// ensureImage returns a sub-image of the pooled texture with the given size.
+func (p *imageDataPool) ensureImage(width, height int) *ebiten.Image {
+	if p.image != nil {
+		if b := p.image.Bounds(); b.Dx() < width || b.Dy() < height {
+			p.image.Deallocate()
+			p.image = nil
+		}
+	}
+	if p.image == nil {
+		p.image = ebiten.NewImageWithOptions(image.Rect(0, 0, nextPowerOf2(width), nextPowerOf2(height)), &ebiten.NewImageOptions{
+			Unmanaged: true,
+		})
+	}
+	return p.image.SubImage(image.Rect(0, 0, width, height)).(*ebiten.Image)
+}
+
+// pixels returns src's pixels in the premultiplied-alpha RGBA format.
+func (p *imageDataPool) pixels(src image.Image) []byte {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if rgba, ok := src.(*image.RGBA); ok && rgba.Stride == 4*w {
+		return rgba.Pix[:4*w*h]
+	}
+	if p.rgba == nil || len(p.rgba.Pix) < 4*w*h {
+		p.rgba = image.NewRGBA(image.Rect(0, 0, w, h))
+	}
+	p.rgba.Pix = p.rgba.Pix[:4*w*h]
+	p.rgba.Stride = 4 * w
+	p.rgba.Rect = image.Rect(0, 0, w, h)
+	draw.Draw(p.rgba, p.rgba.Rect, src, b.Min, draw.Src)
+	return p.rgba.Pix
+}
+
+// DrawImageData draws the standard image src on dst with the options, without creating an ebiten.Image explicitly.
+//
+// src's pixels are uploaded to a transient texture, which is reused by the next DrawImageData call.
+// The position (0, 0) in options.GeoM corresponds to the upper-left corner of src's bounds, as DrawImage does.
+//
+// DrawImageData is intended for one-offs like editors and debug overlays.
+// DrawImageData is slow as the pixels are converted and uploaded for each call, and
+// is unsuitable for hot paths like calling this every frame. For such cases, create an ebiten.Image
+// by ebiten.NewImageFromImage once and reuse it.
+//
+// options can be nil.
+func DrawImageData(dst *ebiten.Image, src image.Image, options *ebiten.DrawImageOptions) {
+	b := src.Bounds()
+	if b.Empty() {
+		return
+	}
+
+	p := &theImageDataPool
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	img := p.ensureImage(b.Dx(), b.Dy())
+	img.WritePixels(p.pixels(src))
+	dst.DrawImage(img, options)
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func TestDrawImageData(t *testing.T) {
+	const w, h = 16, 16
+	dst := ebiten.NewImage(w, h)
+
+	// A non-RGBA image with non-zero origin bounds.
+	src0 := image.NewNRGBA(image.Rect(2, 2, 10, 10))
+	for j := 2; j < 10; j++ {
+		for i := 2; i < 10; i++ {
+			src0.SetNRGBA(i, j, color.NRGBA{R: 0xff, A: 0x80})
+		}
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(4, 4)
+	ebitenutil.DrawImageData(dst, src0, op)
+
+	// A smaller image drawn after a bigger one must not include the previous pixels.
+	src1 := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for j := 0; j < 2; j++ {
+		for i := 0; i < 2; i++ {
+			src1.SetRGBA(i, j, color.RGBA{G: 0xff, A: 0xff})
+		}
+	}
+	ebitenutil.DrawImageData(dst, src1, nil)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			var want color.RGBA
+			switch {
+			case i < 2 && j < 2:
+				want = color.RGBA{G: 0xff, A: 0xff}
+			case 4 <= i && i < 12 && 4 <= j && j < 12:
+				want = color.RGBA{R: 0x80, A: 0x80}
+			}
+			if !sameColors(got, want, 1) {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
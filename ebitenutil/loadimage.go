@@ -15,9 +15,13 @@
 package ebitenutil
 
 import (
+	"context"
+	"fmt"
 	"image"
 	"io"
-	"net/http"
+	"runtime"
+
+	"golang.org/x/sync/errgroup"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -35,24 +39,52 @@ func NewImageFromReader(reader io.Reader) (*ebiten.Image, image.Image, error) {
 	return img2, img, err
 }
 
-// NewImageFromURL creates a new ebiten.Image from the given URL.
+// DecodeImages decodes the given sources and returns ebiten.Images in the same order as sources.
 //
-// Image decoders must be imported when using NewImageFromURL. For example,
+// The sources are decoded in parallel across CPUs, and then ebiten.Images are created serially after all the sources
+// are decoded. This is faster than calling NewImageFromReader for each source one by one,
+// especially when there are many sources like a folder of sprite sheets.
+//
+// If decoding any of the sources fails, DecodeImages returns an error including the index of the failed source,
+// and no ebiten.Image is created.
+//
+// Image decoders must be imported when using DecodeImages. For example,
 // if you want to load a PNG image, you'd need to add `_ "image/png"` to the import section.
-func NewImageFromURL(url string) (*ebiten.Image, error) {
-	res, err := http.Get(url)
-	if err != nil {
+func DecodeImages(sources []io.Reader) ([]*ebiten.Image, error) {
+	imgs := make([]image.Image, len(sources))
+
+	var g errgroup.Group
+	g.SetLimit(runtime.GOMAXPROCS(0))
+	for i, source := range sources {
+		i := i
+		source := source
+		g.Go(func() error {
+			img, _, err := image.Decode(source)
+			if err != nil {
+				return fmt.Errorf("ebitenutil: decoding the source at index %d failed: %w", i, err)
+			}
+			imgs[i] = img
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
 		return nil, err
 	}
-	defer func() {
-		_ = res.Body.Close()
-	}()
 
-	img, _, err := image.Decode(res.Body)
-	if err != nil {
-		return nil, err
+	eimgs := make([]*ebiten.Image, len(imgs))
+	for i, img := range imgs {
+		eimgs[i] = ebiten.NewImageFromImage(img)
 	}
+	return eimgs, nil
+}
 
-	eimg := ebiten.NewImageFromImage(img)
-	return eimg, nil
+// NewImageFromURL creates a new ebiten.Image from the given URL.
+//
+// NewImageFromURL blocks until loading the image finishes, without any timeout.
+// Use NewImageFromURLWithContext or NewImageFromURLAsync to cancel the request or to load the image asynchronously.
+//
+// Image decoders must be imported when using NewImageFromURL. For example,
+// if you want to load a PNG image, you'd need to add `_ "image/png"` to the import section.
+func NewImageFromURL(url string) (*ebiten.Image, error) {
+	return NewImageFromURLWithContext(context.Background(), url, nil)
 }
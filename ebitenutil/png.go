@@ -0,0 +1,75 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// EncodePNG encodes the pixels of img to w as a PNG image.
+//
+// The pixels are read back with one ReadPixels call, and are converted from premultiplied alpha to straight alpha,
+// which is the standard format of PNG. Fully transparent pixels become (0, 0, 0, 0).
+//
+// EncodePNG works on a sub-image. The encoded image's bounds start from (0, 0).
+//
+// As ReadPixels does, EncodePNG can't be called before the main loop starts.
+func EncodePNG(w io.Writer, img *ebiten.Image) error {
+	return png.Encode(w, toNRGBA(img))
+}
+
+// SavePNG saves the pixels of img as a PNG file at path. See EncodePNG for the details of the encoding.
+//
+// On browsers, SavePNG triggers a download of the PNG file, and the base name of path is used as the file name.
+//
+// As ReadPixels does, SavePNG can't be called before the main loop starts.
+func SavePNG(path string, img *ebiten.Image) error {
+	var buf bytes.Buffer
+	if err := EncodePNG(&buf, img); err != nil {
+		return err
+	}
+	return savePNGFile(path, buf.Bytes())
+}
+
+// toNRGBA returns img's pixels in straight alpha.
+func toNRGBA(img *ebiten.Image) *image.NRGBA {
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	pix := dst.Pix
+	img.ReadPixels(pix)
+	for i := 0; i < len(pix); i += 4 {
+		a := uint32(pix[i+3])
+		switch a {
+		case 0:
+			pix[i], pix[i+1], pix[i+2] = 0, 0, 0
+		case 0xff:
+		default:
+			// Round to the nearest value. The result can exceed 0xff for an invalid premultiplied color.
+			for j := 0; j < 3; j++ {
+				c := (uint32(pix[i+j])*0xff + a/2) / a
+				if c > 0xff {
+					c = 0xff
+				}
+				pix[i+j] = uint8(c)
+			}
+		}
+	}
+	return dst
+}
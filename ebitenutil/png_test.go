@@ -0,0 +1,58 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func TestEncodePNG(t *testing.T) {
+	const w, h = 4, 4
+	img := ebiten.NewImage(w, h)
+	img.SubImage(image.Rect(0, 0, 2, h)).(*ebiten.Image).Fill(color.NRGBA{R: 0xff, G: 0x80, B: 0x40, A: 0x80})
+
+	var buf bytes.Buffer
+	// Encode a sub-image to test the bounds.
+	if err := ebitenutil.EncodePNG(&buf, img.SubImage(image.Rect(1, 0, w, h)).(*ebiten.Image)); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := decoded.Bounds(), image.Rect(0, 0, w-1, h); got != want {
+		t.Fatalf("bounds: got: %v, want: %v", got, want)
+	}
+	for j := 0; j < h; j++ {
+		for i := 0; i < w-1; i++ {
+			got := color.NRGBAModel.Convert(decoded.At(i, j)).(color.NRGBA)
+			var want color.NRGBA
+			if i < 1 {
+				want = color.NRGBA{R: 0xff, G: 0x80, B: 0x40, A: 0x80}
+			}
+			// The un-premultiplication can have a small error.
+			if !sameColors(color.RGBA(got), color.RGBA(want), 2) {
+				t.Errorf("At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
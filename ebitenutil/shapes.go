@@ -15,7 +15,10 @@
 package ebitenutil
 
 import (
+	"image"
 	"image/color"
+	"math"
+	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
@@ -24,26 +27,317 @@ import (
 // DrawLine draws a line segment on the given destination dst.
 //
 // DrawLine is intended to be used mainly for debugging or prototyping purpose.
+// The line is anti-aliased.
 //
-// Deprecated: as of v2.5. Use vector.StrokeLine without anti-aliasing instead.
+// Deprecated: as of v2.5. Use vector.StrokeLine or DrawLineAA instead.
 func DrawLine(dst *ebiten.Image, x1, y1, x2, y2 float64, clr color.Color) {
-	vector.StrokeLine(dst, float32(x1), float32(y1), float32(x2), float32(y2), 1, clr, false)
+	vector.StrokeLine(dst, float32(x1), float32(y1), float32(x2), float32(y2), 1, clr, true)
+}
+
+// DrawLineAA draws an anti-aliased line segment (x1, y1)-(x2, y2) with the width and the line cap on dst.
+//
+// DrawLineAA is built on the stroking of the vector package.
+// clr has to be a solid (non-transparent) color.
+//
+// DrawLineAA is intended to be used mainly for debugging or prototyping purpose.
+func DrawLineAA(dst *ebiten.Image, x1, y1, x2, y2, width float64, lineCap vector.LineCap, clr color.Color) {
+	var path vector.Path
+	path.MoveTo(float32(x1), float32(y1))
+	path.LineTo(float32(x2), float32(y2))
+	op := &vector.StrokeOptions{}
+	op.Width = float32(width)
+	op.LineCap = lineCap
+	vector.DrawStrokedPath(dst, &path, clr, true, op)
 }
 
 // DrawRect draws a rectangle on the given destination dst.
 //
 // DrawRect is intended to be used mainly for debugging or prototyping purpose.
+// The edges are anti-aliased.
 //
-// Deprecated: as of v2.5. Use vector.DrawFilledRect without anti-aliasing instead.
+// Deprecated: as of v2.5. Use vector.DrawFilledRect instead.
 func DrawRect(dst *ebiten.Image, x, y, width, height float64, clr color.Color) {
-	vector.DrawFilledRect(dst, float32(x), float32(y), float32(width), float32(height), clr, false)
+	vector.DrawFilledRect(dst, float32(x), float32(y), float32(width), float32(height), clr, true)
 }
 
 // DrawCircle draws a circle on given destination dst.
 //
 // DrawCircle is intended to be used mainly for debugging or prototyping purpose.
+// The edges are anti-aliased.
 //
-// Deprecated: as of v2.5. Use vector.DrawFilledCircle without anti-aliasing instead.
+// Deprecated: as of v2.5. Use vector.DrawFilledCircle or DrawFilledCircle instead.
 func DrawCircle(dst *ebiten.Image, cx, cy, r float64, clr color.Color) {
-	vector.DrawFilledCircle(dst, float32(cx), float32(cy), float32(r), clr, false)
+	vector.DrawFilledCircle(dst, float32(cx), float32(cy), float32(r), clr, true)
+}
+
+var (
+	shapeWhiteImage    = ebiten.NewImage(3, 3)
+	shapeWhiteSubImage = shapeWhiteImage.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+)
+
+func init() {
+	b := shapeWhiteImage.Bounds()
+	pix := make([]byte, 4*b.Dx()*b.Dy())
+	for i := range pix {
+		pix[i] = 0xff
+	}
+	// This is hacky, but WritePixels is better than Fill in term of automatic texture packing.
+	shapeWhiteImage.WritePixels(pix)
+}
+
+// shapeBuffer is a buffer of vertices and indices shared by the shape functions
+// so that drawing many shapes every frame doesn't allocate memory.
+type shapeBuffer struct {
+	vertices []ebiten.Vertex
+	indices  []uint16
+	op       ebiten.DrawTrianglesOptions
+	m        sync.Mutex
+}
+
+var theShapeBuffer shapeBuffer
+
+// maxShapeSegments is the maximum number of segments to approximate a full circle.
+const maxShapeSegments = 1024
+
+// arcSegmentCount returns the number of segments to approximate an arc with the radius r and the angle da,
+// so that the distance between the arc and the segments is within 0.25 pixels.
+func arcSegmentCount(r float64, da float64) int {
+	const tolerance = 0.25
+	step := math.Pi / 4
+	if r > tolerance {
+		step = math.Min(step, 2*math.Acos(1-tolerance/r))
+	}
+	n := int(math.Ceil(math.Abs(da) / step))
+	if n < 1 {
+		n = 1
+	}
+	if max := int(math.Ceil(maxShapeSegments * math.Abs(da) / (2 * math.Pi))); n > max {
+		n = max
+	}
+	return n
+}
+
+func (s *shapeBuffer) reset() {
+	s.vertices = s.vertices[:0]
+	s.indices = s.indices[:0]
+}
+
+func (s *shapeBuffer) appendVertex(x, y float64) uint16 {
+	s.vertices = append(s.vertices, ebiten.Vertex{
+		DstX: float32(x),
+		DstY: float32(y),
+		SrcX: 1,
+		SrcY: 1,
+	})
+	return uint16(len(s.vertices) - 1)
+}
+
+// appendFan appends a convex polygon as a triangle fan from the vertex at base.
+func (s *shapeBuffer) appendFan(base uint16) {
+	for i := base + 2; i < uint16(len(s.vertices)); i++ {
+		s.indices = append(s.indices, base, i-1, i)
+	}
+}
+
+// appendArcPoints appends the points on the arc from a0 to a1 without the start point.
+func (s *shapeBuffer) appendArcPoints(cx, cy, r, a0, a1 float64, n int) {
+	for i := 1; i <= n; i++ {
+		a := a0 + (a1-a0)*float64(i)/float64(n)
+		s.appendVertex(cx+r*math.Cos(a), cy+r*math.Sin(a))
+	}
+}
+
+// appendRing appends a part of a ring between the radii r0 and r1 from the angle a0 to a1.
+func (s *shapeBuffer) appendRing(cx, cy, r0, r1, a0, a1 float64) {
+	n := arcSegmentCount(r1, a1-a0)
+	base := uint16(len(s.vertices))
+	for i := 0; i <= n; i++ {
+		a := a0 + (a1-a0)*float64(i)/float64(n)
+		cos, sin := math.Cos(a), math.Sin(a)
+		s.appendVertex(cx+r0*cos, cy+r0*sin)
+		s.appendVertex(cx+r1*cos, cy+r1*sin)
+	}
+	for i := uint16(0); i < uint16(n); i++ {
+		idx := base + 2*i
+		s.indices = append(s.indices, idx, idx+1, idx+2, idx+1, idx+3, idx+2)
+	}
+}
+
+func (s *shapeBuffer) draw(dst *ebiten.Image, clr color.Color, antialias bool) {
+	r, g, b, a := clr.RGBA()
+	for i := range s.vertices {
+		s.vertices[i].ColorR = float32(r) / 0xffff
+		s.vertices[i].ColorG = float32(g) / 0xffff
+		s.vertices[i].ColorB = float32(b) / 0xffff
+		s.vertices[i].ColorA = float32(a) / 0xffff
+	}
+	s.op = ebiten.DrawTrianglesOptions{}
+	s.op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	s.op.AntiAlias = antialias
+	dst.DrawTriangles(s.vertices, s.indices, shapeWhiteSubImage, &s.op)
+}
+
+// DrawFilledCircle fills a circle with the center position (cx, cy) and the radius r.
+//
+// The triangles never overlap, so clr can be a translucent color.
+//
+// DrawFilledCircle is intended to be used mainly for debugging or prototyping purpose.
+// DrawFilledCircle doesn't allocate memory for each call, so it is fine to call this many times every frame.
+func DrawFilledCircle(dst *ebiten.Image, cx, cy, r float64, clr color.Color, antialias bool) {
+	if r <= 0 {
+		return
+	}
+
+	s := &theShapeBuffer
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.reset()
+	n := arcSegmentCount(r, 2*math.Pi)
+	base := s.appendVertex(cx+r, cy)
+	s.appendArcPoints(cx, cy, r, 0, 2*math.Pi*float64(n-1)/float64(n), n-1)
+	s.appendFan(base)
+	s.draw(dst, clr, antialias)
+}
+
+// DrawCircleOutline strokes a circle with the center position (cx, cy), the radius r, and the stroke width.
+//
+// The stroke is centered on the circle. The triangles never overlap, so clr can be a translucent color.
+//
+// DrawCircleOutline is intended to be used mainly for debugging or prototyping purpose.
+// DrawCircleOutline doesn't allocate memory for each call, so it is fine to call this many times every frame.
+func DrawCircleOutline(dst *ebiten.Image, cx, cy, r, width float64, clr color.Color, antialias bool) {
+	DrawArc(dst, cx, cy, r, 0, 2*math.Pi, width, clr, antialias)
+}
+
+// DrawArc strokes an arc with the center position (cx, cy), the radius r, and the stroke width.
+//
+// The arc starts at startAngle and goes clockwise to endAngle in radians, where the angle 0 is the positive X direction.
+// If endAngle is less than startAngle, the arc goes across the angle 0.
+// If the difference is 2π or more, a full circle is drawn.
+// The ends of the arc are butt. The triangles never overlap, so clr can be a translucent color.
+//
+// DrawArc is intended to be used mainly for debugging or prototyping purpose.
+// DrawArc doesn't allocate memory for each call, so it is fine to call this many times every frame.
+func DrawArc(dst *ebiten.Image, cx, cy, r, startAngle, endAngle, width float64, clr color.Color, antialias bool) {
+	if r <= 0 || width <= 0 {
+		return
+	}
+	if endAngle-startAngle < 2*math.Pi {
+		for endAngle < startAngle {
+			endAngle += 2 * math.Pi
+		}
+	}
+	if endAngle-startAngle > 2*math.Pi {
+		endAngle = startAngle + 2*math.Pi
+	}
+
+	s := &theShapeBuffer
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.reset()
+	s.appendRing(cx, cy, math.Max(r-width/2, 0), r+width/2, startAngle, endAngle)
+	s.draw(dst, clr, antialias)
+}
+
+// DrawThickLine strokes a line segment (x0, y0)-(x1, y1) with the width and round caps.
+//
+// The triangles never overlap, so clr can be a translucent color.
+//
+// DrawThickLine is intended to be used mainly for debugging or prototyping purpose.
+// DrawThickLine doesn't allocate memory for each call, so it is fine to call this many times every frame.
+func DrawThickLine(dst *ebiten.Image, x0, y0, x1, y1, width float64, clr color.Color, antialias bool) {
+	if width <= 0 {
+		return
+	}
+
+	s := &theShapeBuffer
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	// The line with round caps is a convex shape, which consists of two half circles.
+	s.reset()
+	r := width / 2
+	a := math.Atan2(y1-y0, x1-x0)
+	n := arcSegmentCount(r, math.Pi)
+	base := s.appendVertex(x1+r*math.Cos(a-math.Pi/2), y1+r*math.Sin(a-math.Pi/2))
+	s.appendArcPoints(x1, y1, r, a-math.Pi/2, a+math.Pi/2, n)
+	s.appendVertex(x0+r*math.Cos(a+math.Pi/2), y0+r*math.Sin(a+math.Pi/2))
+	s.appendArcPoints(x0, y0, r, a+math.Pi/2, a+3*math.Pi/2, n)
+	s.appendFan(base)
+	s.draw(dst, clr, antialias)
+}
+
+// appendRotatedRect appends the 4 corners of a rectangle with the center (cx, cy), the half extents (hw, hh),
+// and the rotation (cos, sin) in the order of the upper-left, upper-right, lower-right, and lower-left.
+func (s *shapeBuffer) appendRotatedRect(cx, cy, hw, hh, cos, sin float64) {
+	for _, p := range [4][2]float64{{-hw, -hh}, {hw, -hh}, {hw, hh}, {-hw, hh}} {
+		s.appendVertex(cx+p[0]*cos-p[1]*sin, cy+p[0]*sin+p[1]*cos)
+	}
+}
+
+// DrawRectRotated fills a rectangle with the center position (cx, cy), the size (width, height), and the rotation angle
+// in radians around the center.
+//
+// Negative width and height are treated as their absolute values. If width or height is 0, nothing is drawn.
+// DrawRectRotated is not anti-aliased, and batches with the other shape functions of ebitenutil.
+//
+// DrawRectRotated is intended to be used mainly for debugging or prototyping purpose, e.g. oriented bounding boxes.
+// DrawRectRotated doesn't allocate memory for each call, so it is fine to call this many times every frame.
+func DrawRectRotated(dst *ebiten.Image, cx, cy, width, height, angle float64, clr color.Color) {
+	width, height = math.Abs(width), math.Abs(height)
+	if width == 0 || height == 0 {
+		return
+	}
+
+	s := &theShapeBuffer
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.reset()
+	sin, cos := math.Sincos(angle)
+	s.appendRotatedRect(cx, cy, width/2, height/2, cos, sin)
+	s.indices = append(s.indices, 0, 1, 2, 0, 2, 3)
+	s.draw(dst, clr, false)
+}
+
+// DrawRectRotatedOutline strokes a rectangle with the center position (cx, cy), the size (width, height),
+// the rotation angle in radians around the center, and the line width.
+//
+// The stroke is centered on the edges. The triangles never overlap, so clr can be a translucent color.
+// Negative width and height are treated as their absolute values.
+// If both width and height are 0 or lineWidth is not positive, nothing is drawn.
+// DrawRectRotatedOutline is not anti-aliased, and batches with the other shape functions of ebitenutil.
+//
+// DrawRectRotatedOutline is intended to be used mainly for debugging or prototyping purpose, e.g. oriented bounding boxes.
+// DrawRectRotatedOutline doesn't allocate memory for each call, so it is fine to call this many times every frame.
+func DrawRectRotatedOutline(dst *ebiten.Image, cx, cy, width, height, angle, lineWidth float64, clr color.Color) {
+	width, height = math.Abs(width), math.Abs(height)
+	if (width == 0 && height == 0) || lineWidth <= 0 {
+		return
+	}
+
+	s := &theShapeBuffer
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.reset()
+	sin, cos := math.Sincos(angle)
+	ow, oh := (width+lineWidth)/2, (height+lineWidth)/2
+	iw, ih := (width-lineWidth)/2, (height-lineWidth)/2
+	s.appendRotatedRect(cx, cy, ow, oh, cos, sin)
+	if iw <= 0 || ih <= 0 {
+		// The stroke covers the inside.
+		s.indices = append(s.indices, 0, 1, 2, 0, 2, 3)
+		s.draw(dst, clr, false)
+		return
+	}
+	s.appendRotatedRect(cx, cy, iw, ih, cos, sin)
+	// Each edge of the frame is a quadrilateral between the outer vertices 0-3 and the inner vertices 4-7.
+	for i := uint16(0); i < 4; i++ {
+		j := (i + 1) % 4
+		s.indices = append(s.indices, i, j, 4+j, i, 4+j, 4+i)
+	}
+	s.draw(dst, clr, false)
 }
@@ -0,0 +1,212 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+func TestDrawFilledCircle(t *testing.T) {
+	const w, h = 32, 32
+	dst := ebiten.NewImage(w, h)
+	clr := color.RGBA{R: 0x80, A: 0x80}
+	ebitenutil.DrawFilledCircle(dst, 16, 16, 10, clr, true)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			d := math.Hypot(float64(i)+0.5-16, float64(j)+0.5-16)
+			got := dst.At(i, j).(color.RGBA)
+			switch {
+			case d < 9:
+				// A translucent color must not be accumulated by overlapping triangles.
+				if !sameColors(got, clr, 1) {
+					t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, clr)
+				}
+			case d > 11:
+				if got != (color.RGBA{}) {
+					t.Errorf("dst.At(%d, %d): got: %v, want: transparent", i, j, got)
+				}
+			}
+		}
+	}
+}
+
+func TestDrawCircleOutline(t *testing.T) {
+	const w, h = 32, 32
+	dst := ebiten.NewImage(w, h)
+	clr := color.RGBA{G: 0x80, A: 0x80}
+	ebitenutil.DrawCircleOutline(dst, 16, 16, 10, 4, clr, false)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			d := math.Hypot(float64(i)+0.5-16, float64(j)+0.5-16)
+			got := dst.At(i, j).(color.RGBA)
+			switch {
+			case d > 9 && d < 11:
+				if !sameColors(got, clr, 1) {
+					t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, clr)
+				}
+			case d < 7 || d > 13:
+				if got != (color.RGBA{}) {
+					t.Errorf("dst.At(%d, %d): got: %v, want: transparent", i, j, got)
+				}
+			}
+		}
+	}
+}
+
+func TestDrawArc(t *testing.T) {
+	const w, h = 32, 32
+	dst := ebiten.NewImage(w, h)
+	clr := color.RGBA{B: 0xff, A: 0xff}
+	// The lower half, as the angles go clockwise in the Y-down coordinate.
+	ebitenutil.DrawArc(dst, 16, 16, 10, 0, math.Pi, 4, clr, false)
+
+	if got := dst.At(16, 26).(color.RGBA); got != clr {
+		t.Errorf("dst.At(16, 26): got: %v, want: %v", got, clr)
+	}
+	if got := dst.At(16, 5).(color.RGBA); got != (color.RGBA{}) {
+		t.Errorf("dst.At(16, 5): got: %v, want: transparent", got)
+	}
+
+	// The upper half, going across the angle 0.
+	dst.Clear()
+	ebitenutil.DrawArc(dst, 16, 16, 10, math.Pi, 0, 4, clr, false)
+	if got := dst.At(16, 5).(color.RGBA); got != clr {
+		t.Errorf("dst.At(16, 5): got: %v, want: %v", got, clr)
+	}
+	if got := dst.At(16, 26).(color.RGBA); got != (color.RGBA{}) {
+		t.Errorf("dst.At(16, 26): got: %v, want: transparent", got)
+	}
+}
+
+func TestDrawThickLine(t *testing.T) {
+	const w, h = 32, 32
+	dst := ebiten.NewImage(w, h)
+	clr := color.RGBA{R: 0x40, G: 0x40, A: 0x80}
+	ebitenutil.DrawThickLine(dst, 8, 16, 24, 16, 8, clr, true)
+
+	// The inside of the line and the round caps.
+	for _, p := range [][2]int{{16, 16}, {16, 13}, {16, 18}, {5, 16}, {26, 16}} {
+		if got := dst.At(p[0], p[1]).(color.RGBA); !sameColors(got, clr, 1) {
+			t.Errorf("dst.At(%d, %d): got: %v, want: %v", p[0], p[1], got, clr)
+		}
+	}
+	// The corners outside of the round caps.
+	for _, p := range [][2]int{{4, 12}, {27, 19}, {16, 22}} {
+		if got := dst.At(p[0], p[1]).(color.RGBA); got != (color.RGBA{}) {
+			t.Errorf("dst.At(%d, %d): got: %v, want: transparent", p[0], p[1], got)
+		}
+	}
+}
+
+func TestDrawLineAA(t *testing.T) {
+	const w, h = 32, 32
+	clr := color.RGBA{R: 0xff, A: 0xff}
+
+	for _, lineCap := range []vector.LineCap{vector.LineCapButt, vector.LineCapRound, vector.LineCapSquare} {
+		dst := ebiten.NewImage(w, h)
+		ebitenutil.DrawLineAA(dst, 8, 16, 24, 16, 4, lineCap, clr)
+
+		if got := dst.At(16, 15).(color.RGBA); got != clr {
+			t.Errorf("lineCap: %d, dst.At(16, 15): got: %v, want: %v", lineCap, got, clr)
+		}
+		// The left end is covered only with the caps.
+		got := dst.At(6, 15).(color.RGBA)
+		if lineCap == vector.LineCapButt {
+			if got != (color.RGBA{}) {
+				t.Errorf("lineCap: %d, dst.At(6, 15): got: %v, want: transparent", lineCap, got)
+			}
+		} else {
+			if got.R == 0 {
+				t.Errorf("lineCap: %d, dst.At(6, 15): got: %v, want: non-transparent", lineCap, got)
+			}
+		}
+	}
+
+	// A diagonal line has partially covered pixels on its edges.
+	dst := ebiten.NewImage(w, h)
+	ebitenutil.DrawLineAA(dst, 4, 4, 28, 20, 2, vector.LineCapButt, clr)
+	var partial bool
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			if a := dst.At(i, j).(color.RGBA).A; a != 0 && a != 0xff {
+				partial = true
+			}
+		}
+	}
+	if !partial {
+		t.Errorf("a diagonal line must be anti-aliased")
+	}
+}
+
+func TestDrawRectRotated(t *testing.T) {
+	const w, h = 32, 32
+	clr := color.RGBA{R: 0xff, A: 0xff}
+
+	dst := ebiten.NewImage(w, h)
+	// A 20x4 rectangle rotated by 90 degrees is a 4x20 rectangle.
+	ebitenutil.DrawRectRotated(dst, 16, 16, 20, 4, math.Pi/2, clr)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			var want color.RGBA
+			if 14 <= i && i < 18 && 6 <= j && j < 26 {
+				want = clr
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// Negative and zero sizes must not panic.
+	dst.Clear()
+	ebitenutil.DrawRectRotated(dst, 16, 16, -4, -4, 0, clr)
+	if got := dst.At(15, 15).(color.RGBA); got != clr {
+		t.Errorf("dst.At(15, 15): got: %v, want: %v", got, clr)
+	}
+	ebitenutil.DrawRectRotated(dst, 16, 16, 0, 4, 0, clr)
+	ebitenutil.DrawRectRotatedOutline(dst, 16, 16, 0, 0, 0, 1, clr)
+}
+
+func TestDrawRectRotatedOutline(t *testing.T) {
+	const w, h = 32, 32
+	clr := color.RGBA{G: 0x80, A: 0x80}
+
+	dst := ebiten.NewImage(w, h)
+	ebitenutil.DrawRectRotatedOutline(dst, 16, 16, 16, 16, 0, 2, clr)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			var want color.RGBA
+			outer := 7 <= i && i < 25 && 7 <= j && j < 25
+			inner := 9 <= i && i < 23 && 9 <= j && j < 23
+			if outer && !inner {
+				want = clr
+			}
+			// A translucent color must not be accumulated by overlapping triangles.
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TonemapMode represents a tonemapping operator for DrawTonemap.
+type TonemapMode int
+
+const (
+	// TonemapNone doesn't compress the colors, and the colors more than 1 are clamped.
+	TonemapNone TonemapMode = iota
+
+	// TonemapReinhard is the Reinhard operator c / (1 + c) applied to each channel.
+	TonemapReinhard
+
+	// TonemapACES is the ACES filmic curve.
+	// This uses the RRT and ODT fit with the sRGB input and output color space conversions by Stephen Hill,
+	// which is more accurate than the popular single-curve approximation.
+	TonemapACES
+)
+
+// tonemapShaderSrc is a shader to convert linear colors to sRGB colors with exposure and tonemapping.
+var tonemapShaderSrc = []byte(`//kage:unit pixels
+
+package main
+
+var Exposure float
+var Mode int
+
+func rrtAndODTFit(v vec3) vec3 {
+	a := v*(v+0.0245786) - 0.000090537
+	b := v*(0.983729*v+0.4329510) + 0.238081
+	return a / b
+}
+
+func aces(c vec3) vec3 {
+	// The sRGB to the ACES input color space (AP1 with the RRT saturation).
+	c = vec3(
+		dot(vec3(0.59719, 0.35458, 0.04823), c),
+		dot(vec3(0.07600, 0.90834, 0.01566), c),
+		dot(vec3(0.02840, 0.13383, 0.83777), c))
+	c = rrtAndODTFit(c)
+	// The ACES output color space to sRGB.
+	return vec3(
+		dot(vec3(1.60475, -0.53108, -0.07367), c),
+		dot(vec3(-0.10208, 1.10813, -0.00605), c),
+		dot(vec3(-0.00327, -0.07276, 1.07602), c))
+}
+
+func encodeSRGB(c vec3) vec3 {
+	c = clamp(c, 0, 1)
+	lo := c * 12.92
+	hi := 1.055*pow(c, vec3(1/2.4)) - 0.055
+	return mix(hi, lo, step(c, vec3(0.0031308)))
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	c := imageSrc0UnsafeAt(srcPos)
+	if c.a == 0 {
+		return vec4(0)
+	}
+	rgb := c.rgb / c.a * Exposure
+	if Mode == 1 {
+		rgb = rgb / (1 + rgb)
+	} else if Mode == 2 {
+		rgb = aces(rgb)
+	}
+	return vec4(encodeSRGB(rgb)*c.a, c.a)
+}
+`)
+
+// linearizeShaderSrc is a shader to convert sRGB colors to linear colors.
+var linearizeShaderSrc = []byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	c := imageSrc0UnsafeAt(srcPos)
+	if c.a == 0 {
+		return vec4(0)
+	}
+	rgb := c.rgb / c.a
+	lo := rgb / 12.92
+	hi := pow((rgb+0.055)/1.055, vec3(2.4))
+	rgb = mix(hi, lo, step(rgb, vec3(0.04045)))
+	return vec4(rgb*c.a, c.a)
+}
+`)
+
+var (
+	tonemapShader     *ebiten.Shader
+	tonemapShaderOnce sync.Once
+
+	linearizeShader     *ebiten.Shader
+	linearizeShaderOnce sync.Once
+)
+
+func ensureTonemapShader() *ebiten.Shader {
+	tonemapShaderOnce.Do(func() {
+		s, err := ebiten.NewShader(tonemapShaderSrc)
+		if err != nil {
+			panic(fmt.Sprintf("ebitenutil: NewShader for the tonemapping shader failed: %v", err))
+		}
+		tonemapShader = s
+	})
+	return tonemapShader
+}
+
+func ensureLinearizeShader() *ebiten.Shader {
+	linearizeShaderOnce.Do(func() {
+		s, err := ebiten.NewShader(linearizeShaderSrc)
+		if err != nil {
+			panic(fmt.Sprintf("ebitenutil: NewShader for the linearizing shader failed: %v", err))
+		}
+		linearizeShader = s
+	})
+	return linearizeShader
+}
+
+// DrawTonemap renders src onto dst with the tonemapping applied.
+//
+// src is rendered at the origin of dst, and the pixels of dst in the region are replaced.
+// dst and src must not be the same image.
+//
+// src's colors are treated as linear colors. They are multiplied by exposure, compressed into [0, 1] by mode,
+// and then encoded with the sRGB transfer function, which is the exact piecewise function rather than a gamma of 2.2.
+// The alpha values are kept, and the colors are un-premultiplied during the conversion.
+//
+// DrawTonemap is typically used at the end of rendering, where lights are accumulated into src in the linear space,
+// e.g. with the game's screen as dst.
+// Note that the colors more than 1 in src are already clamped unless src can hold such values.
+//
+// If mode is invalid, DrawTonemap panics.
+func DrawTonemap(dst, src *ebiten.Image, exposure float64, mode TonemapMode) {
+	if mode < TonemapNone || mode > TonemapACES {
+		panic(fmt.Sprintf("ebitenutil: invalid TonemapMode: %d", mode))
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = src
+	op.Uniforms = map[string]any{
+		"Exposure": float32(exposure),
+		"Mode":     int(mode),
+	}
+	op.Blend = ebiten.BlendCopy
+	dst.DrawRectShader(w, h, ensureTonemapShader(), op)
+}
+
+// DrawSRGBToLinear renders src onto dst with src's sRGB colors converted to linear colors.
+//
+// src is rendered at the origin of dst, and the pixels of dst in the region are replaced.
+// dst and src must not be the same image.
+//
+// DrawSRGBToLinear is the inverse of DrawTonemap with TonemapNone and the exposure 1.
+// This is useful to convert textures authored in sRGB before accumulating lights in the linear space.
+// Note that the precision of dark colors is lost with 8-bit images.
+func DrawSRGBToLinear(dst, src *ebiten.Image) {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = src
+	op.Blend = ebiten.BlendCopy
+	dst.DrawRectShader(w, h, ensureLinearizeShader(), op)
+}
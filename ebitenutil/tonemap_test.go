@@ -0,0 +1,148 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func encodeSRGB(v float64) float64 {
+	v = math.Max(0, math.Min(1, v))
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+func toByte(v float64) uint8 {
+	return uint8(math.Round(v * 0xff))
+}
+
+// acesGray is a reference implementation of TonemapACES for a gray color.
+func acesGray(v float64) float64 {
+	// The rows of the input and output matrices sum up to 1, so a gray color stays gray.
+	a := v*(v+0.0245786) - 0.000090537
+	b := v*(0.983729*v+0.4329510) + 0.238081
+	return a / b
+}
+
+func TestDrawTonemap(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff})
+	v := float64(0x80) / 0xff
+
+	testCases := []struct {
+		Name     string
+		Exposure float64
+		Mode     ebitenutil.TonemapMode
+		Want     float64
+	}{
+		{
+			Name:     "none",
+			Exposure: 1,
+			Mode:     ebitenutil.TonemapNone,
+			Want:     encodeSRGB(v),
+		},
+		{
+			Name:     "none with exposure",
+			Exposure: 4,
+			Mode:     ebitenutil.TonemapNone,
+			Want:     1,
+		},
+		{
+			Name:     "reinhard",
+			Exposure: 2,
+			Mode:     ebitenutil.TonemapReinhard,
+			Want:     encodeSRGB(2 * v / (1 + 2*v)),
+		},
+		{
+			Name:     "aces",
+			Exposure: 2,
+			Mode:     ebitenutil.TonemapACES,
+			Want:     encodeSRGB(acesGray(2 * v)),
+		},
+		{
+			Name:     "zero exposure",
+			Exposure: 0,
+			Mode:     ebitenutil.TonemapACES,
+			Want:     0,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			dst := ebiten.NewImage(w, h)
+			ebitenutil.DrawTonemap(dst, src, tc.Exposure, tc.Mode)
+			c := toByte(tc.Want)
+			want := color.RGBA{R: c, G: c, B: c, A: 0xff}
+			for j := 0; j < h; j++ {
+				for i := 0; i < w; i++ {
+					got := dst.At(i, j).(color.RGBA)
+					if !sameColors(got, want, 2) {
+						t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDrawTonemapKeepsAlpha(t *testing.T) {
+	src := ebiten.NewImage(4, 4)
+	src.Fill(color.RGBA{R: 0x40, A: 0x80})
+
+	// The existing pixels are replaced.
+	dst := ebiten.NewImage(4, 4)
+	dst.Fill(color.White)
+	ebitenutil.DrawTonemap(dst, src, 1, ebitenutil.TonemapNone)
+	got := dst.At(0, 0).(color.RGBA)
+	// The un-premultiplied red is 0x80.
+	r := toByte(encodeSRGB(float64(0x40)/0x80) * float64(0x80) / 0xff)
+	want := color.RGBA{R: r, A: 0x80}
+	if !sameColors(got, want, 2) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestDrawSRGBToLinear(t *testing.T) {
+	src := ebiten.NewImage(4, 4)
+	src.Fill(color.RGBA{R: 0x80, G: 0x40, B: 0x08, A: 0xff})
+
+	linear := ebiten.NewImage(4, 4)
+	ebitenutil.DrawSRGBToLinear(linear, src)
+	dst := ebiten.NewImage(4, 4)
+	ebitenutil.DrawTonemap(dst, linear, 1, ebitenutil.TonemapNone)
+	got := dst.At(0, 0).(color.RGBA)
+	want := color.RGBA{R: 0x80, G: 0x40, B: 0x08, A: 0xff}
+	// The round trip loses the precision of dark colors.
+	if !sameColors(got, want, 8) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestDrawTonemapInvalidMode(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("DrawTonemap with an invalid mode must panic")
+		}
+	}()
+	ebitenutil.DrawTonemap(ebiten.NewImage(1, 1), ebiten.NewImage(1, 1), 1, ebitenutil.TonemapMode(-1))
+}
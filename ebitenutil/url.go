@@ -0,0 +1,238 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// NewImageFromURLOptions represents options for NewImageFromURLWithContext and NewImageFromURLAsync.
+type NewImageFromURLOptions struct {
+	// Client is the HTTP client to send the request.
+	// Caching or custom headers can be implemented with the client's Transport.
+	//
+	// The default (zero) value is nil, which means http.DefaultClient is used.
+	Client *http.Client
+
+	// OnProgress is called every time a part of the response body is read.
+	// read is the number of bytes read so far, and total is the length of the response body.
+	// total is -1 when the length is unknown.
+	//
+	// OnProgress is called on the goroutine reading the response body.
+	// With NewImageFromURLAsync, this is not the game's goroutine.
+	//
+	// The default (zero) value is nil, which means nothing is called.
+	OnProgress func(read, total int64)
+}
+
+// HTTPStatusError is an error returned when the response status is not 200 OK.
+type HTTPStatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+// Error implements error.
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("ebitenutil: unexpected HTTP status for %s: %s", e.URL, e.Status)
+}
+
+// ContentTypeError is an error returned when the response's Content-Type is not an image type.
+type ContentTypeError struct {
+	URL         string
+	ContentType string
+}
+
+// Error implements error.
+func (e *ContentTypeError) Error() string {
+	return fmt.Sprintf("ebitenutil: unexpected Content-Type for %s: %q", e.URL, e.ContentType)
+}
+
+// ImageDecodeError is an error returned when decoding the response body as an image fails.
+type ImageDecodeError struct {
+	URL string
+	Err error
+}
+
+// Error implements error.
+func (e *ImageDecodeError) Error() string {
+	return fmt.Sprintf("ebitenutil: decoding the image from %s failed: %v", e.URL, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ImageDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// NewImageFromURLWithContext creates a new ebiten.Image from the given URL with the context.
+//
+// The request is canceled when ctx is canceled or its deadline is exceeded, and then the context's error is returned.
+// If the response status is not 200 OK, NewImageFromURLWithContext returns *HTTPStatusError.
+// If the response's Content-Type is specified but is neither an image type nor application/octet-stream,
+// NewImageFromURLWithContext returns *ContentTypeError.
+// If decoding the response body fails, NewImageFromURLWithContext returns *ImageDecodeError.
+//
+// On browsers, the request is sent with the Fetch API, and the CORS rules are applied.
+// Then, an image from a different origin can be loaded only when the server allows it
+// with Access-Control-Allow-Origin. Otherwise, the request fails with an error without any details,
+// as the browser hides them.
+// The fetch mode and credentials can be specified by the special request headers "js.fetch:mode" and
+// "js.fetch:credentials" via Client's Transport. See the documentation of net/http for details.
+//
+// options can be nil.
+//
+// Image decoders must be imported when using NewImageFromURLWithContext. For example,
+// if you want to load a PNG image, you'd need to add `_ "image/png"` to the import section.
+func NewImageFromURLWithContext(ctx context.Context, url string, options *NewImageFromURLOptions) (*ebiten.Image, error) {
+	if options == nil {
+		options = &NewImageFromURLOptions{}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := options.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, &HTTPStatusError{
+			URL:        url,
+			StatusCode: res.StatusCode,
+			Status:     res.Status,
+		}
+	}
+	if ct := res.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || (!strings.HasPrefix(mediaType, "image/") && mediaType != "application/octet-stream") {
+			return nil, &ContentTypeError{
+				URL:         url,
+				ContentType: ct,
+			}
+		}
+	}
+
+	var r io.Reader = res.Body
+	if options.OnProgress != nil {
+		r = &progressReader{
+			reader:     res.Body,
+			total:      res.ContentLength,
+			onProgress: options.OnProgress,
+		}
+	}
+	img, _, err := image.Decode(r)
+	if err != nil {
+		// A canceled context is reported as it is rather than as a decoding error.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, &ImageDecodeError{
+			URL: url,
+			Err: err,
+		}
+	}
+
+	return ebiten.NewImageFromImage(img), nil
+}
+
+type progressReader struct {
+	reader     io.Reader
+	read       int64
+	total      int64
+	onProgress func(read, total int64)
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.reader.Read(buf)
+	if n > 0 {
+		p.read += int64(n)
+		p.onProgress(p.read, p.total)
+	}
+	return n, err
+}
+
+// AsyncImage represents an image loaded asynchronously by NewImageFromURLAsync.
+type AsyncImage struct {
+	image *ebiten.Image
+	err   error
+	done  chan struct{}
+	m     sync.Mutex
+}
+
+// Image returns the loaded image.
+//
+// Image returns nil until loading the image succeeds.
+// This is useful to show a placeholder while loading.
+func (a *AsyncImage) Image() *ebiten.Image {
+	a.m.Lock()
+	defer a.m.Unlock()
+	return a.image
+}
+
+// Err returns the error of loading the image.
+//
+// Err returns nil while loading the image or when loading the image succeeds.
+func (a *AsyncImage) Err() error {
+	a.m.Lock()
+	defer a.m.Unlock()
+	return a.err
+}
+
+// Done returns a channel that is closed when loading the image finishes, regardless of whether it succeeds.
+func (a *AsyncImage) Done() <-chan struct{} {
+	return a.done
+}
+
+// NewImageFromURLAsync starts loading an image from the given URL in a separate goroutine, and returns immediately.
+//
+// The returned AsyncImage's Image returns a non-nil image after loading succeeds.
+// The errors are the same as NewImageFromURLWithContext.
+//
+// options can be nil.
+//
+// Image decoders must be imported when using NewImageFromURLAsync. For example,
+// if you want to load a PNG image, you'd need to add `_ "image/png"` to the import section.
+func NewImageFromURLAsync(ctx context.Context, url string, options *NewImageFromURLOptions) *AsyncImage {
+	a := &AsyncImage{
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(a.done)
+		img, err := NewImageFromURLWithContext(ctx, url, options)
+		a.m.Lock()
+		defer a.m.Unlock()
+		a.image = img
+		a.err = err
+	}()
+	return a
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"context"
+	"errors"
+	"image"
+	_ "image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func newImageServer(t *testing.T) *httptest.Server {
+	data, err := images.ReadFile("text.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/text.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(data)
+		case "/text.html":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = io.WriteString(w, "<html></html>")
+		case "/broken.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(data[:len(data)/2])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestNewImageFromURLWithContext(t *testing.T) {
+	s := newImageServer(t)
+
+	var read, total int64
+	img, err := ebitenutil.NewImageFromURLWithContext(context.Background(), s.URL+"/text.png", &ebitenutil.NewImageFromURLOptions{
+		Client: s.Client(),
+		OnProgress: func(r, t int64) {
+			read, total = r, t
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := img.Bounds().Size(), image.Pt(192, 128); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if read == 0 || read != total {
+		t.Errorf("progress: got: (%d, %d), want: the same positive values", read, total)
+	}
+}
+
+func TestNewImageFromURLWithContextErrors(t *testing.T) {
+	s := newImageServer(t)
+
+	_, err := ebitenutil.NewImageFromURLWithContext(context.Background(), s.URL+"/missing.png", nil)
+	var statusErr *ebitenutil.HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("got: %v, want: *HTTPStatusError with 404", err)
+	}
+
+	_, err = ebitenutil.NewImageFromURLWithContext(context.Background(), s.URL+"/text.html", nil)
+	var contentTypeErr *ebitenutil.ContentTypeError
+	if !errors.As(err, &contentTypeErr) {
+		t.Errorf("got: %v, want: *ContentTypeError", err)
+	}
+
+	_, err = ebitenutil.NewImageFromURLWithContext(context.Background(), s.URL+"/broken.png", nil)
+	var decodeErr *ebitenutil.ImageDecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Errorf("got: %v, want: *ImageDecodeError", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ebitenutil.NewImageFromURLWithContext(ctx, s.URL+"/text.png", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("got: %v, want: context.Canceled", err)
+	}
+}
+
+func TestNewImageFromURLAsync(t *testing.T) {
+	s := newImageServer(t)
+
+	a := ebitenutil.NewImageFromURLAsync(context.Background(), s.URL+"/text.png", nil)
+	<-a.Done()
+	if err := a.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if a.Image() == nil {
+		t.Errorf("Image() must not be nil after loading succeeds")
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ebitentest provides utilities to test a game by stepping it manually.
+// This package is experimental and the API might be changed in the future.
+//
+// A Runner calls a game's Update and Draw explicitly, without depending on the real time nor vsync.
+// Thus, a game's result is deterministic regardless of the machine's speed.
+//
+// As Ebitengine images require Ebitengine's main loop, tests using this package must call Main in TestMain:
+//
+//	func TestMain(m *testing.M) {
+//	    ebitentest.Main(m)
+//	}
+package ebitentest
+
+import (
+	"image"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type mainGame struct {
+	m    *testing.M
+	code int
+}
+
+func (g *mainGame) Update() error {
+	g.code = g.m.Run()
+	return ebiten.Termination
+}
+
+func (*mainGame) Draw(*ebiten.Image) {
+}
+
+func (*mainGame) Layout(int, int) (int, int) {
+	return 320, 240
+}
+
+// Main runs the tests in Ebitengine's main loop and exits the process with the tests' result.
+//
+// Main must be called from TestMain on the main thread.
+func Main(m *testing.M) {
+	g := &mainGame{
+		m:    m,
+		code: 1,
+	}
+	if err := ebiten.RunGame(g); err != nil {
+		panic(err)
+	}
+	os.Exit(g.code)
+}
+
+// Runner steps a game manually.
+//
+// Runner's functions must be called while tests are run with Main.
+type Runner struct {
+	game          ebiten.Game
+	outsideWidth  int
+	outsideHeight int
+	offscreen     *ebiten.Image
+	updateCalled  bool
+}
+
+// NewRunner creates a new Runner for the game.
+//
+// outsideWidth and outsideHeight are the outside size given to the game's Layout.
+// NewRunner panics if outsideWidth or outsideHeight is not a positive number.
+func NewRunner(game ebiten.Game, outsideWidth, outsideHeight int) *Runner {
+	if outsideWidth <= 0 || outsideHeight <= 0 {
+		panic("ebitentest: outsideWidth and outsideHeight must be positive")
+	}
+	return &Runner{
+		game:          game,
+		outsideWidth:  outsideWidth,
+		outsideHeight: outsideHeight,
+	}
+}
+
+// Tick calls the game's Layout and then Update exactly once.
+//
+// Tick doesn't depend on the real time. Each Tick represents one tick whose duration is 1/TPS [s].
+//
+// If Update returns ebiten.Termination, Tick returns ebiten.Termination as it is.
+func (r *Runner) Tick() error {
+	r.layout()
+	if err := r.game.Update(); err != nil {
+		return err
+	}
+	r.updateCalled = true
+	return nil
+}
+
+// RenderFrame calls the game's Layout and then Draw exactly once, and returns the offscreen image that Draw renders.
+//
+// If Update has never been called, RenderFrame calls Tick first in the same way as RunGame does.
+//
+// The returned image is owned by the Runner. The image is valid until the next RenderFrame call.
+// The image is cleared before Draw if ebiten.IsScreenClearedEveryFrame returns true.
+func (r *Runner) RenderFrame() (*ebiten.Image, error) {
+	if !r.updateCalled {
+		if err := r.Tick(); err != nil {
+			return nil, err
+		}
+	}
+
+	w, h := r.layout()
+	if r.offscreen != nil && r.offscreen.Bounds().Size() != image.Pt(w, h) {
+		r.offscreen.Deallocate()
+		r.offscreen = nil
+	}
+	if r.offscreen == nil {
+		r.offscreen = ebiten.NewImage(w, h)
+	}
+	if ebiten.IsScreenClearedEveryFrame() {
+		r.offscreen.Clear()
+	}
+	r.game.Draw(r.offscreen)
+	return r.offscreen, nil
+}
+
+func (r *Runner) layout() (int, int) {
+	if l, ok := r.game.(ebiten.LayoutFer); ok {
+		w, h := l.LayoutF(float64(r.outsideWidth), float64(r.outsideHeight))
+		if w <= 0 || h <= 0 {
+			panic("ebitentest: LayoutF must return positive numbers")
+		}
+		return int(math.Ceil(w)), int(math.Ceil(h))
+	}
+	w, h := r.game.Layout(r.outsideWidth, r.outsideHeight)
+	if w <= 0 || h <= 0 {
+		panic("ebitentest: Layout must return positive numbers")
+	}
+	return w, h
+}
+
+// Step calls the game's Update n times and then Draw once, and returns the rendered image.
+//
+// Step is a shorthand for NewRunner with an outside size 640x480, n Tick calls, and a RenderFrame call.
+// Even if n is 0, Update is called once before Draw.
+func Step(game ebiten.Game, n int) (*ebiten.Image, error) {
+	r := NewRunner(game, 640, 480)
+	for i := 0; i < n; i++ {
+		if err := r.Tick(); err != nil {
+			return nil, err
+		}
+	}
+	return r.RenderFrame()
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitentest_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/ebitentest"
+)
+
+func TestMain(m *testing.M) {
+	ebitentest.Main(m)
+}
+
+type game struct {
+	updateCount int
+	drawCount   int
+}
+
+func (g *game) Update() error {
+	g.updateCount++
+	return nil
+}
+
+func (g *game) Draw(screen *ebiten.Image) {
+	g.drawCount++
+	screen.Fill(color.RGBA{R: byte(g.updateCount), A: 0xff})
+}
+
+func (g *game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth / 2, outsideHeight / 2
+}
+
+func TestRunner(t *testing.T) {
+	g := &game{}
+	r := ebitentest.NewRunner(g, 64, 32)
+
+	for i := 0; i < 3; i++ {
+		if err := r.Tick(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	img, err := r.RenderFrame()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := g.updateCount, 3; got != want {
+		t.Errorf("updateCount: got: %d, want: %d", got, want)
+	}
+	if got, want := g.drawCount, 1; got != want {
+		t.Errorf("drawCount: got: %d, want: %d", got, want)
+	}
+	if got, want := img.Bounds().Size(), image.Pt(32, 16); got != want {
+		t.Errorf("img.Bounds().Size(): got: %v, want: %v", got, want)
+	}
+	if got, want := img.At(0, 0), (color.RGBA{R: 3, A: 0xff}); got != want {
+		t.Errorf("img.At(0, 0): got: %v, want: %v", got, want)
+	}
+}
+
+func TestStep(t *testing.T) {
+	g := &game{}
+	img, err := ebitentest.Step(g, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Update must be called once before Draw.
+	if got, want := g.updateCount, 1; got != want {
+		t.Errorf("updateCount: got: %d, want: %d", got, want)
+	}
+	if got, want := img.At(0, 0), (color.RGBA{R: 1, A: 0xff}); got != want {
+		t.Errorf("img.At(0, 0): got: %v, want: %v", got, want)
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package imgui provides a minimal immediate-mode GUI for tools and menus.
+// This package is experimental and the API might be changed in the future.
+//
+// In the immediate mode, widgets are not objects kept by the program.
+// Instead, a widget function like Button is called every tick with the widget's ID and rectangle,
+// and reports the interaction of the tick like whether the button is clicked.
+// The states of the widgets like values of sliders are held by the program.
+//
+// A typical usage is as follows:
+//
+//	func (g *Game) Update() error {
+//		g.ui.Update()
+//		l := imgui.NewLayout(10, 10, 200)
+//		if g.ui.Button("start", "Start", l.Next(24)) {
+//			// Start the game.
+//		}
+//		g.ui.Slider("volume", l.Next(24), &g.volume, 0, 1)
+//		return nil
+//	}
+//
+//	func (g *Game) Draw(screen *ebiten.Image) {
+//		g.ui.Draw(screen)
+//	}
+//
+// This package is not a full GUI framework. There are no windows, scrolling, or automatic sizing.
+package imgui
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Style represents colors and sizes of widgets.
+type Style struct {
+	// TextColor is the color of texts.
+	TextColor color.Color
+
+	// BackgroundColor is the color of widgets' backgrounds.
+	BackgroundColor color.Color
+
+	// HoveredColor is the color of widgets' backgrounds when the cursor is on them.
+	HoveredColor color.Color
+
+	// ActiveColor is the color of widgets' backgrounds when they are pressed or focused.
+	ActiveColor color.Color
+
+	// AccentColor is the color of marks like checks and slider knobs.
+	AccentColor color.Color
+
+	// BorderColor is the color of widgets' borders.
+	BorderColor color.Color
+
+	// Padding is the horizontal space between the widgets' borders and texts in pixels.
+	Padding int
+}
+
+// DefaultStyle returns the default style.
+func DefaultStyle() Style {
+	return Style{
+		TextColor:       color.White,
+		BackgroundColor: color.RGBA{0x30, 0x30, 0x38, 0xff},
+		HoveredColor:    color.RGBA{0x48, 0x48, 0x54, 0xff},
+		ActiveColor:     color.RGBA{0x20, 0x20, 0x28, 0xff},
+		AccentColor:     color.RGBA{0x40, 0x90, 0xf0, 0xff},
+		BorderColor:     color.RGBA{0x70, 0x70, 0x80, 0xff},
+		Padding:         4,
+	}
+}
+
+type commandType int
+
+const (
+	commandFillRect commandType = iota
+	commandStrokeRect
+	commandText
+)
+
+type command struct {
+	typ   commandType
+	rect  image.Rectangle
+	clr   color.Color
+	text  string
+	align text.Align
+}
+
+// Context is a state of the immediate-mode GUI.
+//
+// Call Update at the beginning of the game's Update, call widget functions after that in the same Update,
+// and call Draw in the game's Draw.
+type Context struct {
+	// Style is the style of widgets.
+	Style Style
+
+	face text.Face
+
+	cursor       image.Point
+	pressed      bool
+	justPressed  bool
+	justReleased bool
+	touchID      ebiten.TouchID
+	touching     bool
+
+	// hovered is the ID of the widget under the cursor in the current tick.
+	hovered string
+
+	// active is the ID of the widget being pressed.
+	active string
+
+	// focused is the ID of the text field receiving texts.
+	focused string
+
+	// focusClaimed reports whether a widget claims the focus in the current tick.
+	focusClaimed bool
+
+	chars []rune
+	ticks int
+
+	commands []command
+	touchIDs []ebiten.TouchID
+}
+
+// NewContext creates a new Context with the face to render texts.
+func NewContext(face text.Face) *Context {
+	return &Context{
+		Style: DefaultStyle(),
+		face:  face,
+	}
+}
+
+// Face returns the face to render texts.
+func (c *Context) Face() text.Face {
+	return c.face
+}
+
+// SetFace sets the face to render texts.
+func (c *Context) SetFace(face text.Face) {
+	c.face = face
+}
+
+// Update reads the input and resets the drawing commands of the previous tick.
+//
+// Update must be called at the beginning of the game's Update, before any widget functions are called.
+func (c *Context) Update() {
+	c.commands = c.commands[:0]
+	c.ticks++
+
+	// A click outside of any widgets unfocuses the text field.
+	if c.justPressed && !c.focusClaimed {
+		c.focused = ""
+	}
+	c.focusClaimed = false
+	if !c.pressed {
+		c.active = ""
+	}
+
+	c.updatePointer()
+	c.hovered = ""
+
+	c.chars = c.chars[:0]
+	if c.focused != "" {
+		c.chars = ebiten.AppendInputChars(c.chars)
+	}
+}
+
+func (c *Context) updatePointer() {
+	c.justPressed = false
+	c.justReleased = false
+
+	// Touches are prior to the mouse.
+	if c.touching {
+		if inpututil.IsTouchJustReleased(c.touchID) {
+			c.cursor.X, c.cursor.Y = inpututil.TouchPositionInPreviousTick(c.touchID)
+			c.touching = false
+			c.pressed = false
+			c.justReleased = true
+			return
+		}
+		c.cursor.X, c.cursor.Y = ebiten.TouchPosition(c.touchID)
+		return
+	}
+	c.touchIDs = inpututil.AppendJustPressedTouchIDs(c.touchIDs[:0])
+	if len(c.touchIDs) > 0 {
+		c.touchID = c.touchIDs[0]
+		c.touching = true
+		c.cursor.X, c.cursor.Y = ebiten.TouchPosition(c.touchID)
+		c.pressed = true
+		c.justPressed = true
+		return
+	}
+
+	c.cursor.X, c.cursor.Y = ebiten.CursorPosition()
+	c.pressed = ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	c.justPressed = inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+	c.justReleased = inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft)
+}
+
+// IsHovered reports whether the cursor is on any widget in the current tick.
+// This is useful to prevent the game world from handling clicks on the GUI.
+//
+// IsHovered must be called after the widget functions are called.
+func (c *Context) IsHovered() bool {
+	return c.hovered != ""
+}
+
+// IsFocused reports whether a text field has the focus.
+// This is useful to prevent the game from handling key inputs for a text field.
+func (c *Context) IsFocused() bool {
+	return c.focused != ""
+}
+
+// Draw draws the widgets of the current tick on dst.
+//
+// Draw can be called multiple times, or can be skipped, in one tick.
+func (c *Context) Draw(dst *ebiten.Image) {
+	for _, cmd := range c.commands {
+		x, y := float32(cmd.rect.Min.X), float32(cmd.rect.Min.Y)
+		w, h := float32(cmd.rect.Dx()), float32(cmd.rect.Dy())
+		switch cmd.typ {
+		case commandFillRect:
+			vector.DrawFilledRect(dst, x, y, w, h, cmd.clr, false)
+		case commandStrokeRect:
+			vector.StrokeRect(dst, x+0.5, y+0.5, w-1, h-1, 1, cmd.clr, false)
+		case commandText:
+			if c.face == nil {
+				continue
+			}
+			// Clip the text by the rectangle.
+			clipped := dst.SubImage(cmd.rect.Intersect(dst.Bounds())).(*ebiten.Image)
+			op := &text.DrawOptions{}
+			op.PrimaryAlign = cmd.align
+			op.SecondaryAlign = text.AlignCenter
+			switch cmd.align {
+			case text.AlignStart:
+				op.GeoM.Translate(float64(cmd.rect.Min.X), 0)
+			case text.AlignCenter:
+				op.GeoM.Translate(float64(cmd.rect.Min.X+cmd.rect.Max.X)/2, 0)
+			case text.AlignEnd:
+				op.GeoM.Translate(float64(cmd.rect.Max.X), 0)
+			}
+			op.GeoM.Translate(0, float64(cmd.rect.Min.Y+cmd.rect.Max.Y)/2)
+			op.ColorScale.ScaleWithColor(cmd.clr)
+			text.Draw(clipped, cmd.text, c.face, op)
+		}
+	}
+}
+
+func (c *Context) fillRect(rect image.Rectangle, clr color.Color) {
+	c.commands = append(c.commands, command{
+		typ:  commandFillRect,
+		rect: rect,
+		clr:  clr,
+	})
+}
+
+func (c *Context) strokeRect(rect image.Rectangle, clr color.Color) {
+	c.commands = append(c.commands, command{
+		typ:  commandStrokeRect,
+		rect: rect,
+		clr:  clr,
+	})
+}
+
+func (c *Context) drawText(str string, rect image.Rectangle, align text.Align) {
+	c.commands = append(c.commands, command{
+		typ:   commandText,
+		rect:  rect,
+		clr:   c.Style.TextColor,
+		text:  str,
+		align: align,
+	})
+}
+
+// interact updates the hovered and active states of the widget, and returns whether the widget is hovered.
+func (c *Context) interact(id string, rect image.Rectangle) (hovered bool) {
+	if id == "" {
+		panic("imgui: id must not be empty")
+	}
+	if c.cursor.In(rect) && (c.active == "" || c.active == id) {
+		c.hovered = id
+		hovered = true
+	}
+	if hovered && c.justPressed {
+		c.active = id
+	}
+	return hovered
+}
+
+func (c *Context) backgroundColor(id string, hovered bool) color.Color {
+	if c.active == id && c.pressed || c.focused == id {
+		return c.Style.ActiveColor
+	}
+	if hovered {
+		return c.Style.HoveredColor
+	}
+	return c.Style.BackgroundColor
+}
+
+func (c *Context) padded(rect image.Rectangle) image.Rectangle {
+	rect.Min.X += c.Style.Padding
+	rect.Max.X -= c.Style.Padding
+	if rect.Min.X > rect.Max.X {
+		rect.Min.X = (rect.Min.X + rect.Max.X) / 2
+		rect.Max.X = rect.Min.X
+	}
+	return rect
+}
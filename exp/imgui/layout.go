@@ -0,0 +1,89 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imgui
+
+import (
+	"image"
+)
+
+// Layout is a cursor to put widgets from top to bottom.
+//
+// Each Next call returns a rectangle for one row, and moves the cursor down.
+type Layout struct {
+	x       int
+	y       int
+	width   int
+	spacing int
+}
+
+// defaultSpacing is the default vertical and horizontal space between rows and columns in pixels.
+const defaultSpacing = 4
+
+// NewLayout creates a new Layout whose rows start at (x, y) and have the given width.
+func NewLayout(x, y, width int) *Layout {
+	return &Layout{
+		x:       x,
+		y:       y,
+		width:   width,
+		spacing: defaultSpacing,
+	}
+}
+
+// Spacing returns the space between rows and columns in pixels.
+func (l *Layout) Spacing() int {
+	return l.spacing
+}
+
+// SetSpacing sets the space between rows and columns in pixels.
+// The default value is 4.
+func (l *Layout) SetSpacing(spacing int) {
+	l.spacing = spacing
+}
+
+// Cursor returns the current position where the next row starts.
+func (l *Layout) Cursor() image.Point {
+	return image.Pt(l.x, l.y)
+}
+
+// Next returns a rectangle for a row with the given height, and moves the cursor down.
+func (l *Layout) Next(height int) image.Rectangle {
+	r := image.Rect(l.x, l.y, l.x+l.width, l.y+height)
+	l.y += height + l.spacing
+	return r
+}
+
+// NextColumns returns rectangles for a row divided into n columns of the same width, and moves the cursor down.
+// The columns are separated by the spacing.
+//
+// If n is not positive, NextColumns panics.
+func (l *Layout) NextColumns(height int, n int) []image.Rectangle {
+	if n <= 0 {
+		panic("imgui: n at NextColumns must be positive")
+	}
+	row := l.Next(height)
+	rects := make([]image.Rectangle, n)
+	w := row.Dx() - l.spacing*(n-1)
+	for i := range rects {
+		x0 := row.Min.X + w*i/n + l.spacing*i
+		x1 := row.Min.X + w*(i+1)/n + l.spacing*i
+		rects[i] = image.Rect(x0, row.Min.Y, x1, row.Max.Y)
+	}
+	return rects
+}
+
+// Skip moves the cursor down by the given height without a row.
+func (l *Layout) Skip(height int) {
+	l.y += height
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imgui_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/imgui"
+)
+
+func TestLayout(t *testing.T) {
+	l := imgui.NewLayout(10, 20, 100)
+	if got, want := l.Next(30), image.Rect(10, 20, 110, 50); got != want {
+		t.Errorf("Next(30): got: %v, want: %v", got, want)
+	}
+	if got, want := l.Cursor(), image.Pt(10, 54); got != want {
+		t.Errorf("Cursor(): got: %v, want: %v", got, want)
+	}
+
+	l.SetSpacing(10)
+	rects := l.NextColumns(20, 3)
+	want := []image.Rectangle{
+		image.Rect(10, 54, 36, 74),
+		image.Rect(46, 54, 73, 74),
+		image.Rect(83, 54, 110, 74),
+	}
+	if len(rects) != len(want) {
+		t.Fatalf("len(NextColumns(20, 3)): got: %d, want: %d", len(rects), len(want))
+	}
+	for i := range rects {
+		if rects[i] != want[i] {
+			t.Errorf("NextColumns(20, 3)[%d]: got: %v, want: %v", i, rects[i], want[i])
+		}
+	}
+
+	l.Skip(6)
+	if got, want := l.Cursor(), image.Pt(10, 90); got != want {
+		t.Errorf("Cursor(): got: %v, want: %v", got, want)
+	}
+}
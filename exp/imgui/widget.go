@@ -0,0 +1,176 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imgui
+
+import (
+	"image"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Label draws a text in the rectangle.
+// The text is left-aligned and vertically centered, and is clipped by the rectangle.
+func (c *Context) Label(label string, rect image.Rectangle) {
+	c.drawText(label, rect, text.AlignStart)
+}
+
+// Button draws a button with the label in the rectangle.
+//
+// Button returns true when the button is clicked, i.e. the button is released after being pressed on it.
+//
+// id must be unique among the widgets in the Context. If id is empty, Button panics.
+func (c *Context) Button(id string, label string, rect image.Rectangle) bool {
+	hovered := c.interact(id, rect)
+
+	c.fillRect(rect, c.backgroundColor(id, hovered))
+	c.strokeRect(rect, c.Style.BorderColor)
+	c.drawText(label, c.padded(rect), text.AlignCenter)
+
+	return hovered && c.active == id && c.justReleased
+}
+
+// Checkbox draws a checkbox with the label in the rectangle.
+// The box is a square at the left side of the rectangle, and the label follows it.
+//
+// The value pointed by checked is toggled when the checkbox is clicked.
+// Checkbox returns true when the value is changed.
+//
+// id must be unique among the widgets in the Context. If id is empty, Checkbox panics.
+func (c *Context) Checkbox(id string, label string, rect image.Rectangle, checked *bool) bool {
+	hovered := c.interact(id, rect)
+
+	var changed bool
+	if hovered && c.active == id && c.justReleased {
+		*checked = !*checked
+		changed = true
+	}
+
+	size := rect.Dy()
+	box := image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+size, rect.Max.Y)
+	c.fillRect(box, c.backgroundColor(id, hovered))
+	c.strokeRect(box, c.Style.BorderColor)
+	if *checked {
+		c.fillRect(box.Inset(size/4), c.Style.AccentColor)
+	}
+	labelRect := rect
+	labelRect.Min.X = box.Max.X + c.Style.Padding
+	c.drawText(label, labelRect, text.AlignStart)
+
+	return changed
+}
+
+// Slider draws a horizontal slider in the rectangle.
+//
+// The value pointed by value is updated while the slider is dragged.
+// The value is clamped to [min, max].
+// Slider returns true when the value is changed.
+//
+// id must be unique among the widgets in the Context. If id is empty, Slider panics.
+func (c *Context) Slider(id string, rect image.Rectangle, value *float64, min, max float64) bool {
+	hovered := c.interact(id, rect)
+
+	old := *value
+	v := old
+	if c.active == id && (c.pressed || c.justReleased) && rect.Dx() > 0 {
+		rate := float64(c.cursor.X-rect.Min.X) / float64(rect.Dx())
+		v = min + rate*(max-min)
+	}
+	if v < min {
+		v = min
+	}
+	if v > max {
+		v = max
+	}
+	*value = v
+
+	c.fillRect(rect, c.backgroundColor(id, hovered))
+	if max > min {
+		w := int(float64(rect.Dx()) * (v - min) / (max - min))
+		c.fillRect(image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+w, rect.Max.Y), c.Style.AccentColor)
+	}
+	c.strokeRect(rect, c.Style.BorderColor)
+
+	return v != old
+}
+
+// textFieldRepeatDelay and textFieldRepeatInterval are the key repeat timing in ticks for the backspace key.
+const (
+	textFieldRepeatDelay    = 30
+	textFieldRepeatInterval = 3
+)
+
+// TextField draws a single-line text field in the rectangle.
+//
+// A text field gets the focus by clicking it, and loses the focus by clicking outside of it,
+// or pressing the enter or escape key.
+// While the text field has the focus, input characters are appended to the text pointed by str,
+// and the backspace key deletes the last character.
+// TextField returns true when the text is changed.
+//
+// TextField doesn't support IME composition or moving the caret.
+//
+// id must be unique among the widgets in the Context. If id is empty, TextField panics.
+func (c *Context) TextField(id string, rect image.Rectangle, str *string) bool {
+	hovered := c.interact(id, rect)
+	if hovered && c.justPressed {
+		c.focused = id
+	}
+	if c.focused == id {
+		c.focusClaimed = true
+	}
+
+	var changed bool
+	if c.focused == id {
+		if len(c.chars) > 0 {
+			*str += string(c.chars)
+			changed = true
+		}
+		if d := inpututil.KeyPressDuration(ebiten.KeyBackspace); d == 1 || d >= textFieldRepeatDelay && (d-textFieldRepeatDelay)%textFieldRepeatInterval == 0 {
+			if _, size := utf8.DecodeLastRuneInString(*str); size > 0 {
+				*str = (*str)[:len(*str)-size]
+				changed = true
+			}
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter) || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+			c.focused = ""
+		}
+	}
+
+	c.fillRect(rect, c.backgroundColor(id, hovered))
+	c.strokeRect(rect, c.Style.BorderColor)
+
+	t := *str
+	align := text.AlignStart
+	if c.focused == id {
+		// Blink the caret.
+		if c.ticks/30%2 == 0 {
+			t += "|"
+		} else {
+			t += " "
+		}
+		// Show the end of the text when the text is longer than the field.
+		if c.face != nil {
+			if w, _ := text.Measure(t, c.face, 0); w > float64(c.padded(rect).Dx()) {
+				align = text.AlignEnd
+			}
+		}
+	}
+	c.drawText(t, c.padded(rect), align)
+
+	return changed
+}
@@ -0,0 +1,168 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rng provides a deterministic pseudo-random number generator for games.
+// This package is experimental and the API might be changed in the future.
+//
+// Unlike math/rand's global functions, a Rand's sequence depends only on its seed, and never changes
+// across platforms and Go versions, as the algorithm (xoshiro256**) is fixed.
+// This is useful for replays and lockstep netplay:
+// record the seed or the State with the inputs, and restore it with SetState to reproduce the same game.
+//
+// A Rand is not concurrent-safe.
+// Use a Rand only from the game's Update to keep the game deterministic.
+package rng
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// StateSize is the size of a Rand's state in bytes.
+const StateSize = 32
+
+// Rand is a deterministic pseudo-random number generator.
+//
+// The zero value is not valid. Use New to create a Rand.
+type Rand struct {
+	s [4]uint64
+}
+
+// New returns a new Rand with the given seed.
+func New(seed uint64) *Rand {
+	r := &Rand{}
+	r.Seed(seed)
+	return r
+}
+
+// Seed resets the state of r with the given seed.
+func (r *Rand) Seed(seed uint64) {
+	// Initialize the state by SplitMix64 as recommended by the authors of xoshiro256**.
+	for i := range r.s {
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		r.s[i] = z ^ (z >> 31)
+	}
+}
+
+// State returns the current state of r as bytes for checkpointing.
+// The returned bytes are the same on any platform.
+func (r *Rand) State() [StateSize]byte {
+	var b [StateSize]byte
+	for i, s := range r.s {
+		binary.LittleEndian.PutUint64(b[8*i:], s)
+	}
+	return b
+}
+
+// SetState restores the state of r from bytes returned by State.
+//
+// SetState returns an error if the state is invalid, i.e. all the bytes are zero.
+func (r *Rand) SetState(state [StateSize]byte) error {
+	var s [4]uint64
+	for i := range s {
+		s[i] = binary.LittleEndian.Uint64(state[8*i:])
+	}
+	if s == [4]uint64{} {
+		return errors.New("rng: the state must not be all zero")
+	}
+	r.s = s
+	return nil
+}
+
+// Uint64 returns a pseudo-random 64-bit value.
+func (r *Rand) Uint64() uint64 {
+	result := bits.RotateLeft64(r.s[1]*5, 7) * 9
+	t := r.s[1] << 17
+
+	r.s[2] ^= r.s[0]
+	r.s[3] ^= r.s[1]
+	r.s[1] ^= r.s[2]
+	r.s[0] ^= r.s[3]
+
+	r.s[2] ^= t
+	r.s[3] = bits.RotateLeft64(r.s[3], 45)
+
+	return result
+}
+
+// Uint32 returns a pseudo-random 32-bit value.
+func (r *Rand) Uint32() uint32 {
+	return uint32(r.Uint64() >> 32)
+}
+
+// Intn returns a pseudo-random number in [0, n).
+//
+// Intn panics if n <= 0.
+func (r *Rand) Intn(n int) int {
+	if n <= 0 {
+		panic("rng: n must be positive at Intn")
+	}
+	return int(r.uint64n(uint64(n)))
+}
+
+// IntRange returns a pseudo-random number in [min, max].
+//
+// IntRange panics if min > max.
+func (r *Rand) IntRange(min, max int) int {
+	if min > max {
+		panic("rng: min must be <= max at IntRange")
+	}
+	n := uint64(max) - uint64(min) + 1
+	if n == 0 {
+		// The range is the whole 64-bit integers.
+		return int(r.Uint64())
+	}
+	return min + int(r.uint64n(n))
+}
+
+// uint64n returns a pseudo-random number in [0, n) without a modulo bias.
+func (r *Rand) uint64n(n uint64) uint64 {
+	// Lemire's method.
+	hi, lo := bits.Mul64(r.Uint64(), n)
+	if lo < n {
+		thresh := -n % n
+		for lo < thresh {
+			hi, lo = bits.Mul64(r.Uint64(), n)
+		}
+	}
+	return hi
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0).
+func (r *Rand) Float64() float64 {
+	return float64(r.Uint64()>>11) / (1 << 53)
+}
+
+// Bool returns a pseudo-random boolean value.
+func (r *Rand) Bool() bool {
+	return r.Uint64()>>63 != 0
+}
+
+// Shuffle pseudo-randomizes the order of elements.
+// n is the number of elements. swap swaps the elements with indexes i and j.
+//
+// Shuffle panics if n < 0.
+func (r *Rand) Shuffle(n int, swap func(i, j int)) {
+	if n < 0 {
+		panic("rng: n must be non-negative at Shuffle")
+	}
+	for i := n - 1; i > 0; i-- {
+		j := int(r.uint64n(uint64(i + 1)))
+		swap(i, j)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rng_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/rng"
+)
+
+func TestUint64(t *testing.T) {
+	// The sequence must never change across platforms and versions.
+	r := rng.New(1)
+	for i, want := range []uint64{
+		0xb3f2af6d0fc710c5,
+		0x853b559647364cea,
+		0x92f89756082a4514,
+		0x642e1c7bc266a3a7,
+	} {
+		if got := r.Uint64(); got != want {
+			t.Errorf("Uint64() #%d: got: %#x, want: %#x", i, got, want)
+		}
+	}
+}
+
+func TestState(t *testing.T) {
+	r := rng.New(42)
+	for i := 0; i < 10; i++ {
+		r.Uint64()
+	}
+
+	state := r.State()
+	var want []int
+	for i := 0; i < 10; i++ {
+		want = append(want, r.Intn(100))
+	}
+
+	r2 := rng.New(0)
+	if err := r2.SetState(state); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		if got := r2.Intn(100); got != want[i] {
+			t.Errorf("Intn(100) #%d: got: %d, want: %d", i, got, want[i])
+		}
+	}
+
+	if err := r2.SetState([rng.StateSize]byte{}); err == nil {
+		t.Errorf("SetState with a zero state must return an error")
+	}
+}
+
+func TestRange(t *testing.T) {
+	r := rng.New(1)
+	for i := 0; i < 1000; i++ {
+		if got := r.Intn(7); got < 0 || got >= 7 {
+			t.Errorf("Intn(7): got: %d, want: [0, 7)", got)
+		}
+		if got := r.IntRange(-3, 3); got < -3 || got > 3 {
+			t.Errorf("IntRange(-3, 3): got: %d, want: [-3, 3]", got)
+		}
+		if got := r.Float64(); got < 0 || got >= 1 {
+			t.Errorf("Float64(): got: %f, want: [0, 1)", got)
+		}
+	}
+}
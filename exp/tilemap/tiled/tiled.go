@@ -0,0 +1,314 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tiled provides a loader for maps of the Tiled map editor (https://www.mapeditor.org/).
+// This package is experimental and the API might be changed in the future.
+//
+// Both the XML formats (TMX and TSX) and the JSON formats (TMJ and TSJ) are supported.
+// Tilesets can be embedded in a map or external files.
+// Tile layer data can be encoded in XML, CSV or base64 with or without zlib or gzip compression.
+//
+// Infinite maps are not supported so far.
+// Group layers are flattened: the layers in a group are put into the map's layers in place of the group,
+// with the group's visibility, opacity and offset applied.
+package tiled
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// Map is a map of Tiled.
+type Map struct {
+	// Orientation is the map orientation like "orthogonal", "isometric", "staggered" or "hexagonal".
+	Orientation string
+
+	// Width and Height are the map size in tiles.
+	Width  int
+	Height int
+
+	// TileWidth and TileHeight are the size of a tile in pixels.
+	TileWidth  int
+	TileHeight int
+
+	// Tilesets are the tilesets used in the map.
+	Tilesets []*Tileset
+
+	// Layers are the tile layers and the object layers in the rendering order.
+	Layers []*Layer
+
+	// Properties are the custom properties of the map.
+	Properties Properties
+}
+
+// Tileset is a tileset of Tiled.
+type Tileset struct {
+	// FirstGID is the first global tile ID of the tileset in the map.
+	FirstGID uint32
+
+	// Source is the path of the external tileset file in the file system given at Load.
+	// Source is empty if the tileset is embedded in the map.
+	Source string
+
+	// Name is the name of the tileset.
+	Name string
+
+	// TileWidth and TileHeight are the size of a tile in pixels.
+	TileWidth  int
+	TileHeight int
+
+	// Spacing is the spacing in pixels between the tiles in the image.
+	Spacing int
+
+	// Margin is the margin in pixels around the tiles in the image.
+	Margin int
+
+	// TileCount is the number of the tiles.
+	TileCount int
+
+	// Columns is the number of the tile columns.
+	Columns int
+
+	// Image is the image of the tileset.
+	Image Image
+
+	// Tiles are the tiles with custom information like properties.
+	// Tiles doesn't include tiles without custom information.
+	Tiles []*Tile
+
+	// Properties are the custom properties of the tileset.
+	Properties Properties
+}
+
+// Image is an image reference of Tiled.
+type Image struct {
+	// Source is the path of the image file in the file system given at Load.
+	Source string
+
+	// Width and Height are the size of the image in pixels.
+	Width  int
+	Height int
+}
+
+// Tile is a tile with custom information in a tileset.
+type Tile struct {
+	// ID is the local ID of the tile in the tileset.
+	ID int
+
+	// Type is the class of the tile.
+	Type string
+
+	// Properties are the custom properties of the tile.
+	Properties Properties
+}
+
+// LayerType represents the type of a layer.
+type LayerType int
+
+const (
+	// LayerTypeTile is a tile layer.
+	LayerTypeTile LayerType = iota
+
+	// LayerTypeObject is an object layer, which is called an object group in Tiled.
+	LayerTypeObject
+)
+
+// Layer is a tile layer or an object layer of Tiled.
+type Layer struct {
+	ID      int
+	Name    string
+	Type    LayerType
+	Visible bool
+	Opacity float64
+	OffsetX float64
+	OffsetY float64
+
+	// Width and Height are the layer size in tiles.
+	// Width and Height are valid only for a tile layer.
+	Width  int
+	Height int
+
+	// Data is the global tile IDs in the row-major order.
+	// The upper bits of a global tile ID are the flip flags, which are the same as the tilemap package's.
+	// 0 means no tile.
+	// Data is valid only for a tile layer.
+	Data []uint32
+
+	// Objects are the objects in the layer.
+	// Objects is valid only for an object layer.
+	Objects []*Object
+
+	// Properties are the custom properties of the layer.
+	Properties Properties
+}
+
+// Object is an object in an object layer.
+type Object struct {
+	ID       int
+	Name     string
+	Type     string
+	X        float64
+	Y        float64
+	Width    float64
+	Height   float64
+	Rotation float64
+	Visible  bool
+
+	// GID is the global tile ID if the object is a tile object. Otherwise, GID is 0.
+	GID uint32
+
+	// Ellipse indicates whether the object is an ellipse.
+	Ellipse bool
+
+	// Point indicates whether the object is a point.
+	Point bool
+
+	// Polygon is the points of the polygon relative to the object's position if the object is a polygon.
+	Polygon []Point
+
+	// Polyline is the points of the polyline relative to the object's position if the object is a polyline.
+	Polyline []Point
+
+	// Properties are the custom properties of the object.
+	Properties Properties
+}
+
+// Point is a point in pixels.
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Property is a custom property.
+type Property struct {
+	Name string
+
+	// Type is the type of the property like "string", "int", "float", "bool", "color" or "file".
+	Type string
+
+	// Value is the value in the string representation.
+	Value string
+}
+
+// Properties is a list of custom properties.
+type Properties []Property
+
+// Get returns the value of the property with the given name.
+// If there is no such property, Get returns false as the second value.
+func (p Properties) Get(name string) (string, bool) {
+	for _, prop := range p {
+		if prop.Name == name {
+			return prop.Value, true
+		}
+	}
+	return "", false
+}
+
+// TilesetForGID returns the tileset that the global tile ID belongs to.
+// The flip flags in gid are ignored.
+// If there is no such tileset, TilesetForGID returns nil.
+func (m *Map) TilesetForGID(gid uint32) *Tileset {
+	gid &^= flagMask
+	if gid == 0 {
+		return nil
+	}
+	var ts *Tileset
+	for _, t := range m.Tilesets {
+		if t.FirstGID <= gid && (ts == nil || ts.FirstGID < t.FirstGID) {
+			ts = t
+		}
+	}
+	return ts
+}
+
+const (
+	flagFlipHorizontal = 1 << 31
+	flagFlipVertical   = 1 << 30
+	flagFlipDiagonal   = 1 << 29
+	flagRotateHex120   = 1 << 28
+	flagMask           = flagFlipHorizontal | flagFlipVertical | flagFlipDiagonal | flagRotateHex120
+)
+
+// Load loads a map from the file of the given name in the file system.
+//
+// The format is decided by the extension: ".tmx" for XML, and ".tmj" or ".json" for JSON.
+// External tilesets and images are resolved relative to the map file.
+func Load(fsys fs.FS, name string) (*Map, error) {
+	bs, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var m *Map
+	switch ext := strings.ToLower(path.Ext(name)); ext {
+	case ".tmx":
+		m, err = parseTMX(bs, path.Dir(name))
+	case ".tmj", ".json":
+		m, err = parseTMJ(bs, path.Dir(name))
+	default:
+		return nil, fmt.Errorf("tiled: unsupported map format: %s", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tiled: parsing %s failed: %w", name, err)
+	}
+
+	for i, ts := range m.Tilesets {
+		if ts.Source == "" {
+			continue
+		}
+		ext, err := LoadTileset(fsys, ts.Source)
+		if err != nil {
+			return nil, err
+		}
+		ext.FirstGID = ts.FirstGID
+		m.Tilesets[i] = ext
+	}
+	return m, nil
+}
+
+// LoadTileset loads an external tileset from the file of the given name in the file system.
+//
+// The format is decided by the extension: ".tsx" for XML, and ".tsj" or ".json" for JSON.
+// The returned tileset's FirstGID is 0, as FirstGID is decided by a map.
+func LoadTileset(fsys fs.FS, name string) (*Tileset, error) {
+	bs, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var ts *Tileset
+	switch ext := strings.ToLower(path.Ext(name)); ext {
+	case ".tsx":
+		ts, err = parseTSX(bs, path.Dir(name))
+	case ".tsj", ".json":
+		ts, err = parseTSJ(bs, path.Dir(name))
+	default:
+		return nil, fmt.Errorf("tiled: unsupported tileset format: %s", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tiled: parsing %s failed: %w", name, err)
+	}
+	ts.Source = name
+	return ts, nil
+}
+
+// resolvePath resolves a path in a Tiled file relative to the directory of the file.
+func resolvePath(dir, p string) string {
+	if p == "" {
+		return ""
+	}
+	return path.Join(dir, p)
+}
@@ -0,0 +1,200 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiled_test
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/tilemap/tiled"
+)
+
+const testTMX = `<?xml version="1.0" encoding="UTF-8"?>
+<map version="1.10" orientation="orthogonal" renderorder="right-down" width="3" height="2" tilewidth="16" tileheight="16" infinite="0">
+ <properties>
+  <property name="title" value="test"/>
+  <property name="level" type="int" value="3"/>
+ </properties>
+ <tileset firstgid="1" source="tiles/tileset.tsx"/>
+ <tileset firstgid="101" name="embedded" tilewidth="16" tileheight="16" tilecount="4" columns="2">
+  <image source="embedded.png" width="32" height="32"/>
+ </tileset>
+ <layer id="1" name="csv" width="3" height="2">
+  <data encoding="csv">
+1,2,3,
+0,101,2147483649
+</data>
+ </layer>
+ <group id="2" name="group" offsetx="4" visible="0">
+  <layer id="3" name="base64" width="3" height="2" offsetx="1">
+   <data encoding="base64" compression="zlib">eJxjZGBgYAJiZgYISAViRgaGBgAENADt</data>
+  </layer>
+ </group>
+ <objectgroup id="4" name="objects">
+  <object id="1" name="spawn" type="player" x="8" y="24">
+   <point/>
+  </object>
+  <object id="2" x="0" y="0">
+   <polygon points="0,0 16,0 16,16"/>
+  </object>
+ </objectgroup>
+</map>
+`
+
+const testTSX = `<?xml version="1.0" encoding="UTF-8"?>
+<tileset version="1.10" name="external" tilewidth="16" tileheight="16" tilecount="100" columns="10">
+ <image source="../images/tiles.png" width="160" height="160"/>
+ <tile id="2" type="wall">
+  <properties>
+   <property name="solid" type="bool" value="true"/>
+  </properties>
+ </tile>
+</tileset>
+`
+
+const testTMJ = `{
+ "orientation": "orthogonal",
+ "width": 3,
+ "height": 2,
+ "tilewidth": 16,
+ "tileheight": 16,
+ "infinite": false,
+ "properties": [
+  {"name": "title", "type": "string", "value": "test"},
+  {"name": "level", "type": "int", "value": 3}
+ ],
+ "tilesets": [
+  {"firstgid": 1, "source": "tiles/tileset.tsj"},
+  {"firstgid": 101, "name": "embedded", "tilewidth": 16, "tileheight": 16, "tilecount": 4, "columns": 2, "image": "embedded.png", "imagewidth": 32, "imageheight": 32}
+ ],
+ "layers": [
+  {"type": "tilelayer", "id": 1, "name": "csv", "width": 3, "height": 2, "visible": true, "opacity": 1, "data": [1, 2, 3, 0, 101, 2147483649]},
+  {"type": "group", "id": 2, "name": "group", "offsetx": 4, "visible": false, "layers": [
+   {"type": "tilelayer", "id": 3, "name": "base64", "width": 3, "height": 2, "offsetx": 1, "encoding": "base64", "compression": "zlib", "data": "eJxjZGBgYAJiZgYISAViRgaGBgAENADt"}
+  ]},
+  {"type": "objectgroup", "id": 4, "name": "objects", "objects": [
+   {"id": 1, "name": "spawn", "type": "player", "x": 8, "y": 24, "point": true},
+   {"id": 2, "x": 0, "y": 0, "polygon": [{"x": 0, "y": 0}, {"x": 16, "y": 0}, {"x": 16, "y": 16}]}
+  ]}
+ ]
+}
+`
+
+const testTSJ = `{
+ "name": "external",
+ "tilewidth": 16,
+ "tileheight": 16,
+ "tilecount": 100,
+ "columns": 10,
+ "image": "../images/tiles.png",
+ "imagewidth": 160,
+ "imageheight": 160,
+ "tiles": [
+  {"id": 2, "type": "wall", "properties": [{"name": "solid", "type": "bool", "value": true}]}
+ ]
+}
+`
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"maps/map.tmx":           {Data: []byte(testTMX)},
+		"maps/tiles/tileset.tsx": {Data: []byte(testTSX)},
+		"maps/map.tmj":           {Data: []byte(testTMJ)},
+		"maps/tiles/tileset.tsj": {Data: []byte(testTSJ)},
+	}
+
+	for _, name := range []string{"maps/map.tmx", "maps/map.tmj"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			m, err := tiled.Load(fsys, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := [4]int{m.Width, m.Height, m.TileWidth, m.TileHeight}, [4]int{3, 2, 16, 16}; got != want {
+				t.Errorf("size: got: %v, want: %v", got, want)
+			}
+			if got, want := m.Properties, (tiled.Properties{{Name: "title", Type: "string", Value: "test"}, {Name: "level", Type: "int", Value: "3"}}); !reflect.DeepEqual(got, want) {
+				t.Errorf("properties: got: %v, want: %v", got, want)
+			}
+
+			if got, want := len(m.Tilesets), 2; got != want {
+				t.Fatalf("len(m.Tilesets): got: %d, want: %d", got, want)
+			}
+			ext := m.Tilesets[0]
+			if got, want := ext.FirstGID, uint32(1); got != want {
+				t.Errorf("FirstGID: got: %d, want: %d", got, want)
+			}
+			if got, want := ext.Name, "external"; got != want {
+				t.Errorf("Name: got: %s, want: %s", got, want)
+			}
+			if got, want := ext.Image.Source, "maps/images/tiles.png"; got != want {
+				t.Errorf("Image.Source: got: %s, want: %s", got, want)
+			}
+			if got, want := len(ext.Tiles), 1; got != want {
+				t.Fatalf("len(Tiles): got: %d, want: %d", got, want)
+			}
+			if v, ok := ext.Tiles[0].Properties.Get("solid"); !ok || v != "true" {
+				t.Errorf("solid: got: %s, %t, want: true, true", v, ok)
+			}
+			if got, want := m.Tilesets[1].Image.Source, "maps/embedded.png"; got != want {
+				t.Errorf("Image.Source: got: %s, want: %s", got, want)
+			}
+			if got, want := m.TilesetForGID(102), m.Tilesets[1]; got != want {
+				t.Errorf("TilesetForGID(102): got: %v, want: %v", got, want)
+			}
+
+			// The layers are in the document order, and the group is flattened.
+			if got, want := len(m.Layers), 3; got != want {
+				t.Fatalf("len(m.Layers): got: %d, want: %d", got, want)
+			}
+			for i, want := range []struct {
+				Name string
+				Type tiled.LayerType
+			}{
+				{Name: "csv", Type: tiled.LayerTypeTile},
+				{Name: "base64", Type: tiled.LayerTypeTile},
+				{Name: "objects", Type: tiled.LayerTypeObject},
+			} {
+				if l := m.Layers[i]; l.Name != want.Name || l.Type != want.Type {
+					t.Errorf("m.Layers[%d]: got: (%s, %d), want: (%s, %d)", i, l.Name, l.Type, want.Name, want.Type)
+				}
+			}
+			for _, l := range m.Layers[:2] {
+				if got, want := l.Data, []uint32{1, 2, 3, 0, 101, 0x80000001}; !reflect.DeepEqual(got, want) {
+					t.Errorf("%s data: got: %v, want: %v", l.Name, got, want)
+				}
+			}
+			if !m.Layers[0].Visible {
+				t.Errorf("Visible: got: false, want: true")
+			}
+			if l := m.Layers[1]; l.Visible || l.OffsetX != 5 {
+				t.Errorf("grouped layer: got: (%t, %f), want: (false, 5)", l.Visible, l.OffsetX)
+			}
+
+			objs := m.Layers[2].Objects
+			if got, want := len(objs), 2; got != want {
+				t.Fatalf("len(objs): got: %d, want: %d", got, want)
+			}
+			if o := objs[0]; o.Name != "spawn" || o.Type != "player" || !o.Point || o.X != 8 || o.Y != 24 {
+				t.Errorf("objs[0]: got: %v", o)
+			}
+			if got, want := objs[1].Polygon, []tiled.Point{{0, 0}, {16, 0}, {16, 16}}; !reflect.DeepEqual(got, want) {
+				t.Errorf("polygon: got: %v, want: %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiled
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/exp/tilemap"
+)
+
+// TilemapLayer converts the layer into a tilemap.Layer for the given tileset.
+//
+// The tiles that don't belong to the tileset become tilemap.EmptyTile.
+// The flip flags are kept.
+// Use tilemap.NewTileset with the tileset's image, TileWidth and TileHeight to render the layer.
+// A tileset with spacing or margin is not supported by the tilemap package.
+//
+// TilemapLayer panics if l is not a tile layer.
+func (l *Layer) TilemapLayer(tileset *Tileset) *tilemap.Layer {
+	if l.Type != LayerTypeTile {
+		panic("tiled: TilemapLayer must be called for a tile layer")
+	}
+	layer := tilemap.NewLayer(l.Width, l.Height)
+	layer.Hidden = !l.Visible
+	for i, gid := range l.Data {
+		id := gid &^ flagMask
+		if id == 0 || id < tileset.FirstGID || int(id-tileset.FirstGID) >= tileset.TileCount {
+			continue
+		}
+		flags := tilemap.Tile(gid & (flagFlipHorizontal | flagFlipVertical | flagFlipDiagonal))
+		layer.SetTile(i%l.Width, i/l.Width, tilemap.NewTile(int(id-tileset.FirstGID), flags))
+	}
+	return layer
+}
@@ -0,0 +1,313 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiled
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+type jsonProperty struct {
+	Name  string          `json:"name"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+func jsonPropertiesToProperties(props []jsonProperty) (Properties, error) {
+	var ps Properties
+	for _, p := range props {
+		t := p.Type
+		if t == "" {
+			t = "string"
+		}
+		// A string value is unquoted. The other values like numbers and booleans are used as they are.
+		v := strings.TrimSpace(string(p.Value))
+		if strings.HasPrefix(v, `"`) {
+			if err := json.Unmarshal(p.Value, &v); err != nil {
+				return nil, err
+			}
+		}
+		ps = append(ps, Property{
+			Name:  p.Name,
+			Type:  t,
+			Value: v,
+		})
+	}
+	return ps, nil
+}
+
+type jsonTile struct {
+	ID         int            `json:"id"`
+	Type       string         `json:"type"`
+	Class      string         `json:"class"`
+	Properties []jsonProperty `json:"properties"`
+}
+
+type jsonTileset struct {
+	FirstGID    uint32         `json:"firstgid"`
+	Source      string         `json:"source"`
+	Name        string         `json:"name"`
+	TileWidth   int            `json:"tilewidth"`
+	TileHeight  int            `json:"tileheight"`
+	Spacing     int            `json:"spacing"`
+	Margin      int            `json:"margin"`
+	TileCount   int            `json:"tilecount"`
+	Columns     int            `json:"columns"`
+	Image       string         `json:"image"`
+	ImageWidth  int            `json:"imagewidth"`
+	ImageHeight int            `json:"imageheight"`
+	Tiles       []jsonTile     `json:"tiles"`
+	Properties  []jsonProperty `json:"properties"`
+}
+
+func (t *jsonTileset) tileset(dir string) (*Tileset, error) {
+	props, err := jsonPropertiesToProperties(t.Properties)
+	if err != nil {
+		return nil, err
+	}
+	ts := &Tileset{
+		FirstGID:   t.FirstGID,
+		Source:     resolvePath(dir, t.Source),
+		Name:       t.Name,
+		TileWidth:  t.TileWidth,
+		TileHeight: t.TileHeight,
+		Spacing:    t.Spacing,
+		Margin:     t.Margin,
+		TileCount:  t.TileCount,
+		Columns:    t.Columns,
+		Image: Image{
+			Source: resolvePath(dir, t.Image),
+			Width:  t.ImageWidth,
+			Height: t.ImageHeight,
+		},
+		Properties: props,
+	}
+	for _, tile := range t.Tiles {
+		props, err := jsonPropertiesToProperties(tile.Properties)
+		if err != nil {
+			return nil, err
+		}
+		typ := tile.Class
+		if typ == "" {
+			typ = tile.Type
+		}
+		ts.Tiles = append(ts.Tiles, &Tile{
+			ID:         tile.ID,
+			Type:       typ,
+			Properties: props,
+		})
+	}
+	return ts, nil
+}
+
+type jsonObject struct {
+	ID         int            `json:"id"`
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Class      string         `json:"class"`
+	X          float64        `json:"x"`
+	Y          float64        `json:"y"`
+	Width      float64        `json:"width"`
+	Height     float64        `json:"height"`
+	Rotation   float64        `json:"rotation"`
+	GID        uint32         `json:"gid"`
+	Visible    *bool          `json:"visible"`
+	Ellipse    bool           `json:"ellipse"`
+	Point      bool           `json:"point"`
+	Polygon    []Point        `json:"polygon"`
+	Polyline   []Point        `json:"polyline"`
+	Properties []jsonProperty `json:"properties"`
+}
+
+type jsonLayer struct {
+	Type        string          `json:"type"`
+	ID          int             `json:"id"`
+	Name        string          `json:"name"`
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	Visible     *bool           `json:"visible"`
+	Opacity     *float64        `json:"opacity"`
+	OffsetX     float64         `json:"offsetx"`
+	OffsetY     float64         `json:"offsety"`
+	Data        json.RawMessage `json:"data"`
+	Encoding    string          `json:"encoding"`
+	Compression string          `json:"compression"`
+	Chunks      json.RawMessage `json:"chunks"`
+	Objects     []jsonObject    `json:"objects"`
+	Layers      []jsonLayer     `json:"layers"`
+	Properties  []jsonProperty  `json:"properties"`
+}
+
+func (l *jsonLayer) apply(group groupState) groupState {
+	opacity := 1.0
+	if l.Opacity != nil {
+		opacity = *l.Opacity
+	}
+	return groupState{
+		inGroup: group.inGroup,
+		hidden:  group.hidden || (l.Visible != nil && !*l.Visible),
+		opacity: group.opacity * opacity,
+		offsetX: group.offsetX + l.OffsetX,
+		offsetY: group.offsetY + l.OffsetY,
+	}
+}
+
+type jsonMap struct {
+	Orientation string         `json:"orientation"`
+	Width       int            `json:"width"`
+	Height      int            `json:"height"`
+	TileWidth   int            `json:"tilewidth"`
+	TileHeight  int            `json:"tileheight"`
+	Infinite    bool           `json:"infinite"`
+	Tilesets    []jsonTileset  `json:"tilesets"`
+	Layers      []jsonLayer    `json:"layers"`
+	Properties  []jsonProperty `json:"properties"`
+}
+
+func appendJSONLayers(m *Map, layers []jsonLayer, group groupState) error {
+	for _, l := range layers {
+		g := l.apply(group)
+		props, err := jsonPropertiesToProperties(l.Properties)
+		if err != nil {
+			return err
+		}
+
+		switch l.Type {
+		case "tilelayer":
+			if len(l.Chunks) > 0 {
+				return errors.New("infinite maps are not supported")
+			}
+			var data []uint32
+			if l.Encoding == "" || l.Encoding == "csv" {
+				if err := json.Unmarshal(l.Data, &data); err != nil {
+					return err
+				}
+			} else {
+				var str string
+				if err := json.Unmarshal(l.Data, &str); err != nil {
+					return err
+				}
+				data, err = decodeLayerData(str, l.Encoding, l.Compression)
+				if err != nil {
+					return err
+				}
+			}
+			if len(data) != l.Width*l.Height {
+				return errors.New("the size of the layer data doesn't match with the layer size")
+			}
+			m.Layers = append(m.Layers, &Layer{
+				ID:         l.ID,
+				Name:       l.Name,
+				Type:       LayerTypeTile,
+				Width:      l.Width,
+				Height:     l.Height,
+				Visible:    !g.hidden,
+				Opacity:    g.opacity,
+				OffsetX:    g.offsetX,
+				OffsetY:    g.offsetY,
+				Data:       data,
+				Properties: props,
+			})
+		case "objectgroup":
+			layer := &Layer{
+				ID:         l.ID,
+				Name:       l.Name,
+				Type:       LayerTypeObject,
+				Visible:    !g.hidden,
+				Opacity:    g.opacity,
+				OffsetX:    g.offsetX,
+				OffsetY:    g.offsetY,
+				Properties: props,
+			}
+			for _, o := range l.Objects {
+				props, err := jsonPropertiesToProperties(o.Properties)
+				if err != nil {
+					return err
+				}
+				typ := o.Class
+				if typ == "" {
+					typ = o.Type
+				}
+				layer.Objects = append(layer.Objects, &Object{
+					ID:         o.ID,
+					Name:       o.Name,
+					Type:       typ,
+					X:          o.X,
+					Y:          o.Y,
+					Width:      o.Width,
+					Height:     o.Height,
+					Rotation:   o.Rotation,
+					Visible:    o.Visible == nil || *o.Visible,
+					GID:        o.GID,
+					Ellipse:    o.Ellipse,
+					Point:      o.Point,
+					Polygon:    o.Polygon,
+					Polyline:   o.Polyline,
+					Properties: props,
+				})
+			}
+			m.Layers = append(m.Layers, layer)
+		case "group":
+			g.inGroup = true
+			if err := appendJSONLayers(m, l.Layers, g); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func parseTMJ(bs []byte, dir string) (*Map, error) {
+	var jm jsonMap
+	if err := json.Unmarshal(bs, &jm); err != nil {
+		return nil, err
+	}
+	if jm.Infinite {
+		return nil, errors.New("infinite maps are not supported")
+	}
+
+	props, err := jsonPropertiesToProperties(jm.Properties)
+	if err != nil {
+		return nil, err
+	}
+	m := &Map{
+		Orientation: jm.Orientation,
+		Width:       jm.Width,
+		Height:      jm.Height,
+		TileWidth:   jm.TileWidth,
+		TileHeight:  jm.TileHeight,
+		Properties:  props,
+	}
+	for _, t := range jm.Tilesets {
+		ts, err := t.tileset(dir)
+		if err != nil {
+			return nil, err
+		}
+		m.Tilesets = append(m.Tilesets, ts)
+	}
+	if err := appendJSONLayers(m, jm.Layers, groupState{opacity: 1}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func parseTSJ(bs []byte, dir string) (*Tileset, error) {
+	var t jsonTileset
+	if err := json.Unmarshal(bs, &t); err != nil {
+		return nil, err
+	}
+	return t.tileset(dir)
+}
@@ -0,0 +1,470 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiled
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type xmlProperties struct {
+	Properties []xmlProperty `xml:"property"`
+}
+
+type xmlProperty struct {
+	Name  string  `xml:"name,attr"`
+	Type  string  `xml:"type,attr"`
+	Value *string `xml:"value,attr"`
+	Text  string  `xml:",chardata"`
+}
+
+func (p *xmlProperties) properties() Properties {
+	var props Properties
+	for _, prop := range p.Properties {
+		t := prop.Type
+		if t == "" {
+			t = "string"
+		}
+		// A multi-line string is stored as the text instead of the value attribute.
+		v := prop.Text
+		if prop.Value != nil {
+			v = *prop.Value
+		}
+		props = append(props, Property{
+			Name:  prop.Name,
+			Type:  t,
+			Value: v,
+		})
+	}
+	return props
+}
+
+type xmlImage struct {
+	Source string `xml:"source,attr"`
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+}
+
+type xmlTile struct {
+	ID         int           `xml:"id,attr"`
+	Type       string        `xml:"type,attr"`
+	Class      string        `xml:"class,attr"`
+	Properties xmlProperties `xml:"properties"`
+}
+
+type xmlTileset struct {
+	FirstGID   uint32        `xml:"firstgid,attr"`
+	Source     string        `xml:"source,attr"`
+	Name       string        `xml:"name,attr"`
+	TileWidth  int           `xml:"tilewidth,attr"`
+	TileHeight int           `xml:"tileheight,attr"`
+	Spacing    int           `xml:"spacing,attr"`
+	Margin     int           `xml:"margin,attr"`
+	TileCount  int           `xml:"tilecount,attr"`
+	Columns    int           `xml:"columns,attr"`
+	Image      xmlImage      `xml:"image"`
+	Tiles      []xmlTile     `xml:"tile"`
+	Properties xmlProperties `xml:"properties"`
+}
+
+func (t *xmlTileset) tileset(dir string) *Tileset {
+	ts := &Tileset{
+		FirstGID:   t.FirstGID,
+		Source:     resolvePath(dir, t.Source),
+		Name:       t.Name,
+		TileWidth:  t.TileWidth,
+		TileHeight: t.TileHeight,
+		Spacing:    t.Spacing,
+		Margin:     t.Margin,
+		TileCount:  t.TileCount,
+		Columns:    t.Columns,
+		Image: Image{
+			Source: resolvePath(dir, t.Image.Source),
+			Width:  t.Image.Width,
+			Height: t.Image.Height,
+		},
+		Properties: t.Properties.properties(),
+	}
+	for _, tile := range t.Tiles {
+		typ := tile.Class
+		if typ == "" {
+			typ = tile.Type
+		}
+		ts.Tiles = append(ts.Tiles, &Tile{
+			ID:         tile.ID,
+			Type:       typ,
+			Properties: tile.Properties.properties(),
+		})
+	}
+	return ts
+}
+
+// xmlLayerAttrs is the common attributes of layers.
+type xmlLayerAttrs struct {
+	ID      int     `xml:"id,attr"`
+	Name    string  `xml:"name,attr"`
+	Visible string  `xml:"visible,attr"`
+	Opacity string  `xml:"opacity,attr"`
+	OffsetX float64 `xml:"offsetx,attr"`
+	OffsetY float64 `xml:"offsety,attr"`
+}
+
+func (l *xmlLayerAttrs) visible() bool {
+	return l.Visible != "0"
+}
+
+func (l *xmlLayerAttrs) opacity() (float64, error) {
+	if l.Opacity == "" {
+		return 1, nil
+	}
+	return strconv.ParseFloat(l.Opacity, 64)
+}
+
+type xmlLayer struct {
+	xmlLayerAttrs
+	Width      int           `xml:"width,attr"`
+	Height     int           `xml:"height,attr"`
+	Properties xmlProperties `xml:"properties"`
+	Data       struct {
+		Encoding    string `xml:"encoding,attr"`
+		Compression string `xml:"compression,attr"`
+		Text        string `xml:",chardata"`
+		Tiles       []struct {
+			GID uint32 `xml:"gid,attr"`
+		} `xml:"tile"`
+		Chunks []struct{} `xml:"chunk"`
+	} `xml:"data"`
+}
+
+type xmlPoints struct {
+	Points string `xml:"points,attr"`
+}
+
+func (p *xmlPoints) points() ([]Point, error) {
+	if p == nil {
+		return nil, nil
+	}
+	var pts []Point
+	for _, s := range strings.Fields(p.Points) {
+		xs, ys, ok := strings.Cut(s, ",")
+		if !ok {
+			return nil, errors.New("invalid points: " + p.Points)
+		}
+		x, err := strconv.ParseFloat(xs, 64)
+		if err != nil {
+			return nil, err
+		}
+		y, err := strconv.ParseFloat(ys, 64)
+		if err != nil {
+			return nil, err
+		}
+		pts = append(pts, Point{X: x, Y: y})
+	}
+	return pts, nil
+}
+
+type xmlObject struct {
+	ID         int           `xml:"id,attr"`
+	Name       string        `xml:"name,attr"`
+	Type       string        `xml:"type,attr"`
+	Class      string        `xml:"class,attr"`
+	X          float64       `xml:"x,attr"`
+	Y          float64       `xml:"y,attr"`
+	Width      float64       `xml:"width,attr"`
+	Height     float64       `xml:"height,attr"`
+	Rotation   float64       `xml:"rotation,attr"`
+	GID        uint32        `xml:"gid,attr"`
+	Visible    string        `xml:"visible,attr"`
+	Ellipse    *struct{}     `xml:"ellipse"`
+	Point      *struct{}     `xml:"point"`
+	Polygon    *xmlPoints    `xml:"polygon"`
+	Polyline   *xmlPoints    `xml:"polyline"`
+	Properties xmlProperties `xml:"properties"`
+}
+
+type xmlObjectGroup struct {
+	xmlLayerAttrs
+	Objects    []xmlObject   `xml:"object"`
+	Properties xmlProperties `xml:"properties"`
+}
+
+// groupState is the accumulated state of the group layers.
+type groupState struct {
+	inGroup bool
+	hidden  bool
+	opacity float64
+	offsetX float64
+	offsetY float64
+}
+
+// tmxDecoder decodes the layers in the TMX's document order, flattening group layers.
+type tmxDecoder struct {
+	m   *Map
+	dir string
+}
+
+func (t *tmxDecoder) decodeChildren(d *xml.Decoder, group groupState) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if err := t.decodeElement(d, tok, group); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+func (t *tmxDecoder) decodeElement(d *xml.Decoder, se xml.StartElement, group groupState) error {
+	switch se.Name.Local {
+	case "properties":
+		var props xmlProperties
+		if err := d.DecodeElement(&props, &se); err != nil {
+			return err
+		}
+		// The properties of group layers are ignored.
+		if !group.inGroup {
+			t.m.Properties = props.properties()
+		}
+	case "tileset":
+		var ts xmlTileset
+		if err := d.DecodeElement(&ts, &se); err != nil {
+			return err
+		}
+		t.m.Tilesets = append(t.m.Tilesets, ts.tileset(t.dir))
+	case "layer":
+		var l xmlLayer
+		if err := d.DecodeElement(&l, &se); err != nil {
+			return err
+		}
+		layer, err := t.layer(&l, group)
+		if err != nil {
+			return err
+		}
+		t.m.Layers = append(t.m.Layers, layer)
+	case "objectgroup":
+		var og xmlObjectGroup
+		if err := d.DecodeElement(&og, &se); err != nil {
+			return err
+		}
+		layer, err := t.objectLayer(&og, group)
+		if err != nil {
+			return err
+		}
+		t.m.Layers = append(t.m.Layers, layer)
+	case "group":
+		var attrs xmlLayerAttrs
+		for _, a := range se.Attr {
+			switch a.Name.Local {
+			case "visible":
+				attrs.Visible = a.Value
+			case "opacity":
+				attrs.Opacity = a.Value
+			case "offsetx", "offsety":
+				v, err := strconv.ParseFloat(a.Value, 64)
+				if err != nil {
+					return err
+				}
+				if a.Name.Local == "offsetx" {
+					attrs.OffsetX = v
+				} else {
+					attrs.OffsetY = v
+				}
+			}
+		}
+		g, err := group.apply(&attrs)
+		if err != nil {
+			return err
+		}
+		g.inGroup = true
+		return t.decodeChildren(d, g)
+	default:
+		return d.Skip()
+	}
+	return nil
+}
+
+func (g groupState) apply(attrs *xmlLayerAttrs) (groupState, error) {
+	o, err := attrs.opacity()
+	if err != nil {
+		return groupState{}, err
+	}
+	return groupState{
+		inGroup: g.inGroup,
+		hidden:  g.hidden || !attrs.visible(),
+		opacity: g.opacity * o,
+		offsetX: g.offsetX + attrs.OffsetX,
+		offsetY: g.offsetY + attrs.OffsetY,
+	}, nil
+}
+
+func (t *tmxDecoder) layer(l *xmlLayer, group groupState) (*Layer, error) {
+	if len(l.Data.Chunks) > 0 {
+		return nil, errors.New("infinite maps are not supported")
+	}
+
+	g, err := group.apply(&l.xmlLayerAttrs)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []uint32
+	if l.Data.Encoding == "" {
+		for _, tile := range l.Data.Tiles {
+			data = append(data, tile.GID)
+		}
+	} else {
+		data, err = decodeLayerData(l.Data.Text, l.Data.Encoding, l.Data.Compression)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(data) != l.Width*l.Height {
+		return nil, errors.New("the size of the layer data doesn't match with the layer size")
+	}
+
+	return &Layer{
+		ID:         l.ID,
+		Name:       l.Name,
+		Type:       LayerTypeTile,
+		Width:      l.Width,
+		Height:     l.Height,
+		Visible:    !g.hidden,
+		Opacity:    g.opacity,
+		OffsetX:    g.offsetX,
+		OffsetY:    g.offsetY,
+		Data:       data,
+		Properties: l.Properties.properties(),
+	}, nil
+}
+
+func (t *tmxDecoder) objectLayer(og *xmlObjectGroup, group groupState) (*Layer, error) {
+	g, err := group.apply(&og.xmlLayerAttrs)
+	if err != nil {
+		return nil, err
+	}
+
+	layer := &Layer{
+		ID:         og.ID,
+		Name:       og.Name,
+		Type:       LayerTypeObject,
+		Visible:    !g.hidden,
+		Opacity:    g.opacity,
+		OffsetX:    g.offsetX,
+		OffsetY:    g.offsetY,
+		Properties: og.Properties.properties(),
+	}
+	for _, o := range og.Objects {
+		polygon, err := o.Polygon.points()
+		if err != nil {
+			return nil, err
+		}
+		polyline, err := o.Polyline.points()
+		if err != nil {
+			return nil, err
+		}
+		typ := o.Class
+		if typ == "" {
+			typ = o.Type
+		}
+		layer.Objects = append(layer.Objects, &Object{
+			ID:         o.ID,
+			Name:       o.Name,
+			Type:       typ,
+			X:          o.X,
+			Y:          o.Y,
+			Width:      o.Width,
+			Height:     o.Height,
+			Rotation:   o.Rotation,
+			Visible:    o.Visible != "0",
+			GID:        o.GID,
+			Ellipse:    o.Ellipse != nil,
+			Point:      o.Point != nil,
+			Polygon:    polygon,
+			Polyline:   polyline,
+			Properties: o.Properties.properties(),
+		})
+	}
+	return layer, nil
+}
+
+func parseTMX(bs []byte, dir string) (*Map, error) {
+	d := xml.NewDecoder(bytes.NewReader(bs))
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if se.Name.Local != "map" {
+			return nil, errors.New("the root element must be map but " + se.Name.Local)
+		}
+
+		m := &Map{}
+		for _, a := range se.Attr {
+			switch a.Name.Local {
+			case "orientation":
+				m.Orientation = a.Value
+			case "infinite":
+				if a.Value == "1" {
+					return nil, errors.New("infinite maps are not supported")
+				}
+			case "width", "height", "tilewidth", "tileheight":
+				v, err := strconv.Atoi(a.Value)
+				if err != nil {
+					return nil, err
+				}
+				switch a.Name.Local {
+				case "width":
+					m.Width = v
+				case "height":
+					m.Height = v
+				case "tilewidth":
+					m.TileWidth = v
+				case "tileheight":
+					m.TileHeight = v
+				}
+			}
+		}
+
+		t := &tmxDecoder{
+			m:   m,
+			dir: dir,
+		}
+		if err := t.decodeChildren(d, groupState{opacity: 1}); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+}
+
+func parseTSX(bs []byte, dir string) (*Tileset, error) {
+	var ts xmlTileset
+	if err := xml.Unmarshal(bs, &ts); err != nil {
+		return nil, err
+	}
+	return ts.tileset(dir), nil
+}
@@ -0,0 +1,332 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tilemap provides a tilemap renderer with viewport culling.
+// This package is experimental and the API might be changed in the future.
+//
+// A Map consists of a Tileset, which is an atlas image divided into tiles of the same size,
+// and layers of tile indices.
+// Map.Draw renders only the tiles visible in a camera rectangle, batched into as few DrawTriangles calls as possible.
+package tilemap
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Tile represents a tile in a Layer.
+//
+// The lower bits are the index of the tile in the Tileset, and the upper bits are the flip flags.
+// The flags are compatible with the Tiled map editor's.
+type Tile uint32
+
+const (
+	// TileFlipHorizontal flips the tile horizontally.
+	TileFlipHorizontal Tile = 1 << 31
+
+	// TileFlipVertical flips the tile vertically.
+	TileFlipVertical Tile = 1 << 30
+
+	// TileFlipDiagonal flips the tile diagonally, i.e. swaps the X and Y axes.
+	// The diagonal flip is applied before the horizontal and vertical flips.
+	TileFlipDiagonal Tile = 1 << 29
+
+	// TileRotate90 rotates the tile by 90 degrees clockwise.
+	TileRotate90 = TileFlipDiagonal | TileFlipHorizontal
+
+	// TileRotate180 rotates the tile by 180 degrees.
+	TileRotate180 = TileFlipHorizontal | TileFlipVertical
+
+	// TileRotate270 rotates the tile by 270 degrees clockwise.
+	TileRotate270 = TileFlipDiagonal | TileFlipVertical
+
+	tileFlagMask = TileFlipHorizontal | TileFlipVertical | TileFlipDiagonal
+
+	// EmptyTile represents no tile.
+	EmptyTile Tile = ^tileFlagMask
+)
+
+// NewTile creates a Tile from the index in a Tileset and the flip flags.
+//
+// If index is negative or too big, NewTile panics.
+func NewTile(index int, flags Tile) Tile {
+	if index < 0 || Tile(index) >= EmptyTile {
+		panic(fmt.Sprintf("tilemap: index is out of range: %d", index))
+	}
+	return Tile(index) | flags&tileFlagMask
+}
+
+// Index returns the index of the tile in a Tileset.
+// If the tile is EmptyTile, Index returns -1.
+func (t Tile) Index() int {
+	if t.IsEmpty() {
+		return -1
+	}
+	return int(t &^ tileFlagMask)
+}
+
+// Flags returns the flip flags of the tile.
+func (t Tile) Flags() Tile {
+	return t & tileFlagMask
+}
+
+// IsEmpty reports whether the tile is empty.
+func (t Tile) IsEmpty() bool {
+	return t&^tileFlagMask == EmptyTile
+}
+
+// Tileset is an atlas image divided into tiles of the same size.
+// Tiles are indexed from the upper-left to the lower-right, row by row.
+type Tileset struct {
+	image      *ebiten.Image
+	tileWidth  int
+	tileHeight int
+	columns    int
+	count      int
+}
+
+// NewTileset creates a new Tileset from the atlas image and the tile size.
+// The remaining pixels at the right and the bottom edges of the image are not used.
+//
+// If the tile size is not positive or bigger than the image, NewTileset panics.
+func NewTileset(image *ebiten.Image, tileWidth, tileHeight int) *Tileset {
+	if tileWidth <= 0 || tileHeight <= 0 {
+		panic(fmt.Sprintf("tilemap: the tile size must be positive but (%d, %d)", tileWidth, tileHeight))
+	}
+	s := image.Bounds().Size()
+	columns, rows := s.X/tileWidth, s.Y/tileHeight
+	if columns == 0 || rows == 0 {
+		panic(fmt.Sprintf("tilemap: the tile size (%d, %d) is bigger than the image size (%d, %d)", tileWidth, tileHeight, s.X, s.Y))
+	}
+	return &Tileset{
+		image:      image,
+		tileWidth:  tileWidth,
+		tileHeight: tileHeight,
+		columns:    columns,
+		count:      columns * rows,
+	}
+}
+
+// TileSize returns the size of a tile in pixels.
+func (t *Tileset) TileSize() (width, height int) {
+	return t.tileWidth, t.tileHeight
+}
+
+// TileCount returns the number of the tiles in the tileset.
+func (t *Tileset) TileCount() int {
+	return t.count
+}
+
+// tileBounds returns the region of the tile at the index in the image.
+func (t *Tileset) tileBounds(index int) image.Rectangle {
+	x := (index%t.columns)*t.tileWidth + t.image.Bounds().Min.X
+	y := (index/t.columns)*t.tileHeight + t.image.Bounds().Min.Y
+	return image.Rect(x, y, x+t.tileWidth, y+t.tileHeight)
+}
+
+// Layer is a 2D grid of tiles.
+type Layer struct {
+	width  int
+	height int
+	tiles  []Tile
+
+	// Hidden indicates whether the layer is skipped at rendering.
+	Hidden bool
+}
+
+// NewLayer creates a new layer with the given size in tiles.
+// All the tiles are EmptyTile initially.
+//
+// If width or height is negative, NewLayer panics.
+func NewLayer(width, height int) *Layer {
+	if width < 0 || height < 0 {
+		panic(fmt.Sprintf("tilemap: the layer size must be non-negative but (%d, %d)", width, height))
+	}
+	l := &Layer{
+		width:  width,
+		height: height,
+		tiles:  make([]Tile, width*height),
+	}
+	for i := range l.tiles {
+		l.tiles[i] = EmptyTile
+	}
+	return l
+}
+
+// Size returns the size of the layer in tiles.
+func (l *Layer) Size() (width, height int) {
+	return l.width, l.height
+}
+
+// Tile returns the tile at (x, y).
+// If (x, y) is out of range, Tile returns EmptyTile.
+func (l *Layer) Tile(x, y int) Tile {
+	if x < 0 || y < 0 || x >= l.width || y >= l.height {
+		return EmptyTile
+	}
+	return l.tiles[y*l.width+x]
+}
+
+// SetTile sets the tile at (x, y).
+//
+// If (x, y) is out of range, SetTile panics.
+func (l *Layer) SetTile(x, y int, tile Tile) {
+	if x < 0 || y < 0 || x >= l.width || y >= l.height {
+		panic(fmt.Sprintf("tilemap: (%d, %d) is out of range of the layer size (%d, %d)", x, y, l.width, l.height))
+	}
+	l.tiles[y*l.width+x] = tile
+}
+
+// Map is a set of layers rendered with a tileset.
+type Map struct {
+	// Tileset is the tileset used for all the layers.
+	Tileset *Tileset
+
+	// Layers is the layers to render. The first layer is rendered first, i.e. at the bottom.
+	Layers []*Layer
+
+	vertices []ebiten.Vertex
+	indices  []uint16
+}
+
+// DrawOptions represents options for Map.Draw.
+type DrawOptions struct {
+	// GeoM is a geometry matrix applied after the camera's translation.
+	// GeoM is useful to zoom the camera, or to put the viewport at a position of the destination image.
+	// The default (zero) value is identity.
+	GeoM ebiten.GeoM
+
+	// ColorScale is a scale of the tiles' colors.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ebiten.ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend ebiten.Blend
+
+	// Filter is a type of texture filter.
+	// The default (zero) value is ebiten.FilterNearest.
+	Filter ebiten.Filter
+}
+
+// Draw draws the tiles visible in the camera rectangle to dst.
+//
+// camera is a rectangle in the map's coordinate in pixels.
+// The upper-left corner of camera comes to the origin of dst before options.GeoM is applied.
+//
+// The tiles are culled by camera, and batched into DrawTriangles calls.
+// Usually, one DrawTriangles call is used for all the layers,
+// unless the number of the visible tiles exceeds ebiten.MaxVertexCount / 4.
+func (m *Map) Draw(dst *ebiten.Image, camera image.Rectangle, options *DrawOptions) {
+	if options == nil {
+		options = &DrawOptions{}
+	}
+
+	tw, th := m.Tileset.TileSize()
+	m.vertices = m.vertices[:0]
+	m.indices = m.indices[:0]
+
+	for _, l := range m.Layers {
+		if l.Hidden {
+			continue
+		}
+		r := visibleTileRange(camera, tw, th, l.width, l.height)
+		for j := r.Min.Y; j < r.Max.Y; j++ {
+			for i := r.Min.X; i < r.Max.X; i++ {
+				t := l.tiles[j*l.width+i]
+				if t.IsEmpty() {
+					continue
+				}
+				idx := t.Index()
+				if idx >= m.Tileset.count {
+					continue
+				}
+				if len(m.vertices)+4 > ebiten.MaxVertexCount {
+					m.flush(dst, options)
+				}
+				x := float32(i*tw - camera.Min.X)
+				y := float32(j*th - camera.Min.Y)
+				m.appendTile(x, y, float32(tw), float32(th), m.Tileset.tileBounds(idx), t.Flags(), options)
+			}
+		}
+	}
+	m.flush(dst, options)
+}
+
+func (m *Map) appendTile(x, y, w, h float32, src image.Rectangle, flags Tile, options *DrawOptions) {
+	sx0, sy0 := float32(src.Min.X), float32(src.Min.Y)
+	sx1, sy1 := float32(src.Max.X), float32(src.Max.Y)
+
+	cr, cg, cb, ca := options.ColorScale.R(), options.ColorScale.G(), options.ColorScale.B(), options.ColorScale.A()
+	base := uint16(len(m.vertices))
+	for _, c := range [4][2]float32{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+		// Find the source position for the destination corner by the inverse of the flips.
+		u, v := c[0], c[1]
+		if flags&TileFlipVertical != 0 {
+			v = 1 - v
+		}
+		if flags&TileFlipHorizontal != 0 {
+			u = 1 - u
+		}
+		if flags&TileFlipDiagonal != 0 {
+			u, v = v, u
+		}
+		dx, dy := options.GeoM.Apply(float64(x+c[0]*w), float64(y+c[1]*h))
+		m.vertices = append(m.vertices, ebiten.Vertex{
+			DstX:   float32(dx),
+			DstY:   float32(dy),
+			SrcX:   sx0 + (sx1-sx0)*u,
+			SrcY:   sy0 + (sy1-sy0)*v,
+			ColorR: cr,
+			ColorG: cg,
+			ColorB: cb,
+			ColorA: ca,
+		})
+	}
+	m.indices = ebiten.AppendRectIndices(m.indices, base)
+}
+
+func (m *Map) flush(dst *ebiten.Image, options *DrawOptions) {
+	if len(m.indices) == 0 {
+		return
+	}
+	op := &ebiten.DrawTrianglesOptions{}
+	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	op.Blend = options.Blend
+	op.Filter = options.Filter
+	dst.DrawTriangles(m.vertices, m.indices, m.Tileset.image, op)
+	m.vertices = m.vertices[:0]
+	m.indices = m.indices[:0]
+}
+
+// visibleTileRange returns the range of the tiles overlapping with the camera rectangle.
+func visibleTileRange(camera image.Rectangle, tileWidth, tileHeight int, layerWidth, layerHeight int) image.Rectangle {
+	r := image.Rect(
+		floorDiv(camera.Min.X, tileWidth),
+		floorDiv(camera.Min.Y, tileHeight),
+		floorDiv(camera.Max.X+tileWidth-1, tileWidth),
+		floorDiv(camera.Max.Y+tileHeight-1, tileHeight),
+	)
+	return r.Intersect(image.Rect(0, 0, layerWidth, layerHeight))
+}
+
+func floorDiv(x, y int) int {
+	q := x / y
+	if x%y != 0 && x < 0 {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilemap
+
+import (
+	"image"
+	"testing"
+)
+
+func TestTile(t *testing.T) {
+	tile := NewTile(42, TileRotate90)
+	if got, want := tile.Index(), 42; got != want {
+		t.Errorf("Index(): got: %d, want: %d", got, want)
+	}
+	if got, want := tile.Flags(), TileFlipDiagonal|TileFlipHorizontal; got != want {
+		t.Errorf("Flags(): got: %x, want: %x", got, want)
+	}
+	if tile.IsEmpty() {
+		t.Errorf("IsEmpty(): got: true, want: false")
+	}
+	if !(EmptyTile | TileFlipHorizontal).IsEmpty() {
+		t.Errorf("IsEmpty(): got: false, want: true")
+	}
+	if got, want := EmptyTile.Index(), -1; got != want {
+		t.Errorf("Index(): got: %d, want: %d", got, want)
+	}
+}
+
+func TestVisibleTileRange(t *testing.T) {
+	testCases := []struct {
+		camera image.Rectangle
+		want   image.Rectangle
+	}{
+		{
+			camera: image.Rect(0, 0, 32, 32),
+			want:   image.Rect(0, 0, 2, 2),
+		},
+		{
+			camera: image.Rect(1, 1, 33, 33),
+			want:   image.Rect(0, 0, 3, 3),
+		},
+		{
+			camera: image.Rect(-20, -20, 20, 20),
+			want:   image.Rect(0, 0, 2, 2),
+		},
+		{
+			camera: image.Rect(100, 100, 200, 200),
+			want:   image.Rect(6, 6, 10, 10),
+		},
+		{
+			camera: image.Rect(-100, -100, -50, -50),
+			want:   image.Rectangle{},
+		},
+	}
+	for _, tc := range testCases {
+		if got := visibleTileRange(tc.camera, 16, 16, 10, 10); got != tc.want {
+			t.Errorf("visibleTileRange(%v): got: %v, want: %v", tc.camera, got, tc.want)
+		}
+	}
+}
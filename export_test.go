@@ -14,6 +14,90 @@
 
 package ebiten
 
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
 var (
 	ImageToBytes = imageToBytes
 )
+
+func DrawDebugPrimitivesForTesting(screen *Image, geoM GeoM) {
+	theDebugDrawer.draw(screen, &geoM)
+}
+
+func RoundTripInputSnapshotForTesting(snapshot *InputSnapshot) *InputSnapshot {
+	var state inputState
+	snapshot.writeTo(&state)
+	var s InputSnapshot
+	s.readFrom(&state)
+	return &s
+}
+
+// GameForUIForTesting runs a game's Update, Draw and the end of a frame in the same order as the UI does.
+type GameForUIForTesting struct {
+	g *gameForUI
+}
+
+func NewGameForUIForTesting(game Game, options *RunGameOptions) (*GameForUIForTesting, error) {
+	if err := validateGameForUI(game, options); err != nil {
+		return nil, err
+	}
+	return &GameForUIForTesting{
+		g: newGameForUI(game, false, options.onPanic(), options.concurrentUpdate()),
+	}, nil
+}
+
+// RunFrame runs one frame with one Update.
+// The game's Draw is called with nil, as the game doesn't have an offscreen.
+func (g *GameForUIForTesting) RunFrame() error {
+	if err := g.g.Update(); err != nil {
+		return err
+	}
+	g.g.game.Draw(nil)
+	return g.g.EndFrame()
+}
+
+// UpdateInputState updates the input state as the UI does before each Update.
+// The input state from the devices is kept as it is, and then the injector set by SetInputInjector is applied.
+func (g *GameForUIForTesting) UpdateInputState() {
+	g.g.UpdateInputState(func(*ui.InputState) {})
+}
+
+// DrawOffscreen draws the game on a new offscreen of the given size, which is the screen size returned by Layout,
+// and returns the offscreen.
+func (g *GameForUIForTesting) DrawOffscreen(width, height int) (*Image, error) {
+	g.g.NewOffscreenImage(width, height)
+	if err := g.g.drawOffscreen(); err != nil {
+		return nil, err
+	}
+	return g.g.offscreen, nil
+}
+
+func (g *GameForUIForTesting) Update() error {
+	return g.g.Update()
+}
+
+func (g *GameForUIForTesting) Close() {
+	g.g.close()
+}
+
+// SuspendForTesting suspends the application as the UI does when e.g. the window loses focus.
+func SuspendForTesting() error {
+	return hook.SuspendAudio()
+}
+
+// ResumeForTesting resumes the application suspended by SuspendForTesting.
+func ResumeForTesting() error {
+	return hook.ResumeAudio()
+}
+
+// SetErrorScreenTimeoutForTesting sets the timeout to wait for the error screen, and returns the previous value.
+func SetErrorScreenTimeoutForTesting(timeout time.Duration) time.Duration {
+	prev := errorScreenTimeout
+	errorScreenTimeout = timeout
+	return prev
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/clock"
+)
+
+// FrameTime returns the time spent to process the last frame.
+//
+// The frame time covers the CPU time to run Update and Draw, and the time to submit the rendering commands
+// to the GPU, including the time waiting for the GPU when the driver blocks.
+// The frame time doesn't include the time waiting for the next frame like vsync.
+// Thus, unlike ActualFPS and ActualTPS, FrameTime indicates how heavy a frame is regardless of the frame rate.
+//
+// The GPU executes the commands asynchronously, so the frame time might not include the whole GPU time.
+//
+// This value is for measurement and adaptive quality like dynamic resolution.
+//
+// FrameTime is concurrent-safe.
+func FrameTime() time.Duration {
+	return clock.FrameTime()
+}
+
+// FrameBudget represents a budget of the frame time for SetFrameBudget.
+type FrameBudget struct {
+	// Budget is the maximum frame time that is considered acceptable.
+	//
+	// If Budget is 0, the duration of one tick (1/TPS seconds) is used.
+	// If TPS is SyncWithFPS, 1/60 seconds is used.
+	Budget time.Duration
+
+	// ConsecutiveFrames is the number of consecutive frames exceeding the budget to invoke OnExceeded.
+	//
+	// If ConsecutiveFrames is 0 or less, 1 is used.
+	ConsecutiveFrames int
+
+	// OnExceeded is called when the frame time exceeds the budget for ConsecutiveFrames frames in a row.
+	// The argument frameTime is the last frame time.
+	//
+	// OnExceeded is called on the same goroutine as Draw, just before Draw.
+	// After OnExceeded is called, the count of the consecutive frames is reset.
+	OnExceeded func(frameTime time.Duration)
+}
+
+// SetFrameBudget sets a budget of the frame time to detect sustained missed frames.
+//
+// If budget is nil, the frame budget is unset.
+// The given value is copied.
+//
+// SetFrameBudget is concurrent-safe.
+func SetFrameBudget(budget *FrameBudget) {
+	theFrameBudget.set(budget)
+}
+
+type frameBudget struct {
+	budget *FrameBudget
+	count  int
+
+	m sync.Mutex
+}
+
+var theFrameBudget frameBudget
+
+func (f *frameBudget) set(budget *FrameBudget) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	f.count = 0
+	if budget == nil {
+		f.budget = nil
+		return
+	}
+	b := *budget
+	f.budget = &b
+}
+
+// check checks the last frame time and calls OnExceeded if needed.
+func (f *frameBudget) check() {
+	callback, frameTime := f.exceeded()
+	if callback != nil {
+		callback(frameTime)
+	}
+}
+
+func (f *frameBudget) exceeded() (func(time.Duration), time.Duration) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if f.budget == nil || f.budget.OnExceeded == nil {
+		return nil, 0
+	}
+
+	budget := f.budget.Budget
+	if budget == 0 {
+		if tps := TPS(); tps > 0 {
+			budget = time.Second / time.Duration(tps)
+		} else {
+			budget = time.Second / 60
+		}
+	}
+
+	frameTime := FrameTime()
+	if frameTime <= budget {
+		f.count = 0
+		return nil, 0
+	}
+
+	f.count++
+	frames := f.budget.ConsecutiveFrames
+	if frames <= 0 {
+		frames = 1
+	}
+	if f.count < frames {
+		return nil, 0
+	}
+	f.count = 0
+	return f.budget.OnExceeded, frameTime
+}
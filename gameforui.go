@@ -15,13 +15,16 @@
 package ebiten
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"math"
+	"runtime/debug"
 	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
 	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
 
@@ -34,16 +37,115 @@ func init() {
 type gameForUI struct {
 	game         Game
 	offscreen    *Image
+	scaled       *Image
 	screen       *Image
 	screenShader *Shader
 	imageDumper  imageDumper
+	debugOverlay debugOverlay
 	transparent  bool
+	onPanic      func(recovered any, stack []byte) PanicAction
+	panicState   *panicState
+	loaded       bool
+
+	// removeHooks removes the hooks registered for the game.
+	removeHooks []func()
+
+	// The fields below are used only when Update runs concurrently with Draw.
+	stateSwapper     StateSwapper
+	updateWorker     *updateWorker
+	updatedSinceSwap bool
+	swapped          bool
+	updateErr        error
+}
+
+// updateResult is the result of the game's Update running on another goroutine.
+type updateResult struct {
+	err       error
+	recovered any
+	stack     []byte
+}
+
+// updateWorker is a goroutine to run the game's Update concurrently with Draw.
+// The same goroutine is reused for every tick.
+type updateWorker struct {
+	requestCh chan struct{}
+	resultCh  chan updateResult
+	running   bool
+}
+
+func newUpdateWorker(update func() error, recoverPanic bool) *updateWorker {
+	w := &updateWorker{
+		requestCh: make(chan struct{}),
+		resultCh:  make(chan updateResult, 1),
+	}
+	go w.loop(update, recoverPanic)
+	return w
+}
+
+func (w *updateWorker) loop(update func() error, recoverPanic bool) {
+	for range w.requestCh {
+		w.resultCh <- runUpdate(update, recoverPanic)
+	}
+}
+
+func runUpdate(update func() error, recoverPanic bool) (result updateResult) {
+	if recoverPanic {
+		defer func() {
+			if r := recover(); r != nil {
+				result.recovered = r
+				result.stack = debug.Stack()
+			}
+		}()
+	}
+	result.err = update()
+	return
 }
 
-func newGameForUI(game Game, transparent bool) *gameForUI {
+// start starts update on the worker goroutine.
+// The result must be received by wait.
+func (w *updateWorker) start() {
+	w.requestCh <- struct{}{}
+	w.running = true
+}
+
+// wait waits for update started by start.
+// wait returns false if update is not running.
+func (w *updateWorker) wait() (updateResult, bool) {
+	if !w.running {
+		return updateResult{}, false
+	}
+	result := <-w.resultCh
+	w.running = false
+	return result, true
+}
+
+// stop stops the worker goroutine.
+// stop must be called after wait.
+func (w *updateWorker) stop() {
+	close(w.requestCh)
+}
+
+// validateGameForUI reports an error if the game doesn't meet the requirements of the options.
+func validateGameForUI(game Game, options *RunGameOptions) error {
+	if options.concurrentUpdate() {
+		if _, ok := game.(StateSwapper); !ok {
+			return errors.New("ebiten: the game must implement StateSwapper when RunGameOptions.ConcurrentUpdate is true")
+		}
+	}
+	return nil
+}
+
+// newGameForUI creates a gameForUI.
+// The game and the options must be validated by validateGameForUI in advance.
+func newGameForUI(game Game, transparent bool, onPanic func(recovered any, stack []byte) PanicAction, concurrentUpdate bool) *gameForUI {
 	g := &gameForUI{
 		game:        game,
 		transparent: transparent,
+		onPanic:     onPanic,
+	}
+
+	if concurrentUpdate {
+		g.stateSwapper = game.(StateSwapper)
 	}
 
 	s, err := NewShader(builtinshader.ScreenShaderSource)
@@ -52,6 +154,11 @@ func newGameForUI(game Game, transparent bool) *gameForUI {
 	}
 	g.screenShader = s
 
+	if s, ok := game.(Suspender); ok {
+		g.removeHooks = append(g.removeHooks, hook.AppendHookOnSuspend(s.OnSuspend))
+		g.removeHooks = append(g.removeHooks, hook.AppendHookOnResume(s.OnResume))
+	}
+
 	return g
 }
 
@@ -85,6 +192,9 @@ func (g *gameForUI) NewScreenImage(width, height int) *ui.Image {
 }
 
 func (g *gameForUI) Layout(outsideWidth, outsideHeight float64) (float64, float64) {
+	// Layout might touch the game's state. Wait for Update running concurrently.
+	g.waitForUpdateAndKeepError()
+
 	if l, ok := g.game.(LayoutFer); ok {
 		return l.LayoutF(outsideWidth, outsideHeight)
 	}
@@ -105,20 +215,199 @@ func (g *gameForUI) Layout(outsideWidth, outsideHeight float64) (float64, float6
 }
 
 func (g *gameForUI) UpdateInputState(fn func(*ui.InputState)) {
+	// Update running concurrently might read the input state. Wait for it before modifying the input state.
+	g.waitForUpdateAndKeepError()
+
 	theInputState.update(fn)
+	theInputInjector.inject(&theInputState)
 }
 
-func (g *gameForUI) Update() error {
-	if err := g.game.Update(); err != nil {
+func (g *gameForUI) Update() (err error) {
+	if g.panicState != nil {
+		// Wait until the error screen is presented.
+		// Do not wait forever, as Draw might not be called e.g. when the window is minimized.
+		if g.panicState.waitsForErrorScreen() {
+			return nil
+		}
+		return g.panicState.finish()
+	}
+
+	if err := g.waitForUpdate(); err != nil {
+		return err
+	}
+	if g.panicState != nil {
+		return nil
+	}
+
+	if err := requestedTermination(); err != nil {
+		return err
+	}
+
+	if g.onPanic != nil {
+		defer func() {
+			if r := recover(); r != nil {
+				err = g.handlePanic(r)
+			}
+		}()
+	}
+
+	if !g.loaded {
+		g.loaded = true
+		if l, ok := g.game.(Loader); ok {
+			if err := l.OnLoad(); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Run the first Update synchronously so that Draw can render the state updated at least once.
+	if g.stateSwapper != nil && g.swapped {
+		g.startUpdate()
+		return nil
+	}
+
+	if err := g.updateGame(); err != nil {
 		return err
 	}
+	if err := g.afterUpdate(); err != nil {
+		return err
+	}
+	if g.stateSwapper != nil && !g.swapped {
+		g.swapState()
+	}
+	return nil
+}
+
+func (g *gameForUI) afterUpdate() error {
+	theTick.Add(1)
 	if err := g.imageDumper.update(); err != nil {
 		return err
 	}
+	g.debugOverlay.update()
+	if g.stateSwapper != nil {
+		g.updatedSinceSwap = true
+	}
 	return nil
 }
 
+// startUpdate starts the game's Update on the worker goroutine.
+// The result must be received by waitForUpdate.
+func (g *gameForUI) startUpdate() {
+	if g.updateWorker == nil {
+		g.updateWorker = newUpdateWorker(g.updateGame, g.onPanic != nil)
+	}
+	g.updateWorker.start()
+}
+
+// close releases the resources for the game after the main loop ends.
+// The game's functions are never called after close.
+func (g *gameForUI) close() {
+	g.stopUpdateWorker()
+	for _, f := range g.removeHooks {
+		f()
+	}
+	g.removeHooks = nil
+}
+
+// stopUpdateWorker waits for the game's Update running on the worker goroutine, if any, and stops the worker.
+func (g *gameForUI) stopUpdateWorker() {
+	if g.updateWorker == nil {
+		return
+	}
+	g.updateWorker.wait()
+	g.updateWorker.stop()
+	g.updateWorker = nil
+}
+
+// updateGame calls the game's Update after delivering the notifications.
+func (g *gameForUI) updateGame() error {
+	if hook.ConsumeLowMemoryNotification() {
+		if h, ok := g.game.(LowMemoryHandler); ok {
+			h.OnLowMemory()
+		}
+	}
+	return g.game.Update()
+}
+
+// waitForUpdate waits for the game's Update running on another goroutine, if any.
+func (g *gameForUI) waitForUpdate() error {
+	if g.updateErr != nil {
+		err := g.updateErr
+		g.updateErr = nil
+		return err
+	}
+	if g.updateWorker == nil {
+		return nil
+	}
+
+	result, ok := g.updateWorker.wait()
+	if !ok {
+		return nil
+	}
+	if result.stack != nil {
+		return g.handlePanicWithStack(result.recovered, result.stack)
+	}
+	if result.err != nil {
+		return result.err
+	}
+	return g.afterUpdate()
+}
+
+// waitForUpdateAndKeepError waits for the game's Update running on another goroutine, if any.
+// An error is kept and returned by the next waitForUpdate.
+func (g *gameForUI) waitForUpdateAndKeepError() {
+	if g.updateWorker == nil || !g.updateWorker.running {
+		return
+	}
+	g.updateErr = g.waitForUpdate()
+}
+
+func (g *gameForUI) EndFrame() error {
+	if g.stateSwapper == nil {
+		return nil
+	}
+	if err := g.waitForUpdate(); err != nil {
+		return err
+	}
+	if g.panicState != nil || !g.updatedSinceSwap {
+		return nil
+	}
+	g.swapState()
+	return nil
+}
+
+func (g *gameForUI) swapState() {
+	g.stateSwapper.SwapState()
+	g.updatedSinceSwap = false
+	g.swapped = true
+}
+
 func (g *gameForUI) DrawOffscreen() error {
+	if g.panicState == nil {
+		if err := g.drawOffscreen(); err != nil {
+			return err
+		}
+	}
+	if g.panicState != nil {
+		g.drawErrorScreen()
+		return nil
+	}
+	return requestedTermination()
+}
+
+func (g *gameForUI) drawOffscreen() (err error) {
+	if g.onPanic != nil {
+		defer func() {
+			if r := recover(); r != nil {
+				err = g.handlePanic(r)
+			}
+		}()
+	}
+
+	theFrameBudget.check()
+	if d, ok := g.game.(ScaledDrawer); ok {
+		g.drawScaled(d)
+	}
 	g.game.Draw(g.offscreen)
 	if err := g.imageDumper.dump(g.offscreen, g.transparent); err != nil {
 		return err
@@ -126,12 +415,58 @@ func (g *gameForUI) DrawOffscreen() error {
 	return nil
 }
 
+func (g *gameForUI) drawScaled(d ScaledDrawer) {
+	scale := d.RenderScale()
+	if scale <= 0 {
+		return
+	}
+
+	sw, sh := g.offscreen.Bounds().Dx(), g.offscreen.Bounds().Dy()
+	w, h := int(math.Ceil(float64(sw)*scale)), int(math.Ceil(float64(sh)*scale))
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	// Reuse the image as long as it is big enough, as the scale might be changed at every frame.
+	if g.scaled == nil || g.scaled.Bounds().Dx() < w || g.scaled.Bounds().Dy() < h {
+		if g.scaled != nil {
+			g.scaled.Deallocate()
+		}
+		g.scaled = NewImage(w, h)
+	}
+
+	scaled := g.scaled.SubImage(image.Rect(0, 0, w, h)).(*Image)
+	scaled.Clear()
+	d.DrawScaled(scaled)
+
+	op := &DrawImageOptions{}
+	op.GeoM.Scale(float64(sw)/float64(w), float64(sh)/float64(h))
+	op.Filter = FilterLinear
+	g.offscreen.DrawImage(scaled, op)
+}
+
 func (g *gameForUI) DrawFinalScreen(scale, offsetX, offsetY float64) {
 	var geoM GeoM
 	geoM.Scale(scale, scale)
 	geoM.Translate(offsetX, offsetY)
 
-	if d, ok := g.game.(FinalScreenDrawer); ok {
+	g.drawFinalScreen(geoM, scale)
+
+	// Draw the debug primitives onto the final screen, not onto the offscreen,
+	// so that the primitives don't persist in the offscreen and screenshots don't include them.
+	if debugDrawEnabled.Load() {
+		theDebugDrawer.draw(g.screen, &geoM)
+	}
+
+	// Draw the debug overlay after the game's rendering so that the game cannot cover it.
+	if debugOverlayEnabled.Load() {
+		g.debugOverlay.draw(g.screen)
+	}
+}
+
+func (g *gameForUI) drawFinalScreen(geoM GeoM, scale float64) {
+	// After a panic, the game's final screen drawer is not reliable. Use the default rendering.
+	if d, ok := g.game.(FinalScreenDrawer); ok && g.panicState == nil {
 		d.DrawFinalScreen(g.screen, g.offscreen, geoM)
 		return
 	}
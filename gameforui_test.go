@@ -0,0 +1,381 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"errors"
+	"image/color"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type concurrentUpdateGame struct {
+	// next is the state written by Update.
+	next int
+
+	// published is the state read by Draw.
+	published int
+
+	updating atomic.Bool
+	drawn    []int
+	swaps    int
+	err      error
+
+	// drawStarted is notified when Draw starts, if not nil.
+	drawStarted chan struct{}
+
+	m sync.Mutex
+}
+
+func (g *concurrentUpdateGame) Update() error {
+	g.updating.Store(true)
+	defer g.updating.Store(false)
+
+	// Block until Draw starts, except for the first Update, which runs synchronously before Draw.
+	if g.drawStarted != nil && g.next > 0 {
+		select {
+		case <-g.drawStarted:
+		case <-time.After(5 * time.Second):
+			g.setError(errors.New("Update didn't run concurrently with Draw"))
+		}
+	}
+	g.next++
+	return nil
+}
+
+func (g *concurrentUpdateGame) Draw(screen *ebiten.Image) {
+	if g.drawStarted != nil && len(g.drawn) > 0 {
+		select {
+		case g.drawStarted <- struct{}{}:
+		case <-time.After(5 * time.Second):
+			g.setError(errors.New("Draw didn't run concurrently with Update"))
+		}
+	}
+	g.drawn = append(g.drawn, g.published)
+}
+
+func (g *concurrentUpdateGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+func (g *concurrentUpdateGame) SwapState() {
+	if g.updating.Load() {
+		g.setError(errors.New("SwapState was called during Update"))
+	}
+	g.published = g.next
+	g.swaps++
+}
+
+func (g *concurrentUpdateGame) setError(err error) {
+	g.m.Lock()
+	defer g.m.Unlock()
+	if g.err == nil {
+		g.err = err
+	}
+}
+
+func TestConcurrentUpdateOverlapsDraw(t *testing.T) {
+	game := &concurrentUpdateGame{
+		drawStarted: make(chan struct{}),
+	}
+	g, err := ebiten.NewGameForUIForTesting(game, &ebiten.RunGameOptions{ConcurrentUpdate: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+
+	for i := 0; i < 4; i++ {
+		if err := g.RunFrame(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if game.err != nil {
+		t.Error(game.err)
+	}
+}
+
+func TestConcurrentUpdateSwapStateOrder(t *testing.T) {
+	game := &concurrentUpdateGame{}
+	g, err := ebiten.NewGameForUIForTesting(game, &ebiten.RunGameOptions{ConcurrentUpdate: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+
+	for i := 0; i < 4; i++ {
+		if err := g.RunFrame(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if game.err != nil {
+		t.Error(game.err)
+	}
+
+	// The first Update runs synchronously and its state is published before the first Draw.
+	// After that, Draw renders the state one frame before.
+	if got, want := game.drawn, []int{1, 1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("drawn states: got: %v, want: %v", got, want)
+	}
+	if got, want := game.swaps, 4; got != want {
+		t.Errorf("swaps: got: %d, want: %d", got, want)
+	}
+	if got, want := game.published, 4; got != want {
+		t.Errorf("published: got: %d, want: %d", got, want)
+	}
+}
+
+type gameWithoutStateSwapper struct{}
+
+func (*gameWithoutStateSwapper) Update() error {
+	return nil
+}
+
+func (*gameWithoutStateSwapper) Draw(screen *ebiten.Image) {
+}
+
+func (*gameWithoutStateSwapper) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+func TestConcurrentUpdateWithoutStateSwapper(t *testing.T) {
+	if err := ebiten.RunGameWithOptions(&gameWithoutStateSwapper{}, &ebiten.RunGameOptions{ConcurrentUpdate: true}); err == nil {
+		t.Errorf("RunGameWithOptions must return an error when the game doesn't implement StateSwapper")
+	}
+}
+
+type lifecycleGame struct {
+	loadErr error
+
+	loadCount    int
+	updateCount  int
+	suspendCount int
+	resumeCount  int
+
+	// updatedBeforeLoad is true if Update was called before OnLoad.
+	updatedBeforeLoad bool
+}
+
+func (g *lifecycleGame) OnLoad() error {
+	g.loadCount++
+	return g.loadErr
+}
+
+func (g *lifecycleGame) Update() error {
+	if g.loadCount == 0 {
+		g.updatedBeforeLoad = true
+	}
+	g.updateCount++
+	return nil
+}
+
+func (g *lifecycleGame) Draw(screen *ebiten.Image) {
+}
+
+func (g *lifecycleGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+func (g *lifecycleGame) OnSuspend() {
+	g.suspendCount++
+}
+
+func (g *lifecycleGame) OnResume() {
+	g.resumeCount++
+}
+
+func TestLoaderOnLoad(t *testing.T) {
+	game := &lifecycleGame{}
+	g, err := ebiten.NewGameForUIForTesting(game, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+
+	for i := 0; i < 3; i++ {
+		if err := g.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if game.updatedBeforeLoad {
+		t.Errorf("Update must not be called before OnLoad")
+	}
+	if got, want := game.loadCount, 1; got != want {
+		t.Errorf("OnLoad count: got: %d, want: %d", got, want)
+	}
+	if got, want := game.updateCount, 3; got != want {
+		t.Errorf("Update count: got: %d, want: %d", got, want)
+	}
+}
+
+func TestLoaderOnLoadError(t *testing.T) {
+	errLoad := errors.New("load error")
+	game := &lifecycleGame{
+		loadErr: errLoad,
+	}
+	g, err := ebiten.NewGameForUIForTesting(game, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+
+	if err := g.Update(); !errors.Is(err, errLoad) {
+		t.Errorf("Update: got: %v, want: %v", err, errLoad)
+	}
+	if got, want := game.updateCount, 0; got != want {
+		t.Errorf("Update count: got: %d, want: %d", got, want)
+	}
+}
+
+func TestSuspenderAfterClose(t *testing.T) {
+	game := &lifecycleGame{}
+	g, err := ebiten.NewGameForUIForTesting(game, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ebiten.SuspendForTesting(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ebiten.ResumeForTesting(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := game.suspendCount, 1; got != want {
+		t.Errorf("OnSuspend count: got: %d, want: %d", got, want)
+	}
+	if got, want := game.resumeCount, 1; got != want {
+		t.Errorf("OnResume count: got: %d, want: %d", got, want)
+	}
+
+	// The hooks must be unregistered after the game is closed, i.e. RunGame returns.
+	g.Close()
+	if err := ebiten.SuspendForTesting(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ebiten.ResumeForTesting(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := game.suspendCount, 1; got != want {
+		t.Errorf("OnSuspend count after Close: got: %d, want: %d", got, want)
+	}
+	if got, want := game.resumeCount, 1; got != want {
+		t.Errorf("OnResume count after Close: got: %d, want: %d", got, want)
+	}
+}
+
+type scaledDrawerGame struct {
+	renderScale float64
+
+	scaledWidth  int
+	scaledHeight int
+	screenWidth  int
+	screenHeight int
+}
+
+func (g *scaledDrawerGame) Update() error {
+	return nil
+}
+
+func (g *scaledDrawerGame) RenderScale() float64 {
+	return g.renderScale
+}
+
+func (g *scaledDrawerGame) DrawScaled(scaled *ebiten.Image) {
+	g.scaledWidth, g.scaledHeight = scaled.Bounds().Dx(), scaled.Bounds().Dy()
+	scaled.Fill(color.RGBA{R: 0xff, A: 0xff})
+}
+
+func (g *scaledDrawerGame) Draw(screen *ebiten.Image) {
+	g.screenWidth, g.screenHeight = screen.Bounds().Dx(), screen.Bounds().Dy()
+	// Draw the UI at the top-left pixel.
+	screen.Set(0, 0, color.RGBA{G: 0xff, A: 0xff})
+}
+
+func (g *scaledDrawerGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+func TestScaledDrawer(t *testing.T) {
+	// The offscreen size is the screen size returned by Layout, regardless of the display's resolution.
+	const w, h = 40, 20
+
+	testCases := []struct {
+		Name         string
+		RenderScale  float64
+		ScaledWidth  int
+		ScaledHeight int
+	}{
+		{
+			Name:         "half",
+			RenderScale:  0.5,
+			ScaledWidth:  20,
+			ScaledHeight: 10,
+		},
+		{
+			Name:         "rounded up",
+			RenderScale:  0.33,
+			ScaledWidth:  14,
+			ScaledHeight: 7,
+		},
+		{
+			Name:         "zero",
+			RenderScale:  0,
+			ScaledWidth:  0,
+			ScaledHeight: 0,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			game := &scaledDrawerGame{
+				renderScale: tc.RenderScale,
+			}
+			g, err := ebiten.NewGameForUIForTesting(game, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer g.Close()
+
+			screen, err := g.DrawOffscreen(w, h)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := [2]int{game.scaledWidth, game.scaledHeight}, [2]int{tc.ScaledWidth, tc.ScaledHeight}; got != want {
+				t.Errorf("scaled size: got: %v, want: %v", got, want)
+			}
+			// Draw is called with the screen at the Layout resolution, not the scaled one.
+			if got, want := [2]int{game.screenWidth, game.screenHeight}, [2]int{w, h}; got != want {
+				t.Errorf("screen size: got: %v, want: %v", got, want)
+			}
+
+			// The UI drawn at Draw is on top of the upscaled image.
+			if got, want := screen.At(0, 0).(color.RGBA), (color.RGBA{G: 0xff, A: 0xff}); got != want {
+				t.Errorf("screen.At(0, 0): got: %v, want: %v", got, want)
+			}
+			want := color.RGBA{R: 0xff, A: 0xff}
+			if tc.RenderScale <= 0 {
+				want = color.RGBA{}
+			}
+			if got := screen.At(w/2, h/2).(color.RGBA); got != want {
+				t.Errorf("screen.At(%d, %d): got: %v, want: %v", w/2, h/2, got, want)
+			}
+		})
+	}
+}
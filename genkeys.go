@@ -199,6 +199,7 @@ func init() {
 		161: "NumpadEqual",
 		117: "MetaLeft",
 		118: "MetaRight",
+		4:   "BrowserBack", // KEYCODE_BACK
 	}
 
 	// https://developer.apple.com/documentation/uikit/uikeyboardhidusage?language=objc
@@ -335,6 +336,7 @@ func init() {
 		"MetaLeft":       "MetaLeft",
 		"MetaRight":      "MetaRight",
 		"IntlBackslash":  "IntlBackslash",
+		"BrowserBack":    "BrowserBack",
 	}
 
 	const (
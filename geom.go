@@ -60,6 +60,11 @@ func (g *GeoM) elements32() (a, b, c, d, tx, ty float32) {
 	return float32(g.a_1) + 1, float32(g.b), float32(g.c), float32(g.d_1) + 1, float32(g.tx), float32(g.ty)
 }
 
+// isIntegerTranslation reports whether g is a translation by integers without any other transformation.
+func (g *GeoM) isIntegerTranslation() bool {
+	return g.a_1 == 0 && g.b == 0 && g.c == 0 && g.d_1 == 0 && g.tx == math.Trunc(g.tx) && g.ty == math.Trunc(g.ty)
+}
+
 // Element returns a value of a matrix at (i, j).
 func (g *GeoM) Element(i, j int) float64 {
 	switch {
@@ -15,6 +15,9 @@
 package ebiten
 
 import (
+	"fmt"
+	"sync/atomic"
+
 	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
@@ -66,9 +69,114 @@ var _ [GraphicsLibraryAuto]int = [0]int{}
 type DebugInfo struct {
 	// GraphicsLibrary represents the graphics library currently in use.
 	GraphicsLibrary GraphicsLibrary
+
+	// GraphicsLibraryErrors represents the errors of the graphics libraries that failed to be initialized
+	// before the current graphics library was chosen, in the tried order.
+	// See also RunGameOptions.GraphicsLibraries.
+	GraphicsLibraryErrors []*GraphicsLibraryError
+}
+
+// GraphicsLibraryError represents an error at initializing a graphics library.
+type GraphicsLibraryError struct {
+	// GraphicsLibrary is the graphics library that failed to be initialized.
+	GraphicsLibrary GraphicsLibrary
+
+	// Err is the reason of the failure.
+	Err error
+}
+
+// Error implements error.
+func (e *GraphicsLibraryError) Error() string {
+	return fmt.Sprintf("ebiten: failed to initialize %s: %v", e.GraphicsLibrary, e.Err)
+}
+
+// Unwrap returns the reason of the failure.
+func (e *GraphicsLibraryError) Unwrap() error {
+	return e.Err
 }
 
 // ReadDebugInfo writes debug info (e.g. current graphics library) into a provided struct.
 func ReadDebugInfo(d *DebugInfo) {
 	d.GraphicsLibrary = GraphicsLibrary(ui.Get().GraphicsLibrary())
+	d.GraphicsLibraryErrors = d.GraphicsLibraryErrors[:0]
+	for _, err := range ui.Get().GraphicsLibraryErrors() {
+		d.GraphicsLibraryErrors = append(d.GraphicsLibraryErrors, &GraphicsLibraryError{
+			GraphicsLibrary: GraphicsLibrary(err.GraphicsLibrary),
+			Err:             err.Err,
+		})
+	}
+}
+
+var deterministicRendering atomic.Bool
+
+// SetDeterministicRendering enables or disables the deterministic rendering mode.
+// The default state is false.
+//
+// In the deterministic rendering mode,
+//
+//   - Mipmaps are never used even when an image is scaled down with FilterLinear.
+//   - A new image is never on an internal automatic texture atlas, as if NewImageOptions.Unmanaged is true.
+//
+// This makes the rendering results reproducible enough to compare them with golden images e.g. in tests.
+// Note that the deterministic rendering mode doesn't guarantee that the results are the same across different GPUs
+// or different graphics libraries.
+//
+// The deterministic rendering mode affects only images created after SetDeterministicRendering is called.
+// Call SetDeterministicRendering before creating any images.
+//
+// The deterministic rendering mode is for testing and debugging, and might decrease performance.
+//
+// SetDeterministicRendering is concurrent-safe.
+func SetDeterministicRendering(enabled bool) {
+	deterministicRendering.Store(enabled)
+}
+
+// IsDeterministicRendering reports whether the deterministic rendering mode is enabled.
+//
+// IsDeterministicRendering is concurrent-safe.
+func IsDeterministicRendering() bool {
+	return deterministicRendering.Load()
+}
+
+// Flush submits the rendering commands issued so far to the GPU.
+//
+// Flush is not necessary for correct rendering results.
+// Ebitengine batches rendering commands, but always executes them in the order they are issued.
+// For example, when an image A is rendered and then A is used as a source to render another image B,
+// the rendering to B always reads the result of the rendering to A.
+// The same is true for reading pixels by ReadPixels or At.
+// The anti-aliased rendering onto an image is resolved automatically before the image is used as a source or read.
+// Then, ping-pong rendering for multi-pass effects like bloom or simulations works without any barriers.
+//
+// Flush might be useful to start the GPU work earlier, e.g. before heavy CPU work in the same frame.
+// Note that Flush can make the rendering slower as the batches are split.
+//
+// If Flush is called outside of the game's Update or Draw, or before the game starts, Flush does nothing.
+func Flush() {
+	ui.Get().Flush()
+}
+
+// PushDebugGroup starts a debug group with the name to label the following rendering commands.
+// A debug group ends by PopDebugGroup, and debug groups can be nested.
+//
+// Debug groups make captures in GPU debuggers like RenderDoc and Xcode readable.
+// Debug groups are emitted via glPushDebugGroup with OpenGL and via the command buffer's debug groups with Metal.
+// With other graphics libraries or environments without the support, PushDebugGroup does nothing.
+//
+// Note that the rendering commands are reordered or batched, so the labels might not match the draw calls exactly.
+// For example, anti-aliased rendering is resolved later and might be outside of the group.
+// As a debug group splits batches, PushDebugGroup can make the rendering slower.
+//
+// PushDebugGroup is concurrent-safe.
+func PushDebugGroup(name string) {
+	ui.Get().PushDebugGroup(name)
+}
+
+// PopDebugGroup ends the latest debug group started by PushDebugGroup.
+//
+// If there is no debug group, PopDebugGroup does nothing.
+//
+// PopDebugGroup is concurrent-safe.
+func PopDebugGroup() {
+	ui.Get().PopDebugGroup()
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/imagetrace"
 	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
@@ -40,12 +41,28 @@ type Image struct {
 	original *Image
 	bounds   image.Rectangle
 
+	// antialias indicates whether all the rendering onto the image uses anti-alias.
+	antialias bool
+
+	// depth is the depth buffer. depth is shared with the sub-images.
+	depth *depthBuffer
+
 	// tmpVertices must not be reused until ui.Image.Draw* is called.
 	tmpVertices []float32
 
+	// tmpIndices must not be reused until ui.Image.Draw* is called.
+	tmpIndices []uint32
+
 	// tmpUniforms must not be reused until ui.Image.Draw* is called.
 	tmpUniforms []uint32
 
+	// tmpColorMUniforms is a reusable uniform map for the built-in shaders with a color matrix.
+	tmpColorMUniforms *colorMUniforms
+
+	// trace is a record of the image's creation site.
+	// trace is nil unless the image tracing is enabled.
+	trace *imagetrace.Token
+
 	// Do not add a 'buffering' member that are resolved lazily.
 	// This tends to forget resolving the buffer easily (#2362).
 }
@@ -72,39 +89,68 @@ func (i *Image) isSubImage() bool {
 	return i.original != nil
 }
 
+// originalImage returns the original image if i is a sub-image, or i itself otherwise.
+func (i *Image) originalImage() *Image {
+	if i.isSubImage() {
+		return i.original
+	}
+	return i
+}
+
 // Clear resets the pixels of the image into 0.
 //
 // When the image is disposed, Clear does nothing.
 func (i *Image) Clear() {
 	i.Fill(color.Transparent)
+	i.ClearDepth()
 }
 
 // Fill fills the image with a solid color.
 //
+// To fill the image with a ColorScale, use FillWithColorScale.
+//
 // When the image is disposed, Fill does nothing.
 func (i *Image) Fill(clr color.Color) {
+	cr, cg, cb, ca := clr.RGBA()
+	i.fill(float32(cr)/0xffff, float32(cg)/0xffff, float32(cb)/0xffff, float32(ca)/0xffff)
+}
+
+// FillWithColorScale fills the image with a solid color represented by the given color scale.
+//
+// The color scale's values are treated as premultiplied-alpha values.
+// The values are clamped to [0, 1], and the color values are clamped to the alpha value.
+//
+// When the image is disposed, FillWithColorScale does nothing.
+func (i *Image) FillWithColorScale(colorScale ColorScale) {
+	r, g, b, a := colorScale.elements()
+	a = clamp01(a)
+	i.fill(clamp(r, 0, a), clamp(g, 0, a), clamp(b, 0, a), a)
+}
+
+func (i *Image) fill(r, g, b, a float32) {
 	i.copyCheck()
 	if i.isDisposed() {
 		return
 	}
-
-	var crf, cgf, cbf, caf float32
-	cr, cg, cb, ca := clr.RGBA()
-	crf = float32(cr) / 0xffff
-	cgf = float32(cg) / 0xffff
-	cbf = float32(cb) / 0xffff
-	caf = float32(ca) / 0xffff
-	i.image.Fill(crf, cgf, cbf, caf, i.adjustedBounds())
+	i.image.Fill(r, g, b, a, i.adjustedBounds())
 }
 
 func canSkipMipmap(geom GeoM, filter builtinshader.Filter) bool {
 	if filter != builtinshader.FilterLinear {
 		return true
 	}
+	if deterministicRendering.Load() {
+		return true
+	}
 	return geom.det2x2() >= 0.999
 }
 
 // DrawImageOptions represents options for DrawImage.
+//
+// DrawImage never modifies nor retains DrawImageOptions.
+// Then, it is safe to reuse the same DrawImageOptions for multiple DrawImage calls after modifying its members,
+// including GeoM, ColorScale and ColorM. To avoid allocations for every sprite, reuse a DrawImageOptions and call Reset
+// before setting the members.
 type DrawImageOptions struct {
 	// GeoM is a geometry matrix to draw.
 	// The default (zero) value is identity, which draws the image at (0, 0).
@@ -139,6 +185,49 @@ type DrawImageOptions struct {
 	// Filter is a type of texture filter.
 	// The default (zero) value is FilterNearest.
 	Filter Filter
+
+	// FlipX reports whether the image is flipped horizontally.
+	// The image is flipped within its own bounds before GeoM is applied,
+	// so the flipped image occupies the same region as the unflipped one.
+	//
+	// The default (zero) value is false.
+	FlipX bool
+
+	// FlipY reports whether the image is flipped vertically.
+	// The image is flipped within its own bounds before GeoM is applied,
+	// so the flipped image occupies the same region as the unflipped one.
+	//
+	// The default (zero) value is false.
+	FlipY bool
+
+	// DepthValue is the depth value of the rendering in [0, 1]. A bigger value is nearer.
+	// DepthValue is used only when the destination image has a depth buffer by NewImageOptions.Depth,
+	// and DepthTest or DepthWrite is true.
+	// For example, a Y coordinate normalized to [0, 1] can be used for Y-sorting without sorting the draw calls.
+	//
+	// The default (zero) value is 0, which is the farthest.
+	DepthValue float32
+
+	// DepthTest indicates whether the rendering is tested against the depth buffer of the destination image.
+	// A pixel is not rendered if DepthValue is less than the depth value in the buffer.
+	// If the destination image doesn't have a depth buffer, DepthTest is ignored.
+	// See NewImageOptions.Depth for the limitations.
+	//
+	// The default (zero) value is false.
+	DepthTest bool
+
+	// DepthWrite indicates whether DepthValue is written to the depth buffer of the destination image.
+	// DepthValue is written only where the source pixel is not transparent and the depth test passes if DepthTest is true.
+	// If the destination image doesn't have a depth buffer, DepthWrite is ignored.
+	// See NewImageOptions.Depth for the limitations.
+	//
+	// The default (zero) value is false.
+	DepthWrite bool
+}
+
+// Reset resets all the members to the default (zero) values.
+func (o *DrawImageOptions) Reset() {
+	*o = DrawImageOptions{}
 }
 
 // adjustPosition converts the position in the *ebiten.Image coordinate to the *ui.Image coordinate.
@@ -232,19 +321,45 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 	}
 	filter := builtinshader.Filter(options.Filter)
 
+	bounds := img.Bounds()
+
 	geoM := options.GeoM
+	if options.FlipX || options.FlipY {
+		var flip GeoM
+		if options.FlipX {
+			flip.Scale(-1, 1)
+			flip.Translate(float64(bounds.Dx()), 0)
+		}
+		if options.FlipY {
+			flip.Scale(1, -1)
+			flip.Translate(0, float64(bounds.Dy()))
+		}
+		flip.Concat(geoM)
+		geoM = flip
+	}
+	if i.depth != nil && (options.DepthTest || options.DepthWrite) {
+		i.drawImageWithDepth(img, geoM, options)
+		return
+	}
 	if offsetX, offsetY := i.adjustPosition(0, 0); offsetX != 0 || offsetY != 0 {
 		geoM.Translate(float64(offsetX), float64(offsetY))
 	}
-	a, b, c, d, tx, ty := geoM.elements32()
 
-	bounds := img.Bounds()
 	sx0, sy0 := img.adjustPosition(bounds.Min.X, bounds.Min.Y)
 	sx1, sy1 := img.adjustPosition(bounds.Max.X, bounds.Max.Y)
 	colorm, cr, cg, cb, ca := colorMToScale(options.ColorM.affineColorM())
 	cr, cg, cb, ca = options.ColorScale.apply(cr, cg, cb, ca)
 	vs := i.ensureTmpVertices(4 * graphics.VertexFloatCount)
-	graphics.QuadVertices(vs, float32(sx0), float32(sy0), float32(sx1), float32(sy1), a, b, c, d, tx, ty, cr, cg, cb, ca)
+	var skipMipmap bool
+	if geoM.isIntegerTranslation() {
+		// A fast path for a simple blit, which is very common for e.g. UI and tiles.
+		graphics.TranslatedQuadVertices(vs, float32(sx0), float32(sy0), float32(sx1), float32(sy1), float32(geoM.tx), float32(geoM.ty), cr, cg, cb, ca)
+		skipMipmap = true
+	} else {
+		a, b, c, d, tx, ty := geoM.elements32()
+		graphics.QuadVertices(vs, float32(sx0), float32(sy0), float32(sx1), float32(sy1), a, b, c, d, tx, ty, cr, cg, cb, ca)
+		skipMipmap = canSkipMipmap(geoM, filter)
+	}
 	is := graphics.QuadIndices()
 
 	srcs := [graphics.ShaderImageCount]*ui.Image{img.image}
@@ -253,16 +368,10 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 	shader := builtinShader(filter, builtinshader.AddressUnsafe, useColorM)
 	i.tmpUniforms = i.tmpUniforms[:0]
 	if useColorM {
-		var body [16]float32
-		var translation [4]float32
-		colorm.Elements(body[:], translation[:])
-		i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, map[string]any{
-			builtinshader.UniformColorMBody:        body[:],
-			builtinshader.UniformColorMTranslation: translation[:],
-		})
+		i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, i.colorMUniforms(colorm))
 	}
 
-	i.image.DrawTriangles(srcs, vs, is, blend, i.adjustedBounds(), [graphics.ShaderImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillAll, canSkipMipmap(geoM, filter), false)
+	i.image.DrawTriangles(srcs, vs, is, blend, i.adjustedBounds(), [graphics.ShaderImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillAll, skipMipmap, i.antialias)
 }
 
 // Vertex represents a vertex passed to DrawTriangles.
@@ -291,6 +400,18 @@ type Vertex struct {
 	ColorG float32
 	ColorB float32
 	ColorA float32
+
+	// Custom0/Custom1/Custom2/Custom3 represents general-purpose values passed to the shader.
+	// Their interpretation depends on the concrete draw call used:
+	// - DrawTriangles: ignored.
+	// - DrawTrianglesShader: arbitrary floating point values sent to the shader.
+	//   These are interpolated linearly and independently of each other,
+	//   and are available as the fourth parameter of type vec4 of the Fragment function.
+	//   If the Fragment function doesn't take the fourth parameter, the custom values are not sent to the GPU.
+	Custom0 float32
+	Custom1 float32
+	Custom2 float32
+	Custom3 float32
 }
 
 // Address represents a sampler address mode.
@@ -450,6 +571,42 @@ func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, o
 		}
 	}
 
+	is := i.ensureTmpIndices(len(indices))
+	for i := range is {
+		is[i] = uint32(indices[i])
+	}
+
+	i.drawTriangles(vertices, is, img, options, nil)
+}
+
+// DrawMesh draws the triangles of the specified retained mesh.
+//
+// DrawMesh works in the same way as DrawTriangles with the mesh's vertices and indices,
+// but skips validating and converting the indices at every call.
+// The converted vertices are also reused while the images and the color scale are the same.
+// This is efficient to draw a large static mesh like a tilemap every frame.
+// See Mesh for the details.
+//
+// img is used as a source image. img cannot be nil.
+//
+// When the given image is disposed, DrawMesh panics.
+//
+// When the image i is disposed, DrawMesh does nothing.
+func (i *Image) DrawMesh(mesh *Mesh, img *Image, options *DrawTrianglesOptions) {
+	i.copyCheck()
+
+	if img != nil && img.isDisposed() {
+		panic("ebiten: the given image to DrawMesh must not be disposed")
+	}
+	if i.isDisposed() {
+		return
+	}
+
+	i.drawTriangles(nil, mesh.indices, img, options, mesh)
+}
+
+// drawTriangles draws the triangles with the vertices, or with the mesh's vertices if mesh is not nil.
+func (i *Image) drawTriangles(vertices []Vertex, indices []uint32, img *Image, options *DrawTrianglesOptions, mesh *Mesh) {
 	if options == nil {
 		options = &DrawTrianglesOptions{}
 	}
@@ -466,14 +623,36 @@ func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, o
 
 	colorm, cr, cg, cb, ca := colorMToScale(options.ColorM.affineColorM())
 
-	vs := i.ensureTmpVertices(len(vertices) * graphics.VertexFloatCount)
-	dst := i
-	if options.ColorScaleMode == ColorScaleModeStraightAlpha {
+	var vs []float32
+	if mesh != nil {
+		// Copy the cached vertices as the vertices passed to ui.Image.DrawTriangles are modified there.
+		cached := mesh.vertexFloats(i, img, options.ColorScaleMode, cr, cg, cb, ca)
+		vs = i.ensureTmpVertices(len(cached))
+		copy(vs, cached)
+	} else {
+		vs = i.ensureTmpVertices(len(vertices) * graphics.VertexFloatCount)
+		convertVertices(vs, vertices, i, img, options.ColorScaleMode, cr, cg, cb, ca)
+	}
+	srcs := [graphics.ShaderImageCount]*ui.Image{img.image}
+
+	useColorM := !colorm.IsIdentity()
+	shader := builtinShader(filter, address, useColorM)
+	i.tmpUniforms = i.tmpUniforms[:0]
+	if useColorM {
+		i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, i.colorMUniforms(colorm))
+	}
+
+	i.image.DrawTriangles(srcs, vs, indices, blend, i.adjustedBounds(), [graphics.ShaderImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), filter != builtinshader.FilterLinear || deterministicRendering.Load(), options.AntiAlias || i.antialias)
+}
+
+// convertVertices converts the vertices for DrawTriangles to the internal vertex format.
+func convertVertices(vs []float32, vertices []Vertex, dst, src *Image, colorScaleMode ColorScaleMode, cr, cg, cb, ca float32) {
+	if colorScaleMode == ColorScaleModeStraightAlpha {
 		for i, v := range vertices {
 			dx, dy := dst.adjustPositionF32(v.DstX, v.DstY)
 			vs[i*graphics.VertexFloatCount] = dx
 			vs[i*graphics.VertexFloatCount+1] = dy
-			sx, sy := img.adjustPositionF32(v.SrcX, v.SrcY)
+			sx, sy := src.adjustPositionF32(v.SrcX, v.SrcY)
 			vs[i*graphics.VertexFloatCount+2] = sx
 			vs[i*graphics.VertexFloatCount+3] = sy
 			vs[i*graphics.VertexFloatCount+4] = v.ColorR * v.ColorA * cr
@@ -486,7 +665,7 @@ func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, o
 			dx, dy := dst.adjustPositionF32(v.DstX, v.DstY)
 			vs[i*graphics.VertexFloatCount] = dx
 			vs[i*graphics.VertexFloatCount+1] = dy
-			sx, sy := img.adjustPositionF32(v.SrcX, v.SrcY)
+			sx, sy := src.adjustPositionF32(v.SrcX, v.SrcY)
 			vs[i*graphics.VertexFloatCount+2] = sx
 			vs[i*graphics.VertexFloatCount+3] = sy
 			vs[i*graphics.VertexFloatCount+4] = v.ColorR * cr
@@ -495,27 +674,6 @@ func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, o
 			vs[i*graphics.VertexFloatCount+7] = v.ColorA * ca
 		}
 	}
-	is := make([]uint32, len(indices))
-	for i := range is {
-		is[i] = uint32(indices[i])
-	}
-
-	srcs := [graphics.ShaderImageCount]*ui.Image{img.image}
-
-	useColorM := !colorm.IsIdentity()
-	shader := builtinShader(filter, address, useColorM)
-	i.tmpUniforms = i.tmpUniforms[:0]
-	if useColorM {
-		var body [16]float32
-		var translation [4]float32
-		colorm.Elements(body[:], translation[:])
-		i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, map[string]any{
-			builtinshader.UniformColorMBody:        body[:],
-			builtinshader.UniformColorMTranslation: translation[:],
-		})
-	}
-
-	i.image.DrawTriangles(srcs, vs, is, blend, i.adjustedBounds(), [graphics.ShaderImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), filter != builtinshader.FilterLinear, options.AntiAlias)
 }
 
 // DrawTrianglesShaderOptions represents options for DrawTrianglesShader.
@@ -573,6 +731,11 @@ var _ [len(DrawTrianglesShaderOptions{}.Images) - graphics.ShaderImageCount]stru
 //
 // For the details about the shader, see https://ebitengine.org/en/documents/shader.html.
 //
+// A shader doesn't depend on any filter like DrawImageOptions.Filter, and imageSrcNAt and imageSrcNUnsafeAt read the nearest pixel.
+// With the pixel-unit mode, a shader can also use imageSrcNLinearAt for bilinear interpolation
+// and imageSrcNBicubicAt for bicubic (Catmull-Rom) interpolation, e.g. for custom upscalers or distortion effects.
+// These functions read 4 and 16 pixels per call respectively, so they are more expensive than imageSrcNAt.
+//
 // If the shader unit is texels, one of the specified image is non-nil and its size is different from (width, height),
 // DrawTrianglesShader panics.
 // If one of the specified image is non-nil and is disposed, DrawTrianglesShader panics.
@@ -626,26 +789,34 @@ func (i *Image) DrawTrianglesShader(vertices []Vertex, indices []uint16, shader
 		blend = options.CompositeMode.blend().internalBlend()
 	}
 
-	vs := i.ensureTmpVertices(len(vertices) * graphics.VertexFloatCount)
+	// The custom values are sent only when the shader takes them.
+	n := shader.vertexFloatCount
+	vs := i.ensureTmpVertices(len(vertices) * n)
 	dst := i
 	src := options.Images[0]
 	for i, v := range vertices {
 		dx, dy := dst.adjustPositionF32(v.DstX, v.DstY)
-		vs[i*graphics.VertexFloatCount] = dx
-		vs[i*graphics.VertexFloatCount+1] = dy
+		vs[i*n] = dx
+		vs[i*n+1] = dy
 		sx, sy := v.SrcX, v.SrcY
 		if src != nil {
 			sx, sy = src.adjustPositionF32(sx, sy)
 		}
-		vs[i*graphics.VertexFloatCount+2] = sx
-		vs[i*graphics.VertexFloatCount+3] = sy
-		vs[i*graphics.VertexFloatCount+4] = v.ColorR
-		vs[i*graphics.VertexFloatCount+5] = v.ColorG
-		vs[i*graphics.VertexFloatCount+6] = v.ColorB
-		vs[i*graphics.VertexFloatCount+7] = v.ColorA
+		vs[i*n+2] = sx
+		vs[i*n+3] = sy
+		vs[i*n+4] = v.ColorR
+		vs[i*n+5] = v.ColorG
+		vs[i*n+6] = v.ColorB
+		vs[i*n+7] = v.ColorA
+		if n == graphics.VertexFloatCountWithCustomValues {
+			vs[i*n+8] = v.Custom0
+			vs[i*n+9] = v.Custom1
+			vs[i*n+10] = v.Custom2
+			vs[i*n+11] = v.Custom3
+		}
 	}
 
-	is := make([]uint32, len(indices))
+	is := i.ensureTmpIndices(len(indices))
 	for i := range is {
 		is[i] = uint32(indices[i])
 	}
@@ -683,7 +854,7 @@ func (i *Image) DrawTrianglesShader(vertices []Vertex, indices []uint16, shader
 	i.tmpUniforms = i.tmpUniforms[:0]
 	i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, options.Uniforms)
 
-	i.image.DrawTriangles(imgs, vs, is, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), true, options.AntiAlias)
+	i.image.DrawTriangles(imgs, vs, is, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), true, options.AntiAlias || i.antialias)
 }
 
 // DrawRectShaderOptions represents options for DrawRectShader.
@@ -804,19 +975,22 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 	}
 	a, b, c, d, tx, ty := geoM.elements32()
 	cr, cg, cb, ca := options.ColorScale.elements()
-	vs := i.ensureTmpVertices(4 * graphics.VertexFloatCount)
+	vs := i.ensureTmpVertices(4 * shader.vertexFloatCount)
 
 	// Do not use srcRegions[0].Dx() and srcRegions[0].Dy() as these might be empty.
 	graphics.QuadVertices(vs,
 		float32(srcRegions[0].Min.X), float32(srcRegions[0].Min.Y),
 		float32(srcRegions[0].Min.X+width), float32(srcRegions[0].Min.Y+height),
 		a, b, c, d, tx, ty, cr, cg, cb, ca)
+	if shader.vertexFloatCount == graphics.VertexFloatCountWithCustomValues {
+		graphics.ExpandToCustomValuesLayout(vs)
+	}
 	is := graphics.QuadIndices()
 
 	i.tmpUniforms = i.tmpUniforms[:0]
 	i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, options.Uniforms)
 
-	i.image.DrawTriangles(imgs, vs, is, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillAll, true, false)
+	i.image.DrawTriangles(imgs, vs, is, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillAll, true, i.antialias)
 }
 
 // SubImage returns an image representing the portion of the image p visible through r.
@@ -850,9 +1024,11 @@ func (i *Image) SubImage(r image.Rectangle) image.Image {
 	}
 
 	img := &Image{
-		image:    i.image,
-		bounds:   r,
-		original: orig,
+		image:     i.image,
+		bounds:    r,
+		original:  orig,
+		antialias: i.antialias,
+		depth:     i.depth,
 	}
 	img.addr = img
 
@@ -963,6 +1139,8 @@ func (i *Image) at(x, y int) (r, g, b, a byte) {
 // Even if a result is an invalid color as a premultiplied-alpha color, i.e. an alpha value exceeds other color values,
 // the value is kept and is not clamped.
 //
+// To write a region of pixels, WritePixels or DrawOver is more efficient than calling Set for each pixel.
+//
 // If the image is disposed, Set does nothing.
 func (i *Image) Set(x, y int, clr color.Color) {
 	i.copyCheck()
@@ -981,6 +1159,62 @@ func (i *Image) Set(x, y int, clr color.Color) {
 	i.image.WritePixels([]byte{byte(cr >> 8), byte(cg >> 8), byte(cb >> 8), byte(ca >> 8)}, image.Rect(dx, dy, dx+1, dy+1))
 }
 
+// DrawOver draws the standard image src over the image, where the upper-left corner of src is placed at the position at.
+//
+// DrawOver is equivalent to draw.Draw(i, image.Rectangle{Min: at, Max: at.Add(src.Bounds().Size())}, src, src.Bounds().Min, draw.Over),
+// but much faster than that, as src's pixels are uploaded at once instead of calling Set for each pixel.
+// If src is opaque, the pixels are uploaded to the image directly.
+//
+// The part outside of the image's bounds is ignored.
+// As with draw.Draw, src can be the image itself or share the pixels with the image, e.g. a sub-image of the image.
+//
+// If the image is disposed, DrawOver does nothing.
+func (i *Image) DrawOver(src image.Image, at image.Point) {
+	i.copyCheck()
+	if i.isDisposed() {
+		return
+	}
+
+	sb := src.Bounds()
+	dr := image.Rectangle{Min: at, Max: at.Add(sb.Size())}.Intersect(i.Bounds())
+	if dr.Empty() {
+		return
+	}
+	sr := dr.Sub(at).Add(sb.Min)
+
+	// If the given image is an Ebitengine image, use DrawImage instead of reading pixels from the source.
+	if src, ok := src.(*Image); ok {
+		s := src.SubImage(sr).(*Image)
+
+		// DrawImage doesn't accept the same image as the receiver, while draw.Draw does.
+		// Copy the source region first in this case.
+		if src.originalImage() == i.originalImage() {
+			tmp := NewImage(sr.Dx(), sr.Dy())
+			defer tmp.Deallocate()
+			tmp.DrawImage(s, &DrawImageOptions{
+				Blend: BlendCopy,
+			})
+			s = tmp
+		}
+
+		op := &DrawImageOptions{}
+		op.GeoM.Translate(float64(dr.Min.X), float64(dr.Min.Y))
+		i.DrawImage(s, op)
+		return
+	}
+
+	pix := imageToBytesInRegion(src, sr)
+
+	orig := i.originalImage()
+	x, y := orig.adjustPosition(dr.Min.X, dr.Min.Y)
+	region := image.Rect(x, y, x+dr.Dx(), y+dr.Dy())
+	if o, ok := src.(interface{ Opaque() bool }); ok && o.Opaque() {
+		orig.image.WritePixels(pix, region)
+		return
+	}
+	orig.image.DrawPixelsOver(pix, region)
+}
+
 // Dispose disposes the image data.
 // After disposing, most of the image functions do nothing and returns meaningless values.
 //
@@ -1003,6 +1237,7 @@ func (i *Image) Dispose() {
 	}
 	i.image.Deallocate()
 	i.image = nil
+	i.trace.Remove()
 }
 
 // Deallocate clears the image and deallocates the internal state of the image.
@@ -1026,6 +1261,108 @@ func (i *Image) Deallocate() {
 		return
 	}
 	i.image.Deallocate()
+	if i.depth != nil {
+		i.depth.deallocate()
+	}
+	i.trace.Remove()
+}
+
+// Pin pins the image so that the image stays at the same internal texture.
+//
+// Ebitengine's automatic texture atlas might move an image to another internal texture for efficiency,
+// which requires copying the pixels on GPU. A pinned image is moved to its own internal texture once if needed,
+// and is never moved after that. On the other hand, a pinned image cannot be batched with other images
+// on a shared internal texture.
+// Pin is useful for a critical image that must not cause stalls by moving, e.g., a big texture for streaming.
+//
+// If the image is a sub-image, Pin pins the original image.
+//
+// If the image is disposed, Pin does nothing.
+func (i *Image) Pin() {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return
+	}
+	i.image.SetPinned(true)
+}
+
+// Unpin unpins the image pinned by Pin.
+// An unpinned image might be moved to a shared internal texture again by the automatic texture atlas.
+//
+// If the image is a sub-image, Unpin unpins the original image.
+//
+// If the image is disposed, Unpin does nothing.
+func (i *Image) Unpin() {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return
+	}
+	i.image.SetPinned(false)
+}
+
+// ImageResidency represents the state of an image in the internal textures.
+// ImageResidency is for debugging.
+type ImageResidency struct {
+	// Allocated reports whether the image has an internal texture.
+	// An internal texture is allocated lazily, e.g., when the image is rendered for the first time.
+	Allocated bool
+
+	// OnAtlas reports whether the image shares an internal texture with other images.
+	OnAtlas bool
+
+	// Pinned reports whether the image is pinned by Pin.
+	Pinned bool
+}
+
+// Residency returns the current state of the image in the internal textures.
+//
+// If the image is a sub-image, Residency returns the state of the original image.
+//
+// If the image is disposed, Residency returns the zero value.
+func (i *Image) Residency() ImageResidency {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return ImageResidency{}
+	}
+	r := i.image.Residency()
+	return ImageResidency{
+		Allocated: r.Allocated,
+		OnAtlas:   r.OnAtlas,
+		Pinned:    r.Pinned,
+	}
+}
+
+// ImageEvictionPolicy represents a policy how the automatic texture atlas moves images between internal textures.
+type ImageEvictionPolicy int
+
+const (
+	// ImageEvictionPolicyAuto indicates that images are moved automatically for efficiency.
+	// For example, an image that has been used only as a rendering source for a while is moved
+	// onto a shared internal texture so that draw calls can be batched.
+	// This is the default policy.
+	ImageEvictionPolicyAuto ImageEvictionPolicy = iota
+
+	// ImageEvictionPolicyKeep indicates that images are never moved automatically for efficiency.
+	// Even with this policy, an image on a shared internal texture is moved when the image is rendered
+	// while the same internal texture is used as a rendering source. Use Pin to avoid this.
+	ImageEvictionPolicyKeep
+)
+
+// SetImageEvictionPolicy sets the policy how the automatic texture atlas moves images between internal textures.
+//
+// SetImageEvictionPolicy is concurrent-safe.
+func SetImageEvictionPolicy(policy ImageEvictionPolicy) {
+	switch policy {
+	case ImageEvictionPolicyAuto:
+		ui.Get().SetImageAutomaticRelocationEnabled(true)
+	case ImageEvictionPolicyKeep:
+		ui.Get().SetImageAutomaticRelocationEnabled(false)
+	default:
+		panic(fmt.Sprintf("ebiten: invalid image eviction policy: %d", policy))
+	}
 }
 
 // WritePixels replaces the pixels of the image.
@@ -1083,6 +1420,29 @@ type NewImageOptions struct {
 	// A regular image is a part of an internal texture atlas, and locating them is done automatically in Ebitengine.
 	// Unmanaged is useful when you want finer controls over the image for performance and memory reasons.
 	Unmanaged bool
+
+	// AntiAlias indicates whether all the rendering onto the image is anti-aliased.
+	//
+	// If AntiAlias is true, the rendering onto the image by DrawImage, DrawTriangles, DrawRectShader and
+	// DrawTrianglesShader is anti-aliased as if DrawTrianglesOptions.AntiAlias is true.
+	// The same applies to sub-images of the image.
+	// AntiAlias increases internal draw calls and might affect performance.
+	//
+	// The default (zero) value is false.
+	AntiAlias bool
+
+	// Depth indicates whether the image has a depth buffer.
+	// With a depth buffer, DrawImage can test and write depth values by DrawImageOptions's DepthValue, DepthTest and DepthWrite.
+	// The depth buffer is shared with the sub-images, and is cleared by Clear and ClearDepth.
+	//
+	// The depth buffer is emulated with additional images, and a depth value has 24-bit precision.
+	// A DrawImage call with the depth test or the depth write takes up to three draw calls and is not batched
+	// with other draw calls, so this is much slower than a regular DrawImage.
+	// With the depth test or the depth write, DrawImage supports only FilterNearest and FilterLinear,
+	// and DrawImageOptions.ColorM only for scaling colors. Otherwise, DrawImage panics.
+	//
+	// The default (zero) value is false.
+	Depth bool
 }
 
 // NewImageWithOptions returns an empty image with the given bounds and the options.
@@ -1105,7 +1465,14 @@ func NewImageWithOptions(bounds image.Rectangle, options *NewImageOptions) *Imag
 	if options != nil && options.Unmanaged {
 		imageType = atlas.ImageTypeUnmanaged
 	}
-	return newImage(bounds, imageType)
+	i := newImage(bounds, imageType)
+	if options != nil {
+		i.antialias = options.AntiAlias
+		if options.Depth {
+			i.depth = newDepthBuffer(bounds)
+		}
+	}
+	return i
 }
 
 func newImage(bounds image.Rectangle, imageType atlas.ImageType) *Image {
@@ -1121,9 +1488,17 @@ func newImage(bounds image.Rectangle, imageType atlas.ImageType) *Image {
 		panic(fmt.Sprintf("ebiten: height at NewImage must be positive but %d", height))
 	}
 
+	if imageType == atlas.ImageTypeRegular && deterministicRendering.Load() {
+		imageType = atlas.ImageTypeUnmanaged
+	}
+
 	i := &Image{
 		image:  ui.Get().NewImage(width, height, imageType),
 		bounds: bounds,
+		trace:  imagetrace.Add(width, height, 0),
+	}
+	if i.trace != nil {
+		i.image.SetTraceID(i.trace.ID())
 	}
 	i.addr = i
 	return i
@@ -1246,6 +1621,36 @@ func (i *Image) ensureTmpVertices(n int) []float32 {
 	return i.tmpVertices[:n]
 }
 
+func (i *Image) ensureTmpIndices(n int) []uint32 {
+	if cap(i.tmpIndices) < n {
+		i.tmpIndices = make([]uint32, n)
+	}
+	return i.tmpIndices[:n]
+}
+
+// colorMUniforms is a set of uniform values for the built-in shaders with a color matrix.
+type colorMUniforms struct {
+	body        [16]float32
+	translation [4]float32
+	uniforms    map[string]any
+}
+
+// colorMUniforms returns a uniform map for the given color matrix.
+// The returned map must not be reused until ui.Image.Draw* is called.
+func (i *Image) colorMUniforms(colorm affine.ColorM) map[string]any {
+	u := i.tmpColorMUniforms
+	if u == nil {
+		u = &colorMUniforms{}
+		u.uniforms = map[string]any{
+			builtinshader.UniformColorMBody:        u.body[:],
+			builtinshader.UniformColorMTranslation: u.translation[:],
+		}
+		i.tmpColorMUniforms = u
+	}
+	colorm.Elements(u.body[:], u.translation[:])
+	return u.uniforms
+}
+
 // private implements FinalScreen.
 func (*Image) private() {
 }
@@ -662,6 +662,52 @@ func BenchmarkDrawImage(b *testing.B) {
 	}
 }
 
+func BenchmarkDrawImageReusedOptions(b *testing.B) {
+	img0 := ebiten.NewImage(16, 16)
+	img1 := ebiten.NewImage(16, 16)
+	op := &ebiten.DrawImageOptions{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		op.Reset()
+		op.GeoM.Rotate(float64(i))
+		op.GeoM.Translate(float64(i%16), float64(i%16))
+		op.ColorScale.ScaleAlpha(0.5)
+		img0.DrawImage(img1, op)
+	}
+}
+
+func BenchmarkDrawImageColorM(b *testing.B) {
+	img0 := ebiten.NewImage(16, 16)
+	img1 := ebiten.NewImage(16, 16)
+	op := &ebiten.DrawImageOptions{}
+	op.ColorM.ChangeHSV(1, 0.5, 0.5)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		img0.DrawImage(img1, op)
+	}
+}
+
+func BenchmarkDrawTrianglesRects(b *testing.B) {
+	img0 := ebiten.NewImage(256, 256)
+	img1 := ebiten.NewImage(16, 16)
+	var vs []ebiten.Vertex
+	var is []uint16
+	op := &ebiten.DrawTrianglesOptions{}
+	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		vs = vs[:0]
+		is = is[:0]
+		for j := 0; j < 256; j++ {
+			var geoM ebiten.GeoM
+			geoM.Translate(float64(j%16)*16, float64(j/16)*16)
+			is = ebiten.AppendRectIndices(is, uint16(len(vs)))
+			vs = ebiten.AppendRectVertices(vs, img1.Bounds(), &geoM, nil)
+		}
+		img0.DrawTriangles(vs, is, img1, op)
+	}
+}
+
 func TestImageLinearGraduation(t *testing.T) {
 	img0 := ebiten.NewImage(2, 2)
 	img0.WritePixels([]byte{
@@ -4623,3 +4669,473 @@ func TestImageInvalidPremultipliedAlphaColor(t *testing.T) {
 		}
 	}
 }
+
+func TestImageNewImageOptionsAntiAlias(t *testing.T) {
+	const w, h = 64, 64
+
+	dst0 := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+		AntiAlias: true,
+	})
+	dst1 := ebiten.NewImage(w, h)
+	src := ebiten.NewImage(3, 3)
+	src.Fill(color.White)
+
+	vs := []ebiten.Vertex{
+		{DstX: 3.5, DstY: 5, SrcX: 1, SrcY: 1, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: 60, DstY: 10.25, SrcX: 2, SrcY: 1, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: 20.75, DstY: 58, SrcX: 1, SrcY: 2, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+	}
+	is := []uint16{0, 1, 2}
+
+	// Rendering onto an anti-aliased image should be the same as rendering with AntiAlias.
+	dst0.DrawTriangles(vs, is, src, nil)
+	op := &ebiten.DrawTrianglesOptions{}
+	op.AntiAlias = true
+	dst1.DrawTriangles(vs, is, src, op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst0.At(i, j)
+			want := dst1.At(i, j)
+			if got != want {
+				t.Errorf("At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// Rendering onto a sub-image of an anti-aliased image should also be anti-aliased.
+	dst2 := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+		AntiAlias: true,
+	})
+	sub := dst2.SubImage(image.Rect(0, 0, w/2, h/2)).(*ebiten.Image)
+	sub.DrawTriangles(vs, is, src, nil)
+	for j := 0; j < h/2; j++ {
+		for i := 0; i < w/2; i++ {
+			got := dst2.At(i, j)
+			want := dst1.At(i, j)
+			if got != want {
+				t.Errorf("At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDrawImageOptionsReset(t *testing.T) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(1, 2)
+	op.ColorScale.ScaleAlpha(0.5)
+	op.ColorM.Scale(1, 0, 0, 1)
+	op.Blend = ebiten.BlendCopy
+	op.Filter = ebiten.FilterLinear
+	op.Reset()
+
+	if got, want := op.GeoM, (ebiten.GeoM{}); got != want {
+		t.Errorf("GeoM: got: %v, want: %v", got, want)
+	}
+	if got, want := op.ColorScale, (ebiten.ColorScale{}); got != want {
+		t.Errorf("ColorScale: got: %v, want: %v", got, want)
+	}
+	var identity ebiten.ColorM
+	if got, want := op.ColorM.String(), identity.String(); got != want {
+		t.Errorf("ColorM: got: %v, want: %v", got, want)
+	}
+	if got, want := op.Blend, (ebiten.Blend{}); got != want {
+		t.Errorf("Blend: got: %v, want: %v", got, want)
+	}
+	if got, want := op.Filter, ebiten.FilterNearest; got != want {
+		t.Errorf("Filter: got: %v, want: %v", got, want)
+	}
+}
+
+func TestAppendRectVertices(t *testing.T) {
+	const w, h = 16, 16
+
+	src := ebiten.NewImage(w, h)
+	pix := make([]byte, 4*w*h)
+	for i := 0; i < w*h; i++ {
+		pix[4*i] = byte(i)
+		pix[4*i+1] = byte(i * 3)
+		pix[4*i+2] = byte(i * 7)
+		pix[4*i+3] = 0xff
+	}
+	src.WritePixels(pix)
+
+	region := image.Rect(2, 3, 10, 12)
+	var geoM ebiten.GeoM
+	geoM.Scale(2, 3)
+	geoM.Translate(5, 7)
+	var colorScale ebiten.ColorScale
+	colorScale.Scale(0.5, 0.25, 1, 0.5)
+
+	dst0 := ebiten.NewImage(w*4, h*4)
+	op0 := &ebiten.DrawImageOptions{}
+	op0.GeoM = geoM
+	op0.ColorScale = colorScale
+	dst0.DrawImage(src.SubImage(region).(*ebiten.Image), op0)
+
+	dst1 := ebiten.NewImage(w*4, h*4)
+	is := ebiten.AppendRectIndices(nil, 0)
+	vs := ebiten.AppendRectVertices(nil, region, &geoM, &colorScale)
+	op1 := &ebiten.DrawTrianglesOptions{}
+	op1.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	dst1.DrawTriangles(vs, is, src, op1)
+
+	for j := 0; j < h*4; j++ {
+		for i := 0; i < w*4; i++ {
+			got := dst1.At(i, j)
+			want := dst0.At(i, j)
+			if !sameColors(got.(color.RGBA), want.(color.RGBA), 1) {
+				t.Errorf("dst1.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImagePool(t *testing.T) {
+	const w, h = 16, 16
+	pool := ebiten.NewImagePool(w, h)
+
+	img0 := pool.Get()
+	if got, want := img0.Bounds().Size(), image.Pt(w, h); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	img0.Fill(color.RGBA{0xff, 0, 0, 0xff})
+	pool.Put(img0)
+	pool.Put(img0)
+
+	// The recycled image must be cleared.
+	img1 := pool.Get()
+	if img1 != img0 {
+		t.Errorf("the image was not recycled")
+	}
+	if got, want := img1.At(0, 0), (color.RGBA{}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// The pool is empty as img0 was put only once effectively.
+	if img2 := pool.Get(); img2 == img1 {
+		t.Errorf("the same image was returned twice")
+	}
+}
+
+func TestImageDrawMesh(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.RGBA{0xff, 0, 0, 0xff})
+
+	vs := ebiten.AppendRectVertices(nil, src.Bounds(), nil, nil)
+	is := ebiten.AppendRectIndices(nil, 0)
+	mesh := ebiten.NewMesh(vs, is)
+
+	dst0 := ebiten.NewImage(w, h)
+	dst0.DrawTriangles(vs, is, src, nil)
+	dst1 := ebiten.NewImage(w, h)
+	dst1.DrawMesh(mesh, src, nil)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst1.At(i, j)
+			want := dst0.At(i, j)
+			if got != want {
+				t.Errorf("dst1.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// Update the vertices.
+	for i := range vs {
+		vs[i].ColorR = 0
+		vs[i].ColorB = 1
+	}
+	mesh.SetVertices(0, vs)
+	dst1.Clear()
+	dst1.DrawMesh(mesh, src, nil)
+	if got, want := dst1.At(0, 0), (color.RGBA{0, 0, 0, 0xff}); got != want {
+		t.Errorf("dst1.At(0, 0): got: %v, want: %v", got, want)
+	}
+
+	// Draw the same mesh with different parameters. The converted vertices must not be reused.
+	mesh.SetVertices(0, ebiten.AppendRectVertices(nil, src.Bounds(), nil, nil))
+	op := &ebiten.DrawTrianglesOptions{}
+	op.ColorM.Scale(0.5, 1, 1, 1)
+	dst1.Clear()
+	dst1.DrawMesh(mesh, src, op)
+	if got, want := dst1.At(0, 0), (color.RGBA{0x80, 0, 0, 0xff}); !sameColors(got.(color.RGBA), want, 1) {
+		t.Errorf("dst1.At(0, 0): got: %v, want: %v", got, want)
+	}
+}
+
+func TestImageDrawImageFlip(t *testing.T) {
+	src := ebiten.NewImage(2, 2)
+	src.WritePixels([]byte{
+		0xff, 0, 0, 0xff, 0, 0xff, 0, 0xff,
+		0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	})
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	green := color.RGBA{0, 0xff, 0, 0xff}
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+
+	testCases := []struct {
+		FlipX bool
+		FlipY bool
+		Want  [4]color.RGBA
+	}{
+		{
+			Want: [4]color.RGBA{red, green, blue, white},
+		},
+		{
+			FlipX: true,
+			Want:  [4]color.RGBA{green, red, white, blue},
+		},
+		{
+			FlipY: true,
+			Want:  [4]color.RGBA{blue, white, red, green},
+		},
+		{
+			FlipX: true,
+			FlipY: true,
+			Want:  [4]color.RGBA{white, blue, green, red},
+		},
+	}
+	for _, tc := range testCases {
+		dst := ebiten.NewImage(8, 8)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(2, 2)
+		op.GeoM.Translate(3, 1)
+		op.FlipX = tc.FlipX
+		op.FlipY = tc.FlipY
+		dst.DrawImage(src, op)
+
+		for j := 0; j < 2; j++ {
+			for i := 0; i < 2; i++ {
+				got := dst.At(3+2*i, 1+2*j)
+				want := tc.Want[j*2+i]
+				if got != want {
+					t.Errorf("FlipX: %t, FlipY: %t, dst.At(%d, %d): got: %v, want: %v", tc.FlipX, tc.FlipY, 3+2*i, 1+2*j, got, want)
+				}
+			}
+		}
+		if got, want := dst.At(2, 1), (color.RGBA{}); got != want {
+			t.Errorf("FlipX: %t, FlipY: %t, dst.At(2, 1): got: %v, want: %v", tc.FlipX, tc.FlipY, got, want)
+		}
+	}
+}
+
+func TestImageFlushPingPong(t *testing.T) {
+	const w, h = 16, 16
+	imgs := [2]*ebiten.Image{ebiten.NewImage(w, h), ebiten.NewImage(w, h)}
+	imgs[0].Fill(color.RGBA{R: 0x01, A: 0xff})
+
+	// Accumulate the red value by rendering the images alternately.
+	for i := 0; i < 8; i++ {
+		src, dst := imgs[i%2], imgs[(i+1)%2]
+		dst.Clear()
+		dst.DrawImage(src, nil)
+		op := &ebiten.DrawImageOptions{}
+		op.Blend = ebiten.BlendLighter
+		dst.DrawImage(src, op)
+		if i%3 == 0 {
+			ebiten.Flush()
+		}
+	}
+
+	got := imgs[0].At(0, 0).(color.RGBA)
+	want := color.RGBA{R: 0xff, A: 0xff}
+	if got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestImageDepth(t *testing.T) {
+	const w, h = 16, 16
+	dst := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+		Depth: true,
+	})
+
+	red := ebiten.NewImage(w, h)
+	red.Fill(color.RGBA{R: 0xff, A: 0xff})
+	green := ebiten.NewImage(w/2, h)
+	green.Fill(color.RGBA{G: 0xff, A: 0xff})
+
+	// Render the nearer red image first, and then the farther green image.
+	op := &ebiten.DrawImageOptions{}
+	op.DepthValue = 0.75
+	op.DepthTest = true
+	op.DepthWrite = true
+	dst.DrawImage(red, op)
+
+	op = &ebiten.DrawImageOptions{}
+	op.DepthValue = 0.25
+	op.DepthTest = true
+	op.DepthWrite = true
+	dst.DrawImage(green, op)
+
+	// The green image is nearer than the depth buffer's initial value, but farther than the red image.
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{R: 0xff, A: 0xff}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// After clearing the depth buffer, the green image passes the depth test.
+	dst.ClearDepth()
+	dst.DrawImage(green, op)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{R: 0xff, A: 0xff}
+			if i < w/2 {
+				want = color.RGBA{G: 0xff, A: 0xff}
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// Without the depth test, the image is rendered regardless of the depth buffer.
+	blue := ebiten.NewImage(w, h)
+	blue.Fill(color.RGBA{B: 0xff, A: 0xff})
+	op = &ebiten.DrawImageOptions{}
+	op.DepthValue = 0
+	dst.DrawImage(blue, op)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{B: 0xff, A: 0xff}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageDepthColorM(t *testing.T) {
+	const w, h = 16, 16
+	dst := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+		Depth: true,
+	})
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.White)
+
+	// A scale-only ColorM is applied.
+	op := &ebiten.DrawImageOptions{}
+	op.ColorM.Scale(1, 0, 0, 1)
+	op.DepthTest = true
+	dst.DrawImage(src, op)
+	if got, want := dst.At(0, 0).(color.RGBA), (color.RGBA{R: 0xff, A: 0xff}); got != want {
+		t.Errorf("dst.At(0, 0): got: %v, want: %v", got, want)
+	}
+
+	// The other ColorM is not available.
+	defer func() {
+		if recover() == nil {
+			t.Errorf("DrawImage with a non-scale ColorM and the depth test must panic")
+		}
+	}()
+	op = &ebiten.DrawImageOptions{}
+	op.ColorM.Translate(0, 0, 1, 0)
+	op.DepthTest = true
+	dst.DrawImage(src, op)
+}
+
+func TestImageSetManyAndDraw(t *testing.T) {
+	const w, h = 64, 64
+
+	for _, rect := range []bool{false, true} {
+		src := ebiten.NewImage(w, h)
+		clr := func(i, j int) color.RGBA {
+			if !rect && (i+j)%3 == 0 {
+				return color.RGBA{}
+			}
+			return color.RGBA{byte(i), byte(j), byte(i + j), 0xff}
+		}
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				if c := clr(i, j); c != (color.RGBA{}) {
+					src.Set(i, j, c)
+				}
+			}
+		}
+
+		dst := ebiten.NewImage(w, h)
+		dst.DrawImage(src, nil)
+
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				got := dst.At(i, j).(color.RGBA)
+				want := clr(i, j)
+				if got != want {
+					t.Errorf("rect: %t, dst.At(%d, %d): got: %v, want: %v", rect, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestImageDrawOverStandardImage(t *testing.T) {
+	const w, h = 16, 16
+	dst := ebiten.NewImage(w, h)
+	dst.Fill(color.RGBA{0, 0, 0xff, 0xff})
+
+	src := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for j := 0; j < 4; j++ {
+		for i := 0; i < 4; i++ {
+			if i < 2 {
+				src.SetRGBA(i, j, color.RGBA{0xff, 0, 0, 0xff})
+			}
+		}
+	}
+	// The right half of src is transparent, and the part outside of dst is ignored.
+	dst.DrawOver(src, image.Pt(w-2, 4))
+	dst.DrawOver(src, image.Pt(1, 2))
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{0, 0, 0xff, 0xff}
+			if (1 <= i && i < 3 && 2 <= j && j < 6) || (w-2 <= i && 4 <= j && j < 8) {
+				want = color.RGBA{0xff, 0, 0, 0xff}
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// An opaque image is uploaded directly.
+	opaque := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for j := 0; j < 2; j++ {
+		for i := 0; i < 2; i++ {
+			opaque.SetRGBA(i, j, color.RGBA{0, 0xff, 0, 0xff})
+		}
+	}
+	dst.DrawOver(opaque, image.Pt(0, 0))
+	if got, want := dst.At(1, 1).(color.RGBA), (color.RGBA{0, 0xff, 0, 0xff}); got != want {
+		t.Errorf("dst.At(1, 1): got: %v, want: %v", got, want)
+	}
+}
+
+func TestImageDrawOverItself(t *testing.T) {
+	const w, h = 8, 8
+	dst := ebiten.NewImage(w, h)
+	dst.SubImage(image.Rect(0, 0, w/2, h)).(*ebiten.Image).Fill(color.RGBA{0xff, 0, 0, 0xff})
+
+	// Copy the left half to the right half. The source and the destination are the same image.
+	dst.DrawOver(dst.SubImage(image.Rect(0, 0, w/2, h)), image.Pt(w/2, 0))
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{0xff, 0, 0, 0xff}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
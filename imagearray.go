@@ -0,0 +1,86 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"image"
+)
+
+// ImageArray represents an array of images with the same size, which emulates a texture array.
+//
+// ImageArray doesn't use a native texture array of the graphics library on any platform.
+// The layers of an ImageArray are allocated in one internal texture, stacked vertically.
+// Then, the height multiplied by the number of layers must not exceed the device-dependent maximum size.
+//
+// A shader can read any layer of an ImageArray with imageSrcNLayerAt(pos vec2, layer int) in the pixel-unit mode,
+// where the source image is the 0th layer, i.e. Layer(0).
+// pos is in the 0th layer's coordinate, and the same position in the specified layer is read.
+// If pos is out of the layer, imageSrcNLayerAt returns a transparent color as imageSrcNAt does,
+// so pixels in adjacent layers never bleed.
+// layer must be in [0, LayerCount()). Otherwise, the result is undefined.
+type ImageArray struct {
+	image  *Image
+	layers []*Image
+}
+
+// NewImageArray returns an empty image array with the given number of layers.
+//
+// If width, height, or layers is less than 1, or height multiplied by layers is more than device-dependent maximum size,
+// NewImageArray panics.
+//
+// NewImageArray panics if RunGame already finishes.
+func NewImageArray(width, height, layers int) *ImageArray {
+	if layers <= 0 {
+		panic(fmt.Sprintf("ebiten: layers at NewImageArray must be positive but %d", layers))
+	}
+	if height <= 0 {
+		panic(fmt.Sprintf("ebiten: height at NewImageArray must be positive but %d", height))
+	}
+
+	a := &ImageArray{
+		image:  NewImage(width, height*layers),
+		layers: make([]*Image, layers),
+	}
+	for i := range a.layers {
+		a.layers[i] = a.image.SubImage(image.Rect(0, height*i, width, height*(i+1))).(*Image)
+	}
+	return a
+}
+
+// Layer returns the image of the specified layer.
+//
+// The returned image can be used as a regular image, e.g. as a render target or a render source.
+// The layer's bounds don't start from (0, 0) except for the 0th layer.
+//
+// If index is out of range, Layer panics.
+func (a *ImageArray) Layer(index int) *Image {
+	if index < 0 || index >= len(a.layers) {
+		panic(fmt.Sprintf("ebiten: index at Layer is out of range: %d", index))
+	}
+	return a.layers[index]
+}
+
+// LayerCount returns the number of the layers.
+func (a *ImageArray) LayerCount() int {
+	return len(a.layers)
+}
+
+// Deallocate clears all the layers and deallocates the internal state.
+//
+// See also (*Image).Deallocate.
+func (a *ImageArray) Deallocate() {
+	a.image.Deallocate()
+}
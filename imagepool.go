@@ -0,0 +1,127 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ImagePool is a pool of images of a fixed size.
+//
+// ImagePool is useful for transient render targets like particle trails and ping-pong effects,
+// which would create and dispose many images of the same size otherwise.
+// Recycling images avoids repeated allocations of GPU textures.
+//
+// The lifetime rules of images in a pool are:
+//
+//   - An image returned by Get is owned by the caller until the image is given to Put.
+//   - After an image is given to Put, the image must not be used, including as a render source of pending draw calls
+//     made by the caller later. Draw calls made before Put are fine.
+//   - An image given to Put is cleared lazily when the image is returned by Get again.
+//   - Images in the pool are kept until Deallocate is called.
+//
+// ImagePool is concurrent-safe.
+type ImagePool struct {
+	width  int
+	height int
+
+	images []*Image
+	m      sync.Mutex
+}
+
+// NewImagePool creates a new pool of images with the given size.
+//
+// If width or height is less than 1, NewImagePool panics.
+func NewImagePool(width, height int) *ImagePool {
+	if width <= 0 {
+		panic(fmt.Sprintf("ebiten: width at NewImagePool must be positive but %d", width))
+	}
+	if height <= 0 {
+		panic(fmt.Sprintf("ebiten: height at NewImagePool must be positive but %d", height))
+	}
+	return &ImagePool{
+		width:  width,
+		height: height,
+	}
+}
+
+// Size returns the size of images in the pool.
+func (p *ImagePool) Size() (width, height int) {
+	return p.width, p.height
+}
+
+// Get returns a cleared image from the pool.
+// If the pool is empty, Get creates a new image.
+//
+// Get is concurrent-safe.
+func (p *ImagePool) Get() *Image {
+	p.m.Lock()
+	var img *Image
+	if n := len(p.images); n > 0 {
+		img = p.images[n-1]
+		p.images[n-1] = nil
+		p.images = p.images[:n-1]
+	}
+	p.m.Unlock()
+
+	if img == nil {
+		return NewImage(p.width, p.height)
+	}
+	img.Clear()
+	return img
+}
+
+// Put returns an image to the pool so that the image is recycled at a later Get.
+//
+// If img is nil, disposed or already in the pool, Put does nothing.
+// If img is a sub-image or its size doesn't match with the pool, Put panics.
+//
+// Put is concurrent-safe.
+func (p *ImagePool) Put(img *Image) {
+	if img == nil || img.isDisposed() {
+		return
+	}
+	if img.isSubImage() {
+		panic("ebiten: a sub-image cannot be put into an ImagePool")
+	}
+	if s := img.Bounds().Size(); s.X != p.width || s.Y != p.height {
+		panic(fmt.Sprintf("ebiten: the image size (%d, %d) doesn't match with the ImagePool size (%d, %d)", s.X, s.Y, p.width, p.height))
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+	for _, i := range p.images {
+		if i == img {
+			return
+		}
+	}
+	p.images = append(p.images, img)
+}
+
+// Deallocate deallocates all the images in the pool.
+// Images that are not returned to the pool by Put are not affected.
+//
+// Deallocate is concurrent-safe.
+func (p *ImagePool) Deallocate() {
+	p.m.Lock()
+	images := p.images
+	p.images = nil
+	p.m.Unlock()
+
+	for _, img := range images {
+		img.Deallocate()
+	}
+}
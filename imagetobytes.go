@@ -75,6 +75,28 @@ func imageToBytes(img image.Image) []byte {
 	}
 }
 
+// imageToBytesInRegion returns RGBA byte slice of the region of the given image.
+func imageToBytesInRegion(img image.Image, region image.Rectangle) []byte {
+	if region == img.Bounds() {
+		return imageToBytes(img)
+	}
+	if img, ok := img.(interface {
+		SubImage(r image.Rectangle) image.Image
+	}); ok {
+		return imageToBytes(img.SubImage(region))
+	}
+
+	w, h := region.Dx(), region.Dy()
+	bs := make([]byte, 4*w*h)
+	dstImg := &image.RGBA{
+		Pix:    bs,
+		Stride: 4 * w,
+		Rect:   image.Rect(0, 0, w, h),
+	}
+	draw.Draw(dstImg, dstImg.Rect, img, region.Min, draw.Src)
+	return bs
+}
+
 func imageToBytesSlow(img image.Image) []byte {
 	size := img.Bounds().Size()
 	w, h := size.X, size.Y
@@ -16,6 +16,7 @@ package ebiten
 
 import (
 	"io/fs"
+	"math"
 	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
@@ -87,6 +88,17 @@ func CursorPosition() (x, y int) {
 	return int(cx), int(cy)
 }
 
+// CursorPositionF returns a position of a mouse cursor relative to the game screen (window) in float values.
+//
+// CursorPositionF is the float version of CursorPosition.
+// The position is converted from the native position with the same scale and offsets as the final screen rendering.
+// This is useful when the game implements LayoutFer and the logical screen size is not an integer.
+//
+// CursorPositionF is concurrent-safe.
+func CursorPositionF() (x, y float64) {
+	return theInputState.cursorPosition()
+}
+
 // Wheel returns x and y offsets of the mouse wheel or touchpad scroll.
 // It returns 0 if the wheel isn't being rolled.
 //
@@ -122,6 +134,65 @@ func GamepadSDLID(id GamepadID) string {
 	return g.SDLID()
 }
 
+// GamepadTransport represents how a gamepad is connected.
+type GamepadTransport int
+
+const (
+	GamepadTransportUnknown   GamepadTransport = GamepadTransport(gamepad.TransportUnknown)
+	GamepadTransportUSB       GamepadTransport = GamepadTransport(gamepad.TransportUSB)
+	GamepadTransportBluetooth GamepadTransport = GamepadTransport(gamepad.TransportBluetooth)
+)
+
+// GamepadDeviceInfoType represents the hardware identifiers of a gamepad, which GamepadDeviceInfo returns.
+//
+// A zero value of each field means the value is unknown on the platform.
+type GamepadDeviceInfoType struct {
+	// VendorID is the USB vendor ID.
+	VendorID uint16
+
+	// ProductID is the USB product ID.
+	ProductID uint16
+
+	// ProductVersion is the product version.
+	ProductVersion uint16
+
+	// Transport is how the gamepad is connected.
+	Transport GamepadTransport
+}
+
+// GamepadDeviceInfo returns the hardware identifiers of the gamepad.
+//
+// The identifiers come from the device itself, so they are stable across reconnects for the same physical device.
+// Note that they identify the model rather than an individual device, i.e. two controllers of the same model have
+// the same identifiers.
+// This is useful to choose button glyphs for the controller, e.g. Xbox, PlayStation, or Nintendo Switch controllers.
+//
+// The available fields depend on the platform:
+//
+//   - Linux: all the fields
+//   - macOS: all the fields
+//   - Windows: VendorID and ProductID, only for DirectInput devices. No fields are available for XInput devices.
+//   - Browsers: VendorID and ProductID, when the browser exposes them in the gamepad's id string
+//   - Android: VendorID and ProductID
+//   - iOS and other platforms: no fields
+//
+// GamepadDeviceInfo returns a zero value if the gamepad is not found.
+//
+// GamepadDeviceInfo is concurrent-safe.
+func GamepadDeviceInfo(id GamepadID) GamepadDeviceInfoType {
+	g := gamepad.Get(id)
+	if g == nil {
+		return GamepadDeviceInfoType{}
+	}
+	info := g.DeviceInfo()
+	return GamepadDeviceInfoType{
+		VendorID:       info.VendorID,
+		ProductID:      info.ProductID,
+		ProductVersion: info.ProductVersion,
+		Transport:      GamepadTransport(info.Transport),
+	}
+}
+
 // GamepadName returns a string with the name.
 // This function may vary in how it returns descriptions for the same device across platforms.
 // for example the following drivers/platforms see an Xbox One controller as the following:
@@ -144,6 +215,9 @@ func GamepadName(id GamepadID) string {
 //
 // AppendGamepadIDs is concurrent-safe.
 func AppendGamepadIDs(gamepadIDs []GamepadID) []GamepadID {
+	if ids, ok := theInputState.appendInjectedGamepadIDs(gamepadIDs); ok {
+		return ids
+	}
 	return gamepad.AppendGamepadIDs(gamepadIDs)
 }
 
@@ -158,6 +232,10 @@ func GamepadIDs() []GamepadID {
 //
 // GamepadAxisCount is concurrent-safe.
 func GamepadAxisCount(id GamepadID) int {
+	if g, ok := theInputState.injectedGamepad(id); ok {
+		return g.axisCount()
+	}
+
 	g := gamepad.Get(id)
 	if g == nil {
 		return 0
@@ -176,6 +254,10 @@ func GamepadAxisNum(id GamepadID) int {
 //
 // GamepadAxisValue is concurrent-safe.
 func GamepadAxisValue(id GamepadID, axis GamepadAxisType) float64 {
+	if g, ok := theInputState.injectedGamepad(id); ok {
+		return g.axisValue(axis)
+	}
+
 	g := gamepad.Get(id)
 	if g == nil {
 		return 0
@@ -194,11 +276,18 @@ func GamepadAxis(id GamepadID, axis GamepadAxisType) float64 {
 //
 // GamepadButtonCount is concurrent-safe.
 func GamepadButtonCount(id GamepadID) int {
+	if g, ok := theInputState.injectedGamepad(id); ok {
+		return g.buttonCount()
+	}
+
 	g := gamepad.Get(id)
 	if g == nil {
 		return 0
 	}
+	return gamepadButtonCount(g)
+}
 
+func gamepadButtonCount(g *gamepad.Gamepad) int {
 	// For backward compatibility, hats are treated as buttons in GLFW.
 	return g.ButtonCount() + g.HatCount()*4
 }
@@ -220,11 +309,18 @@ func GamepadButtonNum(id GamepadID) int {
 // The relationships between physical buttons and button IDs depend on environments.
 // There can be differences even between Chrome and Firefox.
 func IsGamepadButtonPressed(id GamepadID, button GamepadButton) bool {
+	if g, ok := theInputState.injectedGamepad(id); ok {
+		return g.isButtonPressed(button)
+	}
+
 	g := gamepad.Get(id)
 	if g == nil {
 		return false
 	}
+	return isGamepadButtonPressed(g, button)
+}
 
+func isGamepadButtonPressed(g *gamepad.Gamepad, button GamepadButton) bool {
 	nbuttons := g.ButtonCount()
 	if int(button) < nbuttons {
 		return g.Button(int(button))
@@ -245,6 +341,10 @@ func IsGamepadButtonPressed(id GamepadID, button GamepadButton) bool {
 //
 // StandardGamepadAxisValue is concurrent safe.
 func StandardGamepadAxisValue(id GamepadID, axis StandardGamepadAxis) float64 {
+	if g, ok := theInputState.injectedGamepad(id); ok {
+		return g.standardAxisValue(axis)
+	}
+
 	g := gamepad.Get(id)
 	if g == nil {
 		return 0
@@ -258,6 +358,10 @@ func StandardGamepadAxisValue(id GamepadID, axis StandardGamepadAxis) float64 {
 //
 // StandardGamepadButtonValue is concurrent safe.
 func StandardGamepadButtonValue(id GamepadID, button StandardGamepadButton) float64 {
+	if g, ok := theInputState.injectedGamepad(id); ok {
+		return g.standardButtonValue(button)
+	}
+
 	g := gamepad.Get(id)
 	if g == nil {
 		return 0
@@ -271,6 +375,10 @@ func StandardGamepadButtonValue(id GamepadID, button StandardGamepadButton) floa
 //
 // IsStandardGamepadButtonPressed is concurrent safe.
 func IsStandardGamepadButtonPressed(id GamepadID, button StandardGamepadButton) bool {
+	if g, ok := theInputState.injectedGamepad(id); ok {
+		return g.isStandardButtonPressed(button)
+	}
+
 	g := gamepad.Get(id)
 	if g == nil {
 		return false
@@ -282,6 +390,10 @@ func IsStandardGamepadButtonPressed(id GamepadID, button StandardGamepadButton)
 //
 // IsStandardGamepadLayoutAvailable is concurrent-safe.
 func IsStandardGamepadLayoutAvailable(id GamepadID) bool {
+	if g, ok := theInputState.injectedGamepad(id); ok {
+		return g.isStandardLayoutAvailable()
+	}
+
 	g := gamepad.Get(id)
 	if g == nil {
 		return false
@@ -293,6 +405,10 @@ func IsStandardGamepadLayoutAvailable(id GamepadID) bool {
 //
 // IsStandardGamepadAxisAvailable is concurrent-safe.
 func IsStandardGamepadAxisAvailable(id GamepadID, axis StandardGamepadAxis) bool {
+	if g, ok := theInputState.injectedGamepad(id); ok {
+		return g.isStandardLayoutAvailable()
+	}
+
 	g := gamepad.Get(id)
 	if g == nil {
 		return false
@@ -304,6 +420,10 @@ func IsStandardGamepadAxisAvailable(id GamepadID, axis StandardGamepadAxis) bool
 //
 // IsStandardGamepadButtonAvailable is concurrent-safe.
 func IsStandardGamepadButtonAvailable(id GamepadID, button StandardGamepadButton) bool {
+	if g, ok := theInputState.injectedGamepad(id); ok {
+		return g.isStandardLayoutAvailable()
+	}
+
 	g := gamepad.Get(id)
 	if g == nil {
 		return false
@@ -379,14 +499,105 @@ func TouchIDs() []TouchID {
 //
 // TouchPosition is concurrent-safe.
 func TouchPosition(id TouchID) (int, int) {
+	x, y := theInputState.touchPosition(id)
+	return int(x), int(y)
+}
+
+// TouchPositionF returns the position for the touch of the specified ID in float values.
+//
+// TouchPositionF is the float version of TouchPosition.
+// The position is converted from the native position with the same scale and offsets as the final screen rendering.
+//
+// If the touch of the specified ID is not present, TouchPositionF returns (0, 0).
+//
+// TouchPositionF is concurrent-safe.
+func TouchPositionF(id TouchID) (float64, float64) {
 	return theInputState.touchPosition(id)
 }
 
+// TouchPressure returns the pressure of the touch of the specified ID in [0, 1].
+//
+// The pressure is reported on platforms with pressure-sensitive touches or styluses,
+// e.g. MotionEvent's pressure on Android, 3D Touch or Apple Pencil on iOS, and PointerEvent's pressure on browsers.
+// If the pressure is not available for the touch, TouchPressure returns 1. Use IsTouchPressureAvailable to check this.
+//
+// If the touch of the specified ID is not present, TouchPressure returns 0.
+//
+// TouchPressure is concurrent-safe.
+func TouchPressure(id TouchID) float64 {
+	d, ok := theInputState.touchDetails(id)
+	if !ok {
+		return 0
+	}
+	if !d.PressureAvailable {
+		return 1
+	}
+	return d.Pressure
+}
+
+// IsTouchPressureAvailable reports whether the pressure of the touch of the specified ID is available.
+//
+// If the touch of the specified ID is not present, IsTouchPressureAvailable returns false.
+//
+// IsTouchPressureAvailable is concurrent-safe.
+func IsTouchPressureAvailable(id TouchID) bool {
+	d, _ := theInputState.touchDetails(id)
+	return d.PressureAvailable
+}
+
+// IsTouchStylus reports whether the touch of the specified ID is by a stylus.
+//
+// If the platform cannot distinguish a stylus from a finger, IsTouchStylus returns false.
+// If the touch of the specified ID is not present, IsTouchStylus returns false.
+//
+// IsTouchStylus is concurrent-safe.
+func IsTouchStylus(id TouchID) bool {
+	d, _ := theInputState.touchDetails(id)
+	return d.Stylus
+}
+
+// TouchTilt returns the tilt of the stylus of the touch of the specified ID in radians.
+//
+// altitude is the angle between the stylus and the screen surface. π/2 means the stylus is perpendicular to the surface.
+// azimuth is the direction of the stylus projected on the screen surface, from the positive X axis in clockwise.
+//
+// If the tilt is not available for the touch, TouchTilt returns (π/2, 0). Use IsTouchTiltAvailable to check this.
+// If the touch of the specified ID is not present, TouchTilt returns (π/2, 0).
+//
+// TouchTilt is concurrent-safe.
+func TouchTilt(id TouchID) (altitude, azimuth float64) {
+	d, _ := theInputState.touchDetails(id)
+	if !d.TiltAvailable {
+		return math.Pi / 2, 0
+	}
+	return d.Altitude, d.Azimuth
+}
+
+// IsTouchTiltAvailable reports whether the tilt of the touch of the specified ID is available.
+//
+// The tilt is available only for a stylus on platforms that report it, e.g. Android, Apple Pencil on iOS, and
+// a pen reporting PointerEvent's tiltX and tiltY on browsers.
+// If the touch of the specified ID is not present, IsTouchTiltAvailable returns false.
+//
+// IsTouchTiltAvailable is concurrent-safe.
+func IsTouchTiltAvailable(id TouchID) bool {
+	d, _ := theInputState.touchDetails(id)
+	return d.TiltAvailable
+}
+
 var theInputState inputState
 
 type inputState struct {
 	state ui.InputState
-	m     sync.Mutex
+
+	// gamepads is the gamepads injected by SetInputInjector.
+	// gamepads is valid only when gamepadsInjected is true.
+	gamepads         []InputSnapshotGamepad
+	gamepadsInjected bool
+
+	gamepadIDsBuf []GamepadID
+
+	m sync.Mutex
 }
 
 func (i *inputState) update(fn func(*ui.InputState)) {
@@ -395,6 +606,37 @@ func (i *inputState) update(fn func(*ui.InputState)) {
 	fn(&i.state)
 }
 
+// injectedGamepad returns the gamepad injected by SetInputInjector.
+// injected is false if no gamepads are injected at this tick.
+// g is nil if the gamepad is not among the injected gamepads.
+func (i *inputState) injectedGamepad(id GamepadID) (g *InputSnapshotGamepad, injected bool) {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if !i.gamepadsInjected {
+		return nil, false
+	}
+	for j := range i.gamepads {
+		if i.gamepads[j].ID == id {
+			return &i.gamepads[j], true
+		}
+	}
+	return nil, true
+}
+
+func (i *inputState) appendInjectedGamepadIDs(gamepadIDs []GamepadID) ([]GamepadID, bool) {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if !i.gamepadsInjected {
+		return gamepadIDs, false
+	}
+	for _, g := range i.gamepads {
+		gamepadIDs = append(gamepadIDs, g.ID)
+	}
+	return gamepadIDs, true
+}
+
 func (i *inputState) appendInputChars(runes []rune) []rune {
 	i.m.Lock()
 	defer i.m.Unlock()
@@ -451,7 +693,7 @@ func (i *inputState) appendTouchIDs(touches []TouchID) []TouchID {
 	return touches
 }
 
-func (i *inputState) touchPosition(id TouchID) (int, int) {
+func (i *inputState) touchPosition(id TouchID) (float64, float64) {
 	i.m.Lock()
 	defer i.m.Unlock()
 
@@ -464,6 +706,19 @@ func (i *inputState) touchPosition(id TouchID) (int, int) {
 	return 0, 0
 }
 
+func (i *inputState) touchDetails(id TouchID) (ui.TouchDetails, bool) {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	for _, t := range i.state.Touches {
+		if id != t.ID {
+			continue
+		}
+		return t.TouchDetails, true
+	}
+	return ui.TouchDetails{}, false
+}
+
 func (i *inputState) windowBeingClosed() bool {
 	i.m.Lock()
 	defer i.m.Unlock()
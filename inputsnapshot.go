@@ -0,0 +1,364 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+var theTick atomic.Int64
+
+// Tick returns the current tick count, which is the number of the game's Update calls that have finished.
+//
+// Tick is 0 in the first Update, and is incremented by one after each Update.
+// Tick never depends on the wall clock, so a game using Tick as its time is deterministic.
+// This is useful for replays and rollback netcode, which requires Update to be a pure function of the game state and the inputs.
+//
+// Tick is concurrent-safe.
+func Tick() int64 {
+	return theTick.Load()
+}
+
+// InputSnapshotTouch represents a touch in an InputSnapshot.
+type InputSnapshotTouch struct {
+	ID TouchID
+	X  float64
+	Y  float64
+
+	// Pressure is the pressure in [0, 1]. Pressure is valid only when PressureAvailable is true.
+	// See also TouchPressure.
+	Pressure          float64
+	PressureAvailable bool
+
+	// Stylus represents whether the touch is by a stylus. See also IsTouchStylus.
+	Stylus bool
+
+	// Altitude and Azimuth represent the tilt of the stylus in radians.
+	// Altitude and Azimuth are valid only when TiltAvailable is true.
+	// See also TouchTilt.
+	Altitude      float64
+	Azimuth       float64
+	TiltAvailable bool
+}
+
+// InputSnapshotGamepad represents a gamepad in an InputSnapshot.
+type InputSnapshotGamepad struct {
+	ID GamepadID
+
+	// Axes represents the values of the axes. See also GamepadAxisValue.
+	Axes []float64
+
+	// Buttons represents whether each button is pressed. See also IsGamepadButtonPressed.
+	// As with IsGamepadButtonPressed, the hats follow the buttons, and each hat is treated as 4 buttons.
+	Buttons []bool
+
+	// StandardLayoutAvailable represents whether the gamepad has a standard gamepad layout mapping.
+	// See also IsStandardGamepadLayoutAvailable.
+	StandardLayoutAvailable bool
+
+	// StandardAxisValues represents the values of the standard axes. See also StandardGamepadAxisValue.
+	StandardAxisValues [StandardGamepadAxisMax + 1]float64
+
+	// StandardButtonValues represents the values of the standard buttons. See also StandardGamepadButtonValue.
+	StandardButtonValues [StandardGamepadButtonMax + 1]float64
+
+	// StandardButtonPressed represents whether each standard button is pressed. See also IsStandardGamepadButtonPressed.
+	StandardButtonPressed [StandardGamepadButtonMax + 1]bool
+}
+
+func (g *InputSnapshotGamepad) readFrom(id GamepadID, gp *gamepad.Gamepad) {
+	g.ID = id
+	g.Axes = g.Axes[:0]
+	for a := 0; a < gp.AxisCount(); a++ {
+		g.Axes = append(g.Axes, gp.Axis(a))
+	}
+	g.Buttons = g.Buttons[:0]
+	for b := 0; b < gamepadButtonCount(gp); b++ {
+		g.Buttons = append(g.Buttons, isGamepadButtonPressed(gp, GamepadButton(b)))
+	}
+	g.StandardLayoutAvailable = gp.IsStandardLayoutAvailable()
+	for a := StandardGamepadAxis(0); a <= StandardGamepadAxisMax; a++ {
+		g.StandardAxisValues[a] = gp.StandardAxisValue(a)
+	}
+	for b := StandardGamepadButton(0); b <= StandardGamepadButtonMax; b++ {
+		g.StandardButtonValues[b] = gp.StandardButtonValue(b)
+		g.StandardButtonPressed[b] = gp.IsStandardButtonPressed(b)
+	}
+}
+
+// copyFrom copies src to g. The slices in g are reused if possible.
+func (g *InputSnapshotGamepad) copyFrom(src *InputSnapshotGamepad) {
+	axes, buttons := g.Axes[:0], g.Buttons[:0]
+	*g = *src
+	g.Axes = append(axes, src.Axes...)
+	g.Buttons = append(buttons, src.Buttons...)
+}
+
+// The following methods are for an injected gamepad. g can be nil when the gamepad is not connected.
+
+func (g *InputSnapshotGamepad) axisCount() int {
+	if g == nil {
+		return 0
+	}
+	return len(g.Axes)
+}
+
+func (g *InputSnapshotGamepad) axisValue(axis GamepadAxisType) float64 {
+	if g == nil || axis < 0 || axis >= len(g.Axes) {
+		return 0
+	}
+	return g.Axes[axis]
+}
+
+func (g *InputSnapshotGamepad) buttonCount() int {
+	if g == nil {
+		return 0
+	}
+	return len(g.Buttons)
+}
+
+func (g *InputSnapshotGamepad) isButtonPressed(button GamepadButton) bool {
+	if g == nil || button < 0 || int(button) >= len(g.Buttons) {
+		return false
+	}
+	return g.Buttons[button]
+}
+
+func (g *InputSnapshotGamepad) isStandardLayoutAvailable() bool {
+	if g == nil {
+		return false
+	}
+	return g.StandardLayoutAvailable
+}
+
+func (g *InputSnapshotGamepad) standardAxisValue(axis StandardGamepadAxis) float64 {
+	if !g.isStandardLayoutAvailable() || axis < 0 || axis > StandardGamepadAxisMax {
+		return 0
+	}
+	return g.StandardAxisValues[axis]
+}
+
+func (g *InputSnapshotGamepad) standardButtonValue(button StandardGamepadButton) float64 {
+	if !g.isStandardLayoutAvailable() || button < 0 || button > StandardGamepadButtonMax {
+		return 0
+	}
+	return g.StandardButtonValues[button]
+}
+
+func (g *InputSnapshotGamepad) isStandardButtonPressed(button StandardGamepadButton) bool {
+	if !g.isStandardLayoutAvailable() || button < 0 || button > StandardGamepadButtonMax {
+		return false
+	}
+	return g.StandardButtonPressed[button]
+}
+
+// InputSnapshot represents the keyboard, mouse, touch and gamepad input state for one tick.
+type InputSnapshot struct {
+	// KeyPressed represents whether each key is pressed.
+	// The values for the virtual keys KeyAlt, KeyControl, KeyShift and KeyMeta are ignored.
+	// Use the left and right keys like KeyAltLeft instead.
+	KeyPressed [KeyMax + 1]bool
+
+	// MouseButtonPressed represents whether each mouse button is pressed.
+	MouseButtonPressed [MouseButtonMax + 1]bool
+
+	// CursorX and CursorY represent the cursor position.
+	CursorX float64
+	CursorY float64
+
+	// WheelX and WheelY represent the wheel offsets.
+	WheelX float64
+	WheelY float64
+
+	// Touches represents the current touches.
+	Touches []InputSnapshotTouch
+
+	// Runes represents the input characters.
+	Runes []rune
+
+	// Gamepads represents the connected gamepads.
+	// The names and the device information of the gamepads are not included.
+	Gamepads []InputSnapshotGamepad
+}
+
+// appendGamepad extends s.Gamepads by one and returns the last gamepad.
+// The slices in the returned gamepad might be reused.
+func (s *InputSnapshot) appendGamepad() *InputSnapshotGamepad {
+	if len(s.Gamepads) < cap(s.Gamepads) {
+		s.Gamepads = s.Gamepads[:len(s.Gamepads)+1]
+	} else {
+		s.Gamepads = append(s.Gamepads, InputSnapshotGamepad{})
+	}
+	return &s.Gamepads[len(s.Gamepads)-1]
+}
+
+// readFrom reads the input state into s.
+// inputState's mutex must be locked.
+func (s *InputSnapshot) readFrom(inputState *inputState) {
+	state := &inputState.state
+	copy(s.KeyPressed[:], state.KeyPressed[:])
+	copy(s.MouseButtonPressed[:], state.MouseButtonPressed[:])
+	s.CursorX = state.CursorX
+	s.CursorY = state.CursorY
+	s.WheelX = state.WheelX
+	s.WheelY = state.WheelY
+	s.Touches = s.Touches[:0]
+	for _, t := range state.Touches {
+		s.Touches = append(s.Touches, InputSnapshotTouch{
+			ID:                t.ID,
+			X:                 t.X,
+			Y:                 t.Y,
+			Pressure:          t.Pressure,
+			PressureAvailable: t.PressureAvailable,
+			Stylus:            t.Stylus,
+			Altitude:          t.Altitude,
+			Azimuth:           t.Azimuth,
+			TiltAvailable:     t.TiltAvailable,
+		})
+	}
+	s.Runes = append(s.Runes[:0], state.Runes...)
+
+	s.Gamepads = s.Gamepads[:0]
+	if inputState.gamepadsInjected {
+		for i := range inputState.gamepads {
+			s.appendGamepad().copyFrom(&inputState.gamepads[i])
+		}
+		return
+	}
+	inputState.gamepadIDsBuf = gamepad.AppendGamepadIDs(inputState.gamepadIDsBuf[:0])
+	for _, id := range inputState.gamepadIDsBuf {
+		gp := gamepad.Get(id)
+		if gp == nil {
+			continue
+		}
+		s.appendGamepad().readFrom(id, gp)
+	}
+}
+
+// writeTo writes s to the input state.
+// inputState's mutex must be locked.
+func (s *InputSnapshot) writeTo(inputState *inputState) {
+	state := &inputState.state
+	copy(state.KeyPressed[:], s.KeyPressed[:])
+	for _, k := range []Key{KeyAlt, KeyControl, KeyShift, KeyMeta} {
+		state.KeyPressed[k] = false
+	}
+	copy(state.MouseButtonPressed[:], s.MouseButtonPressed[:])
+	state.CursorX = s.CursorX
+	state.CursorY = s.CursorY
+	state.WheelX = s.WheelX
+	state.WheelY = s.WheelY
+	state.Touches = state.Touches[:0]
+	for _, t := range s.Touches {
+		state.Touches = append(state.Touches, ui.Touch{
+			ID: t.ID,
+			X:  t.X,
+			Y:  t.Y,
+			TouchDetails: ui.TouchDetails{
+				Pressure:          t.Pressure,
+				PressureAvailable: t.PressureAvailable,
+				Stylus:            t.Stylus,
+				Altitude:          t.Altitude,
+				Azimuth:           t.Azimuth,
+				TiltAvailable:     t.TiltAvailable,
+			},
+		})
+	}
+	state.Runes = append(state.Runes[:0], s.Runes...)
+
+	// The injected gamepads are never modified after being set, so that the gamepad functions can read them without copying.
+	gamepads := make([]InputSnapshotGamepad, len(s.Gamepads))
+	for i := range s.Gamepads {
+		gamepads[i].copyFrom(&s.Gamepads[i])
+	}
+	inputState.gamepads = gamepads
+	inputState.gamepadsInjected = true
+}
+
+// ReadInputSnapshot reads the input state for the current tick into snapshot.
+// The slices in snapshot are reused if possible.
+//
+// ReadInputSnapshot is useful to record the inputs for replays and rollback netcode.
+// If an injector is set by SetInputInjector, ReadInputSnapshot returns the injected input state.
+//
+// ReadInputSnapshot must be called in a game's Update, not Draw.
+//
+// ReadInputSnapshot is concurrent-safe.
+func ReadInputSnapshot(snapshot *InputSnapshot) {
+	theInputState.m.Lock()
+	defer theInputState.m.Unlock()
+	snapshot.readFrom(&theInputState)
+}
+
+// SetInputInjector sets a function to replace the input state for each tick.
+//
+// injector is called before each Update with the tick count that the Update will have, and the input state from the devices.
+// The input state modified by injector is used as the input state for the tick,
+// e.g. by IsKeyPressed, CursorPosition and IsGamepadButtonPressed, and by inpututil functions.
+// While an injector is set, the gamepad functions use the injected gamepads, except for
+// GamepadSDLID, GamepadName, GamepadDeviceInfo and VibrateGamepad, which use the devices.
+// IsStandardGamepadAxisAvailable and IsStandardGamepadButtonAvailable report whether the injected gamepad has a standard layout.
+// This is useful to replay recorded inputs, or to inject remote players' inputs for lockstep or rollback netcode.
+//
+// If injector is nil, the injector is unset.
+//
+// SetInputInjector is concurrent-safe.
+func SetInputInjector(injector func(tick int64, snapshot *InputSnapshot)) {
+	theInputInjector.set(injector)
+}
+
+type inputInjector struct {
+	injector func(tick int64, snapshot *InputSnapshot)
+	snapshot InputSnapshot
+	m        sync.Mutex
+}
+
+var theInputInjector inputInjector
+
+func (i *inputInjector) set(injector func(tick int64, snapshot *InputSnapshot)) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	i.injector = injector
+}
+
+func (i *inputInjector) inject(inputState *inputState) {
+	i.m.Lock()
+	injector := i.injector
+	i.m.Unlock()
+
+	inputState.m.Lock()
+	// Discard the gamepads injected at the previous tick.
+	inputState.gamepads = nil
+	inputState.gamepadsInjected = false
+	if injector != nil {
+		i.snapshot.readFrom(inputState)
+	}
+	inputState.m.Unlock()
+
+	if injector == nil {
+		return
+	}
+
+	// Call the injector without locking the input state so that the injector can call input functions.
+	injector(Tick(), &i.snapshot)
+
+	inputState.m.Lock()
+	defer inputState.m.Unlock()
+	i.snapshot.writeTo(inputState)
+}
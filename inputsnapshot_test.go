@@ -0,0 +1,164 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestInputSnapshotTouchDetails(t *testing.T) {
+	s := &ebiten.InputSnapshot{
+		Touches: []ebiten.InputSnapshotTouch{
+			{
+				ID: 1,
+				X:  10,
+				Y:  20,
+			},
+			{
+				ID:                2,
+				X:                 30,
+				Y:                 40,
+				Pressure:          0.25,
+				PressureAvailable: true,
+				Stylus:            true,
+				Altitude:          0.5,
+				Azimuth:           1.5,
+				TiltAvailable:     true,
+			},
+		},
+	}
+	got := ebiten.RoundTripInputSnapshotForTesting(s)
+	if !reflect.DeepEqual(got.Touches, s.Touches) {
+		t.Errorf("got: %v, want: %v", got.Touches, s.Touches)
+	}
+}
+
+func TestInputSnapshotGamepads(t *testing.T) {
+	g := ebiten.InputSnapshotGamepad{
+		ID:                      3,
+		Axes:                    []float64{0.5, -1},
+		Buttons:                 []bool{false, true, false},
+		StandardLayoutAvailable: true,
+	}
+	g.StandardAxisValues[ebiten.StandardGamepadAxisLeftStickHorizontal] = -0.25
+	g.StandardButtonValues[ebiten.StandardGamepadButtonFrontBottomRight] = 0.75
+	g.StandardButtonPressed[ebiten.StandardGamepadButtonFrontBottomRight] = true
+
+	s := &ebiten.InputSnapshot{
+		Gamepads: []ebiten.InputSnapshotGamepad{
+			g,
+			{
+				ID: 5,
+			},
+		},
+	}
+	got := ebiten.RoundTripInputSnapshotForTesting(s)
+	if !reflect.DeepEqual(got.Gamepads, s.Gamepads) {
+		t.Errorf("got: %v, want: %v", got.Gamepads, s.Gamepads)
+	}
+}
+
+type emptyGame struct{}
+
+func (*emptyGame) Update() error {
+	return nil
+}
+
+func (*emptyGame) Draw(screen *ebiten.Image) {
+}
+
+func (*emptyGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+func TestInputInjectorGamepads(t *testing.T) {
+	g, err := ebiten.NewGameForUIForTesting(&emptyGame{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+
+	const id ebiten.GamepadID = 100
+	var gotTick int64 = -1
+	ebiten.SetInputInjector(func(tick int64, snapshot *ebiten.InputSnapshot) {
+		gotTick = tick
+		gamepad := ebiten.InputSnapshotGamepad{
+			ID:                      id,
+			Axes:                    []float64{0.5},
+			Buttons:                 []bool{false, true},
+			StandardLayoutAvailable: true,
+		}
+		gamepad.StandardAxisValues[ebiten.StandardGamepadAxisLeftStickVertical] = -1
+		gamepad.StandardButtonValues[ebiten.StandardGamepadButtonFrontBottomLeft] = 1
+		gamepad.StandardButtonPressed[ebiten.StandardGamepadButtonFrontBottomLeft] = true
+		snapshot.Gamepads = append(snapshot.Gamepads[:0], gamepad)
+	})
+	defer ebiten.SetInputInjector(nil)
+
+	g.UpdateInputState()
+	if got, want := gotTick, ebiten.Tick(); got != want {
+		t.Errorf("tick: got: %d, want: %d", got, want)
+	}
+
+	if got, want := ebiten.AppendGamepadIDs(nil), []ebiten.GamepadID{id}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AppendGamepadIDs: got: %v, want: %v", got, want)
+	}
+	if got, want := ebiten.GamepadAxisCount(id), 1; got != want {
+		t.Errorf("GamepadAxisCount: got: %d, want: %d", got, want)
+	}
+	if got, want := ebiten.GamepadAxisValue(id, 0), 0.5; got != want {
+		t.Errorf("GamepadAxisValue: got: %f, want: %f", got, want)
+	}
+	if got, want := ebiten.GamepadButtonCount(id), 2; got != want {
+		t.Errorf("GamepadButtonCount: got: %d, want: %d", got, want)
+	}
+	if got, want := ebiten.IsGamepadButtonPressed(id, ebiten.GamepadButton1), true; got != want {
+		t.Errorf("IsGamepadButtonPressed: got: %t, want: %t", got, want)
+	}
+	if got, want := ebiten.IsGamepadButtonPressed(id, ebiten.GamepadButton2), false; got != want {
+		t.Errorf("IsGamepadButtonPressed out of range: got: %t, want: %t", got, want)
+	}
+	if got, want := ebiten.IsStandardGamepadLayoutAvailable(id), true; got != want {
+		t.Errorf("IsStandardGamepadLayoutAvailable: got: %t, want: %t", got, want)
+	}
+	if got, want := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical), -1.0; got != want {
+		t.Errorf("StandardGamepadAxisValue: got: %f, want: %f", got, want)
+	}
+	if got, want := ebiten.StandardGamepadButtonValue(id, ebiten.StandardGamepadButtonFrontBottomLeft), 1.0; got != want {
+		t.Errorf("StandardGamepadButtonValue: got: %f, want: %f", got, want)
+	}
+	if got, want := ebiten.IsStandardGamepadButtonPressed(id, ebiten.StandardGamepadButtonFrontBottomLeft), true; got != want {
+		t.Errorf("IsStandardGamepadButtonPressed: got: %t, want: %t", got, want)
+	}
+	if got, want := ebiten.IsGamepadButtonPressed(id+1, ebiten.GamepadButton1), false; got != want {
+		t.Errorf("IsGamepadButtonPressed for a gamepad not injected: got: %t, want: %t", got, want)
+	}
+
+	var snapshot ebiten.InputSnapshot
+	ebiten.ReadInputSnapshot(&snapshot)
+	if len(snapshot.Gamepads) != 1 || snapshot.Gamepads[0].ID != id {
+		t.Errorf("ReadInputSnapshot: got: %v, want: the injected gamepad %d", snapshot.Gamepads, id)
+	}
+
+	// Unsetting the injector discards the injected gamepads at the next tick.
+	ebiten.SetInputInjector(nil)
+	g.UpdateInputState()
+	if got, want := ebiten.IsGamepadButtonPressed(id, ebiten.GamepadButton1), false; got != want {
+		t.Errorf("IsGamepadButtonPressed after unsetting the injector: got: %t, want: %t", got, want)
+	}
+}
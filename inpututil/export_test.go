@@ -0,0 +1,35 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inpututil
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// SetGamepadInputHistoryForTesting replaces the input history of the gamepad with frames from the oldest to the newest.
+func SetGamepadInputHistoryForTesting(id ebiten.GamepadID, frames []GamepadInputFrame) {
+	theInputState.m.Lock()
+	defer theInputState.m.Unlock()
+
+	h := &gamepadInputHistory{}
+	for _, f := range frames {
+		h.frames[h.head] = f
+		h.head = (h.head + 1) % len(h.frames)
+		if h.count < len(h.frames) {
+			h.count++
+		}
+	}
+	theInputState.gamepadInputHistories[id] = h
+}
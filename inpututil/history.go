@@ -0,0 +1,195 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inpututil
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// MaxGamepadInputHistoryFrames is the maximum number of ticks kept in a gamepad input history.
+const MaxGamepadInputHistoryFrames = 120
+
+// GamepadInputFrame represents the state of a standard gamepad at one tick.
+type GamepadInputFrame struct {
+	// Buttons represents whether each standard gamepad button is pressed.
+	Buttons [ebiten.StandardGamepadButtonMax + 1]bool
+
+	// Axes represents the value of each standard gamepad axis.
+	Axes [ebiten.StandardGamepadAxisMax + 1]float64
+}
+
+// Direction represents a direction in the numpad notation, which is common in fighting games.
+type Direction int
+
+const (
+	DirectionDownLeft  Direction = 1
+	DirectionDown      Direction = 2
+	DirectionDownRight Direction = 3
+	DirectionLeft      Direction = 4
+	DirectionNeutral   Direction = 5
+	DirectionRight     Direction = 6
+	DirectionUpLeft    Direction = 7
+	DirectionUp        Direction = 8
+	DirectionUpRight   Direction = 9
+)
+
+// Direction returns the direction of the D-pad and the left stick.
+//
+// The D-pad takes priority over the left stick.
+// threshold is the minimum absolute value of an axis to treat the stick as tilted. 0.5 is a typical value.
+func (f *GamepadInputFrame) Direction(threshold float64) Direction {
+	var x, y int
+	switch {
+	case f.Buttons[ebiten.StandardGamepadButtonLeftLeft] && !f.Buttons[ebiten.StandardGamepadButtonLeftRight]:
+		x = -1
+	case f.Buttons[ebiten.StandardGamepadButtonLeftRight] && !f.Buttons[ebiten.StandardGamepadButtonLeftLeft]:
+		x = 1
+	case f.Axes[ebiten.StandardGamepadAxisLeftStickHorizontal] <= -threshold:
+		x = -1
+	case f.Axes[ebiten.StandardGamepadAxisLeftStickHorizontal] >= threshold:
+		x = 1
+	}
+	switch {
+	case f.Buttons[ebiten.StandardGamepadButtonLeftTop] && !f.Buttons[ebiten.StandardGamepadButtonLeftBottom]:
+		y = 1
+	case f.Buttons[ebiten.StandardGamepadButtonLeftBottom] && !f.Buttons[ebiten.StandardGamepadButtonLeftTop]:
+		y = -1
+	case f.Axes[ebiten.StandardGamepadAxisLeftStickVertical] <= -threshold:
+		// The vertical axis value is negative when the stick is tilted up.
+		y = 1
+	case f.Axes[ebiten.StandardGamepadAxisLeftStickVertical] >= threshold:
+		y = -1
+	}
+	return Direction(5 + x + 3*y)
+}
+
+type gamepadInputHistory struct {
+	frames [MaxGamepadInputHistoryFrames]GamepadInputFrame
+	// head is the index of the next frame to write.
+	head  int
+	count int
+}
+
+func (h *gamepadInputHistory) record(id ebiten.GamepadID) {
+	f := &h.frames[h.head]
+	standard := ebiten.IsStandardGamepadLayoutAvailable(id)
+	for b := ebiten.StandardGamepadButton(0); b <= ebiten.StandardGamepadButtonMax; b++ {
+		f.Buttons[b] = standard && ebiten.IsStandardGamepadButtonPressed(id, b)
+	}
+	for a := ebiten.StandardGamepadAxis(0); a <= ebiten.StandardGamepadAxisMax; a++ {
+		if standard {
+			f.Axes[a] = ebiten.StandardGamepadAxisValue(id, a)
+		} else {
+			f.Axes[a] = 0
+		}
+	}
+	h.head = (h.head + 1) % len(h.frames)
+	if h.count < len(h.frames) {
+		h.count++
+	}
+}
+
+// appendFrames appends the last n frames from the oldest to the newest.
+func (h *gamepadInputHistory) appendFrames(frames []GamepadInputFrame, n int) []GamepadInputFrame {
+	if n > h.count {
+		n = h.count
+	}
+	for i := n; i > 0; i-- {
+		frames = append(frames, h.frames[(h.head-i+len(h.frames))%len(h.frames)])
+	}
+	return frames
+}
+
+// AppendGamepadInputHistory appends the states of the standard gamepad at the last n ticks to frames
+// and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+//
+// The frames are appended from the oldest to the newest, and the last one is the current tick's state.
+// n is clamped to MaxGamepadInputHistoryFrames.
+// The history starts when the gamepad is connected, so the number of appended frames can be less than n.
+// If the gamepad doesn't have the standard layout, all the buttons and the axes are treated as neutral.
+//
+// AppendGamepadInputHistory must be called in a game's Update, not Draw.
+//
+// AppendGamepadInputHistory is concurrent safe.
+func AppendGamepadInputHistory(frames []GamepadInputFrame, id ebiten.GamepadID, n int) []GamepadInputFrame {
+	theInputState.m.RLock()
+	defer theInputState.m.RUnlock()
+
+	h, ok := theInputState.gamepadInputHistories[id]
+	if !ok {
+		return frames
+	}
+	return h.appendFrames(frames, n)
+}
+
+// GamepadInputHistory returns the states of the standard gamepad at the last n ticks.
+//
+// For the details, see AppendGamepadInputHistory.
+//
+// GamepadInputHistory must be called in a game's Update, not Draw.
+//
+// GamepadInputHistory is concurrent safe.
+func GamepadInputHistory(id ebiten.GamepadID, n int) []GamepadInputFrame {
+	return AppendGamepadInputHistory(nil, id, n)
+}
+
+// IsGamepadMotionJustCompleted reports whether the given motion is input in the last window ticks,
+// and the motion's last direction is input at the current tick.
+//
+// motion is a sequence of directions like {DirectionDown, DirectionDownRight, DirectionRight} for a quarter-circle forward.
+// Each direction in motion must be input in this order, and other directions can be input in between.
+// window is the leniency in ticks for the whole motion, and is clamped to MaxGamepadInputHistoryFrames.
+// threshold is passed to GamepadInputFrame.Direction.
+//
+// IsGamepadMotionJustCompleted must be called in a game's Update, not Draw.
+//
+// IsGamepadMotionJustCompleted is concurrent safe.
+func IsGamepadMotionJustCompleted(id ebiten.GamepadID, motion []Direction, window int, threshold float64) bool {
+	if len(motion) == 0 {
+		return false
+	}
+
+	theInputState.m.RLock()
+	defer theInputState.m.RUnlock()
+
+	h, ok := theInputState.gamepadInputHistories[id]
+	if !ok || h.count == 0 {
+		return false
+	}
+	if window > h.count {
+		window = h.count
+	}
+
+	// Check the current tick first, and then match the rest of the motion backward.
+	idx := func(i int) int {
+		return (h.head - 1 - i + 2*len(h.frames)) % len(h.frames)
+	}
+	if h.frames[idx(0)].Direction(threshold) != motion[len(motion)-1] {
+		return false
+	}
+	// The motion's last direction must be new at the current tick.
+	if h.count > 1 && h.frames[idx(1)].Direction(threshold) == motion[len(motion)-1] {
+		return false
+	}
+
+	m := len(motion) - 2
+	for i := 1; i < window && m >= 0; i++ {
+		if h.frames[idx(i)].Direction(threshold) == motion[m] {
+			m--
+		}
+	}
+	return m < 0
+}
@@ -0,0 +1,228 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inpututil_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+func frameWithButtons(buttons ...ebiten.StandardGamepadButton) inpututil.GamepadInputFrame {
+	var f inpututil.GamepadInputFrame
+	for _, b := range buttons {
+		f.Buttons[b] = true
+	}
+	return f
+}
+
+func frameWithStick(x, y float64) inpututil.GamepadInputFrame {
+	var f inpututil.GamepadInputFrame
+	f.Axes[ebiten.StandardGamepadAxisLeftStickHorizontal] = x
+	f.Axes[ebiten.StandardGamepadAxisLeftStickVertical] = y
+	return f
+}
+
+func framesWithDirections(dirs ...inpututil.Direction) []inpututil.GamepadInputFrame {
+	var frames []inpututil.GamepadInputFrame
+	for _, d := range dirs {
+		x := float64((int(d)-1)%3 - 1)
+		y := -float64((int(d)-1)/3 - 1)
+		frames = append(frames, frameWithStick(x, y))
+	}
+	return frames
+}
+
+func TestGamepadInputFrameDirection(t *testing.T) {
+	const (
+		left   = ebiten.StandardGamepadButtonLeftLeft
+		right  = ebiten.StandardGamepadButtonLeftRight
+		up     = ebiten.StandardGamepadButtonLeftTop
+		down   = ebiten.StandardGamepadButtonLeftBottom
+		thresh = 0.5
+	)
+
+	testCases := []struct {
+		Name  string
+		Frame inpututil.GamepadInputFrame
+		Want  inpututil.Direction
+	}{
+		{
+			Name:  "neutral",
+			Frame: inpututil.GamepadInputFrame{},
+			Want:  inpututil.DirectionNeutral,
+		},
+		{
+			Name:  "d-pad down-left",
+			Frame: frameWithButtons(down, left),
+			Want:  inpututil.DirectionDownLeft,
+		},
+		{
+			Name:  "d-pad up-right",
+			Frame: frameWithButtons(up, right),
+			Want:  inpututil.DirectionUpRight,
+		},
+		{
+			Name:  "d-pad opposite horizontal directions cancel",
+			Frame: frameWithButtons(left, right, up),
+			Want:  inpututil.DirectionUp,
+		},
+		{
+			Name:  "stick up",
+			Frame: frameWithStick(0, -1),
+			Want:  inpututil.DirectionUp,
+		},
+		{
+			Name:  "stick down-right",
+			Frame: frameWithStick(0.7, 0.7),
+			Want:  inpututil.DirectionDownRight,
+		},
+		{
+			Name:  "stick at threshold",
+			Frame: frameWithStick(-0.5, 0),
+			Want:  inpututil.DirectionLeft,
+		},
+		{
+			Name:  "stick under threshold",
+			Frame: frameWithStick(0.4, -0.4),
+			Want:  inpututil.DirectionNeutral,
+		},
+		{
+			Name: "d-pad takes priority over stick",
+			Frame: func() inpututil.GamepadInputFrame {
+				f := frameWithStick(1, 1)
+				f.Buttons[left] = true
+				return f
+			}(),
+			Want: inpututil.DirectionDownLeft,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := tc.Frame.Direction(thresh); got != tc.Want {
+				t.Errorf("got: %d, want: %d", got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestIsGamepadMotionJustCompleted(t *testing.T) {
+	const (
+		d  = inpututil.DirectionDown
+		dr = inpututil.DirectionDownRight
+		r  = inpututil.DirectionRight
+		n  = inpututil.DirectionNeutral
+		u  = inpututil.DirectionUp
+	)
+	quarterCircle := []inpututil.Direction{d, dr, r}
+
+	testCases := []struct {
+		Name    string
+		History []inpututil.Direction
+		Motion  []inpututil.Direction
+		Window  int
+		Want    bool
+	}{
+		{
+			Name:    "exact",
+			History: []inpututil.Direction{n, d, dr, r},
+			Motion:  quarterCircle,
+			Window:  10,
+			Want:    true,
+		},
+		{
+			Name:    "other directions in between",
+			History: []inpututil.Direction{d, n, dr, u, r},
+			Motion:  quarterCircle,
+			Window:  10,
+			Want:    true,
+		},
+		{
+			Name:    "held directions",
+			History: []inpututil.Direction{d, d, d, dr, dr, r},
+			Motion:  quarterCircle,
+			Window:  10,
+			Want:    true,
+		},
+		{
+			Name:    "last direction held from the previous tick",
+			History: []inpututil.Direction{d, dr, r, r},
+			Motion:  quarterCircle,
+			Window:  10,
+			Want:    false,
+		},
+		{
+			Name:    "wrong order",
+			History: []inpututil.Direction{dr, d, r},
+			Motion:  quarterCircle,
+			Window:  10,
+			Want:    false,
+		},
+		{
+			Name:    "missing direction",
+			History: []inpututil.Direction{d, r},
+			Motion:  quarterCircle,
+			Window:  10,
+			Want:    false,
+		},
+		{
+			Name:    "out of window",
+			History: []inpututil.Direction{d, n, n, dr, r},
+			Motion:  quarterCircle,
+			Window:  4,
+			Want:    false,
+		},
+		{
+			Name:    "at the window's edge",
+			History: []inpututil.Direction{d, n, dr, r},
+			Motion:  quarterCircle,
+			Window:  4,
+			Want:    true,
+		},
+		{
+			Name:    "single direction",
+			History: []inpututil.Direction{n, u},
+			Motion:  []inpututil.Direction{u},
+			Window:  1,
+			Want:    true,
+		},
+		{
+			Name:    "empty motion",
+			History: []inpututil.Direction{d, dr, r},
+			Motion:  nil,
+			Window:  10,
+			Want:    false,
+		},
+		{
+			Name:    "no history",
+			History: nil,
+			Motion:  quarterCircle,
+			Window:  10,
+			Want:    false,
+		},
+	}
+	for i, tc := range testCases {
+		i, tc := i, tc
+		t.Run(tc.Name, func(t *testing.T) {
+			id := ebiten.GamepadID(i)
+			inpututil.SetGamepadInputHistoryForTesting(id, framesWithDirections(tc.History...))
+			if got := inpututil.IsGamepadMotionJustCompleted(id, tc.Motion, tc.Window, 0.5); got != tc.Want {
+				t.Errorf("got: %t, want: %t", got, tc.Want)
+			}
+		})
+	}
+}
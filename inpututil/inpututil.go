@@ -44,6 +44,8 @@ type inputState struct {
 	standardGamepadButtonDurations     map[ebiten.GamepadID][]int
 	prevStandardGamepadButtonDurations map[ebiten.GamepadID][]int
 
+	gamepadInputHistories map[ebiten.GamepadID]*gamepadInputHistory
+
 	touchIDs           map[ebiten.TouchID]struct{}
 	touchDurations     map[ebiten.TouchID]int
 	touchPositions     map[ebiten.TouchID]pos
@@ -72,6 +74,8 @@ var theInputState = &inputState{
 	standardGamepadButtonDurations:     map[ebiten.GamepadID][]int{},
 	prevStandardGamepadButtonDurations: map[ebiten.GamepadID][]int{},
 
+	gamepadInputHistories: map[ebiten.GamepadID]*gamepadInputHistory{},
+
 	touchIDs:           map[ebiten.TouchID]struct{}{},
 	touchDurations:     map[ebiten.TouchID]int{},
 	touchPositions:     map[ebiten.TouchID]pos{},
@@ -163,6 +167,11 @@ func (i *inputState) update() {
 				i.standardGamepadButtonDurations[id][b] = 0
 			}
 		}
+
+		if _, ok := i.gamepadInputHistories[id]; !ok {
+			i.gamepadInputHistories[id] = &gamepadInputHistory{}
+		}
+		i.gamepadInputHistories[id].record(id)
 	}
 	for id := range i.gamepadButtonDurations {
 		if _, ok := i.gamepadIDs[id]; !ok {
@@ -174,6 +183,11 @@ func (i *inputState) update() {
 			delete(i.standardGamepadButtonDurations, id)
 		}
 	}
+	for id := range i.gamepadInputHistories {
+		if _, ok := i.gamepadIDs[id]; !ok {
+			delete(i.gamepadInputHistories, id)
+		}
+	}
 
 	// Touches
 
@@ -20,6 +20,7 @@ import (
 	"math"
 	"math/bits"
 	"runtime"
+	"sort"
 	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/debug"
@@ -85,6 +86,11 @@ func putImagesOnSourceBackend() {
 	})
 	imagesUsedAsDestination.clear()
 
+	if automaticRelocationDisabled {
+		imagesToPutOnSourceBackend.clear()
+		return
+	}
+
 	imagesToPutOnSourceBackend.forEach(func(i *Image) {
 		if i.usedAsSourceCount < math.MaxInt {
 			i.usedAsSourceCount++
@@ -116,6 +122,32 @@ type backend struct {
 	// sourceInThisFrame reports whether this backend is used as a source in this frame.
 	// sourceInThisFrame is reset every frame.
 	sourceInThisFrame bool
+
+	// regions is a set of the images' regions on this backend for debugging.
+	// For a backend without a page, the key is nil.
+	regions map[*packing.Node]regionInfo
+}
+
+// regionInfo represents an image on a backend.
+type regionInfo struct {
+	width     int
+	height    int
+	padding   int
+	imageType ImageType
+	traceID   uint64
+}
+
+func (b *backend) addRegion(node *packing.Node, i *Image) {
+	if b.regions == nil {
+		b.regions = map[*packing.Node]regionInfo{}
+	}
+	b.regions[node] = regionInfo{
+		width:     i.width,
+		height:    i.height,
+		padding:   i.paddingSize(),
+		imageType: i.imageType,
+		traceID:   i.traceID,
+	}
 }
 
 func (b *backend) tryAlloc(width, height int) (*packing.Node, bool) {
@@ -212,6 +244,9 @@ var (
 
 	imagesUsedAsDestination smallImageSet
 
+	// automaticRelocationDisabled indicates whether putting images onto a source backend automatically is disabled.
+	automaticRelocationDisabled bool
+
 	graphicsDriverInitialized bool
 
 	deferred []func()
@@ -256,6 +291,28 @@ type Image struct {
 	//
 	// usedAsDestinationCount is never reset.
 	usedAsDestinationCount int
+
+	// pinned indicates whether the image is pinned.
+	// A pinned image is on its own backend, and is never moved to another backend.
+	pinned bool
+
+	// traceID is an ID to identify the image for debugging.
+	traceID uint64
+}
+
+// Residency represents the state of an image in the backends.
+type Residency struct {
+	// Allocated indicates whether the image has a backend.
+	Allocated bool
+
+	// OnAtlas indicates whether the image shares a backend with other images.
+	OnAtlas bool
+
+	// OnSourceBackend indicates whether the image is on a backend for rendering sources.
+	OnSourceBackend bool
+
+	// Pinned indicates whether the image is pinned.
+	Pinned bool
 }
 
 // moveTo moves its content to the given image dst.
@@ -467,12 +524,13 @@ func (i *Image) drawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices [
 
 	dx, dy := float32(r.Min.X), float32(r.Min.Y)
 
+	vfc := shader.vertexFloatCount
 	var oxf, oyf float32
 	if srcs[0] != nil {
 		r := srcs[0].regionWithPadding()
 		oxf, oyf = float32(r.Min.X), float32(r.Min.Y)
 		n := len(vertices)
-		for i := 0; i < n; i += graphics.VertexFloatCount {
+		for i := 0; i < n; i += vfc {
 			vertices[i] += dx
 			vertices[i+1] += dy
 			vertices[i+2] += oxf
@@ -481,14 +539,14 @@ func (i *Image) drawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices [
 		if shader.ir.Unit == shaderir.Texels {
 			sw, sh := srcs[0].backend.image.InternalSize()
 			swf, shf := float32(sw), float32(sh)
-			for i := 0; i < n; i += graphics.VertexFloatCount {
+			for i := 0; i < n; i += vfc {
 				vertices[i+2] /= swf
 				vertices[i+3] /= shf
 			}
 		}
 	} else {
 		n := len(vertices)
-		for i := 0; i < n; i += graphics.VertexFloatCount {
+		for i := 0; i < n; i += vfc {
 			vertices[i] += dx
 			vertices[i+1] += dy
 		}
@@ -680,6 +738,8 @@ func (i *Image) deallocate() {
 		return
 	}
 
+	delete(i.backend.regions, i.node)
+
 	if !i.isOnAtlas() {
 		i.backend.image.Dispose()
 		i.backend.image = nil
@@ -709,6 +769,98 @@ func (i *Image) deallocate() {
 	panic("atlas: backend not found at an image being deallocated")
 }
 
+// SetTraceID sets an ID to identify the image for debugging.
+// The ID is reported as AtlasRegion's TraceID.
+func (i *Image) SetTraceID(id uint64) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	i.traceID = id
+	if i.backend == nil {
+		return
+	}
+	if r, ok := i.backend.regions[i.node]; ok {
+		r.traceID = id
+		i.backend.regions[i.node] = r
+	}
+}
+
+// SetPinned pins or unpins the image.
+//
+// A pinned image is moved to its own backend if needed, and is never moved to another backend after that.
+// An unpinned image can be put onto an atlas again in the usual way.
+func (i *Image) SetPinned(pinned bool) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		appendDeferred(func() {
+			i.setPinned(pinned)
+		})
+		return
+	}
+
+	i.setPinned(pinned)
+}
+
+func (i *Image) setPinned(pinned bool) {
+	if i.pinned == pinned {
+		return
+	}
+	i.pinned = pinned
+	if !pinned {
+		return
+	}
+
+	i.resetUsedAsSourceCount()
+
+	if i.backend == nil {
+		// The image will be allocated on its own backend later.
+		return
+	}
+	if !i.isOnAtlas() {
+		return
+	}
+
+	newI := NewImage(i.width, i.height, i.imageType)
+	newI.pinned = true
+	newI.allocate(nil, i.isOnSourceBackend())
+
+	w, h := float32(i.width), float32(i.height)
+	vs := make([]float32, 4*graphics.VertexFloatCount)
+	graphics.QuadVertices(vs, 0, 0, w, h, 1, 0, 0, 1, 0, 0, 1, 1, 1, 1)
+	is := graphics.QuadIndices()
+	dr := image.Rect(0, 0, i.width, i.height)
+	newI.drawTriangles([graphics.ShaderImageCount]*Image{i}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, NearestFilterShader, nil, graphicsdriver.FillAll)
+
+	usedAsDestinationCount := i.usedAsDestinationCount
+	newI.moveTo(i)
+	i.usedAsDestinationCount = usedAsDestinationCount
+}
+
+// Residency returns the current state of the image in the backends.
+func (i *Image) Residency() Residency {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	return Residency{
+		Allocated:       i.backend != nil,
+		OnAtlas:         i.isOnAtlas(),
+		OnSourceBackend: i.isOnSourceBackend(),
+		Pinned:          i.pinned,
+	}
+}
+
+// SetAutomaticRelocationEnabled enables or disables putting images onto a source backend automatically.
+//
+// Even when this is disabled, an image is still moved when the image is used as a rendering destination
+// while its backend is used as a rendering source.
+func SetAutomaticRelocationEnabled(enabled bool) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+	automaticRelocationDisabled = !enabled
+}
+
 func NewImage(width, height int, imageType ImageType) *Image {
 	// Actual allocation is done lazily, and the lock is not needed.
 	return &Image{
@@ -725,6 +877,9 @@ func (i *Image) canBePutOnAtlas() bool {
 	if i.imageType != ImageTypeRegular {
 		return false
 	}
+	if i.pinned {
+		return false
+	}
 	return i.width+i.paddingSize() <= maxSize && i.height+i.paddingSize() <= maxSize
 }
 
@@ -758,6 +913,7 @@ func (i *Image) allocate(forbiddenBackends []*backend, asSource bool) {
 			width:  i.width,
 			height: i.height,
 		}
+		i.backend.addRegion(nil, i)
 		theBackends = append(theBackends, i.backend)
 		return
 	}
@@ -776,6 +932,7 @@ func (i *Image) allocate(forbiddenBackends []*backend, asSource bool) {
 			height: hp,
 			source: asSource && i.imageType == ImageTypeRegular,
 		}
+		i.backend.addRegion(nil, i)
 		theBackends = append(theBackends, i.backend)
 		return
 	}
@@ -795,6 +952,7 @@ loop:
 		if n, ok := b.tryAlloc(wp, hp); ok {
 			i.backend = b
 			i.node = n
+			b.addRegion(n, i)
 			return
 		}
 	}
@@ -833,6 +991,7 @@ loop:
 	}
 	i.backend = b
 	i.node = n
+	b.addRegion(n, i)
 }
 
 func (i *Image) DumpScreenshot(graphicsDriver graphicsdriver.Graphics, path string, blackbg bool) (string, error) {
@@ -864,6 +1023,48 @@ func EndFrame() error {
 	return nil
 }
 
+// Flush flushes the rendering commands enqueued so far in the current frame.
+// If Flush is called out of a frame, Flush does nothing.
+func Flush(graphicsDriver graphicsdriver.Graphics) error {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		return nil
+	}
+	return graphicscommand.FlushCommands(graphicsDriver, false)
+}
+
+// PushDebugGroup pushes a debug group with the name to label the following rendering commands.
+func PushDebugGroup(name string) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		appendDeferred(func() {
+			graphicscommand.PushDebugGroup(name)
+		})
+		return
+	}
+
+	graphicscommand.PushDebugGroup(name)
+}
+
+// PopDebugGroup pops the latest debug group.
+func PopDebugGroup() {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		appendDeferred(func() {
+			graphicscommand.PopDebugGroup()
+		})
+		return
+	}
+
+	graphicscommand.PopDebugGroup()
+}
+
 func SwapBuffers(graphicsDriver graphicsdriver.Graphics) error {
 	func() {
 		backendsM.Lock()
@@ -958,3 +1159,98 @@ func DumpImages(graphicsDriver graphicsdriver.Graphics, dir string) (string, err
 	}
 	return graphicscommand.DumpImages(images, graphicsDriver, dir)
 }
+
+// AtlasRegion represents an image on an internal image for debugging.
+type AtlasRegion struct {
+	// Bounds is the region of the image excluding the padding.
+	Bounds image.Rectangle
+
+	// Padding is the padding size on the right and bottom edges of the image.
+	Padding int
+
+	ImageType ImageType
+
+	// TraceID is the ID set by SetTraceID, or 0 if not set.
+	TraceID uint64
+}
+
+// AtlasInfo represents an internal image, which might be an atlas, for debugging.
+type AtlasInfo struct {
+	Width  int
+	Height int
+
+	// Pixels is the pixels in the premultiplied-alpha RGBA format.
+	Pixels []byte
+
+	// Shared reports whether multiple images can share the internal image.
+	Shared bool
+
+	// Source reports whether the internal image is mainly used as a rendering source.
+	Source bool
+
+	Regions []AtlasRegion
+}
+
+// ReadAtlases reads all the internal images except for the screen with the information of the images on them.
+//
+// ReadAtlases returns false when the current state is not in between BeginFrame and EndFrame.
+func ReadAtlases(graphicsDriver graphicsdriver.Graphics) ([]AtlasInfo, bool, error) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		return nil, false, nil
+	}
+
+	var infos []AtlasInfo
+	for _, b := range theBackends {
+		if b.image == nil {
+			continue
+		}
+		// The screen image cannot be read.
+		if r, ok := b.regions[nil]; ok && r.imageType == ImageTypeScreen {
+			continue
+		}
+
+		pix := make([]byte, 4*b.width*b.height)
+		if err := b.image.ReadPixels(graphicsDriver, []graphicsdriver.PixelsArgs{
+			{
+				Pixels: pix,
+				Region: image.Rect(0, 0, b.width, b.height),
+			},
+		}); err != nil {
+			return nil, false, err
+		}
+
+		regions := make([]AtlasRegion, 0, len(b.regions))
+		for n, r := range b.regions {
+			var origin image.Point
+			if n != nil {
+				origin = n.Region().Min
+			}
+			regions = append(regions, AtlasRegion{
+				Bounds:    image.Rectangle{Min: origin, Max: origin.Add(image.Pt(r.width, r.height))},
+				Padding:   r.padding,
+				ImageType: r.imageType,
+				TraceID:   r.traceID,
+			})
+		}
+		sort.Slice(regions, func(i, j int) bool {
+			a, b := regions[i].Bounds.Min, regions[j].Bounds.Min
+			if a.Y != b.Y {
+				return a.Y < b.Y
+			}
+			return a.X < b.X
+		})
+
+		infos = append(infos, AtlasInfo{
+			Width:   b.width,
+			Height:  b.height,
+			Pixels:  pix,
+			Shared:  b.page != nil,
+			Source:  b.source,
+			Regions: regions,
+		})
+	}
+	return infos, true, nil
+}
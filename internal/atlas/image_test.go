@@ -687,6 +687,82 @@ func TestImageIsNotReputOnSourceBackendWithoutUsingAsSource(t *testing.T) {
 	}
 }
 
+func TestPinnedImageIsNotReputOnSourceBackend(t *testing.T) {
+	const size = 16
+
+	src := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+	defer src.Deallocate()
+	src2 := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+	defer src2.Deallocate()
+	dst := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+	defer dst.Deallocate()
+
+	// Use src as a render target so that src is not on a source backend.
+	vs := quadVertices(size, size, 0, 0, 1)
+	is := graphics.QuadIndices()
+	dr := image.Rect(0, 0, size, size)
+	src.DrawTriangles([graphics.ShaderImageCount]*atlas.Image{src2}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, atlas.NearestFilterShader, nil, graphicsdriver.FillAll)
+	if got, want := src.Residency().OnAtlas, true; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// Pinning moves the image to its own backend.
+	src.SetPinned(true)
+	if got, want := src.Residency(), (atlas.Residency{Allocated: true, Pinned: true}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// Use src as a render source. The pinned image should never be moved.
+	for i := 0; i < atlas.BaseCountToPutOnSourceBackend*2; i++ {
+		atlas.PutImagesOnSourceBackendForTesting()
+		vs := quadVertices(size, size, 0, 0, 1)
+		dst.DrawTriangles([graphics.ShaderImageCount]*atlas.Image{src}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, atlas.NearestFilterShader, nil, graphicsdriver.FillAll)
+		if got, want := src.Residency(), (atlas.Residency{Allocated: true, Pinned: true}); got != want {
+			t.Errorf("got: %v, want: %v", got, want)
+		}
+	}
+
+	// After unpinning, the image can be put onto a source backend again.
+	src.SetPinned(false)
+	for i := 0; i < atlas.BaseCountToPutOnSourceBackend; i++ {
+		atlas.PutImagesOnSourceBackendForTesting()
+		vs := quadVertices(size, size, 0, 0, 1)
+		dst.DrawTriangles([graphics.ShaderImageCount]*atlas.Image{src}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, atlas.NearestFilterShader, nil, graphicsdriver.FillAll)
+	}
+	atlas.PutImagesOnSourceBackendForTesting()
+	if got, want := src.IsOnSourceBackendForTesting(), true; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestAutomaticRelocationDisabled(t *testing.T) {
+	atlas.SetAutomaticRelocationEnabled(false)
+	defer atlas.SetAutomaticRelocationEnabled(true)
+
+	const size = 16
+
+	src := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+	defer src.Deallocate()
+	src2 := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+	defer src2.Deallocate()
+	dst := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+	defer dst.Deallocate()
+
+	vs := quadVertices(size, size, 0, 0, 1)
+	is := graphics.QuadIndices()
+	dr := image.Rect(0, 0, size, size)
+	src.DrawTriangles([graphics.ShaderImageCount]*atlas.Image{src2}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, atlas.NearestFilterShader, nil, graphicsdriver.FillAll)
+
+	for i := 0; i < atlas.BaseCountToPutOnSourceBackend*2; i++ {
+		atlas.PutImagesOnSourceBackendForTesting()
+		vs := quadVertices(size, size, 0, 0, 1)
+		dst.DrawTriangles([graphics.ShaderImageCount]*atlas.Image{src}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, atlas.NearestFilterShader, nil, graphicsdriver.FillAll)
+		if got, want := src.IsOnSourceBackendForTesting(), false; got != want {
+			t.Errorf("got: %v, want: %v", got, want)
+		}
+	}
+}
+
 func TestImageWritePixelsModify(t *testing.T) {
 	for _, typ := range []atlas.ImageType{atlas.ImageTypeRegular, atlas.ImageTypeVolatile, atlas.ImageTypeUnmanaged} {
 		const size = 16
@@ -881,4 +957,54 @@ func TestGC(t *testing.T) {
 	}
 }
 
+func TestReadAtlases(t *testing.T) {
+	const size = 16
+
+	const traceID = 12345
+
+	img := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+	defer img.Deallocate()
+	img.SetTraceID(traceID)
+	pix := make([]byte, 4*size*size)
+	for i := 0; i < size*size; i++ {
+		pix[4*i] = 0xff
+		pix[4*i+3] = 0xff
+	}
+	img.WritePixels(pix, image.Rect(0, 0, size, size))
+
+	infos, ok, err := atlas.ReadAtlases(ui.Get().GraphicsDriverForTesting())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("ReadAtlases failed")
+	}
+
+	var found bool
+	for _, info := range infos {
+		if got, want := len(info.Pixels), 4*info.Width*info.Height; got != want {
+			t.Errorf("len(info.Pixels): got: %d, want: %d", got, want)
+		}
+		for _, r := range info.Regions {
+			if r.Bounds.Dx() != size || r.Bounds.Dy() != size {
+				continue
+			}
+			if r.TraceID != traceID {
+				continue
+			}
+			if got, want := r.Padding, img.PaddingSizeForTesting(); got != want {
+				t.Errorf("Padding: got: %d, want: %d", got, want)
+			}
+			idx := 4 * (r.Bounds.Min.Y*info.Width + r.Bounds.Min.X)
+			if got, want := info.Pixels[idx:idx+4], []byte{0xff, 0, 0, 0xff}; string(got) != string(want) {
+				continue
+			}
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("the image's region was not found")
+	}
+}
+
 // TODO: Add tests to extend image on an atlas out of the main loop
@@ -29,15 +29,23 @@ import (
 type Shader struct {
 	ir     *shaderir.Program
 	shader *graphicscommand.Shader
+
+	vertexFloatCount int
 }
 
 func NewShader(ir *shaderir.Program) *Shader {
 	// A shader is initialized lazily, and the lock is not needed.
 	return &Shader{
-		ir: ir,
+		ir:               ir,
+		vertexFloatCount: graphics.ShaderVertexFloatCount(ir),
 	}
 }
 
+// VertexFloatCount returns the number of float values per vertex for the shader.
+func (s *Shader) VertexFloatCount() int {
+	return s.vertexFloatCount
+}
+
 func (s *Shader) finalize() {
 	// A function from finalizer must not be blocked, but disposing operation can be blocked.
 	// Defer this operation until it becomes safe. (#913)
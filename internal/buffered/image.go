@@ -67,6 +67,18 @@ func (i *Image) Deallocate() {
 	i.pixelsUnsynced = false
 }
 
+func (i *Image) SetPinned(pinned bool) {
+	i.img.SetPinned(pinned)
+}
+
+func (i *Image) SetTraceID(id uint64) {
+	i.img.SetTraceID(id)
+}
+
+func (i *Image) Residency() atlas.Residency {
+	return i.img.Residency()
+}
+
 func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte, region image.Rectangle) (bool, error) {
 	// Do not call flushDotsBufferIfNeeded here. This would slow (image/draw).Draw.
 	// See ebiten.TestImageDrawOver.
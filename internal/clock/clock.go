@@ -141,6 +141,18 @@ func updateFPSAndTPS(now int64, count int) {
 //
 // UpdateFrame is expected to be called once per frame.
 func UpdateFrame() int {
+	return updateFrame(false)
+}
+
+// UpdateFrameAtLeastOnce is the same as UpdateFrame except that this returns at least 1 unless tps is 0.
+//
+// UpdateFrameAtLeastOnce is used when a frame is processed only by an event, and then the frame must update the game
+// even if the frame comes earlier than the next tick.
+func UpdateFrameAtLeastOnce() int {
+	return updateFrame(true)
+}
+
+func updateFrame(atLeastOnce bool) int {
 	m.Lock()
 	defer m.Unlock()
 
@@ -156,6 +168,11 @@ func UpdateFrame() int {
 		c = 1
 	} else if tps > 0 {
 		c = calcCountFromTPS(int64(tps), n)
+		if atLeastOnce && c == 0 {
+			// The game time is synced with the system clock so that the forced tick is not counted twice.
+			c = 1
+			lastSystemTime = n
+		}
 	}
 	updateFPSAndTPS(n, c)
 
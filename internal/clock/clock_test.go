@@ -0,0 +1,80 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/clock"
+)
+
+func TestCalcCountFromTPS(t *testing.T) {
+	const tick = int64(time.Second) / 60
+	testCases := []struct {
+		Name     string
+		LastTime int64
+		Now      int64
+		Want     int
+	}{
+		{
+			Name:     "just one tick",
+			LastTime: 0,
+			Now:      tick,
+			Want:     1,
+		},
+		{
+			Name:     "two ticks",
+			LastTime: 0,
+			Now:      2*tick + tick/4,
+			Want:     2,
+		},
+		{
+			Name:     "less than a half tick",
+			LastTime: 0,
+			Now:      tick / 4,
+			Want:     0,
+		},
+		{
+			Name:     "future",
+			LastTime: tick,
+			Now:      0,
+			Want:     0,
+		},
+		{
+			Name:     "too old",
+			LastTime: 0,
+			Now:      int64(time.Second) * 10,
+			Want:     1,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := clock.CalcCountFromTPSForTesting(60, tc.LastTime, tc.Now); got != tc.Want {
+				t.Errorf("got: %d, want: %d", got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestUpdateFrameAtLeastOnce(t *testing.T) {
+	if got, want := clock.UpdateFrameJustAfterTickForTesting(false), 0; got != want {
+		t.Errorf("updateFrame(false): got: %d, want: %d", got, want)
+	}
+	if got, want := clock.UpdateFrameJustAfterTickForTesting(true), 1; got != want {
+		t.Errorf("updateFrame(true): got: %d, want: %d", got, want)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"time"
+)
+
+func CalcCountFromTPSForTesting(tps int64, lastTime int64, now int64) int {
+	m.Lock()
+	defer m.Unlock()
+
+	origLastSystemTime := lastSystemTime
+	origPrevTPS := prevTPS
+	defer func() {
+		lastSystemTime = origLastSystemTime
+		prevTPS = origPrevTPS
+	}()
+
+	lastSystemTime = lastTime
+	prevTPS = tps
+	return calcCountFromTPS(tps, now)
+}
+
+// UpdateFrameJustAfterTickForTesting calls updateFrame just after the game time is synced with the current time.
+func UpdateFrameJustAfterTickForTesting(atLeastOnce bool) int {
+	m.Lock()
+	origTPS := tps
+	tps = DefaultTPS
+	prevTPS = DefaultTPS
+	// Make the game time a little ahead so that the next frame is surely earlier than the next tick.
+	lastSystemTime = now() + int64(time.Second)/DefaultTPS/4
+	m.Unlock()
+
+	defer func() {
+		m.Lock()
+		tps = origTPS
+		m.Unlock()
+	}()
+
+	return updateFrame(atLeastOnce)
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var (
+	frameStartTime atomic.Int64
+	lastFrameTime  atomic.Int64
+)
+
+// BeginFrameTime starts measuring the time of the current frame.
+//
+// BeginFrameTime is concurrent-safe.
+func BeginFrameTime() {
+	frameStartTime.Store(now())
+}
+
+// EndFrameTime ends measuring the time of the current frame.
+//
+// EndFrameTime is concurrent-safe.
+func EndFrameTime() {
+	lastFrameTime.Store(now() - frameStartTime.Load())
+}
+
+// FrameTime returns the time measured between the last BeginFrameTime and EndFrameTime.
+//
+// FrameTime is concurrent-safe.
+func FrameTime() time.Duration {
+	return time.Duration(lastFrameTime.Load())
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	maxFPS atomic.Int32
+
+	// nextFrameTime is the time when the next frame can start.
+	// nextFrameTime is accessed only from the rendering goroutine.
+	nextFrameTime int64
+)
+
+// MaxFPS returns the maximum FPS. 0 means that FPS is not limited.
+//
+// MaxFPS is concurrent-safe.
+func MaxFPS() int {
+	return int(maxFPS.Load())
+}
+
+// SetMaxFPS sets the maximum FPS. 0 means that FPS is not limited.
+//
+// SetMaxFPS is concurrent-safe.
+func SetMaxFPS(fps int) {
+	if fps < 0 {
+		panic(fmt.Sprintf("clock: fps must be >= 0 but %d", fps))
+	}
+	maxFPS.Store(int32(fps))
+}
+
+// WaitForMaxFPS sleeps until the next frame can start based on the maximum FPS.
+//
+// WaitForMaxFPS must be called after swapping buffers on the rendering goroutine.
+func WaitForMaxFPS() {
+	fps := maxFPS.Load()
+	if fps <= 0 {
+		nextFrameTime = 0
+		return
+	}
+
+	interval := int64(time.Second) / int64(fps)
+	n := now()
+	if nextFrameTime == 0 {
+		nextFrameTime = n
+	}
+	if n < nextFrameTime {
+		time.Sleep(time.Duration(nextFrameTime - n))
+	}
+
+	// Advance the target time by the interval to avoid accumulating errors of sleeping.
+	// If the frame is too late, e.g. the game is too heavy, reset the target time.
+	nextFrameTime += interval
+	if n := now(); nextFrameTime < n-interval {
+		nextFrameTime = n + interval
+	}
+}
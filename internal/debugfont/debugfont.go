@@ -0,0 +1,71 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate go run gen.go
+
+// Package debugfont provides a bitmap font image for debug printing.
+//
+// The image is shared by ebitenutil.DebugPrint and the debug drawing in the ebiten package.
+package debugfont
+
+import (
+	"bytes"
+	_ "embed"
+	"image"
+	"image/png"
+	"sync"
+)
+
+const (
+	// CharWidth is the width of a glyph in pixels.
+	CharWidth = 6
+
+	// LineHeight is the height of a line in pixels.
+	LineHeight = 16
+)
+
+//go:embed text.png
+var text_png []byte
+
+var (
+	textImage     image.Image
+	textImageOnce sync.Once
+)
+
+// Image returns the image including all the glyphs in U+0000 to U+00FF.
+//
+// Each glyph is rendered with a shadow.
+func Image() image.Image {
+	textImageOnce.Do(func() {
+		img, err := png.Decode(bytes.NewReader(text_png))
+		if err != nil {
+			panic(err)
+		}
+		textImage = img
+	})
+	return textImage
+}
+
+// PNG returns the PNG-encoded bytes of the image returned by Image.
+func PNG() []byte {
+	return text_png
+}
+
+// GlyphRect returns the region of the glyph for r in the image returned by Image.
+func GlyphRect(r rune) image.Rectangle {
+	n := Image().Bounds().Dx() / CharWidth
+	x := (int(r) % n) * CharWidth
+	y := (int(r) / n) * LineHeight
+	return image.Rect(x, y, x+CharWidth, y+LineHeight)
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugfont_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/debugfont"
+)
+
+func TestImage(t *testing.T) {
+	img := debugfont.Image()
+	if got, want := img.Bounds(), image.Rect(0, 0, debugfont.CharWidth*32, debugfont.LineHeight*8); got != want {
+		t.Errorf("Bounds(): got: %v, want: %v", got, want)
+	}
+
+	// Image must be decoded from PNG.
+	decoded, err := png.Decode(bytes.NewReader(debugfont.PNG()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := decoded.Bounds(), img.Bounds(); got != want {
+		t.Errorf("decoded PNG's Bounds(): got: %v, want: %v", got, want)
+	}
+}
+
+func TestGlyphRect(t *testing.T) {
+	testCases := []struct {
+		In  rune
+		Out image.Rectangle
+	}{
+		{
+			In:  0,
+			Out: image.Rect(0, 0, 6, 16),
+		},
+		{
+			In:  'A',
+			Out: image.Rect(6, 32, 12, 48),
+		},
+		{
+			In:  0x1f,
+			Out: image.Rect(186, 0, 192, 16),
+		},
+		{
+			In:  0xff,
+			Out: image.Rect(186, 112, 192, 128),
+		},
+	}
+	for _, tc := range testCases {
+		if got, want := debugfont.GlyphRect(tc.In), tc.Out; got != want {
+			t.Errorf("GlyphRect(%U): got: %v, want: %v", tc.In, got, want)
+		}
+	}
+}
+
+func TestGlyphHasPixels(t *testing.T) {
+	img := debugfont.Image()
+
+	// A space has no pixels, and 'A' has some pixels.
+	for _, r := range []rune{' ', 'A'} {
+		var found bool
+		rect := debugfont.GlyphRect(r)
+		for j := rect.Min.Y; j < rect.Max.Y; j++ {
+			for i := rect.Min.X; i < rect.Max.X; i++ {
+				if _, _, _, a := img.At(i, j).RGBA(); a != 0 {
+					found = true
+				}
+			}
+		}
+		if got, want := found, r != ' '; got != want {
+			t.Errorf("glyph %q has pixels: got: %t, want: %t", r, got, want)
+		}
+	}
+}
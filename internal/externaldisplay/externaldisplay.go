@@ -0,0 +1,57 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package externaldisplay manages the state of an external display connected to a mobile device.
+package externaldisplay
+
+import (
+	"sync"
+)
+
+// Display represents an external display.
+type Display struct {
+	Width             float64
+	Height            float64
+	DeviceScaleFactor float64
+}
+
+var theState state
+
+type state struct {
+	display   Display
+	connected bool
+
+	m sync.Mutex
+}
+
+// Set sets the state of the external display.
+func Set(connected bool, display Display) {
+	theState.m.Lock()
+	defer theState.m.Unlock()
+
+	theState.connected = connected
+	if connected {
+		theState.display = display
+	} else {
+		theState.display = Display{}
+	}
+}
+
+// Get returns the external display and reports whether the external display is connected.
+func Get() (Display, bool) {
+	theState.m.Lock()
+	defer theState.m.Unlock()
+
+	return theState.display, theState.connected
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Transport represents how a gamepad is connected.
+type Transport int
+
+const (
+	TransportUnknown Transport = iota
+	TransportUSB
+	TransportBluetooth
+)
+
+// DeviceInfo represents the hardware identifiers of a gamepad.
+// A zero value of each field means the value is unknown.
+type DeviceInfo struct {
+	VendorID       uint16
+	ProductID      uint16
+	ProductVersion uint16
+	Transport      Transport
+}
+
+// transportFromLinuxBusType returns a transport from the bus type of Linux's input_id.
+func transportFromLinuxBusType(busType uint16) Transport {
+	// See linux/input.h.
+	const (
+		BUS_USB       = 0x03
+		BUS_BLUETOOTH = 0x05
+	)
+
+	switch busType {
+	case BUS_USB:
+		return TransportUSB
+	case BUS_BLUETOOTH:
+		return TransportBluetooth
+	}
+	return TransportUnknown
+}
+
+// transportFromHIDTransport returns a transport from the value of IOKit's kIOHIDTransportKey property.
+func transportFromHIDTransport(transport string) Transport {
+	switch {
+	case transport == "USB":
+		return TransportUSB
+	case strings.HasPrefix(transport, "Bluetooth"):
+		// This includes "Bluetooth Low Energy".
+		return TransportBluetooth
+	}
+	return TransportUnknown
+}
+
+var (
+	// chromiumGamepadIDRe matches an ID like "Xbox 360 Controller (XInput STANDARD GAMEPAD Vendor: 045e Product: 028e)".
+	chromiumGamepadIDRe = regexp.MustCompile(`Vendor: ([0-9a-fA-F]{1,4}) Product: ([0-9a-fA-F]{1,4})`)
+
+	// firefoxGamepadIDRe matches an ID like "45e-28e-Xbox 360 Controller" or "045e-028e-Xbox 360 Controller".
+	// Safari uses the same format.
+	firefoxGamepadIDRe = regexp.MustCompile(`^([0-9a-fA-F]{1,4})-([0-9a-fA-F]{1,4})-`)
+)
+
+// deviceInfoFromBrowserGamepadID returns a device information from the Gamepad API's id string.
+// The format of the id string depends on the browser. Only the vendor and the product IDs are available.
+func deviceInfoFromBrowserGamepadID(id string) DeviceInfo {
+	m := chromiumGamepadIDRe.FindStringSubmatch(id)
+	if m == nil {
+		m = firefoxGamepadIDRe.FindStringSubmatch(id)
+	}
+	if m == nil {
+		return DeviceInfo{}
+	}
+
+	vendor, err := strconv.ParseUint(m[1], 16, 16)
+	if err != nil {
+		return DeviceInfo{}
+	}
+	product, err := strconv.ParseUint(m[2], 16, 16)
+	if err != nil {
+		return DeviceInfo{}
+	}
+	return DeviceInfo{
+		VendorID:  uint16(vendor),
+		ProductID: uint16(product),
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad
+
+import (
+	"testing"
+)
+
+func TestDeviceInfoFromBrowserGamepadID(t *testing.T) {
+	testCases := []struct {
+		ID   string
+		Want DeviceInfo
+	}{
+		{
+			ID:   "Xbox 360 Controller (XInput STANDARD GAMEPAD Vendor: 045e Product: 028e)",
+			Want: DeviceInfo{VendorID: 0x045e, ProductID: 0x028e},
+		},
+		{
+			ID:   "DUALSHOCK 4 Wireless Controller (STANDARD GAMEPAD Vendor: 054c Product: 09cc)",
+			Want: DeviceInfo{VendorID: 0x054c, ProductID: 0x09cc},
+		},
+		{
+			ID:   "054c-09cc-Wireless Controller",
+			Want: DeviceInfo{VendorID: 0x054c, ProductID: 0x09cc},
+		},
+		{
+			ID:   "57e-2009-Pro Controller",
+			Want: DeviceInfo{VendorID: 0x057e, ProductID: 0x2009},
+		},
+		{
+			ID:   "xinput",
+			Want: DeviceInfo{},
+		},
+		{
+			ID:   "Unknown Gamepad (STANDARD GAMEPAD)",
+			Want: DeviceInfo{},
+		},
+		{
+			ID:   "",
+			Want: DeviceInfo{},
+		},
+	}
+	for _, tc := range testCases {
+		if got := deviceInfoFromBrowserGamepadID(tc.ID); got != tc.Want {
+			t.Errorf("deviceInfoFromBrowserGamepadID(%q): got: %+v, want: %+v", tc.ID, got, tc.Want)
+		}
+	}
+}
+
+func TestTransportFromLinuxBusType(t *testing.T) {
+	testCases := []struct {
+		BusType uint16
+		Want    Transport
+	}{
+		{BusType: 0x03, Want: TransportUSB},
+		{BusType: 0x05, Want: TransportBluetooth},
+		{BusType: 0x06, Want: TransportUnknown},
+		{BusType: 0, Want: TransportUnknown},
+	}
+	for _, tc := range testCases {
+		if got := transportFromLinuxBusType(tc.BusType); got != tc.Want {
+			t.Errorf("transportFromLinuxBusType(%#x): got: %d, want: %d", tc.BusType, got, tc.Want)
+		}
+	}
+}
+
+func TestTransportFromHIDTransport(t *testing.T) {
+	testCases := []struct {
+		Transport string
+		Want      Transport
+	}{
+		{Transport: "USB", Want: TransportUSB},
+		{Transport: "Bluetooth", Want: TransportBluetooth},
+		{Transport: "Bluetooth Low Energy", Want: TransportBluetooth},
+		{Transport: "Virtual", Want: TransportUnknown},
+		{Transport: "", Want: TransportUnknown},
+	}
+	for _, tc := range testCases {
+		if got := transportFromHIDTransport(tc.Transport); got != tc.Want {
+			t.Errorf("transportFromHIDTransport(%q): got: %d, want: %d", tc.Transport, got, tc.Want)
+		}
+	}
+}
@@ -18,8 +18,8 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepaddb"
 )
 
-func AddAndroidGamepad(androidDeviceID int, name, sdlID string, axisCount, hatCount int) {
-	theGamepads.addAndroidGamepad(androidDeviceID, name, sdlID, axisCount, hatCount)
+func AddAndroidGamepad(androidDeviceID int, name, sdlID string, axisCount, hatCount int, vendorID, productID int) {
+	theGamepads.addAndroidGamepad(androidDeviceID, name, sdlID, axisCount, hatCount, vendorID, productID)
 }
 
 func RemoveAndroidGamepad(androidDeviceID int) {
@@ -38,11 +38,16 @@ func UpdateAndroidGamepadHat(androidDeviceID int, hat int, xValue, yValue int) {
 	theGamepads.updateAndroidGamepadHat(androidDeviceID, hat, xValue, yValue)
 }
 
-func (g *gamepads) addAndroidGamepad(androidDeviceID int, name, sdlID string, axisCount, hatCount int) {
+func (g *gamepads) addAndroidGamepad(androidDeviceID int, name, sdlID string, axisCount, hatCount int, vendorID, productID int) {
 	g.m.Lock()
 	defer g.m.Unlock()
 
 	gp := g.add(name, sdlID)
+	// Android doesn't provide the product version and the transport.
+	gp.deviceInfo = DeviceInfo{
+		VendorID:  uint16(vendorID),
+		ProductID: uint16(productID),
+	}
 	gp.native = &nativeGamepadImpl{
 		androidDeviceID: androidDeviceID,
 		axesReady:       make([]bool, axisCount),
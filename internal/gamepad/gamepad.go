@@ -180,9 +180,10 @@ func (g *gamepads) setNativeWindow(nativeWindow uintptr) {
 }
 
 type Gamepad struct {
-	name  string
-	sdlID string
-	m     sync.Mutex
+	name       string
+	sdlID      string
+	deviceInfo DeviceInfo
+	m          sync.Mutex
 
 	native nativeGamepad
 }
@@ -273,6 +274,12 @@ func (g *Gamepad) SDLID() string {
 	return g.sdlID
 }
 
+// DeviceInfo is concurrent-safe.
+func (g *Gamepad) DeviceInfo() DeviceInfo {
+	// This is immutable and doesn't have to be protected by a mutex.
+	return g.deviceInfo
+}
+
 // AxisCount is concurrent-safe.
 func (g *Gamepad) AxisCount() int {
 	g.m.Lock()
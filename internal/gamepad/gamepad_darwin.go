@@ -182,6 +182,13 @@ func (g *nativeGamepadsImpl) addDevice(device _IOHIDDeviceRef, gamepads *gamepad
 		_CFNumberGetValue(_CFNumberRef(prop), kCFNumberSInt32Type, unsafe.Pointer(&version))
 	}
 
+	var transport string
+	if prop := _IOHIDDeviceGetProperty(device, _CFStringCreateWithCString(kCFAllocatorDefault, kIOHIDTransportKey, kCFStringEncodingUTF8)); prop != 0 {
+		var cstr [256]byte
+		_CFStringGetCString(_CFStringRef(prop), cstr[:], kCFStringEncodingUTF8)
+		transport = strings.TrimRight(string(cstr[:]), "\x00")
+	}
+
 	var sdlID string
 	if vendor != 0 && product != 0 {
 		sdlID = fmt.Sprintf("03000000%02x%02x0000%02x%02x0000%02x%02x0000",
@@ -201,6 +208,12 @@ func (g *nativeGamepadsImpl) addDevice(device _IOHIDDeviceRef, gamepads *gamepad
 		device: device,
 	}
 	gp := gamepads.add(name, sdlID)
+	gp.deviceInfo = DeviceInfo{
+		VendorID:       uint16(vendor),
+		ProductID:      uint16(product),
+		ProductVersion: uint16(version),
+		Transport:      transportFromHIDTransport(transport),
+	}
 	gp.native = n
 
 	for i := _CFIndex(0); i < _CFArrayGetCount(elements); i++ {
@@ -373,7 +373,10 @@ func (g *nativeGamepadsDesktop) dinput8EnumDevicesCallback(lpddi *_DIDEVICEINSTA
 
 	name := windows.UTF16ToString(lpddi.tszInstanceName[:])
 	var sdlID string
+	var deviceInfo DeviceInfo
 	if string(lpddi.guidProduct.Data4[2:8]) == "PIDVID" {
+		deviceInfo.VendorID = uint16(lpddi.guidProduct.Data1)
+		deviceInfo.ProductID = uint16(lpddi.guidProduct.Data1 >> 16)
 		// This seems different from the current SDL implementation.
 		// Probably guidProduct includes the vendor and the product information, but this works.
 		// From the game controller database, the 'version' part seems always 0.
@@ -392,6 +395,7 @@ func (g *nativeGamepadsDesktop) dinput8EnumDevicesCallback(lpddi *_DIDEVICEINSTA
 	}
 
 	gp := gamepads.add(name, sdlID)
+	gp.deviceInfo = deviceInfo
 	gp.native = &nativeGamepadDesktop{
 		dinputDevice:  device,
 		dinputObjects: ctx.objects,
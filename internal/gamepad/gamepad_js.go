@@ -89,6 +89,7 @@ func (g *nativeGamepadsImpl) update(gamepads *gamepads) error {
 			copy(sdlID[:], []byte(name))
 
 			gamepad = gamepads.add(name, hex.EncodeToString(sdlID[:]))
+			gamepad.deviceInfo = deviceInfoFromBrowserGamepadID(name)
 			gamepad.native = &nativeGamepadImpl{
 				index:   index,
 				mapping: gp.Get("mapping").String(),
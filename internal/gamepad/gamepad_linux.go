@@ -177,6 +177,12 @@ func (*nativeGamepadsImpl) openGamepad(gamepads *gamepads, path string) (err err
 		fd:   fd,
 	}
 	gp := gamepads.add(name, sdlID)
+	gp.deviceInfo = DeviceInfo{
+		VendorID:       id.vendor,
+		ProductID:      id.product,
+		ProductVersion: id.version,
+		Transport:      transportFromLinuxBusType(id.bustype),
+	}
 	gp.native = n
 	runtime.SetFinalizer(gp, func(gp *Gamepad) {
 		n.close()
@@ -79,3 +79,129 @@ func BenchmarkAdjustPixel(b *testing.B) {
 		graphics.AdjustDestinationPixelForTesting(float32(i) / 17)
 	}
 }
+
+func TestTranslatedQuadVertices(t *testing.T) {
+	tests := []struct {
+		SX0 float32
+		SY0 float32
+		SX1 float32
+		SY1 float32
+		TX  float32
+		TY  float32
+	}{
+		{0, 0, 16, 16, 0, 0},
+		{1, 2, 17, 34, 100, 200},
+		{3, 5, 7, 11, -13, -17},
+		{0, 0, 1, 1, -1, 1},
+	}
+	for _, tc := range tests {
+		got := make([]float32, 4*graphics.VertexFloatCount)
+		want := make([]float32, 4*graphics.VertexFloatCount)
+		graphics.TranslatedQuadVertices(got, tc.SX0, tc.SY0, tc.SX1, tc.SY1, tc.TX, tc.TY, 0.1, 0.2, 0.3, 0.4)
+		graphics.QuadVertices(want, tc.SX0, tc.SY0, tc.SX1, tc.SY1, 1, 0, 0, 1, tc.TX, tc.TY, 0.1, 0.2, 0.3, 0.4)
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("TranslatedQuadVertices(%v)[%d]: got: %f, want: %f", tc, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func BenchmarkQuadVertices(b *testing.B) {
+	vs := make([]float32, 4*graphics.VertexFloatCount)
+	for i := 0; i < b.N; i++ {
+		graphics.QuadVertices(vs, 0, 0, 16, 16, 1, 0, 0, 1, float32(i%640), float32(i%480), 1, 1, 1, 1)
+	}
+}
+
+func BenchmarkTranslatedQuadVertices(b *testing.B) {
+	vs := make([]float32, 4*graphics.VertexFloatCount)
+	for i := 0; i < b.N; i++ {
+		graphics.TranslatedQuadVertices(vs, 0, 0, 16, 16, float32(i%640), float32(i%480), 1, 1, 1, 1)
+	}
+}
+
+func TestShaderVertexFloatCount(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+		want int
+	}{
+		{
+			name: "standard",
+			src: `//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`,
+			want: graphics.VertexFloatCount,
+		},
+		{
+			name: "omitted varyings",
+			src: `//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4) vec4 {
+	return vec4(1)
+}
+`,
+			want: graphics.VertexFloatCount,
+		},
+		{
+			name: "custom values",
+			src: `//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4) vec4 {
+	return custom
+}
+`,
+			want: graphics.VertexFloatCountWithCustomValues,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ir, err := graphics.CompileShader([]byte(tc.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := graphics.ShaderVertexFloatCount(ir); got != tc.want {
+				t.Errorf("got: %d, want: %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExpandToCustomValuesLayout(t *testing.T) {
+	const n = 3
+	vs := make([]float32, n*graphics.VertexFloatCountWithCustomValues)
+	for i := 0; i < n*graphics.VertexFloatCount; i++ {
+		vs[i] = float32(i + 1)
+	}
+	// Fill the rest with garbage to check the custom values are reset.
+	for i := n * graphics.VertexFloatCount; i < len(vs); i++ {
+		vs[i] = -1
+	}
+
+	graphics.ExpandToCustomValuesLayout(vs)
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < graphics.VertexFloatCountWithCustomValues; j++ {
+			got := vs[i*graphics.VertexFloatCountWithCustomValues+j]
+			var want float32
+			if j < graphics.VertexFloatCount {
+				want = float32(i*graphics.VertexFloatCount + j + 1)
+			}
+			if got != want {
+				t.Errorf("vs[%d]: got: %f, want: %f", i*graphics.VertexFloatCountWithCustomValues+j, got, want)
+			}
+		}
+	}
+}
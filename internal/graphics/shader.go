@@ -17,12 +17,20 @@ package graphics
 import (
 	"bytes"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/shader"
 	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
 )
 
-func shaderSuffix(unit shaderir.Unit) (string, error) {
+const (
+	vertexEntryPoint   = "__vertex"
+	fragmentEntryPoint = "Fragment"
+)
+
+func shaderSuffix(unit shaderir.Unit, customValues bool) (string, error) {
 	shaderSuffix := fmt.Sprintf(`
 var __imageDstTextureSize vec2
 
@@ -136,6 +144,66 @@ func imageSrc%[1]dAt(pos vec2) vec4 {
 	in := step(__imageSrcRegionOrigins[0], pos) - step(__imageSrcRegionOrigins[0] + __imageSrcRegionSizes[%[1]d], pos)
 	return __texelAt(__t%[1]d, %[2]s) * in.x * in.y
 }
+
+// imageSrc%[1]dLayerAt returns the color at pos in the given layer of an image array.
+// The source image must be the 0th layer of an image array, and pos is in the 0th layer's coordinate.
+// If pos is out of the layer, the result is 0.
+// layer must be in [0, the number of layers). Otherwise, the result is undefined.
+func imageSrc%[1]dLayerAt(pos vec2, layer int) vec4 {
+	in := step(__imageSrcRegionOrigins[0], pos) - step(__imageSrcRegionOrigins[0] + __imageSrcRegionSizes[%[1]d], pos)
+	// The layers are stacked vertically in the same texture.
+	offset := vec2(0, float(layer) * __imageSrcRegionSizes[%[1]d].y)
+	return __texelAt(__t%[1]d, %[2]s + offset) * in.x * in.y
+}
+
+func __clampToImageSrc%[1]dRegion(pos vec2) vec2 {
+	// Clamp pos to the centers of the edge pixels so that the pixels out of the region are not sampled.
+	return clamp(pos, __imageSrcRegionOrigins[0] + 1/2.0, __imageSrcRegionOrigins[0] + __imageSrcRegionSizes[%[1]d] - 1/2.0)
+}
+
+// imageSrc%[1]dLinearAt returns the color at pos with bilinear interpolation.
+// The pixels out of the region are treated as the nearest edge pixels.
+//
+// imageSrc%[1]dLinearAt reads 4 pixels.
+func imageSrc%[1]dLinearAt(pos vec2) vec4 {
+	p0 := pos - 1/2.0
+	p1 := pos + 1/2.0
+	c0 := imageSrc%[1]dUnsafeAt(__clampToImageSrc%[1]dRegion(p0))
+	c1 := imageSrc%[1]dUnsafeAt(__clampToImageSrc%[1]dRegion(vec2(p1.x, p0.y)))
+	c2 := imageSrc%[1]dUnsafeAt(__clampToImageSrc%[1]dRegion(vec2(p0.x, p1.y)))
+	c3 := imageSrc%[1]dUnsafeAt(__clampToImageSrc%[1]dRegion(p1))
+	rate := fract(p1)
+	return mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)
+}
+
+func __bicubicRowOfImageSrc%[1]d(x float, y float, w vec4) vec4 {
+	c0 := imageSrc%[1]dUnsafeAt(__clampToImageSrc%[1]dRegion(vec2(x-1, y)))
+	c1 := imageSrc%[1]dUnsafeAt(__clampToImageSrc%[1]dRegion(vec2(x, y)))
+	c2 := imageSrc%[1]dUnsafeAt(__clampToImageSrc%[1]dRegion(vec2(x+1, y)))
+	c3 := imageSrc%[1]dUnsafeAt(__clampToImageSrc%[1]dRegion(vec2(x+2, y)))
+	return c0*w.x + c1*w.y + c2*w.z + c3*w.w
+}
+
+// imageSrc%[1]dBicubicAt returns the color at pos with bicubic (Catmull-Rom) interpolation.
+// The pixels out of the region are treated as the nearest edge pixels.
+// The result is clamped to a valid premultiplied-alpha color.
+//
+// imageSrc%[1]dBicubicAt reads 16 pixels, and is much more expensive than imageSrc%[1]dAt and imageSrc%[1]dLinearAt.
+func imageSrc%[1]dBicubicAt(pos vec2) vec4 {
+	p := pos - 1/2.0
+	// c is the center of the upper-left pixel of the 2x2 pixels around pos.
+	c := floor(p) + 1/2.0
+	wx := __catmullRomWeights(fract(p.x))
+	wy := __catmullRomWeights(fract(p.y))
+	clr := __bicubicRowOfImageSrc%[1]d(c.x, c.y-1, wx)*wy.x +
+		__bicubicRowOfImageSrc%[1]d(c.x, c.y, wx)*wy.y +
+		__bicubicRowOfImageSrc%[1]d(c.x, c.y+1, wx)*wy.z +
+		__bicubicRowOfImageSrc%[1]d(c.x, c.y+2, wx)*wy.w
+	// The Catmull-Rom spline can overshoot. Keep the color valid.
+	clr = clamp(clr, 0, 1)
+	clr.rgb = min(clr.rgb, clr.a)
+	return clr
+}
 `, i, pos)
 		case shaderir.Texels:
 			shaderSuffix += fmt.Sprintf(`
@@ -151,22 +219,71 @@ func imageSrc%[1]dAt(pos vec2) vec4 {
 		}
 	}
 
+	if unit == shaderir.Pixels {
+		shaderSuffix += `
+// __catmullRomWeights returns the weights of the 4 pixels for the Catmull-Rom spline at t in [0, 1).
+func __catmullRomWeights(t float) vec4 {
+	t2 := t * t
+	t3 := t2 * t
+	return vec4(-t3+2*t2-t, 3*t3-5*t2+2, -3*t3+4*t2+t, t3-t2) / 2
+}
+`
+	}
+
 	shaderSuffix += `
 var __projectionMatrix mat4
-
+`
+	// The vertex layout with custom values is used only when the fragment entry point takes them,
+	// so that the other shaders can use the smaller standard layout.
+	if customValues {
+		shaderSuffix += `
+func __vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec4, vec2, vec4, vec4) {
+	return __projectionMatrix * vec4(dstPos, 0, 1), srcPos, color, custom
+}
+`
+	} else {
+		shaderSuffix += `
 func __vertex(dstPos vec2, srcPos vec2, color vec4) (vec4, vec2, vec4) {
 	return __projectionMatrix * vec4(dstPos, 0, 1), srcPos, color
 }
 `
+	}
 	return shaderSuffix, nil
 }
 
+// fragmentTakesCustomValues reports whether the fragment entry point in the source takes custom values,
+// which is the fourth parameter.
+//
+// If the source cannot be parsed, fragmentTakesCustomValues returns false and the compiler reports the error later.
+func fragmentTakesCustomValues(fragmentSrc []byte) bool {
+	f, err := parser.ParseFile(token.NewFileSet(), "", fragmentSrc, parser.SkipObjectResolution)
+	if err != nil {
+		return false
+	}
+	for _, d := range f.Decls {
+		fd, ok := d.(*ast.FuncDecl)
+		if !ok || fd.Recv != nil || fd.Name.Name != fragmentEntryPoint {
+			continue
+		}
+		var n int
+		for _, p := range fd.Type.Params.List {
+			if len(p.Names) == 0 {
+				n++
+				continue
+			}
+			n += len(p.Names)
+		}
+		return n >= 4
+	}
+	return false
+}
+
 func completeShaderSource(fragmentSrc []byte) ([]byte, error) {
 	unit, err := shader.ParseCompilerDirectives(fragmentSrc)
 	if err != nil {
 		return nil, err
 	}
-	suffix, err := shaderSuffix(unit)
+	suffix, err := shaderSuffix(unit, fragmentTakesCustomValues(fragmentSrc))
 	if err != nil {
 		return nil, err
 	}
@@ -184,20 +301,16 @@ func CompileShader(fragmentSrc []byte) (*shaderir.Program, error) {
 		return nil, err
 	}
 
-	const (
-		vert = "__vertex"
-		frag = "Fragment"
-	)
-	ir, err := shader.Compile(src, vert, frag, ShaderImageCount)
+	ir, err := shader.Compile(src, vertexEntryPoint, fragmentEntryPoint, ShaderImageCount)
 	if err != nil {
 		return nil, err
 	}
 
 	if ir.VertexFunc.Block == nil {
-		return nil, fmt.Errorf("graphics: vertex shader entry point '%s' is missing", vert)
+		return nil, fmt.Errorf("graphics: vertex shader entry point '%s' is missing", vertexEntryPoint)
 	}
 	if ir.FragmentFunc.Block == nil {
-		return nil, fmt.Errorf("graphics: fragment shader entry point '%s' is missing", frag)
+		return nil, fmt.Errorf("graphics: fragment shader entry point '%s' is missing", fragmentEntryPoint)
 	}
 
 	return ir, nil
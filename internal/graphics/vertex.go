@@ -14,6 +14,10 @@
 
 package graphics
 
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+)
+
 const (
 	ShaderImageCount = 4
 
@@ -39,7 +43,17 @@ const (
 )
 
 const (
+	// VertexFloatCount is the number of float values per vertex in the standard layout.
+	// A vertex consists of a destination position (2), a source position (2), and a color (4).
 	VertexFloatCount = 8
+
+	// VertexFloatCountWithCustomValues is the number of float values per vertex in the layout with custom values.
+	// A vertex consists of the standard values and custom values (4).
+	// This layout is used only for a shader whose fragment entry point takes custom values.
+	VertexFloatCountWithCustomValues = VertexFloatCount + 4
+
+	// MaxVertexFloatCount is the maximum number of float values per vertex among the layouts.
+	MaxVertexFloatCount = VertexFloatCountWithCustomValues
 )
 
 var (
@@ -99,6 +113,54 @@ func QuadVertices(dst []float32, sx0, sy0, sx1, sy1 float32, a, b, c, d, tx, ty
 	dst[31] = ca
 }
 
+// TranslatedQuadVertices sets a float32 slice for a quadrangle that is translated by integers without any other
+// transformation.
+// TranslatedQuadVertices returns the same result as QuadVertices with an identity matrix and the integer
+// translation (tx, ty), but is faster as this skips the matrix multiplications and the pixel adjustments.
+func TranslatedQuadVertices(dst []float32, sx0, sy0, sx1, sy1 float32, tx, ty float32, cr, cg, cb, ca float32) {
+	x0, y0 := tx, ty
+	x1, y1 := sx1-sx0+tx, sy1-sy0+ty
+
+	// This function is very performance-sensitive and implement in a very dumb way.
+	dst = dst[:4*VertexFloatCount]
+
+	dst[0] = x0
+	dst[1] = y0
+	dst[2] = sx0
+	dst[3] = sy0
+	dst[4] = cr
+	dst[5] = cg
+	dst[6] = cb
+	dst[7] = ca
+
+	dst[8] = x1
+	dst[9] = y0
+	dst[10] = sx1
+	dst[11] = sy0
+	dst[12] = cr
+	dst[13] = cg
+	dst[14] = cb
+	dst[15] = ca
+
+	dst[16] = x0
+	dst[17] = y1
+	dst[18] = sx0
+	dst[19] = sy1
+	dst[20] = cr
+	dst[21] = cg
+	dst[22] = cb
+	dst[23] = ca
+
+	dst[24] = x1
+	dst[25] = y1
+	dst[26] = sx1
+	dst[27] = sy1
+	dst[28] = cr
+	dst[29] = cg
+	dst[30] = cb
+	dst[31] = ca
+}
+
 func adjustDestinationPixel(x float32) float32 {
 	// Avoid the center of the pixel, which is problematic (#929, #1171).
 	// Instead, align the vertices with about 1/3 pixels.
@@ -124,3 +186,30 @@ func adjustDestinationPixel(x float32) float32 {
 		return ix + 16.0/16.0
 	}
 }
+
+// ShaderVertexFloatCount returns the number of float values per vertex for the given shader program.
+func ShaderVertexFloatCount(program *shaderir.Program) int {
+	var n int
+	for _, a := range program.Attributes {
+		n += a.Uint32Count()
+	}
+	return n
+}
+
+// ExpandToCustomValuesLayout converts vertices in the standard layout to the layout with custom values in place.
+// The vertices in the standard layout must be at the head of vertices,
+// and len(vertices) must be the length for the layout with custom values.
+// The custom values are filled with zeros.
+func ExpandToCustomValuesLayout(vertices []float32) {
+	const (
+		n0 = VertexFloatCount
+		n1 = VertexFloatCountWithCustomValues
+	)
+	// Iterate backward so that the values not converted yet are not overwritten.
+	for i := len(vertices)/n1 - 1; i >= 0; i-- {
+		copy(vertices[i*n1:i*n1+n0], vertices[i*n0:(i+1)*n0])
+		for j := i*n1 + n0; j < (i+1)*n1; j++ {
+			vertices[j] = 0
+		}
+	}
+}
@@ -166,7 +166,7 @@ func (c *drawTrianglesCommand) CanMergeWithDrawTrianglesCommand(dst *Image, srcs
 	if c.fillRule != fillRule {
 		return false
 	}
-	if c.fillRule != graphicsdriver.FillAll && mightOverlapDstRegions(c.vertices, vertices) {
+	if c.fillRule != graphicsdriver.FillAll && mightOverlapDstRegions(c.vertices, vertices, shader.vertexFloatCount) {
 		return false
 	}
 	return true
@@ -177,15 +177,15 @@ var (
 	negInf32 = float32(math.Inf(-1))
 )
 
-func dstRegionFromVertices(vertices []float32) (minX, minY, maxX, maxY float32) {
+func dstRegionFromVertices(vertices []float32, vertexFloatCount int) (minX, minY, maxX, maxY float32) {
 	minX = posInf32
 	minY = posInf32
 	maxX = negInf32
 	maxY = negInf32
 
-	for i := 0; i < len(vertices)/graphics.VertexFloatCount; i++ {
-		x := vertices[graphics.VertexFloatCount*i]
-		y := vertices[graphics.VertexFloatCount*i+1]
+	for i := 0; i < len(vertices)/vertexFloatCount; i++ {
+		x := vertices[vertexFloatCount*i]
+		y := vertices[vertexFloatCount*i+1]
 		if x < minX {
 			minX = x
 		}
@@ -202,9 +202,9 @@ func dstRegionFromVertices(vertices []float32) (minX, minY, maxX, maxY float32)
 	return
 }
 
-func mightOverlapDstRegions(vertices1, vertices2 []float32) bool {
-	minX1, minY1, maxX1, maxY1 := dstRegionFromVertices(vertices1)
-	minX2, minY2, maxX2, maxY2 := dstRegionFromVertices(vertices2)
+func mightOverlapDstRegions(vertices1, vertices2 []float32, vertexFloatCount int) bool {
+	minX1, minY1, maxX1, maxY1 := dstRegionFromVertices(vertices1, vertexFloatCount)
+	minX2, minY2, maxX2, maxY2 := dstRegionFromVertices(vertices2, vertexFloatCount)
 	const margin = 1
 	return minX1 < maxX2+margin && minX2 < maxX1+margin && minY1 < maxY2+margin && minY2 < maxY1+margin
 }
@@ -362,6 +362,46 @@ func (c *newShaderCommand) NeedsSync() bool {
 	return true
 }
 
+// pushDebugGroupCommand is a command to push a debug group to label the following commands.
+type pushDebugGroupCommand struct {
+	name string
+}
+
+func (c *pushDebugGroupCommand) String() string {
+	return fmt.Sprintf("push-debug-group: name: %q", c.name)
+}
+
+// Exec executes a pushDebugGroupCommand.
+func (c *pushDebugGroupCommand) Exec(commandQueue *commandQueue, graphicsDriver graphicsdriver.Graphics, indexOffset int) error {
+	if d, ok := graphicsDriver.(graphicsdriver.DebugGrouper); ok {
+		d.PushDebugGroup(c.name)
+	}
+	return nil
+}
+
+func (c *pushDebugGroupCommand) NeedsSync() bool {
+	return false
+}
+
+// popDebugGroupCommand is a command to pop the latest debug group.
+type popDebugGroupCommand struct{}
+
+func (c *popDebugGroupCommand) String() string {
+	return "pop-debug-group"
+}
+
+// Exec executes a popDebugGroupCommand.
+func (c *popDebugGroupCommand) Exec(commandQueue *commandQueue, graphicsDriver graphicsdriver.Graphics, indexOffset int) error {
+	if d, ok := graphicsDriver.(graphicsdriver.DebugGrouper); ok {
+		d.PopDebugGroup()
+	}
+	return nil
+}
+
+func (c *popDebugGroupCommand) NeedsSync() bool {
+	return false
+}
+
 // InitializeGraphicsDriverState initialize the current graphics driver state.
 func InitializeGraphicsDriverState(graphicsDriver graphicsdriver.Graphics) (err error) {
 	runOnRenderThread(func() {
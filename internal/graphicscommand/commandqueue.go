@@ -37,10 +37,8 @@ const (
 	// This value cannot be exactly 2^32 especially with WebGL 2, as 2^32th vertex is not rendered correctly.
 	// See https://registry.khronos.org/webgl/specs/latest/2.0/#5.18 .
 	//
-	// On 32bit architectures, this value is an adjusted number so that maxVertexFloatCount doesn't overflow int.
-	MaxVertexCount = is64bit*math.MaxUint32 + is32bit*(math.MaxInt32/graphics.VertexFloatCount)
-
-	maxVertexFloatCount = MaxVertexCount * graphics.VertexFloatCount
+	// On 32bit architectures, this value is an adjusted number so that the number of vertex float values doesn't overflow int.
+	MaxVertexCount = is64bit*math.MaxUint32 + is32bit*(math.MaxInt32/graphics.MaxVertexFloatCount)
 )
 
 var vsyncEnabled atomic.Bool
@@ -66,6 +64,27 @@ func FlushCommands(graphicsDriver graphicsdriver.Graphics, endFrame bool) error
 	return nil
 }
 
+// PushDebugGroup enqueues a command to push a debug group with the name.
+// The following commands are labeled with the name for GPU debuggers until PopDebugGroup is called.
+//
+// If the graphics driver doesn't support debug groups, the command does nothing.
+func PushDebugGroup(name string) {
+	theCommandQueueManager.debugGroupDepth++
+	theCommandQueueManager.enqueueCommand(&pushDebugGroupCommand{
+		name: name,
+	})
+}
+
+// PopDebugGroup enqueues a command to pop the latest debug group.
+// If there is no debug group, PopDebugGroup does nothing.
+func PopDebugGroup() {
+	if theCommandQueueManager.debugGroupDepth == 0 {
+		return
+	}
+	theCommandQueueManager.debugGroupDepth--
+	theCommandQueueManager.enqueueCommand(&popDebugGroupCommand{})
+}
+
 // commandQueue is a command queue for drawing commands.
 type commandQueue struct {
 	// commands is a queue of drawing commands.
@@ -77,6 +96,9 @@ type commandQueue struct {
 
 	tmpNumVertexFloats int
 
+	// tmpVertexFloatCount is the number of float values per vertex in the current vertex buffer.
+	tmpVertexFloatCount int
+
 	drawTrianglesCommandPool drawTrianglesCommandPool
 
 	uint32sBuffer uint32sBuffer
@@ -100,26 +122,36 @@ func (q *commandQueue) appendIndices(indices []uint32, offset uint32) {
 }
 
 // mustUseDifferentVertexBuffer reports whether a different vertex buffer must be used.
-func mustUseDifferentVertexBuffer(nextNumVertexFloats int) bool {
-	return nextNumVertexFloats > maxVertexFloatCount
+//
+// A vertex buffer has only one vertex layout, so a different vertex buffer must be used when the layout changes.
+func mustUseDifferentVertexBuffer(nextNumVertexFloats int, currentVertexFloatCount, nextVertexFloatCount int) bool {
+	if currentVertexFloatCount != nextVertexFloatCount {
+		return true
+	}
+	return nextNumVertexFloats/nextVertexFloatCount > MaxVertexCount
 }
 
 // EnqueueDrawTrianglesCommand enqueues a drawing-image command.
 func (q *commandQueue) EnqueueDrawTrianglesCommand(dst *Image, srcs [graphics.ShaderImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) {
-	if len(vertices) > maxVertexFloatCount {
-		panic(fmt.Sprintf("graphicscommand: len(vertices) must equal to or less than %d but was %d", maxVertexFloatCount, len(vertices)))
+	vertexFloatCount := shader.vertexFloatCount
+	if len(vertices)%vertexFloatCount != 0 {
+		panic(fmt.Sprintf("graphicscommand: len(vertices) must be a multiple of %d but was %d", vertexFloatCount, len(vertices)))
+	}
+	if len(vertices)/vertexFloatCount > MaxVertexCount {
+		panic(fmt.Sprintf("graphicscommand: the number of vertices must equal to or less than %d but was %d", MaxVertexCount, len(vertices)/vertexFloatCount))
 	}
 
 	split := false
-	if mustUseDifferentVertexBuffer(q.tmpNumVertexFloats + len(vertices)) {
+	if q.tmpNumVertexFloats > 0 && mustUseDifferentVertexBuffer(q.tmpNumVertexFloats+len(vertices), q.tmpVertexFloatCount, vertexFloatCount) {
 		q.tmpNumVertexFloats = 0
 		split = true
 	}
+	q.tmpVertexFloatCount = vertexFloatCount
 
 	// Assume that all the image sizes are same.
 	// Assume that the images are packed from the front in the slice srcs.
 	q.vertices = append(q.vertices, vertices...)
-	q.appendIndices(indices, uint32(q.tmpNumVertexFloats/graphics.VertexFloatCount))
+	q.appendIndices(indices, uint32(q.tmpNumVertexFloats/vertexFloatCount))
 	q.tmpNumVertexFloats += len(vertices)
 
 	// prependPreservedUniforms not only prepends values to the given slice but also creates a new slice.
@@ -256,8 +288,10 @@ func (q *commandQueue) flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 		q.vertices = q.vertices[:0]
 		q.indices = q.indices[:0]
 		q.tmpNumVertexFloats = 0
+		q.tmpVertexFloatCount = 0
 
 		if endFrame {
+			endFrameStats()
 			q.uint32sBuffer.reset()
 			for i, f := range q.finalizers {
 				f()
@@ -272,11 +306,13 @@ func (q *commandQueue) flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 		nv := 0
 		ne := 0
 		nc := 0
+		vfc := 0
 		for _, c := range cs {
 			if dtc, ok := c.(*drawTrianglesCommand); ok {
-				if nc > 0 && mustUseDifferentVertexBuffer(nv+dtc.numVertices()) {
+				if nv > 0 && mustUseDifferentVertexBuffer(nv+dtc.numVertices(), vfc, dtc.shader.vertexFloatCount) {
 					break
 				}
+				vfc = dtc.shader.vertexFloatCount
 				nv += dtc.numVertices()
 				ne += dtc.numIndices()
 			}
@@ -300,6 +336,7 @@ func (q *commandQueue) flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 			// introduced than drawTrianglesCommand.
 			if dtc, ok := c.(*drawTrianglesCommand); ok {
 				indexOffset += dtc.numIndices()
+				drawCallCount.Add(1)
 			}
 		}
 		cs = cs[nc:]
@@ -453,6 +490,9 @@ func (c *commandQueuePool) put(queue *commandQueue) {
 type commandQueueManager struct {
 	pool    commandQueuePool
 	current *commandQueue
+
+	// debugGroupDepth is the number of the debug groups pushed and not popped yet.
+	debugGroupDepth int
 }
 
 var theCommandQueueManager commandQueueManager
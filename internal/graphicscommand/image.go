@@ -71,9 +71,17 @@ func NewImage(width, height int, screenFramebuffer bool) *Image {
 		screen: screenFramebuffer,
 	}
 	theCommandQueueManager.enqueueCommand(c)
+	imageCount.Add(1)
+	imageBytes.Add(i.byteSize())
 	return i
 }
 
+// byteSize returns the estimated size of the image in bytes on GPU.
+func (i *Image) byteSize() int64 {
+	w, h := i.InternalSize()
+	return 4 * int64(w) * int64(h)
+}
+
 func (i *Image) flushBufferedWritePixels() {
 	if len(i.bufferedWritePixelsArgs) == 0 {
 		return
@@ -94,6 +102,8 @@ func (i *Image) Dispose() {
 		target: i,
 	}
 	theCommandQueueManager.enqueueCommand(c)
+	imageCount.Add(-1)
+	imageBytes.Add(-i.byteSize())
 }
 
 func (i *Image) InternalSize() (int, int) {
@@ -15,6 +15,7 @@
 package graphicscommand
 
 import (
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
 	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
 )
@@ -31,12 +32,16 @@ type Shader struct {
 	shader graphicsdriver.Shader
 	ir     *shaderir.Program
 	id     int
+
+	// vertexFloatCount is the number of float values per vertex for this shader.
+	vertexFloatCount int
 }
 
 func NewShader(ir *shaderir.Program) *Shader {
 	s := &Shader{
-		ir: ir,
-		id: genNextShaderID(),
+		ir:               ir,
+		id:               genNextShaderID(),
+		vertexFloatCount: graphics.ShaderVertexFloatCount(ir),
 	}
 	c := &newShaderCommand{
 		result: s,
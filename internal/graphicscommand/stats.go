@@ -0,0 +1,53 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicscommand
+
+import (
+	"sync/atomic"
+)
+
+// FrameStats represents statistics of graphics commands.
+type FrameStats struct {
+	// DrawCallCount is the number of the draw calls executed in the last frame.
+	DrawCallCount int
+
+	// ImageCount is the number of the current images.
+	ImageCount int
+
+	// ImageBytes is the estimated total size of the current images in bytes.
+	ImageBytes int64
+}
+
+var (
+	drawCallCount          atomic.Int64
+	lastFrameDrawCallCount atomic.Int64
+	imageCount             atomic.Int64
+	imageBytes             atomic.Int64
+)
+
+// LastFrameStats returns the statistics of the last frame.
+//
+// LastFrameStats is concurrent-safe.
+func LastFrameStats() FrameStats {
+	return FrameStats{
+		DrawCallCount: int(lastFrameDrawCallCount.Load()),
+		ImageCount:    int(imageCount.Load()),
+		ImageBytes:    imageBytes.Load(),
+	}
+}
+
+func endFrameStats() {
+	lastFrameDrawCallCount.Store(drawCallCount.Swap(0))
+}
@@ -55,6 +55,15 @@ var inputElementDescsForDX11 = []_D3D11_INPUT_ELEMENT_DESC{
 		InputSlotClass:       _D3D11_INPUT_PER_VERTEX_DATA,
 		InstanceDataStepRate: 0,
 	},
+	{
+		SemanticName:         &([]byte("TEXCOORD\000"))[0],
+		SemanticIndex:        1,
+		Format:               _DXGI_FORMAT_R32G32B32A32_FLOAT,
+		InputSlot:            0,
+		AlignedByteOffset:    _D3D11_APPEND_ALIGNED_ELEMENT,
+		InputSlotClass:       _D3D11_INPUT_PER_VERTEX_DATA,
+		InstanceDataStepRate: 0,
+	},
 }
 
 func blendFactorToBlend11(f graphicsdriver.BlendFactor, alpha bool) _D3D11_BLEND {
@@ -138,6 +147,10 @@ type graphics11 struct {
 	vertexBuffer            *_ID3D11Buffer
 	vertexBufferSizeInBytes uint32
 
+	// vertexBufferStrideInBytes is the stride of the bound vertex buffer.
+	// The stride depends on the vertex layout of the shader.
+	vertexBufferStrideInBytes uint32
+
 	indexBuffer            *_ID3D11Buffer
 	indexBufferSizeInBytes uint32
 
@@ -333,8 +346,8 @@ func (g *graphics11) SetVertices(vertices []float32, indices []uint32) error {
 		}
 		g.vertexBuffer = b
 		g.vertexBufferSizeInBytes = size
-		g.deviceContext.IASetVertexBuffers(0, []*_ID3D11Buffer{g.vertexBuffer},
-			[]uint32{graphics.VertexFloatCount * uint32(unsafe.Sizeof(vertices[0]))}, []uint32{0})
+		// The new vertex buffer is bound when a shader is used.
+		g.vertexBufferStrideInBytes = 0
 	}
 	if size := pow2(uint32(len(indices)) * uint32(unsafe.Sizeof(indices[0]))); g.indexBufferSizeInBytes < size {
 		if g.indexBuffer != nil {
@@ -494,6 +507,8 @@ func (g *graphics11) NewShader(program *shaderir.Program) (graphicsdriver.Shader
 		uniformOffsets:   hlsl.CalcUniformMemoryOffsets(program),
 		vertexShaderBlob: vsh,
 		pixelShaderBlob:  psh,
+		attributeCount:   len(program.Attributes),
+		vertexFloatCount: graphics.ShaderVertexFloatCount(program),
 	}
 	g.addShader(s)
 	return s, nil
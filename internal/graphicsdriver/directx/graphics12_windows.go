@@ -1076,6 +1076,9 @@ func (g *graphics12) NewShader(program *shaderir.Program) (graphicsdriver.Shader
 		uniformOffsets: hlsl.CalcUniformMemoryOffsets(program),
 		vertexShader:   vsh,
 		pixelShader:    psh,
+
+		attributeCount:   len(program.Attributes),
+		vertexFloatCount: graphics.ShaderVertexFloatCount(program),
 	}
 	g.addShader(s)
 	return s, nil
@@ -1148,7 +1151,7 @@ func (g *graphics12) DrawTriangles(dstID graphicsdriver.ImageID, srcs [graphics.
 		{
 			BufferLocation: g.vertices[g.frameIndex][len(g.vertices[g.frameIndex])-1].value.GetGPUVirtualAddress(),
 			SizeInBytes:    g.vertices[g.frameIndex][len(g.vertices[g.frameIndex])-1].sizeInBytes,
-			StrideInBytes:  graphics.VertexFloatCount * uint32(unsafe.Sizeof(float32(0))),
+			StrideInBytes:  uint32(shader.vertexFloatCount) * uint32(unsafe.Sizeof(float32(0))),
 		},
 	})
 	g.drawCommandList.IASetIndexBuffer(&_D3D12_INDEX_BUFFER_VIEW{
@@ -51,6 +51,15 @@ var inputElementDescsForDX12 = []_D3D12_INPUT_ELEMENT_DESC{
 		InputSlotClass:       _D3D12_INPUT_CLASSIFICATION_PER_VERTEX_DATA,
 		InstanceDataStepRate: 0,
 	},
+	{
+		SemanticName:         &([]byte("TEXCOORD\000"))[0],
+		SemanticIndex:        1,
+		Format:               _DXGI_FORMAT_R32G32B32A32_FLOAT,
+		InputSlot:            0,
+		AlignedByteOffset:    _D3D12_APPEND_ALIGNED_ELEMENT,
+		InputSlotClass:       _D3D12_INPUT_CLASSIFICATION_PER_VERTEX_DATA,
+		InstanceDataStepRate: 0,
+	},
 }
 
 const numDescriptorsPerFrame = 32
@@ -422,7 +431,7 @@ func (p *pipelineStates) ensureRootSignature(device *_ID3D12Device) (rootSignatu
 	return p.rootSignature, nil
 }
 
-func (p *pipelineStates) newPipelineState(device *_ID3D12Device, vsh, psh *_ID3DBlob, blend graphicsdriver.Blend, stencilMode stencilMode, screen bool) (state *_ID3D12PipelineState, ferr error) {
+func (p *pipelineStates) newPipelineState(device *_ID3D12Device, vsh, psh *_ID3DBlob, attributeCount int, blend graphicsdriver.Blend, stencilMode stencilMode, screen bool) (state *_ID3D12PipelineState, ferr error) {
 	rootSignature, err := p.ensureRootSignature(device)
 	if err != nil {
 		return nil, err
@@ -527,9 +536,10 @@ func (p *pipelineStates) newPipelineState(device *_ID3D12Device, vsh, psh *_ID3D
 			ConservativeRaster:    _D3D12_CONSERVATIVE_RASTERIZATION_MODE_OFF,
 		},
 		DepthStencilState: depthStencilDesc,
+		// Use only the input elements for the attributes of the shader.
 		InputLayout: _D3D12_INPUT_LAYOUT_DESC{
 			pInputElementDescs: &inputElementDescsForDX12[0],
-			NumElements:        uint32(len(inputElementDescsForDX12)),
+			NumElements:        uint32(attributeCount),
 		},
 		PrimitiveTopologyType: _D3D12_PRIMITIVE_TOPOLOGY_TYPE_TRIANGLE,
 		NumRenderTargets:      1,
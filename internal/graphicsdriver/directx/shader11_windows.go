@@ -29,6 +29,8 @@ type shader11 struct {
 	uniformOffsets   []int
 	vertexShaderBlob *_ID3DBlob
 	pixelShaderBlob  *_ID3DBlob
+	attributeCount   int
+	vertexFloatCount int
 
 	inputLayout    *_ID3D11InputLayout
 	vertexShader   *_ID3D11VertexShader
@@ -97,6 +99,11 @@ func (s *shader11) use(uniforms []uint32, srcs [graphics.ShaderImageCount]*image
 	}
 	s.graphics.deviceContext.IASetInputLayout(il)
 
+	if stride := uint32(s.vertexFloatCount) * uint32(unsafe.Sizeof(float32(0))); s.graphics.vertexBufferStrideInBytes != stride {
+		s.graphics.deviceContext.IASetVertexBuffers(0, []*_ID3D11Buffer{s.graphics.vertexBuffer}, []uint32{stride}, []uint32{0})
+		s.graphics.vertexBufferStrideInBytes = stride
+	}
+
 	cb, err := s.ensureConstantBuffer()
 	if err != nil {
 		return err
@@ -135,7 +142,8 @@ func (s *shader11) ensureInputLayout() (*_ID3D11InputLayout, error) {
 		return s.inputLayout, nil
 	}
 
-	i, err := s.graphics.device.CreateInputLayout(inputElementDescsForDX11, s.vertexShaderBlob.GetBufferPointer(), s.vertexShaderBlob.GetBufferSize())
+	// Use only the input elements for the attributes of the shader.
+	i, err := s.graphics.device.CreateInputLayout(inputElementDescsForDX11[:s.attributeCount], s.vertexShaderBlob.GetBufferPointer(), s.vertexShaderBlob.GetBufferSize())
 	if err != nil {
 		return nil, err
 	}
@@ -33,6 +33,9 @@ type shader12 struct {
 	vertexShader   *_ID3DBlob
 	pixelShader    *_ID3DBlob
 
+	attributeCount   int
+	vertexFloatCount int
+
 	pipelineStates map[pipelineStateKey]*_ID3D12PipelineState
 }
 
@@ -77,7 +80,7 @@ func (s *shader12) pipelineState(blend graphicsdriver.Blend, stencilMode stencil
 		return state, nil
 	}
 
-	state, err := s.graphics.pipelineStates.newPipelineState(s.graphics.device, s.vertexShader, s.pixelShader, blend, stencilMode, screen)
+	state, err := s.graphics.pipelineStates.newPipelineState(s.graphics.device, s.vertexShader, s.pixelShader, s.attributeCount, blend, stencilMode, screen)
 	if err != nil {
 		return nil, err
 	}
@@ -75,6 +75,12 @@ type Resetter interface {
 	Reset() error
 }
 
+// DebugGrouper is implemented by a driver that can label a group of GPU commands for GPU debuggers.
+type DebugGrouper interface {
+	PushDebugGroup(name string)
+	PopDebugGroup()
+}
+
 type Image interface {
 	ID() ImageID
 	Dispose()
@@ -61,6 +61,10 @@ type Graphics struct {
 	maxImageSize int
 	tmpTextures  []mtl.Texture
 
+	// debugGroups is the stack of the debug group names.
+	// The debug groups are pushed to every command buffer again, as a debug group cannot span command buffers.
+	debugGroups []string
+
 	pool cocoa.NSAutoreleasePool
 }
 
@@ -183,9 +187,7 @@ func (g *Graphics) gcBuffers() {
 }
 
 func (g *Graphics) availableBuffer(length uintptr) mtl.Buffer {
-	if g.cb == (mtl.CommandBuffer{}) {
-		g.cb = g.cq.CommandBuffer()
-	}
+	g.ensureCommandBuffer()
 
 	var newBuf mtl.Buffer
 	for b := range g.unusedBuffers {
@@ -223,6 +225,36 @@ func (g *Graphics) SetVertices(vertices []float32, indices []uint32) error {
 	return nil
 }
 
+func (g *Graphics) ensureCommandBuffer() {
+	if g.cb != (mtl.CommandBuffer{}) {
+		return
+	}
+	g.cb = g.cq.CommandBuffer()
+	for _, name := range g.debugGroups {
+		g.cb.PushDebugGroup(name)
+	}
+}
+
+func (g *Graphics) PushDebugGroup(name string) {
+	// A debug group of a command buffer cannot be pushed or popped while an encoder is active.
+	g.flushRenderCommandEncoderIfNeeded()
+	g.debugGroups = append(g.debugGroups, name)
+	if g.cb != (mtl.CommandBuffer{}) {
+		g.cb.PushDebugGroup(name)
+	}
+}
+
+func (g *Graphics) PopDebugGroup() {
+	if len(g.debugGroups) == 0 {
+		return
+	}
+	g.flushRenderCommandEncoderIfNeeded()
+	g.debugGroups = g.debugGroups[:len(g.debugGroups)-1]
+	if g.cb != (mtl.CommandBuffer{}) {
+		g.cb.PopDebugGroup()
+	}
+}
+
 func (g *Graphics) flushIfNeeded(present bool) {
 	if g.cb == (mtl.CommandBuffer{}) && !present {
 		return
@@ -240,6 +272,9 @@ func (g *Graphics) flushIfNeeded(present bool) {
 		}
 	}
 
+	for range g.debugGroups {
+		g.cb.PopDebugGroup()
+	}
 	g.cb.Commit()
 
 	for _, t := range g.tmpTextures {
@@ -504,9 +539,7 @@ func (g *Graphics) draw(dst *Image, dstRegions []graphicsdriver.DstRegion, srcs
 			rpd.StencilAttachment.Texture = dst.stencil
 		}
 
-		if g.cb == (mtl.CommandBuffer{}) {
-			g.cb = g.cq.CommandBuffer()
-		}
+		g.ensureCommandBuffer()
 		g.rce = g.cb.RenderCommandEncoderWithDescriptor(rpd)
 	}
 
@@ -869,9 +902,7 @@ func (i *Image) WritePixels(args []graphicsdriver.PixelsArgs) error {
 		}, 0, unsafe.Pointer(&a.Pixels[0]), 4*a.Region.Dx())
 	}
 
-	if g.cb == (mtl.CommandBuffer{}) {
-		g.cb = i.graphics.cq.CommandBuffer()
-	}
+	g.ensureCommandBuffer()
 	bce := g.cb.BlitCommandEncoder()
 	for _, a := range args {
 		so := mtl.Origin{X: a.Region.Min.X - region.Min.X, Y: a.Region.Min.Y - region.Min.Y, Z: 0}
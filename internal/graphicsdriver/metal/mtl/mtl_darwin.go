@@ -530,6 +530,8 @@ var (
 	sel_presentDrawable                                                                                                               = objc.RegisterName("presentDrawable:")
 	sel_commit                                                                                                                        = objc.RegisterName("commit")
 	sel_waitUntilCompleted                                                                                                            = objc.RegisterName("waitUntilCompleted")
+	sel_pushDebugGroup                                                                                                                = objc.RegisterName("pushDebugGroup:")
+	sel_popDebugGroup                                                                                                                 = objc.RegisterName("popDebugGroup")
 	sel_waitUntilScheduled                                                                                                            = objc.RegisterName("waitUntilScheduled")
 	sel_renderCommandEncoderWithDescriptor                                                                                            = objc.RegisterName("renderCommandEncoderWithDescriptor:")
 	sel_stencilAttachment                                                                                                             = objc.RegisterName("stencilAttachment")
@@ -844,6 +846,22 @@ func (cb CommandBuffer) WaitUntilScheduled() {
 	cb.commandBuffer.Send(sel_waitUntilScheduled)
 }
 
+// PushDebugGroup pushes a new string onto this command buffer's stack of debug groups.
+//
+// Reference: https://developer.apple.com/documentation/metal/mtlcommandbuffer/2869549-pushdebuggroup?language=objc.
+func (cb CommandBuffer) PushDebugGroup(name string) {
+	s := cocoa.NSString_alloc().InitWithUTF8String(name)
+	defer s.ID.Send(sel_release)
+	cb.commandBuffer.Send(sel_pushDebugGroup, s.ID)
+}
+
+// PopDebugGroup pops the latest string off of this command buffer's stack of debug groups.
+//
+// Reference: https://developer.apple.com/documentation/metal/mtlcommandbuffer/2869548-popdebuggroup?language=objc.
+func (cb CommandBuffer) PopDebugGroup() {
+	cb.commandBuffer.Send(sel_popDebugGroup)
+}
+
 // RenderCommandEncoderWithDescriptor creates a render command encoder from a descriptor.
 //
 // Reference: https://developer.apple.com/documentation/metal/mtlcommandbuffer/1442999-rendercommandencoderwithdescript?language=objc.
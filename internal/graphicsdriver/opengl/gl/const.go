@@ -88,3 +88,8 @@ const (
 	WRITE_ONLY            = 0x88B9
 	ZERO                  = 0
 )
+
+// Constants for KHR_debug, which is optional.
+const (
+	DEBUG_SOURCE_APPLICATION = 0x824A
+)
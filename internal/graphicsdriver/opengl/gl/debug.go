@@ -454,6 +454,22 @@ func (d *DebugContext) PixelStorei(arg0 uint32, arg1 int32) {
 	}
 }
 
+func (d *DebugContext) PopDebugGroup() {
+	d.Context.PopDebugGroup()
+	fmt.Fprintln(os.Stderr, "PopDebugGroup")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at PopDebugGroup", e))
+	}
+}
+
+func (d *DebugContext) PushDebugGroup(arg0 string) {
+	d.Context.PushDebugGroup(arg0)
+	fmt.Fprintln(os.Stderr, "PushDebugGroup")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at PushDebugGroup", e))
+	}
+}
+
 func (d *DebugContext) ReadPixels(arg0 []uint8, arg1 int32, arg2 int32, arg3 int32, arg4 int32, arg5 uint32, arg6 uint32) {
 	d.Context.ReadPixels(arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 	fmt.Fprintln(os.Stderr, "ReadPixels")
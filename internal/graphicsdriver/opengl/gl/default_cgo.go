@@ -220,6 +220,14 @@ package gl
 //   typedef void (*fn)(GLenum pname, GLint param);
 //   ((fn)(fnptr))(pname, param);
 // }
+// static void glowPopDebugGroup(uintptr_t fnptr) {
+//   typedef void (*fn)();
+//   ((fn)(fnptr))();
+// }
+// static void glowPushDebugGroup(uintptr_t fnptr, GLenum source, GLuint id, GLsizei length, const GLchar* message) {
+//   typedef void (*fn)(GLenum source, GLuint id, GLsizei length, const GLchar* message);
+//   ((fn)(fnptr))(source, id, length, message);
+// }
 // static void glowReadPixels(uintptr_t fnptr, GLint x, GLint y, GLsizei width, GLsizei height, GLenum format, GLenum type, void* pixels) {
 //   typedef void (*fn)(GLint x, GLint y, GLsizei width, GLsizei height, GLenum format, GLenum type, void* pixels);
 //   ((fn)(fnptr))(x, y, width, height, format, type, pixels);
@@ -374,6 +382,8 @@ type defaultContext struct {
 	gpIsRenderbuffer           C.uintptr_t
 	gpLinkProgram              C.uintptr_t
 	gpPixelStorei              C.uintptr_t
+	gpPopDebugGroup            C.uintptr_t
+	gpPushDebugGroup           C.uintptr_t
 	gpReadPixels               C.uintptr_t
 	gpRenderbufferStorage      C.uintptr_t
 	gpScissor                  C.uintptr_t
@@ -656,6 +666,22 @@ func (c *defaultContext) PixelStorei(pname uint32, param int32) {
 	C.glowPixelStorei(c.gpPixelStorei, C.GLenum(pname), C.GLint(param))
 }
 
+func (c *defaultContext) PopDebugGroup() {
+	if c.gpPopDebugGroup == 0 {
+		return
+	}
+	C.glowPopDebugGroup(c.gpPopDebugGroup)
+}
+
+func (c *defaultContext) PushDebugGroup(message string) {
+	if c.gpPushDebugGroup == 0 {
+		return
+	}
+	cmessage := C.CString(message)
+	defer C.free(unsafe.Pointer(cmessage))
+	C.glowPushDebugGroup(c.gpPushDebugGroup, DEBUG_SOURCE_APPLICATION, 0, -1, (*C.GLchar)(unsafe.Pointer(cmessage)))
+}
+
 func (c *defaultContext) ReadPixels(dst []byte, x int32, y int32, width int32, height int32, format uint32, xtype uint32) {
 	C.glowReadPixels(c.gpReadPixels, C.GLint(x), C.GLint(y), C.GLsizei(width), C.GLsizei(height), C.GLenum(format), C.GLenum(xtype), unsafe.Pointer(&dst[0]))
 }
@@ -824,6 +850,8 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpIsRenderbuffer = C.uintptr_t(g.get("glIsRenderbuffer"))
 	c.gpLinkProgram = C.uintptr_t(g.get("glLinkProgram"))
 	c.gpPixelStorei = C.uintptr_t(g.get("glPixelStorei"))
+	c.gpPopDebugGroup = C.uintptr_t(g.getOptional("glPopDebugGroup", "glPopDebugGroupKHR"))
+	c.gpPushDebugGroup = C.uintptr_t(g.getOptional("glPushDebugGroup", "glPushDebugGroupKHR"))
 	c.gpReadPixels = C.uintptr_t(g.get("glReadPixels"))
 	c.gpRenderbufferStorage = C.uintptr_t(g.get("glRenderbufferStorage"))
 	c.gpScissor = C.uintptr_t(g.get("glScissor"))
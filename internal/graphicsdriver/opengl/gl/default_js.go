@@ -501,6 +501,14 @@ func (c *defaultContext) PixelStorei(pname uint32, param int32) {
 	c.fnPixelStorei.Invoke(pname, param)
 }
 
+func (c *defaultContext) PopDebugGroup() {
+	// WebGL doesn't have debug groups.
+}
+
+func (c *defaultContext) PushDebugGroup(message string) {
+	// WebGL doesn't have debug groups.
+}
+
 func (c *defaultContext) ReadPixels(dst []byte, x int32, y int32, width int32, height int32, format uint32, xtype uint32) {
 	if dst == nil {
 		c.fnReadPixels.Invoke(x, y, width, height, format, xtype, 0)
@@ -74,6 +74,8 @@ type defaultContext struct {
 	gpIsRenderbuffer           uintptr
 	gpLinkProgram              uintptr
 	gpPixelStorei              uintptr
+	gpPopDebugGroup            uintptr
+	gpPushDebugGroup           uintptr
 	gpReadPixels               uintptr
 	gpRenderbufferStorage      uintptr
 	gpScissor                  uintptr
@@ -356,6 +358,22 @@ func (c *defaultContext) PixelStorei(pname uint32, param int32) {
 	purego.SyscallN(c.gpPixelStorei, uintptr(pname), uintptr(param))
 }
 
+func (c *defaultContext) PopDebugGroup() {
+	if c.gpPopDebugGroup == 0 {
+		return
+	}
+	purego.SyscallN(c.gpPopDebugGroup)
+}
+
+func (c *defaultContext) PushDebugGroup(message string) {
+	if c.gpPushDebugGroup == 0 {
+		return
+	}
+	cmessage, free := cStr(message)
+	defer free()
+	purego.SyscallN(c.gpPushDebugGroup, DEBUG_SOURCE_APPLICATION, 0, ^uintptr(0), uintptr(unsafe.Pointer(cmessage)))
+}
+
 func (c *defaultContext) ReadPixels(dst []byte, x int32, y int32, width int32, height int32, format uint32, xtype uint32) {
 	purego.SyscallN(c.gpReadPixels, uintptr(x), uintptr(y), uintptr(width), uintptr(height), uintptr(format), uintptr(xtype), uintptr(unsafe.Pointer(&dst[0])))
 }
@@ -524,6 +542,8 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpIsRenderbuffer = g.get("glIsRenderbuffer")
 	c.gpLinkProgram = g.get("glLinkProgram")
 	c.gpPixelStorei = g.get("glPixelStorei")
+	c.gpPopDebugGroup = g.getOptional("glPopDebugGroup", "glPopDebugGroupKHR")
+	c.gpPushDebugGroup = g.getOptional("glPushDebugGroup", "glPushDebugGroupKHR")
 	c.gpReadPixels = g.get("glReadPixels")
 	c.gpRenderbufferStorage = g.get("glRenderbufferStorage")
 	c.gpScissor = g.get("glScissor")
@@ -77,6 +77,8 @@ type Context interface {
 	IsRenderbuffer(renderbuffer uint32) bool
 	LinkProgram(program uint32)
 	PixelStorei(pname uint32, param int32)
+	PopDebugGroup()
+	PushDebugGroup(message string)
 	ReadPixels(dst []byte, x int32, y int32, width int32, height int32, format uint32, xtype uint32)
 	RenderbufferStorage(target uint32, internalFormat uint32, width int32, height int32)
 	Scissor(x, y, width, height int32)
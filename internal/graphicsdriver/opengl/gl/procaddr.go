@@ -38,6 +38,21 @@ func (p *procAddressGetter) get(name string) uintptr {
 	return proc
 }
 
+// getOptional returns the first available function of the given names, or 0 if none is available.
+// Unlike get, a missing function is not an error.
+func (p *procAddressGetter) getOptional(names ...string) uintptr {
+	for _, name := range names {
+		proc, err := p.ctx.getProcAddress(name)
+		if err != nil {
+			continue
+		}
+		if proc != 0 {
+			return proc
+		}
+	}
+	return 0
+}
+
 func (p *procAddressGetter) error() error {
 	return p.err
 }
@@ -90,6 +90,14 @@ func (g *Graphics) End(present bool) error {
 	return nil
 }
 
+func (g *Graphics) PushDebugGroup(name string) {
+	g.context.ctx.PushDebugGroup(name)
+}
+
+func (g *Graphics) PopDebugGroup() {
+	g.context.ctx.PopDebugGroup()
+}
+
 func (g *Graphics) SetTransparent(transparent bool) {
 	// Do nothing.
 }
@@ -250,6 +258,7 @@ func (g *Graphics) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphics.
 		imgs[i].native = g.images[srcID].texture
 	}
 
+	g.state.useArrayBufferLayout(&g.context, shader.layout)
 	if err := g.useProgram(program, g.uniformVars, imgs); err != nil {
 		return err
 	}
@@ -106,11 +106,45 @@ var theArrayBufferLayout = arrayBufferLayout{
 	},
 }
 
+// theArrayBufferLayoutWithCustomValues is the array buffer layout for a shader taking custom values.
+var theArrayBufferLayoutWithCustomValues = arrayBufferLayout{
+	parts: []arrayBufferLayoutPart{
+		{
+			name: "A0",
+			num:  2,
+		},
+		{
+			name: "A1",
+			num:  2,
+		},
+		{
+			name: "A2",
+			num:  4,
+		},
+		{
+			name: "A3",
+			num:  4,
+		},
+	},
+}
+
 func init() {
 	vertexFloatCount := theArrayBufferLayout.totalBytes() / floatSizeInBytes
 	if graphics.VertexFloatCount != vertexFloatCount {
 		panic(fmt.Sprintf("vertex float num must be %d but %d", graphics.VertexFloatCount, vertexFloatCount))
 	}
+	vertexFloatCount = theArrayBufferLayoutWithCustomValues.totalBytes() / floatSizeInBytes
+	if graphics.VertexFloatCountWithCustomValues != vertexFloatCount {
+		panic(fmt.Sprintf("vertex float num with custom values must be %d but %d", graphics.VertexFloatCountWithCustomValues, vertexFloatCount))
+	}
+}
+
+// arrayBufferLayoutForShader returns the array buffer layout for the given shader program.
+func arrayBufferLayoutForShader(program *shaderir.Program) *arrayBufferLayout {
+	if graphics.ShaderVertexFloatCount(program) == graphics.VertexFloatCountWithCustomValues {
+		return &theArrayBufferLayoutWithCustomValues
+	}
+	return &theArrayBufferLayout
 }
 
 type openGLState struct {
@@ -126,6 +160,9 @@ type openGLState struct {
 
 	elementArrayBufferSizeInBytes int
 
+	// lastArrayBufferLayout is the array buffer layout enabled last time.
+	lastArrayBufferLayout *arrayBufferLayout
+
 	lastProgram       program
 	lastUniforms      map[string][]uint32
 	lastActiveTexture int
@@ -162,6 +199,7 @@ func (s *openGLState) reset(context *context) error {
 	s.elementArrayBuffer = 0
 	s.elementArrayBufferSizeInBytes = 0
 	s.vertexArray = 0
+	s.lastArrayBufferLayout = nil
 
 	return nil
 }
@@ -195,7 +233,9 @@ func (s *openGLState) setVertices(context *context, vertices []float32, indices
 		s.arrayBufferSizeInBytes = newSize
 
 		// Reenable the array buffer layout explicitly after resetting the array buffer.
-		theArrayBufferLayout.enable(context)
+		if s.lastArrayBufferLayout != nil {
+			s.lastArrayBufferLayout.enable(context)
+		}
 	}
 
 	if size := len(indices) * int(unsafe.Sizeof(indices[0])); s.elementArrayBufferSizeInBytes < size {
@@ -216,6 +256,19 @@ func (s *openGLState) setVertices(context *context, vertices []float32, indices
 	context.ctx.BufferSubData(gl.ELEMENT_ARRAY_BUFFER, 0, is)
 }
 
+// useArrayBufferLayout enables the given array buffer layout for the current array buffer.
+func (s *openGLState) useArrayBufferLayout(context *context, layout *arrayBufferLayout) {
+	if s.lastArrayBufferLayout == layout {
+		return
+	}
+	if s.lastArrayBufferLayout != nil {
+		s.lastArrayBufferLayout.disable(context)
+	}
+	context.ctx.BindBuffer(gl.ARRAY_BUFFER, uint32(s.arrayBuffer))
+	layout.enable(context)
+	s.lastArrayBufferLayout = layout
+}
+
 func (s *openGLState) resetLastUniforms() {
 	for k := range s.lastUniforms {
 		delete(s.lastUniforms, k)
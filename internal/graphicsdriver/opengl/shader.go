@@ -29,8 +29,9 @@ type Shader struct {
 	id       graphicsdriver.ShaderID
 	graphics *Graphics
 
-	ir *shaderir.Program
-	p  program
+	ir     *shaderir.Program
+	p      program
+	layout *arrayBufferLayout
 }
 
 func newShader(id graphicsdriver.ShaderID, graphics *Graphics, program *shaderir.Program) (*Shader, error) {
@@ -38,6 +39,7 @@ func newShader(id graphicsdriver.ShaderID, graphics *Graphics, program *shaderir
 		id:       id,
 		graphics: graphics,
 		ir:       program,
+		layout:   arrayBufferLayoutForShader(program),
 	}
 	if err := s.compile(); err != nil {
 		return nil, err
@@ -69,7 +71,7 @@ func (s *Shader) compile() error {
 	}
 	defer s.graphics.context.ctx.DeleteShader(uint32(fs))
 
-	p, err := s.graphics.context.newProgram([]shader{vs, fs}, theArrayBufferLayout.names())
+	p, err := s.graphics.context.newProgram([]shader{vs, fs}, s.layout.names())
 	if err != nil {
 		return err
 	}
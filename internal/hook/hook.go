@@ -16,6 +16,7 @@ package hook
 
 import (
 	"sync"
+	"time"
 )
 
 var m sync.Mutex
@@ -42,6 +43,33 @@ func RunBeforeUpdateHooks() error {
 	return nil
 }
 
+var (
+	onBeforeExitHooks []func()
+	beforeExitOnce    sync.Once
+)
+
+// AppendHookOnBeforeExit appends a hook function that is run once before the main loop ends.
+func AppendHookOnBeforeExit(f func()) {
+	m.Lock()
+	onBeforeExitHooks = append(onBeforeExitHooks, f)
+	m.Unlock()
+}
+
+// RunBeforeExitHooks runs the hook functions appended by AppendHookOnBeforeExit.
+// RunBeforeExitHooks runs the hook functions only at the first call.
+//
+// The hook functions are run outside of the lock, so they can call other functions in this package.
+func RunBeforeExitHooks() {
+	beforeExitOnce.Do(func() {
+		m.Lock()
+		hooks := onBeforeExitHooks
+		m.Unlock()
+		for _, f := range hooks {
+			f()
+		}
+	})
+}
+
 var (
 	audioSuspended bool
 	onSuspendAudio func() error
@@ -60,28 +88,147 @@ func OnResumeAudio(f func() error) {
 	m.Unlock()
 }
 
+type hookFunc struct {
+	f func()
+}
+
+var (
+	onSuspendHooks []*hookFunc
+	onResumeHooks  []*hookFunc
+)
+
+// AppendHookOnSuspend appends a hook function that is run when the application is suspended,
+// e.g. when the application goes to the background or the window loses focus.
+// AppendHookOnSuspend returns a function to remove the hook function.
+//
+// The hook functions are run outside of the lock, so they can call other functions in this package.
+func AppendHookOnSuspend(f func()) (remove func()) {
+	return appendHook(&onSuspendHooks, f)
+}
+
+// AppendHookOnResume appends a hook function that is run when the suspended application is resumed.
+// AppendHookOnResume returns a function to remove the hook function.
+//
+// The hook functions are run outside of the lock, so they can call other functions in this package.
+func AppendHookOnResume(f func()) (remove func()) {
+	return appendHook(&onResumeHooks, f)
+}
+
+func appendHook(hooks *[]*hookFunc, f func()) func() {
+	m.Lock()
+	defer m.Unlock()
+
+	h := &hookFunc{f: f}
+	*hooks = append(*hooks, h)
+	return func() {
+		m.Lock()
+		defer m.Unlock()
+
+		// Create a new slice instead of modifying the current one, as the current one might be being iterated
+		// outside of the lock.
+		var newHooks []*hookFunc
+		for _, hh := range *hooks {
+			if hh != h {
+				newHooks = append(newHooks, hh)
+			}
+		}
+		*hooks = newHooks
+	}
+}
+
+var lowMemoryNotified bool
+
+// NotifyLowMemory notifies that the system is running low on memory.
+// The notification is kept until ConsumeLowMemoryNotification is called.
+func NotifyLowMemory() {
+	m.Lock()
+	lowMemoryNotified = true
+	m.Unlock()
+}
+
+// ConsumeLowMemoryNotification reports whether NotifyLowMemory was called since the last call,
+// and resets the state.
+func ConsumeLowMemoryNotification() bool {
+	m.Lock()
+	defer m.Unlock()
+	n := lowMemoryNotified
+	lowMemoryNotified = false
+	return n
+}
+
 func SuspendAudio() error {
+	hooks, err := suspendAudio()
+	for _, h := range hooks {
+		h.f()
+	}
+	return err
+}
+
+func suspendAudio() ([]*hookFunc, error) {
 	m.Lock()
 	defer m.Unlock()
 	if audioSuspended {
-		return nil
+		return nil, nil
 	}
 	audioSuspended = true
 	if onSuspendAudio != nil {
-		return onSuspendAudio()
+		if err := onSuspendAudio(); err != nil {
+			return onSuspendHooks, err
+		}
 	}
-	return nil
+	return onSuspendHooks, nil
 }
 
 func ResumeAudio() error {
+	hooks, err := resumeAudio()
+	for _, h := range hooks {
+		h.f()
+	}
+	return err
+}
+
+func resumeAudio() ([]*hookFunc, error) {
 	m.Lock()
 	defer m.Unlock()
 	if !audioSuspended {
-		return nil
+		return nil, nil
 	}
 	audioSuspended = false
 	if onResumeAudio != nil {
-		return onResumeAudio()
+		if err := onResumeAudio(); err != nil {
+			return onResumeHooks, err
+		}
 	}
-	return nil
+	return onResumeHooks, nil
+}
+
+// AudioStats represents statistics of audio players.
+type AudioStats struct {
+	// PlayingPlayerCount is the number of the playing players.
+	PlayingPlayerCount int
+
+	// MinBufferedDuration is the smallest duration of the buffered data among the playing players.
+	// If this is too small, the audio might be interrupted.
+	MinBufferedDuration time.Duration
+}
+
+var (
+	audioStats  AudioStats
+	audioStatsM sync.Mutex
+)
+
+// SetAudioStats sets the current statistics of audio players.
+//
+// SetAudioStats doesn't use the same lock as the other hooks, as SetAudioStats is called from a hook.
+func SetAudioStats(stats AudioStats) {
+	audioStatsM.Lock()
+	audioStats = stats
+	audioStatsM.Unlock()
+}
+
+// CurrentAudioStats returns the current statistics of audio players.
+func CurrentAudioStats() AudioStats {
+	audioStatsM.Lock()
+	defer audioStatsM.Unlock()
+	return audioStats
 }
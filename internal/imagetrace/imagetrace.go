@@ -0,0 +1,194 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package imagetrace records where the live images are created to find leaking images.
+package imagetrace
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	enabled atomic.Bool
+
+	records  = map[uint64]*record{}
+	nextID   uint64
+	recordsM sync.Mutex
+)
+
+type record struct {
+	stack []uintptr
+	bytes int64
+}
+
+// Token is a handle of a record for a live image.
+//
+// A record is removed when Remove is called or the token is garbage-collected.
+// A token must be held only by the image, so that the image's garbage collection removes the record.
+type Token struct {
+	id uint64
+}
+
+// SetEnabled enables or disables the tracing.
+// Disabling the tracing doesn't remove the existing records.
+func SetEnabled(value bool) {
+	enabled.Store(value)
+}
+
+// IsEnabled reports whether the tracing is enabled.
+func IsEnabled() bool {
+	return enabled.Load()
+}
+
+// Add records the current stack trace for a new image with the size and returns its token.
+// skip is the number of stack frames to skip, where 0 identifies the caller of Add.
+//
+// If the tracing is disabled, Add returns nil.
+func Add(width, height int, skip int) *Token {
+	if !enabled.Load() {
+		return nil
+	}
+
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip+2, pcs)
+
+	recordsM.Lock()
+	defer recordsM.Unlock()
+
+	nextID++
+	t := &Token{id: nextID}
+	records[t.id] = &record{
+		stack: pcs[:n],
+		bytes: 4 * int64(width) * int64(height),
+	}
+	runtime.SetFinalizer(t, (*Token).Remove)
+	return t
+}
+
+// ID returns the ID of the record, which is unique in the process and never 0.
+//
+// If the token is nil, ID returns 0.
+func (t *Token) ID() uint64 {
+	if t == nil {
+		return 0
+	}
+	return t.id
+}
+
+// Remove removes the record of the token.
+//
+// If the token is nil, Remove does nothing.
+func (t *Token) Remove() {
+	if t == nil {
+		return
+	}
+
+	recordsM.Lock()
+	defer recordsM.Unlock()
+
+	delete(records, t.id)
+}
+
+type site struct {
+	stack string
+	ids   []uint64
+	bytes int64
+}
+
+// Dump writes the live images grouped by their creation sites to w.
+// The sites are sorted by the total bytes in descending order.
+// Each site lists the IDs of its images.
+//
+// The frames in the packages with the prefix trimmedPackagePrefix at the top of a stack are omitted
+// so that the caller of the package is shown as the creation site.
+func Dump(w io.Writer, trimmedPackagePrefix string) error {
+	recordsM.Lock()
+	stacks := map[string]*site{}
+	var count int
+	var bytes int64
+	for id, r := range records {
+		s := formatStack(r.stack, trimmedPackagePrefix)
+		st, ok := stacks[s]
+		if !ok {
+			st = &site{stack: s}
+			stacks[s] = st
+		}
+		st.ids = append(st.ids, id)
+		st.bytes += r.bytes
+		count++
+		bytes += r.bytes
+	}
+	recordsM.Unlock()
+
+	sites := make([]*site, 0, len(stacks))
+	for _, s := range stacks {
+		sites = append(sites, s)
+	}
+	sort.Slice(sites, func(i, j int) bool {
+		if sites[i].bytes != sites[j].bytes {
+			return sites[i].bytes > sites[j].bytes
+		}
+		if len(sites[i].ids) != len(sites[j].ids) {
+			return len(sites[i].ids) > len(sites[j].ids)
+		}
+		return sites[i].stack < sites[j].stack
+	})
+
+	if _, err := fmt.Fprintf(w, "%d live images (%d bytes) at %d creation sites\n", count, bytes, len(sites)); err != nil {
+		return err
+	}
+	for _, s := range sites {
+		sort.Slice(s.ids, func(i, j int) bool {
+			return s.ids[i] < s.ids[j]
+		})
+		ids := make([]string, 0, len(s.ids))
+		for _, id := range s.ids {
+			ids = append(ids, strconv.FormatUint(id, 10))
+		}
+		if _, err := fmt.Fprintf(w, "\n%d images (%d bytes, IDs: %s) created at:\n%s", len(s.ids), s.bytes, strings.Join(ids, ", "), s.stack); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatStack(stack []uintptr, trimmedPackagePrefix string) string {
+	var sb strings.Builder
+	frames := runtime.CallersFrames(stack)
+	top := true
+	for {
+		f, more := frames.Next()
+		if top && trimmedPackagePrefix != "" && strings.HasPrefix(f.Function, trimmedPackagePrefix) {
+			if !more {
+				break
+			}
+			continue
+		}
+		top = false
+		if f.Function != "" {
+			fmt.Fprintf(&sb, "\t%s\n\t\t%s:%d\n", f.Function, f.File, f.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagetrace_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/imagetrace"
+)
+
+func newTracedImage() *imagetrace.Token {
+	return imagetrace.Add(16, 16, 0)
+}
+
+func TestTokenID(t *testing.T) {
+	var nilToken *imagetrace.Token
+	if got, want := nilToken.ID(), uint64(0); got != want {
+		t.Errorf("ID of nil: got: %d, want: %d", got, want)
+	}
+
+	imagetrace.SetEnabled(true)
+	defer imagetrace.SetEnabled(false)
+
+	t0 := newTracedImage()
+	t1 := newTracedImage()
+	defer t0.Remove()
+	defer t1.Remove()
+	if t0.ID() == 0 || t0.ID() == t1.ID() {
+		t.Errorf("IDs must be unique and non-zero: %d, %d", t0.ID(), t1.ID())
+	}
+}
+
+func TestDump(t *testing.T) {
+	if got := imagetrace.Add(16, 16, 0); got != nil {
+		t.Errorf("Add with the tracing disabled: got: %v, want: nil", got)
+	}
+
+	imagetrace.SetEnabled(true)
+	defer imagetrace.SetEnabled(false)
+
+	var tokens []*imagetrace.Token
+	for i := 0; i < 2; i++ {
+		tokens = append(tokens, newTracedImage())
+	}
+
+	var buf bytes.Buffer
+	if err := imagetrace.Dump(&buf, "github.com/hajimehoshi/ebiten/v2/internal/imagetrace_test.newTracedImage"); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	if !strings.HasPrefix(got, "2 live images (2048 bytes) at 1 creation sites\n") {
+		t.Errorf("Dump: got: %q", got)
+	}
+	if !strings.Contains(got, "imagetrace_test.TestDump") {
+		t.Errorf("Dump must include the caller: got: %q", got)
+	}
+	if strings.Contains(got, "newTracedImage") {
+		t.Errorf("Dump must not include the trimmed frame: got: %q", got)
+	}
+	if want := fmt.Sprintf("2 images (2048 bytes, IDs: %d, %d) created at:\n", tokens[0].ID(), tokens[1].ID()); !strings.Contains(got, want) {
+		t.Errorf("Dump must include the IDs: got: %q, want: %q", got, want)
+	}
+
+	for _, token := range tokens {
+		token.Remove()
+	}
+	buf.Reset()
+	if err := imagetrace.Dump(&buf, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "0 live images (0 bytes) at 0 creation sites\n"; got != want {
+		t.Errorf("Dump after Remove: got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package launchparam manages key/value parameters given when the application is launched or reopened.
+package launchparam
+
+import (
+	"sync"
+)
+
+var theState state
+
+type state struct {
+	pending map[string]string
+	queue   []map[string]string
+
+	m sync.Mutex
+}
+
+// Add adds a parameter to the pending set of parameters.
+// The pending set is delivered at Commit.
+func Add(key, value string) {
+	theState.m.Lock()
+	defer theState.m.Unlock()
+
+	if theState.pending == nil {
+		theState.pending = map[string]string{}
+	}
+	theState.pending[key] = value
+}
+
+// Commit delivers the pending set of parameters, even if the set is empty.
+func Commit() {
+	theState.m.Lock()
+	defer theState.m.Unlock()
+
+	p := theState.pending
+	if p == nil {
+		p = map[string]string{}
+	}
+	theState.queue = append(theState.queue, p)
+	theState.pending = nil
+}
+
+// Next returns the oldest delivered set of parameters that is not returned yet.
+func Next() (map[string]string, bool) {
+	theState.m.Lock()
+	defer theState.m.Unlock()
+
+	if len(theState.queue) == 0 {
+		return nil, false
+	}
+	p := theState.queue[0]
+	theState.queue[0] = nil
+	theState.queue = theState.queue[1:]
+	return p, true
+}
@@ -56,6 +56,22 @@ func (m *Mipmap) DumpScreenshot(graphicsDriver graphicsdriver.Graphics, name str
 	return m.orig.DumpScreenshot(graphicsDriver, name, blackbg)
 }
 
+// SetPinned pins or unpins the original image.
+// Mipmap images are not affected since they can be recreated anytime.
+func (m *Mipmap) SetPinned(pinned bool) {
+	m.orig.SetPinned(pinned)
+}
+
+// SetTraceID sets an ID to the original image for debugging.
+// Mipmap images don't have the ID.
+func (m *Mipmap) SetTraceID(id uint64) {
+	m.orig.SetTraceID(id)
+}
+
+func (m *Mipmap) Residency() atlas.Residency {
+	return m.orig.Residency()
+}
+
 func (m *Mipmap) WritePixels(pix []byte, region image.Rectangle) {
 	m.orig.WritePixels(pix, region)
 	m.deallocateMipmaps()
@@ -74,8 +90,8 @@ func (m *Mipmap) DrawTriangles(srcs [graphics.ShaderImageCount]*Mipmap, vertices
 	// TODO: Do we need to check all the sources' states of being volatile?
 	if !canSkipMipmap && srcs[0] != nil && canUseMipmap(srcs[0].imageType) {
 		level = math.MaxInt32
+		n := uint32(shader.VertexFloatCount())
 		for i := 0; i < len(indices)/3; i++ {
-			const n = graphics.VertexFloatCount
 			dx0 := vertices[n*indices[3*i]+0]
 			dy0 := vertices[n*indices[3*i]+1]
 			sx0 := vertices[n*indices[3*i]+2]
@@ -110,7 +126,7 @@ func (m *Mipmap) DrawTriangles(srcs [graphics.ShaderImageCount]*Mipmap, vertices
 		}
 		if level != 0 {
 			if img := src.level(level); img != nil {
-				const n = graphics.VertexFloatCount
+				n := shader.VertexFloatCount()
 				s := float32(pow2(level))
 				for i := 0; i < len(vertices)/n; i++ {
 					vertices[i*n+2] /= s
@@ -299,6 +299,14 @@ func (cs *compileState) parse(f *ast.File) {
 		}
 		n := fd.Name.Name
 		if n == cs.vertexEntry {
+			// Determine the varyings in advance so that the fragment entry point can omit the trailing varyings.
+			_, outParams, _ := cs.parseFuncParams(&cs.global, n, fd)
+			if len(outParams) > 0 && outParams[0].typ.Main == shaderir.Vec4 {
+				for _, v := range outParams[1:] {
+					cs.ir.Varyings = append(cs.ir.Varyings, v.typ)
+				}
+				cs.varyingParsed = true
+			}
 			continue
 		}
 		if n == cs.fragmentEntry {
@@ -827,6 +835,13 @@ func (cs *compileState) parseFunc(block *block, d *ast.FuncDecl) (function, bool
 			}
 
 			if cs.varyingParsed {
+				// The fragment entry point can omit the trailing varyings.
+				// Add unnamed parameters for them so that the indices of the local variables are consistent.
+				for i := len(inParams) - 1; i < len(cs.ir.Varyings); i++ {
+					inParams = append(inParams, variable{
+						typ: cs.ir.Varyings[i],
+					})
+				}
 				checkVaryings(inParams[1:])
 			} else {
 				for _, v := range inParams[1:] {
@@ -93,8 +93,19 @@ func Compile(p *shaderir.Program) (vertexShader, pixelShader string) {
 		unit: p.Unit,
 	}
 
+	prelude := Prelude
+	// Add the extra varyings like custom values only when the program uses them.
+	if len(p.Varyings) > 2 {
+		const lastVarying = "\tfloat4 M1 : COLOR;\n"
+		var extra string
+		for i, v := range p.Varyings[2:] {
+			extra += fmt.Sprintf("\t%s : TEXCOORD%d;\n", c.varDecl(p, &v, fmt.Sprintf("M%d", i+2)), i+1)
+		}
+		prelude = strings.Replace(prelude, lastVarying, lastVarying+extra, 1)
+	}
+
 	var lines []string
-	lines = append(lines, strings.Split(Prelude, "\n")...)
+	lines = append(lines, strings.Split(prelude, "\n")...)
 	lines = append(lines, "", "{{.Structs}}")
 
 	if len(p.Uniforms) > 0 {
@@ -156,7 +167,13 @@ func Compile(p *shaderir.Program) (vertexShader, pixelShader string) {
 	}
 	if p.VertexFunc.Block != nil && len(p.VertexFunc.Block.Stmts) > 0 {
 		vslines = append(vslines, "")
-		vslines = append(vslines, "Varyings VSMain(float2 A0 : POSITION, float2 A1 : TEXCOORD, float4 A2 : COLOR) {")
+		params := []string{"float2 A0 : POSITION", "float2 A1 : TEXCOORD", "float4 A2 : COLOR"}
+		if len(p.Attributes) > 3 {
+			for i, a := range p.Attributes[3:] {
+				params = append(params, fmt.Sprintf("%s : TEXCOORD%d", c.varDecl(p, &a, fmt.Sprintf("A%d", i+3)), i+1))
+			}
+		}
+		vslines = append(vslines, fmt.Sprintf("Varyings VSMain(%s) {", strings.Join(params, ", ")))
 		vslines = append(vslines, fmt.Sprintf("\tVaryings %s;", vsOut))
 		vslines = append(vslines, c.block(p, p.VertexFunc.Block, p.VertexFunc.Block, 0)...)
 		if last := fmt.Sprintf("\treturn %s;", vsOut); vslines[len(vslines)-1] != last {
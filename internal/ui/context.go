@@ -38,6 +38,9 @@ type Game interface {
 	Update() error
 	DrawOffscreen() error
 	DrawFinalScreen(scale, offsetX, offsetY float64)
+
+	// EndFrame is called at the end of a frame after drawing the game.
+	EndFrame() error
 }
 
 type context struct {
@@ -70,7 +73,14 @@ func newContext(game Game) *context {
 
 func (c *context) updateFrame(graphicsDriver graphicsdriver.Graphics, outsideWidth, outsideHeight float64, deviceScaleFactor float64, ui *UserInterface) error {
 	// TODO: If updateCount is 0 and vsync is disabled, swapping buffers can be skipped.
-	return c.updateFrameImpl(graphicsDriver, clock.UpdateFrame(), outsideWidth, outsideHeight, deviceScaleFactor, ui, false)
+	var updateCount int
+	if ui.IsUpdateOnEvent() {
+		// A frame in the on-event mode is processed only when woken up, so the game must be updated at least once.
+		updateCount = clock.UpdateFrameAtLeastOnce()
+	} else {
+		updateCount = clock.UpdateFrame()
+	}
+	return c.updateFrameImpl(graphicsDriver, updateCount, outsideWidth, outsideHeight, deviceScaleFactor, ui, false)
 }
 
 func (c *context) forceUpdateFrame(graphicsDriver graphicsdriver.Graphics, outsideWidth, outsideHeight float64, deviceScaleFactor float64, ui *UserInterface) error {
@@ -97,6 +107,8 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 
 	debug.Logf("----\n")
 
+	clock.BeginFrameTime()
+
 	if err := atlas.BeginFrame(graphicsDriver); err != nil {
 		return err
 	}
@@ -107,10 +119,16 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 			return
 		}
 
+		// Measure the frame time before swapping buffers, as swapping buffers might wait for vsync.
+		clock.EndFrameTime()
+
 		if err1 := atlas.SwapBuffers(graphicsDriver); err1 != nil && err == nil {
 			err = err1
 			return
 		}
+
+		// Wait after swapping buffers so that the limit works regardless of vsync.
+		clock.WaitForMaxFPS()
 	}()
 
 	// Flush deferred functions, like reading pixels from GPU.
@@ -166,6 +184,11 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 		return err
 	}
 
+	// Let the game finish its work in this frame, e.g. Update running concurrently with Draw.
+	if err := c.game.EndFrame(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -17,6 +17,7 @@ package ui
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
 )
@@ -29,7 +30,64 @@ type graphicsDriverCreator interface {
 	newPlayStation5() (graphicsdriver.Graphics, error)
 }
 
-func newGraphicsDriver(creator graphicsDriverCreator, graphicsLibrary GraphicsLibrary) (graphicsdriver.Graphics, GraphicsLibrary, error) {
+// GraphicsLibraryError represents an error at initializing a graphics library.
+type GraphicsLibraryError struct {
+	GraphicsLibrary GraphicsLibrary
+	Err             error
+}
+
+func (e *GraphicsLibraryError) Error() string {
+	return fmt.Sprintf("%s: %v", e.GraphicsLibrary, e.Err)
+}
+
+func (e *GraphicsLibraryError) Unwrap() error {
+	return e.Err
+}
+
+// newGraphicsDriver tries to initialize the given graphics libraries in order, and returns the first successful one.
+// The errors of the failed graphics libraries are recorded and can be obtained by GraphicsLibraryErrors.
+func (u *UserInterface) newGraphicsDriver(creator graphicsDriverCreator, graphicsLibraries []GraphicsLibrary) (graphicsdriver.Graphics, GraphicsLibrary, error) {
+	if len(graphicsLibraries) == 0 {
+		graphicsLibraries = []GraphicsLibrary{GraphicsLibraryAuto}
+	}
+
+	var errs []*GraphicsLibraryError
+	defer func() {
+		u.graphicsLibraryErrorsM.Lock()
+		defer u.graphicsLibraryErrorsM.Unlock()
+		u.graphicsLibraryErrors = errs
+	}()
+
+	for _, library := range graphicsLibraries {
+		g, lib, err := newGraphicsDriverForLibrary(creator, library)
+		if err != nil {
+			errs = append(errs, &GraphicsLibraryError{
+				GraphicsLibrary: library,
+				Err:             err,
+			})
+			continue
+		}
+		return g, lib, nil
+	}
+
+	if len(errs) == 1 {
+		return nil, 0, errs[0].Err
+	}
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return nil, 0, fmt.Errorf("ui: failed to initialize any of the graphics libraries: %s", strings.Join(msgs, ", "))
+}
+
+// GraphicsLibraryErrors returns the errors of the graphics libraries that failed to be initialized.
+func (u *UserInterface) GraphicsLibraryErrors() []*GraphicsLibraryError {
+	u.graphicsLibraryErrorsM.Lock()
+	defer u.graphicsLibraryErrorsM.Unlock()
+	return u.graphicsLibraryErrors
+}
+
+func newGraphicsDriverForLibrary(creator graphicsDriverCreator, graphicsLibrary GraphicsLibrary) (graphicsdriver.Graphics, GraphicsLibrary, error) {
 	if graphicsLibrary == GraphicsLibraryAuto {
 		envName := "EBITENGINE_GRAPHICS_LIBRARY"
 		env := os.Getenv(envName)
@@ -77,6 +77,22 @@ func (i *Image) Deallocate() {
 	i.mipmap.Deallocate()
 }
 
+func (i *Image) SetPinned(pinned bool) {
+	i.mipmap.SetPinned(pinned)
+}
+
+func (i *Image) SetTraceID(id uint64) {
+	i.mipmap.SetTraceID(id)
+}
+
+func (i *Image) Residency() atlas.Residency {
+	return i.mipmap.Residency()
+}
+
+func (u *UserInterface) SetImageAutomaticRelocationEnabled(enabled bool) {
+	atlas.SetAutomaticRelocationEnabled(enabled)
+}
+
 func (i *Image) DrawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, antialias bool) {
 	if i.modifyCallback != nil {
 		i.modifyCallback()
@@ -124,6 +140,21 @@ func (i *Image) WritePixels(pix []byte, region image.Rectangle) {
 	i.mipmap.WritePixels(pix, region)
 }
 
+// DrawPixelsOver draws the given pixels over the region with the source-over blending.
+func (i *Image) DrawPixelsOver(pix []byte, region image.Rectangle) {
+	w, h := region.Dx(), region.Dy()
+	src := i.ui.NewImage(w, h, atlas.ImageTypeRegular)
+	defer src.Deallocate()
+	src.WritePixels(pix, image.Rect(0, 0, w, h))
+
+	vs := make([]float32, 4*graphics.VertexFloatCount)
+	graphics.QuadVertices(vs, 0, 0, float32(w), float32(h), 1, 0, 0, 1, float32(region.Min.X), float32(region.Min.Y), 1, 1, 1, 1)
+	is := graphics.QuadIndices()
+	srcs := [graphics.ShaderImageCount]*Image{src}
+	srcRegions := [graphics.ShaderImageCount]image.Rectangle{image.Rect(0, 0, w, h)}
+	i.DrawTriangles(srcs, vs, is, graphicsdriver.BlendSourceOver, image.Rect(0, 0, i.width, i.height), srcRegions, NearestFilterShader, nil, graphicsdriver.FillAll, true, false)
+}
+
 func (i *Image) ReadPixels(pixels []byte, region image.Rectangle) {
 	// Check the error existence and avoid unnecessary calls.
 	if i.ui.error() != nil {
@@ -224,7 +255,7 @@ func (i *bigOffscreenImage) drawTriangles(srcs [graphics.ShaderImageCount]*Image
 	i.blend = blend
 
 	// If the new region doesn't match with the current region, remove the buffer image and recreate it later.
-	if r := i.requiredRegion(vertices); i.region != r {
+	if r := i.requiredRegion(vertices, shader.shader.VertexFloatCount()); i.region != r {
 		i.flush()
 		i.image = nil
 		i.region = r
@@ -255,7 +286,7 @@ func (i *bigOffscreenImage) drawTriangles(srcs [graphics.ShaderImageCount]*Image
 		i.image.DrawTriangles(srcs, i.tmpVerticesForCopying, is, graphicsdriver.BlendCopy, dstRegion, [graphics.ShaderImageCount]image.Rectangle{}, NearestFilterShader, nil, graphicsdriver.FillAll, true, false)
 	}
 
-	for idx := 0; idx < len(vertices); idx += graphics.VertexFloatCount {
+	for idx := 0; idx < len(vertices); idx += shader.shader.VertexFloatCount() {
 		vertices[idx] = (vertices[idx] - float32(i.region.Min.X)) * bigOffscreenScale
 		vertices[idx+1] = (vertices[idx+1] - float32(i.region.Min.Y)) * bigOffscreenScale
 	}
@@ -306,12 +337,12 @@ func (i *bigOffscreenImage) flush() {
 	i.dirty = false
 }
 
-func (i *bigOffscreenImage) requiredRegion(vertices []float32) image.Rectangle {
+func (i *bigOffscreenImage) requiredRegion(vertices []float32, vertexFloatCount int) image.Rectangle {
 	minX := float32(i.orig.width)
 	minY := float32(i.orig.height)
 	maxX := float32(0)
 	maxY := float32(0)
-	for i := 0; i < len(vertices); i += graphics.VertexFloatCount {
+	for i := 0; i < len(vertices); i += vertexFloatCount {
 		dstX := vertices[i]
 		dstY := vertices[i+1]
 		if minX > floor(dstX)-1 {
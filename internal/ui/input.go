@@ -34,8 +34,26 @@ type TouchID int
 
 type Touch struct {
 	ID TouchID
-	X  int
-	Y  int
+	X  float64
+	Y  float64
+	TouchDetails
+}
+
+// TouchDetails represents the states of a touch other than the position.
+type TouchDetails struct {
+	// Pressure is the pressure in [0, 1]. Pressure is valid only when PressureAvailable is true.
+	Pressure          float64
+	PressureAvailable bool
+
+	// Stylus reports whether the touch is by a stylus.
+	Stylus bool
+
+	// Altitude is the angle in radians between the stylus and the screen surface.
+	// Azimuth is the angle in radians of the stylus projected on the screen, from the positive X axis in clockwise.
+	// Altitude and Azimuth are valid only when TiltAvailable is true.
+	Altitude      float64
+	Azimuth       float64
+	TiltAvailable bool
 }
 
 type InputState struct {
@@ -37,10 +37,17 @@ var (
 	stringTouchmove  = js.ValueOf("touchmove")
 )
 
+type pointerInClient struct {
+	x       float64
+	y       float64
+	details TouchDetails
+}
+
 type touchInClient struct {
-	id TouchID
-	x  float64
-	y  float64
+	id      TouchID
+	x       float64
+	y       float64
+	details TouchDetails
 }
 
 func jsCodeToID(code js.Value) Key {
@@ -203,13 +210,94 @@ func (u *UserInterface) updateTouchesFromEvent(e js.Value) {
 	for i := 0; i < touches.Length(); i++ {
 		t := touches.Call("item", i)
 		u.touchesInClient = append(u.touchesInClient, touchInClient{
-			id: TouchID(t.Get("identifier").Int()),
-			x:  t.Get("clientX").Float(),
-			y:  t.Get("clientY").Float(),
+			id:      TouchID(t.Get("identifier").Int()),
+			x:       t.Get("clientX").Float(),
+			y:       t.Get("clientY").Float(),
+			details: u.touchDetailsAt(t.Get("clientX").Float(), t.Get("clientY").Float()),
 		})
 	}
 }
 
+// updatePointerFromEvent records the details of a pointer of Pointer Events for a touch or a stylus.
+//
+// Touch Events don't have portable details like pressure, so the details are taken from Pointer Events.
+// Pointer Events are dispatched before the corresponding Touch Events.
+func (u *UserInterface) updatePointerFromEvent(e js.Value) {
+	if e.Get("pointerType").String() == "mouse" {
+		return
+	}
+
+	id := e.Get("pointerId").Int()
+	if t := e.Get("type").String(); t == "pointerup" || t == "pointercancel" {
+		delete(u.pointersInClient, id)
+		return
+	}
+
+	if u.pointersInClient == nil {
+		u.pointersInClient = map[int]pointerInClient{}
+	}
+	u.pointersInClient[id] = pointerInClient{
+		x:       e.Get("clientX").Float(),
+		y:       e.Get("clientY").Float(),
+		details: touchDetailsFromJSPointerEvent(e),
+	}
+}
+
+// touchDetailsAt returns the details of the pointer at the given client position.
+func (u *UserInterface) touchDetailsAt(x, y float64) TouchDetails {
+	const epsilon = 1
+	for _, p := range u.pointersInClient {
+		if math.Abs(p.x-x) <= epsilon && math.Abs(p.y-y) <= epsilon {
+			return p.details
+		}
+	}
+	return TouchDetails{}
+}
+
+// touchDetailsFromJSPointerEvent returns the details of a PointerEvent.
+//
+// pressure is regarded as available for a stylus, or for a touch reporting a value other than 0.5,
+// as 0.5 is the value for hardware without pressure support.
+// tiltX and tiltY are regarded as available only for a stylus.
+func touchDetailsFromJSPointerEvent(e js.Value) TouchDetails {
+	var d TouchDetails
+	stylus := e.Get("pointerType").String() == "pen"
+	if p := e.Get("pressure"); p.Type() == js.TypeNumber {
+		if v := p.Float(); stylus || (v > 0 && v != 0.5) {
+			d.Pressure = v
+			d.PressureAvailable = true
+		}
+	}
+	if stylus {
+		d.Stylus = true
+		tx := e.Get("tiltX")
+		ty := e.Get("tiltY")
+		if tx.Type() == js.TypeNumber && ty.Type() == js.TypeNumber {
+			d.Altitude, d.Azimuth = tiltToAltitudeAzimuth(tx.Float(), ty.Float())
+			d.TiltAvailable = true
+		}
+	}
+	return d
+}
+
+// tiltToAltitudeAzimuth converts Pointer Events' tiltX and tiltY in degrees to the altitude and the azimuth in radians.
+//
+// See https://w3c.github.io/pointerevents/#converting-between-tiltx-tilty-and-altitudeangle-azimuthangle.
+func tiltToAltitudeAzimuth(tiltX, tiltY float64) (altitude, azimuth float64) {
+	if tiltX == 0 && tiltY == 0 {
+		return math.Pi / 2, 0
+	}
+	// When tiltX or tiltY is ±90, the tangent is a very big finite value and the altitude becomes almost 0 as expected.
+	tanX := math.Tan(tiltX * math.Pi / 180)
+	tanY := math.Tan(tiltY * math.Pi / 180)
+	azimuth = math.Atan2(tanY, tanX)
+	if azimuth < 0 {
+		azimuth += 2 * math.Pi
+	}
+	altitude = math.Atan(1 / math.Hypot(tanX, tanY))
+	return altitude, azimuth
+}
+
 func isKeyString(str string) bool {
 	// From https://www.w3.org/TR/uievents-key/#keys-unicode,
 	//
@@ -345,9 +433,10 @@ func (u *UserInterface) updateInputState() error {
 	for _, t := range u.touchesInClient {
 		x, y := u.context.clientPositionToLogicalPosition(t.x, t.y, s)
 		u.inputState.Touches = append(u.inputState.Touches, Touch{
-			ID: t.id,
-			X:  int(x),
-			Y:  int(y),
+			ID:           t.id,
+			X:            x,
+			Y:            y,
+			TouchDetails: t.details,
 		})
 	}
 
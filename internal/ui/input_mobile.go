@@ -24,6 +24,8 @@ type TouchForInput struct {
 
 	// Y is in device-independent pixels.
 	Y float64
+
+	TouchDetails
 }
 
 func (u *UserInterface) updateInputStateFromOutside(keys map[Key]struct{}, runes []rune, touches []TouchForInput) {
@@ -53,9 +55,10 @@ func (u *UserInterface) updateInputState() error {
 	for _, t := range u.touches {
 		x, y := u.context.clientPositionToLogicalPosition(t.X, t.Y, s)
 		u.inputState.Touches = append(u.inputState.Touches, Touch{
-			ID: t.ID,
-			X:  int(x),
-			Y:  int(y),
+			ID:           t.ID,
+			X:            x,
+			Y:            y,
+			TouchDetails: t.TouchDetails,
 		})
 	}
 	return nil
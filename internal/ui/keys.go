@@ -60,6 +60,7 @@ const (
 	KeyBackspace
 	KeyBracketLeft
 	KeyBracketRight
+	KeyBrowserBack
 	KeyCapsLock
 	KeyComma
 	KeyContextMenu
@@ -224,6 +225,8 @@ func (k Key) String() string {
 		return "KeyBracketLeft"
 	case KeyBracketRight:
 		return "KeyBracketRight"
+	case KeyBrowserBack:
+		return "KeyBrowserBack"
 	case KeyCapsLock:
 		return "KeyCapsLock"
 	case KeyComma:
@@ -34,6 +34,7 @@ var uiKeyToJSCode = map[Key]js.Value{
 	KeyBackspace:      js.ValueOf("Backspace"),
 	KeyBracketLeft:    js.ValueOf("BracketLeft"),
 	KeyBracketRight:   js.ValueOf("BracketRight"),
+	KeyBrowserBack:    js.ValueOf("BrowserBack"),
 	KeyC:              js.ValueOf("KeyC"),
 	KeyCapsLock:       js.ValueOf("CapsLock"),
 	KeyComma:          js.ValueOf("Comma"),
@@ -51,6 +51,11 @@ func (m *Monitor) Size() (int, int) {
 	return int(w), int(h)
 }
 
+// RefreshRate returns the monitor's current refresh rate in Hz.
+func (m *Monitor) RefreshRate() float64 {
+	return theUI.monitorRefreshRate(m)
+}
+
 func (m *Monitor) sizeInDIP() (float64, float64) {
 	w, h := m.boundsInGLFWPixels.Dx(), m.boundsInGLFWPixels.Dy()
 	s := m.DeviceScaleFactor()
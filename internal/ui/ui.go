@@ -76,9 +76,19 @@ type UserInterface struct {
 	errM sync.Mutex
 
 	isScreenClearedEveryFrame atomic.Bool
+	updateOnEvent             atomic.Bool
 	graphicsLibrary           atomic.Int32
+	graphicsLibraryErrors     []*GraphicsLibraryError
+	graphicsLibraryErrorsM    sync.Mutex
 	running                   atomic.Bool
 	terminated                atomic.Bool
+	systemBarsHidden          atomic.Bool
+	immersiveMode             atomic.Bool
+	backButtonHandled         atomic.Bool
+
+	safeAreaInsets        [4]float64
+	virtualKeyboardHeight float64
+	insetsM               sync.Mutex
 
 	whiteImage *Image
 
@@ -161,6 +171,23 @@ func (u *UserInterface) readPixels(mipmap *mipmap.Mipmap, pixels []byte, region
 	return nil
 }
 
+func (u *UserInterface) Flush() {
+	if !u.running.Load() {
+		return
+	}
+	if err := atlas.Flush(u.graphicsDriver); err != nil {
+		u.setError(err)
+	}
+}
+
+func (u *UserInterface) PushDebugGroup(name string) {
+	atlas.PushDebugGroup(name)
+}
+
+func (u *UserInterface) PopDebugGroup() {
+	atlas.PopDebugGroup()
+}
+
 func (u *UserInterface) dumpScreenshot(mipmap *mipmap.Mipmap, name string, blackbg bool) (string, error) {
 	return mipmap.DumpScreenshot(u.graphicsDriver, name, blackbg)
 }
@@ -169,8 +196,42 @@ func (u *UserInterface) dumpImages(dir string) (string, error) {
 	return atlas.DumpImages(u.graphicsDriver, dir)
 }
 
+// ReadAtlases reads all the internal images with the information of the images on them.
+func (u *UserInterface) ReadAtlases() ([]atlas.AtlasInfo, error) {
+	if !u.running.Load() {
+		panic("ui: ReadAtlases cannot be called before the game starts")
+	}
+
+	infos, ok, err := atlas.ReadAtlases(u.graphicsDriver)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return infos, nil
+	}
+
+	// ReadAtlases failed since this was called in between two frames.
+	// Try this again at the next frame.
+	var err1 error
+	u.context.runInFrame(func() {
+		infos, ok, err = atlas.ReadAtlases(u.graphicsDriver)
+		if err != nil {
+			err1 = err
+			return
+		}
+		if !ok {
+			// This never reaches since this function must be called in a frame.
+			panic("ui: ReadAtlases unexpectedly failed")
+		}
+	})
+	if err1 != nil {
+		return nil, err1
+	}
+	return infos, nil
+}
+
 type RunOptions struct {
-	GraphicsLibrary   GraphicsLibrary
+	GraphicsLibraries []GraphicsLibrary
 	InitUnfocused     bool
 	ScreenTransparent bool
 	SkipTaskbar       bool
@@ -206,6 +267,98 @@ func (u *UserInterface) SetScreenClearedEveryFrame(cleared bool) {
 	u.isScreenClearedEveryFrame.Store(cleared)
 }
 
+func (u *UserInterface) IsUpdateOnEvent() bool {
+	return u.updateOnEvent.Load()
+}
+
+func (u *UserInterface) SetUpdateOnEvent(onEvent bool) {
+	u.updateOnEvent.Store(onEvent)
+	u.updateOnEventChanged()
+}
+
+// isUpdatedOnlyWhenNeeded reports whether a frame is processed only by an input or an explicit request.
+func (u *UserInterface) isUpdatedOnlyWhenNeeded(fpsMode FPSModeType) bool {
+	return fpsMode == FPSModeVsyncOffMinimum || u.updateOnEvent.Load()
+}
+
+func (u *UserInterface) IsSystemBarsVisible() bool {
+	return !u.systemBarsHidden.Load()
+}
+
+func (u *UserInterface) SetSystemBarsVisible(visible bool) {
+	if u.systemBarsHidden.Swap(!visible) == !visible {
+		return
+	}
+	u.systemBarsChanged()
+}
+
+func (u *UserInterface) IsImmersiveMode() bool {
+	return u.immersiveMode.Load()
+}
+
+func (u *UserInterface) SetImmersiveMode(immersive bool) {
+	if u.immersiveMode.Swap(immersive) == immersive {
+		return
+	}
+	u.systemBarsChanged()
+}
+
+func (u *UserInterface) IsBackButtonHandled() bool {
+	return u.backButtonHandled.Load()
+}
+
+func (u *UserInterface) SetBackButtonHandled(handled bool) {
+	if u.backButtonHandled.Swap(handled) == handled {
+		return
+	}
+	u.backButtonHandledChanged()
+}
+
+// SafeAreaInsets returns the insets of the area not covered by system UIs like status bars and notches,
+// in device-independent pixels.
+func (u *UserInterface) SafeAreaInsets() (left, top, right, bottom float64) {
+	u.insetsM.Lock()
+	defer u.insetsM.Unlock()
+	return u.safeAreaInsets[0], u.safeAreaInsets[1], u.safeAreaInsets[2], u.safeAreaInsets[3]
+}
+
+// SetSafeAreaInsets is called from mobile/ebitenmobileview.
+//
+// SetSafeAreaInsets is concurrent safe.
+func (u *UserInterface) SetSafeAreaInsets(left, top, right, bottom float64) {
+	u.insetsM.Lock()
+	insets := [4]float64{left, top, right, bottom}
+	changed := u.safeAreaInsets != insets
+	u.safeAreaInsets = insets
+	u.insetsM.Unlock()
+
+	if changed {
+		u.ScheduleFrame()
+	}
+}
+
+// VirtualKeyboardHeight returns the height of the area of the view covered by the software keyboard,
+// in device-independent pixels.
+func (u *UserInterface) VirtualKeyboardHeight() float64 {
+	u.insetsM.Lock()
+	defer u.insetsM.Unlock()
+	return u.virtualKeyboardHeight
+}
+
+// SetVirtualKeyboardHeight is called from mobile/ebitenmobileview.
+//
+// SetVirtualKeyboardHeight is concurrent safe.
+func (u *UserInterface) SetVirtualKeyboardHeight(height float64) {
+	u.insetsM.Lock()
+	changed := u.virtualKeyboardHeight != height
+	u.virtualKeyboardHeight = height
+	u.insetsM.Unlock()
+
+	if changed {
+		u.ScheduleFrame()
+	}
+}
+
 func (u *UserInterface) setGraphicsLibrary(library GraphicsLibrary) {
 	u.graphicsLibrary.Store(int32(library))
 }
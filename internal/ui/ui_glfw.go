@@ -60,6 +60,9 @@ type userInterfaceImpl struct {
 	maxWindowWidthInDIP  int
 	maxWindowHeightInDIP int
 
+	windowAspectRatioNumer int
+	windowAspectRatioDenom int
+
 	runnableOnUnfocused  bool
 	fpsMode              FPSModeType
 	iconImages           []image.Image
@@ -128,6 +131,8 @@ func (u *UserInterface) init() error {
 		minWindowHeightInDIP:     glfw.DontCare,
 		maxWindowWidthInDIP:      glfw.DontCare,
 		maxWindowHeightInDIP:     glfw.DontCare,
+		windowAspectRatioNumer:   glfw.DontCare,
+		windowAspectRatioDenom:   glfw.DontCare,
 		initCursorMode:           CursorModeVisible,
 		initWindowDecorated:      true,
 		initWindowPositionXInDIP: invalidPos,
@@ -282,6 +287,27 @@ func (u *UserInterface) Monitor() *Monitor {
 	return monitor
 }
 
+// monitorRefreshRate returns the current refresh rate of the monitor.
+// The video mode is queried every time as a user might change the display settings while the game is running.
+func (u *UserInterface) monitorRefreshRate(monitor *Monitor) float64 {
+	if !u.isRunning() {
+		return float64(monitor.videoMode.RefreshRate)
+	}
+	var rate float64
+	u.mainThread.Call(func() {
+		if u.isTerminated() {
+			return
+		}
+		vm, err := monitor.m.GetVideoMode()
+		if err != nil {
+			u.setError(err)
+			return
+		}
+		rate = float64(vm.RefreshRate)
+	})
+	return rate
+}
+
 // setWindowMonitor must be called on the main thread.
 func (u *UserInterface) setWindowMonitor(monitor *Monitor) error {
 	if microsoftgdk.IsXbox() {
@@ -374,6 +400,37 @@ func (u *UserInterface) setWindowSizeLimitsInDIP(minw, minh, maxw, maxh int) boo
 	return true
 }
 
+func (u *UserInterface) getWindowAspectRatio() (numer, denom int) {
+	if microsoftgdk.IsXbox() {
+		return glfw.DontCare, glfw.DontCare
+	}
+
+	u.m.RLock()
+	defer u.m.RUnlock()
+	return u.windowAspectRatioNumer, u.windowAspectRatioDenom
+}
+
+func (u *UserInterface) setWindowAspectRatio(numer, denom int) bool {
+	if microsoftgdk.IsXbox() {
+		// Do nothing. The size is always fixed.
+		return false
+	}
+
+	if numer <= 0 || denom <= 0 {
+		numer = glfw.DontCare
+		denom = glfw.DontCare
+	}
+
+	u.m.Lock()
+	defer u.m.Unlock()
+	if u.windowAspectRatioNumer == numer && u.windowAspectRatioDenom == denom {
+		return false
+	}
+	u.windowAspectRatioNumer = numer
+	u.windowAspectRatioDenom = denom
+	return true
+}
+
 func (u *UserInterface) isWindowMaximizable() bool {
 	_, _, maxw, maxh := u.getWindowSizeLimitsInDIP()
 	return maxw == glfw.DontCare && maxh == glfw.DontCare
@@ -717,6 +774,38 @@ func (u *UserInterface) ScheduleFrame() {
 	}
 }
 
+func (u *UserInterface) updateOnEventChanged() {
+	if u.isTerminated() {
+		return
+	}
+	if !u.isRunning() {
+		// The sticky input mode is updated at setFPSMode when the game starts.
+		return
+	}
+
+	u.mainThread.Call(func() {
+		if u.isTerminated() {
+			return
+		}
+		if !u.fpsModeInited {
+			return
+		}
+		if err := u.updateStickyInputMode(); err != nil {
+			u.setError(err)
+			return
+		}
+	})
+
+	// Wake up the main thread waiting for events so that the new mode takes effect immediately.
+	u.ScheduleFrame()
+}
+
+func (u *UserInterface) systemBarsChanged() {
+}
+
+func (u *UserInterface) backButtonHandledChanged() {
+}
+
 func (u *UserInterface) CursorMode() CursorMode {
 	if u.isTerminated() {
 		return 0
@@ -872,6 +961,9 @@ func (u *UserInterface) createWindow() error {
 	if err := u.updateWindowSizeLimits(); err != nil {
 		return err
 	}
+	if err := u.updateWindowAspectRatio(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -1059,9 +1151,9 @@ func (u *UserInterface) initOnMainThread(options *RunOptions) error {
 		return err
 	}
 
-	g, lib, err := newGraphicsDriver(&graphicsDriverCreatorImpl{
+	g, lib, err := u.newGraphicsDriver(&graphicsDriverCreatorImpl{
 		transparent: options.ScreenTransparent,
-	}, options.GraphicsLibrary)
+	}, options.GraphicsLibraries)
 	if err != nil {
 		return err
 	}
@@ -1218,6 +1310,21 @@ func (u *UserInterface) outsideSize() (float64, float64, error) {
 	return w, h, nil
 }
 
+// updateStickyInputMode must be called from the main thread.
+func (u *UserInterface) updateStickyInputMode() error {
+	sticky := glfw.True
+	if u.isUpdatedOnlyWhenNeeded(u.fpsMode) {
+		sticky = glfw.False
+	}
+	if err := u.window.SetInputMode(glfw.StickyMouseButtonsMode, sticky); err != nil {
+		return err
+	}
+	if err := u.window.SetInputMode(glfw.StickyKeysMode, sticky); err != nil {
+		return err
+	}
+	return nil
+}
+
 // setFPSMode must be called from the main thread.
 func (u *UserInterface) setFPSMode(fpsMode FPSModeType) error {
 	needUpdate := u.fpsMode != fpsMode || !u.fpsModeInited
@@ -1228,14 +1335,7 @@ func (u *UserInterface) setFPSMode(fpsMode FPSModeType) error {
 		return nil
 	}
 
-	sticky := glfw.True
-	if fpsMode == FPSModeVsyncOffMinimum {
-		sticky = glfw.False
-	}
-	if err := u.window.SetInputMode(glfw.StickyMouseButtonsMode, sticky); err != nil {
-		return err
-	}
-	if err := u.window.SetInputMode(glfw.StickyKeysMode, sticky); err != nil {
+	if err := u.updateStickyInputMode(); err != nil {
 		return err
 	}
 
@@ -1343,7 +1443,7 @@ func (u *UserInterface) update() (float64, float64, error) {
 		}
 	}
 
-	if u.fpsMode != FPSModeVsyncOffMinimum {
+	if !u.isUpdatedOnlyWhenNeeded(u.fpsMode) {
 		// TODO: Updating the input can be skipped when clock.Update returns 0 (#1367).
 		if err := glfw.PollEvents(); err != nil {
 			return 0, 0, err
@@ -1407,6 +1507,8 @@ func (u *UserInterface) loopGame() (ferr error) {
 			u.setTerminated()
 		})
 	}()
+	// Run the hooks on the game thread before terminating the graphics.
+	defer hook.RunBeforeExitHooks()
 
 	for {
 		if err := u.updateGame(); err != nil {
@@ -1577,6 +1679,15 @@ func (u *UserInterface) updateWindowSizeLimits() error {
 	return nil
 }
 
+// updateWindowAspectRatio must be called from the main thread.
+func (u *UserInterface) updateWindowAspectRatio() error {
+	numer, denom := u.getWindowAspectRatio()
+	if err := u.window.SetAspectRatio(numer, denom); err != nil {
+		return err
+	}
+	return nil
+}
+
 // disableWindowSizeLimits disables a window size limitation temporarily, especially for fullscreen
 // In order to enable the size limitation, call updateWindowSizeLimits.
 //
@@ -105,6 +105,7 @@ type userInterfaceImpl struct {
 	origCursorXInClient       float64
 	origCursorYInClient       float64
 	touchesInClient           []touchInClient
+	pointersInClient          map[int]pointerInClient
 
 	savedCursorX              float64
 	savedCursorY              float64
@@ -114,6 +115,12 @@ type userInterfaceImpl struct {
 
 	keyboardLayoutMap js.Value
 
+	// frameM is locked while the game loop runs a frame.
+	frameM sync.Mutex
+
+	// beforeExitRequested is set when the hooks before exit are requested during a frame.
+	beforeExitRequested bool
+
 	m         sync.Mutex
 	dropFileM sync.Mutex
 }
@@ -197,6 +204,15 @@ func (u *UserInterface) ScheduleFrame() {
 	u.renderingScheduled = true
 }
 
+func (u *UserInterface) updateOnEventChanged() {
+}
+
+func (u *UserInterface) systemBarsChanged() {
+}
+
+func (u *UserInterface) backButtonHandledChanged() {
+}
+
 func (u *UserInterface) CursorMode() CursorMode {
 	if !canvas.Truthy() {
 		return CursorModeHidden
@@ -359,7 +375,7 @@ func (u *UserInterface) updateImpl(force bool) error {
 }
 
 func (u *UserInterface) needsUpdate() bool {
-	if u.fpsMode != FPSModeVsyncOffMinimum {
+	if !u.isUpdatedOnlyWhenNeeded(u.fpsMode) {
 		return true
 	}
 	if !u.onceUpdateCalled {
@@ -379,6 +395,18 @@ func (u *UserInterface) loopGame() error {
 
 	var cf js.Func
 	f := func() {
+		u.frameM.Lock()
+		defer u.frameM.Unlock()
+
+		// Run the hooks requested by beforeunload during this frame.
+		// This must not block until the lock is released, or beforeunload might miss the request.
+		defer func() {
+			if u.beforeExitRequested {
+				u.beforeExitRequested = false
+				hook.RunBeforeExitHooks()
+			}
+		}()
+
 		if err := u.error(); err != nil {
 			errCh <- err
 			return
@@ -456,7 +484,9 @@ func (u *UserInterface) loopGame() error {
 		}
 	}()
 
-	return <-errCh
+	err := <-errCh
+	hook.RunBeforeExitHooks()
+	return err
 }
 
 func (u *UserInterface) init() error {
@@ -549,6 +579,20 @@ func (u *UserInterface) init() error {
 }
 
 func (u *UserInterface) setWindowEventHandlers(v js.Value) {
+	// beforeunload is the last chance to run the hooks when the tab is closed or reloaded.
+	// This is best-effort, as the browser might not wait for the hooks or might not fire the event at all.
+	v.Call("addEventListener", "beforeunload", js.FuncOf(func(this js.Value, args []js.Value) any {
+		// Don't run the hooks concurrently with Update.
+		// If a frame is running, dispatch the hooks to the game loop so that they run at the end of the frame.
+		if !u.frameM.TryLock() {
+			u.beforeExitRequested = true
+			return nil
+		}
+		defer u.frameM.Unlock()
+		hook.RunBeforeExitHooks()
+		return nil
+	}))
+
 	v.Call("addEventListener", "resize", js.FuncOf(func(this js.Value, args []js.Value) any {
 		u.updateScreenSize()
 
@@ -661,6 +705,15 @@ func (u *UserInterface) setCanvasEventHandlers(v js.Value) {
 		return nil
 	}))
 
+	// Pointer
+	// Pointer events are used only to get the details of touches like pressure.
+	for _, name := range []string{"pointerdown", "pointermove", "pointerup", "pointercancel"} {
+		v.Call("addEventListener", name, js.FuncOf(func(this js.Value, args []js.Value) any {
+			u.updatePointerFromEvent(args[0])
+			return nil
+		}))
+	}
+
 	// Context menu
 	v.Call("addEventListener", "contextmenu", js.FuncOf(func(this js.Value, args []js.Value) any {
 		e := args[0]
@@ -715,7 +768,7 @@ func (u *UserInterface) appendDroppedFiles(data js.Value) {
 }
 
 func (u *UserInterface) forceUpdateOnMinimumFPSMode() {
-	if u.fpsMode != FPSModeVsyncOffMinimum {
+	if !u.isUpdatedOnlyWhenNeeded(u.fpsMode) {
 		return
 	}
 
@@ -738,9 +791,9 @@ func (u *UserInterface) initOnMainThread(options *RunOptions) error {
 		}
 	}
 
-	g, lib, err := newGraphicsDriver(&graphicsDriverCreatorImpl{
+	g, lib, err := u.newGraphicsDriver(&graphicsDriverCreatorImpl{
 		canvas: canvas,
-	}, options.GraphicsLibrary)
+	}, options.GraphicsLibraries)
 	if err != nil {
 		return err
 	}
@@ -799,6 +852,11 @@ func (m *Monitor) DeviceScaleFactor() float64 {
 	return m.deviceScaleFactor
 }
 
+func (m *Monitor) RefreshRate() float64 {
+	// Browsers don't provide the refresh rate. 0 means unknown.
+	return 0
+}
+
 func (m *Monitor) Size() (int, int) {
 	return screen.Get("width").Int(), screen.Get("height").Int()
 }
@@ -100,6 +100,9 @@ type userInterfaceImpl struct {
 	fpsMode         atomic.Int32
 	renderRequester RenderRequester
 
+	systemBarsController SystemBarsController
+	backButtonController BackButtonController
+
 	m sync.RWMutex
 }
 
@@ -143,11 +146,12 @@ func (u *UserInterface) runMobile(game Game, options *RunOptions) (err error) {
 
 	u.context = newContext(game)
 
-	g, lib, err := newGraphicsDriver(&graphicsDriverCreatorImpl{}, options.GraphicsLibrary)
+	g, lib, err := u.newGraphicsDriver(&graphicsDriverCreatorImpl{}, options.GraphicsLibraries)
 	if err != nil {
 		return err
 	}
 	u.graphicsDriver = g
+	u.graphicsDriver.SetTransparent(options.ScreenTransparent)
 	u.setGraphicsLibrary(lib)
 	close(u.graphicsLibraryInitCh)
 
@@ -240,7 +244,31 @@ func (u *UserInterface) updateExplicitRenderingModeIfNeeded(fpsMode FPSModeType)
 	if u.renderRequester == nil {
 		return
 	}
-	u.renderRequester.SetExplicitRenderingMode(fpsMode == FPSModeVsyncOffMinimum)
+	u.renderRequester.SetExplicitRenderingMode(u.isUpdatedOnlyWhenNeeded(fpsMode))
+}
+
+func (u *UserInterface) updateOnEventChanged() {
+	u.updateExplicitRenderingModeIfNeeded(FPSModeType(u.fpsMode.Load()))
+}
+
+func (u *UserInterface) systemBarsChanged() {
+	u.m.RLock()
+	c := u.systemBarsController
+	u.m.RUnlock()
+	if c == nil {
+		return
+	}
+	c.SetSystemBarsState(u.IsSystemBarsVisible(), u.IsImmersiveMode())
+}
+
+func (u *UserInterface) backButtonHandledChanged() {
+	u.m.RLock()
+	c := u.backButtonController
+	u.m.RUnlock()
+	if c == nil {
+		return
+	}
+	c.SetBackButtonHandled(u.IsBackButtonHandled())
 }
 
 func (u *UserInterface) readInputState(inputState *InputState) {
@@ -279,6 +307,11 @@ func (m *Monitor) DeviceScaleFactor() float64 {
 	return m.deviceScaleFactor
 }
 
+func (m *Monitor) RefreshRate() float64 {
+	// The refresh rate is not available via gomobile. 0 means unknown.
+	return 0
+}
+
 func (m *Monitor) Size() (int, int) {
 	// TODO: Return a valid value.
 	return 0, 0
@@ -294,7 +327,7 @@ func (u *UserInterface) Monitor() *Monitor {
 
 func (u *UserInterface) UpdateInput(keys map[Key]struct{}, runes []rune, touches []TouchForInput) {
 	u.updateInputStateFromOutside(keys, runes, touches)
-	if FPSModeType(u.fpsMode.Load()) == FPSModeVsyncOffMinimum {
+	if u.isUpdatedOnlyWhenNeeded(FPSModeType(u.fpsMode.Load())) {
 		u.renderRequester.RequestRenderIfNeeded()
 	}
 }
@@ -309,8 +342,30 @@ func (u *UserInterface) SetRenderRequester(renderRequester RenderRequester) {
 	u.updateExplicitRenderingModeIfNeeded(FPSModeType(u.fpsMode.Load()))
 }
 
+type SystemBarsController interface {
+	SetSystemBarsState(visible bool, immersive bool)
+}
+
+func (u *UserInterface) SetSystemBarsController(systemBarsController SystemBarsController) {
+	u.m.Lock()
+	u.systemBarsController = systemBarsController
+	u.m.Unlock()
+	u.systemBarsChanged()
+}
+
+type BackButtonController interface {
+	SetBackButtonHandled(handled bool)
+}
+
+func (u *UserInterface) SetBackButtonController(backButtonController BackButtonController) {
+	u.m.Lock()
+	u.backButtonController = backButtonController
+	u.m.Unlock()
+	u.backButtonHandledChanged()
+}
+
 func (u *UserInterface) ScheduleFrame() {
-	if u.renderRequester != nil && FPSModeType(u.fpsMode.Load()) == FPSModeVsyncOffMinimum {
+	if u.renderRequester != nil && u.isUpdatedOnlyWhenNeeded(FPSModeType(u.fpsMode.Load())) {
 		u.renderRequester.RequestRenderIfNeeded()
 	}
 }
@@ -320,5 +375,5 @@ func (u *UserInterface) updateIconIfNeeded() error {
 }
 
 func IsScreenTransparentAvailable() bool {
-	return false
+	return true
 }
@@ -74,9 +74,9 @@ func (u *UserInterface) init() error {
 
 func (u *UserInterface) initOnMainThread(options *RunOptions) error {
 	n := C.ebitengine_Initialize()
-	g, lib, err := newGraphicsDriver(&graphicsDriverCreatorImpl{
+	g, lib, err := u.newGraphicsDriver(&graphicsDriverCreatorImpl{
 		nativeWindow: n,
-	}, options.GraphicsLibrary)
+	}, options.GraphicsLibraries)
 	if err != nil {
 		return err
 	}
@@ -146,6 +146,9 @@ func (*UserInterface) SetFPSMode(mode FPSModeType) {
 func (*UserInterface) ScheduleFrame() {
 }
 
+func (*UserInterface) updateOnEventChanged() {
+}
+
 func (*UserInterface) Window() Window {
 	return &nullWindow{}
 }
@@ -166,6 +169,10 @@ func (m *Monitor) DeviceScaleFactor() float64 {
 	return 1
 }
 
+func (m *Monitor) RefreshRate() float64 {
+	return 0
+}
+
 func (m *Monitor) Size() (int, int) {
 	return int(C.kScreenWidth), int(C.kScreenHeight)
 }
@@ -69,7 +69,7 @@ func (u *UserInterface) init() error {
 }
 
 func (u *UserInterface) initOnMainThread(options *RunOptions) error {
-	g, lib, err := newGraphicsDriver(&graphicsDriverCreatorImpl{}, options.GraphicsLibrary)
+	g, lib, err := u.newGraphicsDriver(&graphicsDriverCreatorImpl{}, options.GraphicsLibraries)
 	if err != nil {
 		return err
 	}
@@ -134,6 +134,9 @@ func (*UserInterface) SetFPSMode(mode FPSModeType) {
 func (*UserInterface) ScheduleFrame() {
 }
 
+func (*UserInterface) updateOnEventChanged() {
+}
+
 func (*UserInterface) Window() Window {
 	return &nullWindow{}
 }
@@ -159,6 +162,10 @@ func (m *Monitor) DeviceScaleFactor() float64 {
 	return 1
 }
 
+func (m *Monitor) RefreshRate() float64 {
+	return 0
+}
+
 func (m *Monitor) Size() (int, int) {
 	return screenWidth, screenHeight
 }
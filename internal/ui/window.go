@@ -30,6 +30,8 @@ type Window interface {
 	SetSize(width, height int)
 	SizeLimits() (minw, minh, maxw, maxh int)
 	SetSizeLimits(minw, minh, maxw, maxh int)
+	AspectRatio() (numer, denom int)
+	SetAspectRatio(numer, denom int)
 	IsFloating() bool
 	SetFloating(floating bool)
 	Maximize()
@@ -85,6 +87,13 @@ func (*nullWindow) SizeLimits() (minw, minh, maxw, maxh int) {
 func (*nullWindow) SetSizeLimits(minw, minh, maxw, maxh int) {
 }
 
+func (*nullWindow) AspectRatio() (numer, denom int) {
+	return -1, -1
+}
+
+func (*nullWindow) SetAspectRatio(numer, denom int) {
+}
+
 func (*nullWindow) IsFloating() bool {
 	return false
 }
@@ -428,6 +428,32 @@ func (w *glfwWindow) SetSizeLimits(minw, minh, maxw, maxh int) {
 	})
 }
 
+func (w *glfwWindow) AspectRatio() (numer, denom int) {
+	return w.ui.getWindowAspectRatio()
+}
+
+func (w *glfwWindow) SetAspectRatio(numer, denom int) {
+	if w.ui.isTerminated() {
+		return
+	}
+	if !w.ui.setWindowAspectRatio(numer, denom) {
+		return
+	}
+	if !w.ui.isRunning() {
+		return
+	}
+
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		if err := w.ui.updateWindowAspectRatio(); err != nil {
+			w.ui.setError(err)
+			return
+		}
+	})
+}
+
 func (w *glfwWindow) SetIcon(iconImages []image.Image) {
 	if w.ui.isTerminated() {
 		return
@@ -67,6 +67,7 @@ const (
 	KeyBackspace      Key = Key(ui.KeyBackspace)
 	KeyBracketLeft    Key = Key(ui.KeyBracketLeft)
 	KeyBracketRight   Key = Key(ui.KeyBracketRight)
+	KeyBrowserBack    Key = Key(ui.KeyBrowserBack)
 	KeyCapsLock       Key = Key(ui.KeyCapsLock)
 	KeyComma          Key = Key(ui.KeyComma)
 	KeyContextMenu    Key = Key(ui.KeyContextMenu)
@@ -272,6 +273,8 @@ func (k Key) isValid() bool {
 		return true
 	case KeyBracketRight:
 		return true
+	case KeyBrowserBack:
+		return true
 	case KeyCapsLock:
 		return true
 	case KeyComma:
@@ -527,6 +530,8 @@ func (k Key) String() string {
 		return "BracketLeft"
 	case KeyBracketRight:
 		return "BracketRight"
+	case KeyBrowserBack:
+		return "BrowserBack"
 	case KeyCapsLock:
 		return "CapsLock"
 	case KeyComma:
@@ -799,6 +804,8 @@ func keyNameToKeyCode(name string) (Key, bool) {
 		return KeyBracketLeft, true
 	case "bracketright":
 		return KeyBracketRight, true
+	case "browserback":
+		return KeyBrowserBack, true
 	case "capslock":
 		return KeyCapsLock, true
 	case "comma":
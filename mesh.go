@@ -0,0 +1,152 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
+)
+
+// Mesh is a set of vertices and indices for Image.DrawMesh, which is validated and converted in advance.
+//
+// A Mesh is built once and can be drawn many times.
+// The indices are validated and converted only when they are updated, not at every draw call.
+// The vertices are converted to the internal format when they are updated or when the drawing parameters change,
+// e.g., the destination image, the source image, or the color scale.
+//
+// Note that a Mesh is not a GPU buffer.
+// A Mesh reduces CPU work for drawing, but its vertices and indices are still copied and sent to the GPU
+// with the other draw commands in each frame as DrawTriangles's are.
+//
+// Mesh is not concurrent-safe.
+type Mesh struct {
+	vertices []Vertex
+	indices  []uint32
+
+	// floats is the vertices converted to the internal format.
+	// floats is valid only when floatsValid is true and floatsKey matches the drawing parameters.
+	floats      []float32
+	floatsKey   meshFloatsKey
+	floatsValid bool
+}
+
+// meshFloatsKey is the set of parameters to convert the vertices of a mesh.
+type meshFloatsKey struct {
+	dstX, dstY     float32
+	srcX, srcY     float32
+	colorScaleMode ColorScaleMode
+	cr, cg, cb, ca float32
+}
+
+// NewMesh creates a new mesh with the specified vertices and indices.
+// The given slices are copied.
+//
+// If len(vertices) is more than MaxVertexCount, NewMesh panics.
+//
+// If len(indices) is not multiple of 3, NewMesh panics.
+//
+// If a value in indices is out of range of vertices, NewMesh panics.
+func NewMesh(vertices []Vertex, indices []uint16) *Mesh {
+	if len(vertices) > graphicscommand.MaxVertexCount {
+		panic(fmt.Sprintf("ebiten: len(vertices) must be less than or equal to MaxVertexCount (%d) but was %d", graphicscommand.MaxVertexCount, len(vertices)))
+	}
+	if len(indices)%3 != 0 {
+		panic("ebiten: len(indices) % 3 must be 0")
+	}
+
+	m := &Mesh{
+		vertices: make([]Vertex, len(vertices)),
+		indices:  make([]uint32, len(indices)),
+	}
+	copy(m.vertices, vertices)
+	m.SetIndices(0, indices)
+	return m
+}
+
+// VertexCount returns the number of the vertices.
+func (m *Mesh) VertexCount() int {
+	return len(m.vertices)
+}
+
+// IndexCount returns the number of the indices.
+func (m *Mesh) IndexCount() int {
+	return len(m.indices)
+}
+
+// SetVertices updates the vertices from the offset with the specified vertices.
+// The number of the vertices doesn't change.
+//
+// If the range [offset, offset+len(vertices)) is out of range of the mesh's vertices, SetVertices panics.
+func (m *Mesh) SetVertices(offset int, vertices []Vertex) {
+	if offset < 0 || offset+len(vertices) > len(m.vertices) {
+		panic(fmt.Sprintf("ebiten: the range [%d, %d) is out of range of the vertices [0, %d)", offset, offset+len(vertices), len(m.vertices)))
+	}
+	copy(m.vertices[offset:], vertices)
+	m.floatsValid = false
+}
+
+// SetIndices updates the indices from the offset with the specified indices.
+// The number of the indices doesn't change.
+//
+// If the range [offset, offset+len(indices)) is out of range of the mesh's indices, SetIndices panics.
+//
+// If a value in indices is out of range of the mesh's vertices, SetIndices panics.
+func (m *Mesh) SetIndices(offset int, indices []uint16) {
+	if offset < 0 || offset+len(indices) > len(m.indices) {
+		panic(fmt.Sprintf("ebiten: the range [%d, %d) is out of range of the indices [0, %d)", offset, offset+len(indices), len(m.indices)))
+	}
+	for i, idx := range indices {
+		if int(idx) >= len(m.vertices) {
+			panic(fmt.Sprintf("ebiten: indices[%d] must be less than the number of the vertices (%d) but was %d", i, len(m.vertices), idx))
+		}
+	}
+	for i, idx := range indices {
+		m.indices[offset+i] = uint32(idx)
+	}
+}
+
+// vertexFloats returns the vertices converted to the internal format for the given drawing parameters.
+// The result is cached while the parameters are the same.
+func (m *Mesh) vertexFloats(dst, src *Image, colorScaleMode ColorScaleMode, cr, cg, cb, ca float32) []float32 {
+	// The origins of the images affect the converted positions.
+	dx, dy := dst.adjustPositionF32(0, 0)
+	sx, sy := src.adjustPositionF32(0, 0)
+	key := meshFloatsKey{
+		dstX:           dx,
+		dstY:           dy,
+		srcX:           sx,
+		srcY:           sy,
+		colorScaleMode: colorScaleMode,
+		cr:             cr,
+		cg:             cg,
+		cb:             cb,
+		ca:             ca,
+	}
+	if m.floatsValid && m.floatsKey == key {
+		return m.floats
+	}
+
+	n := len(m.vertices) * graphics.VertexFloatCount
+	if cap(m.floats) < n {
+		m.floats = make([]float32, n)
+	}
+	m.floats = m.floats[:n]
+	convertVertices(m.floats, m.vertices, dst, src, colorScaleMode, cr, cg, cb, ca)
+	m.floatsKey = key
+	m.floatsValid = true
+	return m.floats
+}
@@ -0,0 +1,31 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build android || ios
+
+package ebitenmobileview
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/externaldisplay"
+)
+
+// SetExternalDisplay sets the state of the external display.
+// width and height are in device-independent pixels.
+func SetExternalDisplay(connected bool, width, height float64, deviceScaleFactor float64) {
+	externaldisplay.Set(connected, externaldisplay.Display{
+		Width:             width,
+		Height:            height,
+		DeviceScaleFactor: deviceScaleFactor,
+	})
+}
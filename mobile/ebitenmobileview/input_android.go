@@ -17,6 +17,7 @@ package ebitenmobileview
 import (
 	"encoding/hex"
 	"hash/crc32"
+	"math"
 	"unicode"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
@@ -75,6 +76,12 @@ const (
 	sourceJoystick = 0x01000010
 )
 
+// https://developer.android.com/reference/android/view/MotionEvent
+const (
+	toolTypeStylus = 2
+	toolTypeEraser = 4
+)
+
 // See https://github.com/libsdl-org/SDL/blob/47f2373dc13b66c48bf4024fcdab53cd0bdd59bb/src/joystick/android/SDL_sysjoystick.c#L71-L172
 // TODO: This exceeds gamepad.SDLControllerButtonMax. Is that OK?
 
@@ -119,10 +126,30 @@ var androidKeyToSDL = map[int]int{
 	keycodeButton16:     35,
 }
 
-func UpdateTouchesOnAndroid(action int, id int, x, y int) {
+// UpdateTouchesOnAndroid updates the touch states.
+//
+// pressure is MotionEvent's pressure. toolType is MotionEvent's tool type.
+// tilt and orientation are MotionEvent's AXIS_TILT and AXIS_ORIENTATION values in radians, which are used only for a stylus.
+func UpdateTouchesOnAndroid(action int, id int, x, y int, pressure float64, toolType int, tilt, orientation float64) {
 	switch action {
 	case 0x00, 0x05, 0x02: // ACTION_DOWN, ACTION_POINTER_DOWN, ACTION_MOVE
-		touches[ui.TouchID(id)] = position{x, y}
+		d := ui.TouchDetails{
+			Pressure:          math.Max(0, math.Min(1, pressure)),
+			PressureAvailable: true,
+		}
+		if toolType == toolTypeStylus || toolType == toolTypeEraser {
+			d.Stylus = true
+			// AXIS_TILT is the angle from the perpendicular, and AXIS_ORIENTATION is the angle from the upward direction
+			// in clockwise.
+			d.Altitude = math.Pi/2 - tilt
+			d.Azimuth = orientation - math.Pi/2
+			d.TiltAvailable = true
+		}
+		touches[ui.TouchID(id)] = touch{
+			x:       x,
+			y:       y,
+			details: d,
+		}
 		updateInput(nil)
 	case 0x01, 0x06: // ACTION_UP, ACTION_POINTER_UP
 		delete(touches, ui.TouchID(id))
@@ -202,7 +229,7 @@ func OnGamepadAdded(deviceID int, name string, axisCount int, hatCount int, desc
 	sdlid[14] = byte(axisMask)
 	sdlid[15] = byte(axisMask >> 8)
 
-	gamepad.AddAndroidGamepad(deviceID, name, hex.EncodeToString(sdlid[:]), axisCount, hatCount)
+	gamepad.AddAndroidGamepad(deviceID, name, hex.EncodeToString(sdlid[:]), axisCount, hatCount, vendorID, productID)
 }
 
 func OnInputDeviceRemoved(deviceID int) {
@@ -16,6 +16,7 @@ package ebitenmobileview
 
 import (
 	"fmt"
+	"math"
 	"unicode"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
@@ -44,11 +45,31 @@ func getIDFromPtr(ptr int64) int {
 	return id
 }
 
-func UpdateTouchesOnIOS(phase int, ptr int64, x, y int) {
+// UpdateTouchesOnIOS updates the touch states.
+//
+// pressure is UITouch's force divided by maximumPossibleForce, and is used only when pressureAvailable is true.
+// altitude and azimuth are UITouch's altitudeAngle and azimuthAngle in radians, which are used only when stylus is true.
+func UpdateTouchesOnIOS(phase int, ptr int64, x, y int, pressure float64, pressureAvailable bool, stylus bool, altitude, azimuth float64) {
 	switch phase {
 	case C.UITouchPhaseBegan, C.UITouchPhaseMoved, C.UITouchPhaseStationary:
 		id := getIDFromPtr(ptr)
-		touches[ui.TouchID(id)] = position{x, y}
+		d := ui.TouchDetails{
+			Stylus: stylus,
+		}
+		if pressureAvailable {
+			d.Pressure = math.Max(0, math.Min(1, pressure))
+			d.PressureAvailable = true
+		}
+		if stylus {
+			d.Altitude = altitude
+			d.Azimuth = azimuth
+			d.TiltAvailable = true
+		}
+		touches[ui.TouchID(id)] = touch{
+			x:       x,
+			y:       y,
+			details: d,
+		}
 		updateInput(nil)
 	case C.UITouchPhaseEnded, C.UITouchPhaseCancelled:
 		id := getIDFromPtr(ptr)
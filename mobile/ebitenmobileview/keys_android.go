@@ -21,6 +21,7 @@ import (
 )
 
 var androidKeyToUIKey = map[int]ui.Key{
+	4:   ui.KeyBrowserBack,
 	7:   ui.KeyDigit0,
 	8:   ui.KeyDigit1,
 	9:   ui.KeyDigit2,
@@ -30,6 +30,7 @@ import (
 	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
 
@@ -112,6 +113,10 @@ func Resume() error {
 	return ui.Get().SetForeground(true)
 }
 
+func OnLowMemory() {
+	hook.NotifyLowMemory()
+}
+
 func DeviceScale() float64 {
 	return ui.Get().Monitor().DeviceScaleFactor()
 }
@@ -125,6 +130,30 @@ func SetRenderRequester(renderRequester RenderRequester) {
 	ui.Get().SetRenderRequester(renderRequester)
 }
 
+func SetSafeAreaInsets(left, top, right, bottom float64) {
+	ui.Get().SetSafeAreaInsets(left, top, right, bottom)
+}
+
+func SetVirtualKeyboardHeight(height float64) {
+	ui.Get().SetVirtualKeyboardHeight(height)
+}
+
+type SystemBarsController interface {
+	SetSystemBarsState(visible bool, immersive bool)
+}
+
+func SetSystemBarsController(systemBarsController SystemBarsController) {
+	ui.Get().SetSystemBarsController(systemBarsController)
+}
+
+type BackButtonController interface {
+	SetBackButtonHandled(handled bool)
+}
+
+func SetBackButtonController(backButtonController BackButtonController) {
+	ui.Get().SetBackButtonController(backButtonController)
+}
+
 func SetSetGameNotifier(setGameNotifier SetGameNotifier) {
 	theState.setSetGameNotifier(setGameNotifier)
 }
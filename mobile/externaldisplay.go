@@ -0,0 +1,56 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mobile
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/externaldisplay"
+)
+
+// ExternalDisplay represents an external display connected to a mobile device, e.g. via HDMI or AirPlay.
+type ExternalDisplay struct {
+	// Width and Height are the size of the display in device-independent pixels.
+	Width  float64
+	Height float64
+
+	// DeviceScaleFactor is the ratio of the physical pixels to the device-independent pixels.
+	DeviceScaleFactor float64
+}
+
+// ConnectedExternalDisplay returns the external display connected to the device,
+// and reports whether an external display is connected.
+// If multiple external displays are connected, ConnectedExternalDisplay returns one of them.
+//
+// ConnectedExternalDisplay is intended to be polled in the game's Update, e.g. to switch the device's screen to
+// a controller UI while an external display is connected for local multiplayer.
+// The connection is detected by EbitenView on Android and EbitenViewController on iOS with ebitenmobile.
+//
+// Rendering a different image on an external display is not supported so far.
+// The external display mirrors the device's screen, and the operating system fits the mirrored image
+// into the external display. Touch inputs are always bound to the device's screen.
+//
+// ConnectedExternalDisplay is concurrent-safe.
+//
+// On non-mobile platforms, ConnectedExternalDisplay always returns false.
+func ConnectedExternalDisplay() (ExternalDisplay, bool) {
+	d, ok := externaldisplay.Get()
+	if !ok {
+		return ExternalDisplay{}, false
+	}
+	return ExternalDisplay{
+		Width:             d.Width,
+		Height:            d.Height,
+		DeviceScaleFactor: d.DeviceScaleFactor,
+	}, true
+}
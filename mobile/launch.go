@@ -0,0 +1,54 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mobile
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/launchparam"
+)
+
+// NextLaunchParameters returns the oldest set of launch parameters that is not returned yet,
+// and reports whether there is such a set.
+//
+// A set of launch parameters is delivered by the host application when the application is launched,
+// e.g. from a notification or a deep link, and also when the running application is reopened with new parameters.
+// With ebitenmobile, call EbitenView's setLaunchParameters with an Intent on Android
+// at Activity's onCreate and onNewIntent, and call EbitenViewController's setLaunchURL: with a URL on iOS
+// at UIApplicationDelegate's application:openURL:options: and application:continueUserActivity:restorationHandler:.
+//
+// NextLaunchParameters is intended to be polled in the game's Update. For example:
+//
+//	func (g *Game) Update() error {
+//		for {
+//			params, ok := mobile.NextLaunchParameters()
+//			if !ok {
+//				break
+//			}
+//			if level, ok := params["level"]; ok {
+//				g.selectLevel(level)
+//			}
+//		}
+//		...
+//	}
+//
+// With the above code, a level can be selected on Android with adb:
+//
+//	adb shell am start -n com.example.mygame/.MainActivity --es level 3
+//
+// NextLaunchParameters is concurrent-safe.
+//
+// On non-mobile platforms, NextLaunchParameters always returns false.
+func NextLaunchParameters() (map[string]string, bool) {
+	return launchparam.Next()
+}
@@ -51,6 +51,21 @@ func (m *MonitorType) Size() (int, int) {
 	return (*ui.Monitor)(m).Size()
 }
 
+// RefreshRate returns the current refresh rate of the monitor in Hz.
+//
+// RefreshRate queries the current value every time, then the returned value reflects the display settings changed
+// while the game is running.
+// The returned value might be rounded to an integer on some platforms.
+//
+// RefreshRate returns 0 if the refresh rate is unknown, and the caller must treat 0 as unknown.
+// As browsers don't provide the refresh rate, RefreshRate always returns 0 on browsers.
+// RefreshRate also returns 0 on mobiles and consoles.
+//
+// RefreshRate is concurrent-safe.
+func (m *MonitorType) RefreshRate() float64 {
+	return (*ui.Monitor)(m).RefreshRate()
+}
+
 // Monitor returns the current monitor.
 func Monitor() *MonitorType {
 	m := ui.Get().Monitor()
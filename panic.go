@@ -0,0 +1,119 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"time"
+)
+
+// errorScreenTimeout is the duration to wait for the error screen to be drawn after a panic.
+// If the error screen is not drawn in time e.g. when the window is minimized, the game ends without it.
+var errorScreenTimeout = time.Second
+
+// PanicAction represents how Ebitengine behaves after a panic is recovered by RunGameOptions.OnPanic.
+type PanicAction struct {
+	// DrawErrorScreen is called to render one final frame before the game ends.
+	// The argument screen is the offscreen that is cleared beforehand, in the same way as Draw's argument.
+	// DrawErrorScreen might be called more than once until the game ends.
+	//
+	// If DrawErrorScreen is nil, the game ends immediately without rendering a final frame.
+	// If the final frame cannot be rendered in a while e.g. when the window is minimized, the game ends without it.
+	DrawErrorScreen func(screen *Image)
+
+	// ReturnError indicates whether RunGame returns a *PanicError instead of re-panicking.
+	//
+	// If ReturnError is false, Ebitengine panics again with the recovered value,
+	// after printing the stack trace of the original panic to the standard error.
+	ReturnError bool
+}
+
+// PanicError is an error that RunGame returns when a panic is recovered by RunGameOptions.OnPanic
+// and its PanicAction's ReturnError is true.
+type PanicError struct {
+	// Value is the recovered value.
+	Value any
+
+	// Stack is the stack trace of the goroutine at the panic.
+	Stack []byte
+}
+
+// Error implements error.
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("ebiten: recovered from a panic: %v", p.Value)
+}
+
+// Unwrap returns the recovered value if the value is an error. Otherwise, Unwrap returns nil.
+func (p *PanicError) Unwrap() error {
+	if err, ok := p.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+type panicState struct {
+	err              *PanicError
+	action           PanicAction
+	errorScreenDrawn bool
+	recoveredAt      time.Time
+}
+
+// waitsForErrorScreen reports whether the game should wait for the error screen to be drawn.
+func (p *panicState) waitsForErrorScreen() bool {
+	if p.action.DrawErrorScreen == nil || p.errorScreenDrawn {
+		return false
+	}
+	return time.Since(p.recoveredAt) < errorScreenTimeout
+}
+
+func (p *panicState) finish() error {
+	if p.action.ReturnError {
+		return p.err
+	}
+	// Panicking again loses the stack trace of the original panic. Print it so that the cause can be found.
+	fmt.Fprintf(os.Stderr, "ebiten: panicking again with the recovered value. The stack trace of the original panic:\n%s\n", p.err.Stack)
+	panic(p.err.Value)
+}
+
+// handlePanic handles the recovered value r.
+// handlePanic must be called in a deferred function so that the stack trace includes the panicking function.
+func (g *gameForUI) handlePanic(r any) error {
+	return g.handlePanicWithStack(r, debug.Stack())
+}
+
+// handlePanicWithStack handles the recovered value r with the stack trace of the panicking goroutine.
+func (g *gameForUI) handlePanicWithStack(r any, stack []byte) error {
+	g.panicState = &panicState{
+		err: &PanicError{
+			Value: r,
+			Stack: stack,
+		},
+		action:      g.onPanic(r, stack),
+		recoveredAt: time.Now(),
+	}
+	if g.panicState.action.DrawErrorScreen != nil {
+		// The error will be returned after the error screen is rendered.
+		return nil
+	}
+	return g.panicState.finish()
+}
+
+func (g *gameForUI) drawErrorScreen() {
+	g.offscreen.Clear()
+	g.panicState.action.DrawErrorScreen(g.offscreen)
+	g.panicState.errorScreenDrawn = true
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type panickingGame struct{}
+
+func (*panickingGame) Update() error {
+	panic("panickingGame.Update")
+}
+
+func (*panickingGame) Draw(screen *ebiten.Image) {
+}
+
+func (*panickingGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+func TestPanicReturnError(t *testing.T) {
+	var stack []byte
+	g, err := ebiten.NewGameForUIForTesting(&panickingGame{}, &ebiten.RunGameOptions{
+		OnPanic: func(recovered any, s []byte) ebiten.PanicAction {
+			stack = s
+			return ebiten.PanicAction{
+				ReturnError: true,
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+
+	err = g.Update()
+	var perr *ebiten.PanicError
+	if !errors.As(err, &perr) {
+		t.Fatalf("Update: got: %v, want: *ebiten.PanicError", err)
+	}
+	if got, want := perr.Value, any("panickingGame.Update"); got != want {
+		t.Errorf("Value: got: %v, want: %v", got, want)
+	}
+	// The stack trace must be the one of the original panic.
+	if !strings.Contains(string(perr.Stack), "panickingGame).Update") {
+		t.Errorf("Stack doesn't include the panicking function:\n%s", perr.Stack)
+	}
+	if string(perr.Stack) != string(stack) {
+		t.Errorf("Stack must be the same as the stack given to OnPanic")
+	}
+}
+
+func TestPanicErrorScreenTimeout(t *testing.T) {
+	prev := ebiten.SetErrorScreenTimeoutForTesting(0)
+	defer ebiten.SetErrorScreenTimeoutForTesting(prev)
+
+	g, err := ebiten.NewGameForUIForTesting(&panickingGame{}, &ebiten.RunGameOptions{
+		OnPanic: func(recovered any, stack []byte) ebiten.PanicAction {
+			return ebiten.PanicAction{
+				DrawErrorScreen: func(screen *ebiten.Image) {},
+				ReturnError:     true,
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+
+	// The first Update waits for the error screen.
+	if err := g.Update(); err != nil {
+		t.Fatalf("Update: got: %v, want: nil", err)
+	}
+
+	// Draw is never called e.g. when the window is minimized.
+	// The next Update must end the game without the error screen after the timeout.
+	var perr *ebiten.PanicError
+	if err := g.Update(); !errors.As(err, &perr) {
+		t.Errorf("Update: got: %v, want: *ebiten.PanicError", err)
+	}
+}
+
+func TestPanicRepanic(t *testing.T) {
+	g, err := ebiten.NewGameForUIForTesting(&panickingGame{}, &ebiten.RunGameOptions{
+		OnPanic: func(recovered any, stack []byte) ebiten.PanicAction {
+			return ebiten.PanicAction{}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+
+	defer func() {
+		if got, want := recover(), any("panickingGame.Update"); got != want {
+			t.Errorf("recover(): got: %v, want: %v", got, want)
+		}
+	}()
+	_ = g.Update()
+	t.Errorf("Update must panic")
+}
@@ -19,9 +19,11 @@ import (
 	"image"
 	"image/color"
 	"io/fs"
+	"sync"
 	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/clock"
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
 
@@ -61,6 +63,8 @@ type Game interface {
 	//
 	// The frequency of Draw calls depends on the user's environment, especially the monitors refresh rate.
 	// For portability, you should not put your game logic in Draw in general.
+	//
+	// Draw cannot return an error. To stop the game from Draw, use Terminate.
 	Draw(screen *Image)
 
 	// Layout accepts a native outside size in device-independent pixels and returns the game's logical screen
@@ -96,9 +100,102 @@ type LayoutFer interface {
 	// size in pixels. The logical size is used for 1) the screen size given at Draw and 2) calculation of the
 	// scale from the screen to the final screen size. For 1), the actual screen size is a rounded up of the
 	// logical size.
+	//
+	// The scale and the offsets to render the screen are calculated with the float logical size.
+	// The same transform is used to calculate the cursor and touch positions.
+	// Use CursorPositionF and TouchPositionF to get the positions without losing precision.
 	LayoutF(outsideWidth, outsideHeight float64) (screenWidth, screenHeight float64)
 }
 
+// ScaledDrawer is an interface for a game to render a part of the screen at a different internal resolution.
+//
+// This is useful to render a heavy game world at a lower resolution and upscale it,
+// while the UI drawn at Game.Draw keeps the screen resolution.
+//
+// Note that the screen resolution is the logical screen size returned by Layout, not the native resolution of the display.
+// The screen is scaled to the display after Draw, so the UI is not crisp at the display's resolution unless Layout returns
+// the outside size multiplied by the device scale factor. See also Monitor().DeviceScaleFactor.
+type ScaledDrawer interface {
+	// RenderScale returns the scale of the internal render resolution to the screen size.
+	// For example, 0.5 means that the internal image for DrawScaled is half the width and half the height of the screen,
+	// whose size is decided by Layout.
+	//
+	// RenderScale is called every frame before DrawScaled, so the scale can be changed dynamically.
+	// If RenderScale returns 0 or a negative value, DrawScaled is not called.
+	RenderScale() float64
+
+	// DrawScaled draws the game world on scaled.
+	// If a game implementing ScaledDrawer is passed to RunGame, DrawScaled is called before Draw.
+	//
+	// scaled is cleared before DrawScaled is called.
+	// The size of scaled is the screen size multiplied by RenderScale and rounded up.
+	// After DrawScaled, scaled is scaled with linear filtering to the screen size and drawn on the screen.
+	// Then, Game.Draw is called with the screen to draw things like the UI at the screen resolution.
+	DrawScaled(scaled *Image)
+}
+
+// Loader is an interface for a game to initialize itself after the graphics are ready.
+type Loader interface {
+	// OnLoad is called once before the first Update.
+	// If a game implementing Loader is passed to RunGame, OnLoad is called on the same goroutine as Update.
+	//
+	// The graphics are ready when OnLoad is called, so OnLoad can create images and shaders.
+	// If OnLoad returns an error, RunGame returns the error without calling Update.
+	OnLoad() error
+}
+
+// Suspender is an interface for a game to be notified when the application is suspended and resumed.
+//
+// The application is suspended when e.g. the application goes to the background on mobiles,
+// the browser tab is hidden, or the window loses focus while the application is not runnable on unfocused.
+// See also SetRunnableOnUnfocused.
+//
+// OnSuspend and OnResume are never called after RunGame returns.
+type Suspender interface {
+	// OnSuspend is called when the application is suspended.
+	// Update and Draw are not called until OnResume is called.
+	//
+	// OnSuspend might be called on a different goroutine from Update, and might be called concurrently with Update.
+	// On mobiles, the application might be killed after OnSuspend without any notification,
+	// so OnSuspend is the last chance to save data.
+	OnSuspend()
+
+	// OnResume is called when the suspended application is resumed.
+	//
+	// OnResume might be called on a different goroutine from Update, and might be called concurrently with Update.
+	OnResume()
+}
+
+// LowMemoryHandler is an interface for a game to be notified when the system is running low on memory.
+type LowMemoryHandler interface {
+	// OnLowMemory is called when the system is running low on memory.
+	// If a game implementing LowMemoryHandler is passed to RunGame, OnLowMemory is called on the same goroutine as
+	// Update, just before the next Update.
+	// Multiple notifications before the next Update are merged into one call.
+	//
+	// OnLowMemory is useful to release caches that can be recreated, e.g. by Image.Deallocate.
+	// Otherwise, the application might be killed by the system.
+	//
+	// OnLowMemory is called only on mobiles so far.
+	// On Android, OnLowMemory is called when the Activity's onLowMemory is called, or onTrimMemory is called
+	// with a level indicating the memory is low.
+	// On iOS, OnLowMemory is called when the view controller receives a memory warning.
+	OnLowMemory()
+}
+
+// StateSwapper is an interface for a game whose Update runs concurrently with Draw.
+// See RunGameOptions.ConcurrentUpdate.
+type StateSwapper interface {
+	// SwapState publishes the state updated by Update to Draw.
+	//
+	// SwapState is called when neither Update nor Draw is running, once per frame after Update is called at least once.
+	// Typically, a game keeps two copies of the state: one written by Update and one read by Draw,
+	// and SwapState copies or swaps them.
+	//
+	// Draw must read only the state published by SwapState, and Update must not modify the published state.
+	SwapState()
+}
+
 // FinalScreen represents the final screen image.
 // FinalScreen implements a part of Image functions.
 type FinalScreen interface {
@@ -200,6 +297,78 @@ func IsScreenFilterEnabled() bool {
 // Termination is a special error which indicates Game termination without error.
 var Termination = ui.RegularTermination
 
+type terminationRequest struct {
+	err error
+}
+
+var theTerminationRequest atomic.Pointer[terminationRequest]
+
+// Terminate requests to stop the main loop.
+//
+// If err is nil, RunGame returns nil as if Update returns Termination.
+// If err is not nil, RunGame returns err.
+// If Terminate is called multiple times, the first request is adopted.
+//
+// Terminate takes effect after the current Update or Draw finishes, so Terminate can be called from Draw to stop the
+// main loop when e.g. a resource fails at draw time. The current Draw's result might not be presented.
+// While the application is suspended, Terminate takes effect after the application is resumed.
+//
+// Terminate is concurrent-safe.
+func Terminate(err error) {
+	theTerminationRequest.CompareAndSwap(nil, &terminationRequest{err: err})
+}
+
+var (
+	beforeExit  func()
+	beforeExitM sync.Mutex
+)
+
+func init() {
+	hook.AppendHookOnBeforeExit(func() {
+		beforeExitM.Lock()
+		f := beforeExit
+		beforeExitM.Unlock()
+		if f != nil {
+			f()
+		}
+	})
+}
+
+// SetBeforeExit sets a function that is called once just before the main loop ends.
+// If f is nil, the function set previously is removed.
+//
+// f is called when the window is closed, when Update returns Termination or an error, or when Terminate is called.
+// f is called on the same goroutine as Update before the graphics are terminated.
+// This is useful to flush saved data or to stop audio before the process exits.
+//
+// On browsers, f is also called at the beforeunload event when the tab is closed or reloaded.
+// f is never called concurrently with Update. If the event is fired during a frame, f is called at the end of the frame.
+// This is best-effort: f might be called on a different goroutine from Update, the browser might not wait for f to
+// finish, and the event might not be fired at all e.g. when the browser is killed.
+// Save data periodically as well if losing it matters.
+//
+// On mobiles, f is never called as the application might be killed without any notification.
+// Use Suspender to save data on mobiles.
+//
+// SetBeforeExit is concurrent-safe.
+func SetBeforeExit(f func()) {
+	beforeExitM.Lock()
+	defer beforeExitM.Unlock()
+	beforeExit = f
+}
+
+// requestedTermination returns the error requested by Terminate if exists.
+func requestedTermination() error {
+	r := theTerminationRequest.Load()
+	if r == nil {
+		return nil
+	}
+	if r.err == nil {
+		return Termination
+	}
+	return r.err
+}
+
 // RunGame starts the main loop and runs the game.
 // game's Update function is called every tick to update the game logic.
 // game's Draw function is called every frame to draw the screen.
@@ -209,7 +378,12 @@ var Termination = ui.RegularTermination
 // The argument screen represents the final screen. The argument offscreen is an offscreen modified at Draw.
 // If game does not implement FinalScreenDrawer, the default rendering for the final screen is used.
 //
-// game's functions are called on the same goroutine.
+// If game implements Loader, its OnLoad is called once before the first Update.
+// If game implements Suspender, its OnSuspend and OnResume are called when the application is suspended and resumed.
+// To clean up before the main loop ends, use SetBeforeExit.
+// If game implements LowMemoryHandler, its OnLowMemory is called before Update when the system is running low on memory.
+//
+// game's functions are called on the same goroutine, except for Suspender's functions.
 //
 // On browsers, it is strongly recommended to use iframe if you embed an Ebitengine application in your website.
 //
@@ -227,6 +401,7 @@ var Termination = ui.RegularTermination
 //
 // If you want to terminate a game on desktops, it is recommended to return Termination at Update, which will halt
 // execution without returning an error value from RunGame.
+// To terminate a game from Draw or other goroutines, use Terminate.
 //
 // The size unit is device-independent pixel.
 //
@@ -240,8 +415,21 @@ type RunGameOptions struct {
 	// GraphicsLibrary is a graphics library Ebitengine will use.
 	//
 	// The default (zero) value is GraphicsLibraryAuto, which lets Ebitengine choose the graphics library.
+	//
+	// GraphicsLibrary is ignored when GraphicsLibraries is not empty.
 	GraphicsLibrary GraphicsLibrary
 
+	// GraphicsLibraries is an ordered fallback chain of graphics libraries Ebitengine will try to use.
+	//
+	// Ebitengine tries to initialize the graphics libraries in order, and uses the first one that succeeds.
+	// If all of them fail, RunGameWithOptions returns an error including all the failure reasons.
+	// The graphics library in use and the failure reasons of the preceding ones are available via ReadDebugInfo.
+	//
+	// For example, {GraphicsLibraryDirectX, GraphicsLibraryOpenGL} means to try DirectX first and then OpenGL.
+	//
+	// The default (zero) value is nil, which means GraphicsLibrary is used instead.
+	GraphicsLibraries []GraphicsLibrary
+
 	// InitUnfocused indicates whether the window is unfocused or not on launching.
 	// InitUnfocused is valid on desktops and browsers.
 	//
@@ -249,7 +437,11 @@ type RunGameOptions struct {
 	InitUnfocused bool
 
 	// ScreenTransparent indicates whether the window is transparent or not.
-	// ScreenTransparent is valid on desktops and browsers.
+	// ScreenTransparent is valid on desktops, browsers, and mobiles.
+	//
+	// On mobiles, the view must be configured to be transparent as well,
+	// e.g. by EbitenView's constructor with transparent true on Android, and by EbitenViewController's transparent
+	// property on iOS with ebitenmobile.
 	//
 	// The default (zero) value is false, which means that the window is not transparent.
 	ScreenTransparent bool
@@ -279,6 +471,42 @@ type RunGameOptions struct {
 
 	// X11InstanceName is an instance name in the ICCCM WM_CLASS window property.
 	X11InstanceName string
+
+	// OnPanic is called when the game's Update or Draw panics.
+	// recovered is the recovered value, and stack is the stack trace of the goroutine at the panic.
+	// The returned PanicAction specifies how Ebitengine behaves after the panic.
+	// For example, OnPanic can save a crash report and ask Ebitengine to render an error screen.
+	//
+	// Update and Draw are never called after their panic.
+	// If OnPanic itself panics, the panic is not recovered.
+	//
+	// The default (zero) value is nil, which means that a panic is not recovered.
+	OnPanic func(recovered any, stack []byte) PanicAction
+
+	// ConcurrentUpdate indicates whether the game's Update runs on a separate goroutine concurrently with Draw.
+	// If ConcurrentUpdate is true, the game must implement StateSwapper. Otherwise, RunGameWithOptions returns an error.
+	//
+	// In this mode, the last Update in a frame runs concurrently with Draw, and Draw renders the state
+	// published by the last StateSwapper.SwapState, i.e. the state one frame before.
+	// Update and Draw never run concurrently with SwapState, Layout, or the other Updates.
+	// All the graphics operations in Update still happen within the same frame.
+	//
+	// The default (zero) value is false, which means that Update and Draw run sequentially on the same goroutine.
+	ConcurrentUpdate bool
+}
+
+func (o *RunGameOptions) onPanic() func(recovered any, stack []byte) PanicAction {
+	if o == nil {
+		return nil
+	}
+	return o.OnPanic
+}
+
+func (o *RunGameOptions) concurrentUpdate() bool {
+	if o == nil {
+		return false
+	}
+	return o.ConcurrentUpdate
 }
 
 // RunGameWithOptions starts the main loop and runs the game with the specified options.
@@ -292,7 +520,12 @@ type RunGameOptions struct {
 // The argument screen represents the final screen. The argument offscreen is an offscreen modified at Draw.
 // If game does not implement FinalScreenDrawer, the default rendering for the final screen is used.
 //
-// game's functions are called on the same goroutine.
+// If game implements Loader, its OnLoad is called once before the first Update.
+// If game implements Suspender, its OnSuspend and OnResume are called when the application is suspended and resumed.
+// To clean up before the main loop ends, use SetBeforeExit.
+//
+// game's functions are called on the same goroutine, except for Suspender's functions
+// and Update with RunGameOptions.ConcurrentUpdate.
 //
 // On browsers, it is strongly recommended to use iframe if you embed an Ebitengine application in your website.
 //
@@ -308,6 +541,9 @@ type RunGameOptions struct {
 // use errors.Is when you check the returned error is the error you want, rather than comparing the values
 // with == or != directly.
 //
+// If options.OnPanic is specified and returns a PanicAction with ReturnError, RunGameWithOptions returns a *PanicError
+// when Update or Draw panics.
+//
 // If you want to terminate a game on desktops, it is recommended to return Termination at Update, which will halt
 // execution without returning an error value from RunGameWithOptions.
 //
@@ -315,6 +551,10 @@ type RunGameOptions struct {
 //
 // Don't call RunGame or RunGameWithOptions twice or more in one process.
 func RunGameWithOptions(game Game, options *RunGameOptions) error {
+	if err := validateGameForUI(game, options); err != nil {
+		return err
+	}
+
 	defer isRunGameEnded_.Store(true)
 
 	initializeWindowPositionIfNeeded(WindowSize())
@@ -322,7 +562,8 @@ func RunGameWithOptions(game Game, options *RunGameOptions) error {
 	op := toUIRunOptions(options)
 	// This is necessary to change the result of IsScreenTransparent.
 	screenTransparent.Store(op.ScreenTransparent)
-	g := newGameForUI(game, op.ScreenTransparent)
+	g := newGameForUI(game, op.ScreenTransparent, options.onPanic(), options.concurrentUpdate())
+	defer g.close()
 
 	if err := ui.Get().Run(g, op); err != nil {
 		if errors.Is(err, Termination) {
@@ -473,6 +714,108 @@ func SetRunnableOnUnfocused(runnableOnUnfocused bool) {
 	ui.Get().SetRunnableOnUnfocused(runnableOnUnfocused)
 }
 
+// IsSystemBarsVisible reports whether the system bars like the status bar and the navigation bar are visible.
+//
+// IsSystemBarsVisible is concurrent-safe.
+func IsSystemBarsVisible() bool {
+	return ui.Get().IsSystemBarsVisible()
+}
+
+// SetSystemBarsVisible sets the visibility of the system bars like the status bar and the navigation bar.
+// The initial state is true.
+//
+// When the system bars are hidden, the user can show them again by swiping from an edge of the screen,
+// and then they stay visible until SetSystemBarsVisible(false) is called again.
+// To keep hiding the system bars for a full-screen game, use SetImmersiveMode instead.
+//
+// SetSystemBarsVisible works only on Android so far, and does nothing on the other platforms.
+//
+// SetSystemBarsVisible is concurrent-safe.
+func SetSystemBarsVisible(visible bool) {
+	ui.Get().SetSystemBarsVisible(visible)
+}
+
+// IsImmersiveMode reports whether the immersive mode is enabled.
+//
+// IsImmersiveMode is concurrent-safe.
+func IsImmersiveMode() bool {
+	return ui.Get().IsImmersiveMode()
+}
+
+// SetImmersiveMode enables or disables the immersive mode.
+// The initial state is false.
+//
+// In the immersive mode, the system bars are hidden regardless of SetSystemBarsVisible.
+// When the user swipes from an edge of the screen, the system bars appear transiently and are hidden again
+// automatically. The system bars are hidden again also after the software keyboard is closed.
+//
+// SetImmersiveMode works only on Android so far, and does nothing on the other platforms.
+//
+// SetImmersiveMode is concurrent-safe.
+func SetImmersiveMode(immersive bool) {
+	ui.Get().SetImmersiveMode(immersive)
+}
+
+// IsBackButtonHandled reports whether the game handles the system back button.
+//
+// IsBackButtonHandled is concurrent-safe.
+func IsBackButtonHandled() bool {
+	return ui.Get().IsBackButtonHandled()
+}
+
+// SetBackButtonHandled sets whether the game handles the system back button instead of the system.
+// The initial state is false.
+//
+// On Android, the back button or the back gesture finishes the activity by default.
+// When the state is true, the default behavior is suppressed, and a back press is delivered to the game
+// as KeyBrowserBack, which can be checked with IsKeyPressed or inpututil.IsKeyJustPressed.
+// This is useful to open a pause menu instead of quitting the game.
+// The back press is delivered via OnBackInvokedDispatcher on Android 13 or later,
+// so that the predictive back gesture works when the application enables it.
+//
+// It is recommended to set false when the game's top-level screen is shown,
+// so that the user can leave the application with the back button as usual.
+//
+// SetBackButtonHandled works only on Android so far, and does nothing on the other platforms.
+//
+// SetBackButtonHandled is concurrent-safe.
+func SetBackButtonHandled(handled bool) {
+	ui.Get().SetBackButtonHandled(handled)
+}
+
+// SafeAreaInsets returns the insets of the safe area from the edges of the screen, in device-independent pixels.
+// The safe area is the area not covered by the system UIs like the system bars, notches, and rounded corners.
+// Important contents like buttons should be put in the safe area.
+//
+// The insets change when e.g. the system bars are shown or hidden, or the device is rotated.
+// The outside size passed to Layout is the size of the whole screen including the insets.
+// Convert the insets to the game screen's scale with the ratio of the screen size to the outside size.
+//
+// SafeAreaInsets returns non-zero values only on Android and iOS so far.
+//
+// SafeAreaInsets is concurrent-safe.
+func SafeAreaInsets() (left, top, right, bottom float64) {
+	return ui.Get().SafeAreaInsets()
+}
+
+// VirtualKeyboardHeight returns the height of the area of the game's view covered by the software keyboard,
+// in device-independent pixels. If the software keyboard is not shown, VirtualKeyboardHeight returns 0.
+//
+// VirtualKeyboardHeight counts only the part of the keyboard overlapping the view.
+// If the view is resized to avoid the keyboard, e.g. by windowSoftInputMode adjustResize on Android,
+// the outside size passed to Layout already excludes the keyboard, and VirtualKeyboardHeight returns 0.
+// Then, the visible area of the view is always the outside size minus VirtualKeyboardHeight from the bottom.
+// Convert the height to the game screen's scale with the ratio of the screen size to the outside size.
+//
+// This is useful to move up a text field above the keyboard.
+//
+// VirtualKeyboardHeight returns non-zero values only on Android and iOS so far.
+//
+// VirtualKeyboardHeight is concurrent-safe.
+func VirtualKeyboardHeight() float64 {
+	return ui.Get().VirtualKeyboardHeight()
+}
+
 // DeviceScaleFactor returns a device scale factor value of the current monitor which the window belongs to.
 //
 // DeviceScaleFactor returns a meaningful value on high-DPI display environment,
@@ -507,6 +850,66 @@ func SetVsyncEnabled(enabled bool) {
 	}
 }
 
+// IsVsyncEffective reports whether swapping buffers seems actually synchronized with the
+// current monitor's refresh rate.
+//
+// Even when vsync is enabled by SetVsyncEnabled, some environments like some Linux drivers ignore the request.
+// IsVsyncEffective detects such situations by comparing the actual FPS with the monitor's refresh rate.
+// IsVsyncEffective returns false if the actual FPS is obviously more than the refresh rate.
+//
+// IsVsyncEffective always returns false when vsync is disabled.
+// If the refresh rate is unknown, or the actual FPS is not measured yet just after the game starts,
+// IsVsyncEffective returns the same value as IsVsyncEnabled.
+//
+// IsVsyncEffective is concurrent-safe.
+func IsVsyncEffective() bool {
+	if !IsVsyncEnabled() {
+		return false
+	}
+	m := Monitor()
+	if m == nil {
+		return true
+	}
+	rate := m.RefreshRate()
+	if rate <= 0 {
+		return true
+	}
+	fps := ActualFPS()
+	if fps <= 0 {
+		return true
+	}
+	// Allow some errors of the measurement.
+	return fps <= rate*1.1
+}
+
+// MaxFPS returns the maximum FPS (frames per second) set by SetMaxFPS.
+// 0 means that FPS is not limited by Ebitengine.
+//
+// MaxFPS is concurrent-safe.
+func MaxFPS() int {
+	return clock.MaxFPS()
+}
+
+// SetMaxFPS sets the maximum FPS (frames per second), that represents how many swapping buffers happen at most per second.
+// The initial value is 0, which means that FPS is not limited by Ebitengine.
+//
+// The FPS is determined by the combination of vsync and the maximum FPS, consistently on all the platforms:
+//
+//   - Vsync enabled and the maximum FPS 0: FPS matches with the monitor's refresh rate (e.g. 120 on a 120Hz display).
+//   - Vsync enabled and the maximum FPS n: FPS is the smaller one of n and the refresh rate.
+//   - Vsync disabled and the maximum FPS n: FPS is capped to n.
+//   - Vsync disabled and the maximum FPS 0: FPS is uncapped.
+//
+// Note that TPS is independent from FPS unless TPS is SyncWithFPS.
+// Use Monitor().RefreshRate to get the refresh rate, and ActualFPS to get the achieved FPS.
+//
+// If fps is negative, SetMaxFPS panics.
+//
+// SetMaxFPS is concurrent-safe.
+func SetMaxFPS(fps int) {
+	clock.SetMaxFPS(fps)
+}
+
 // FPSModeType is a type of FPS modes.
 //
 // Deprecated: as of v2.5. Use SetVsyncEnabled instead.
@@ -539,8 +942,8 @@ const (
 	// 1) new inputting except for gamepads is detected, or 2) ScheduleFrame is called.
 	// In FPSModeVsyncOffMinimum, TPS is SyncWithFPS no matter what TPS is specified at SetTPS.
 	//
-	// Deprecated: as of v2.5. Use SetScreenClearedEveryFrame(false) instead.
-	// See examples/skipdraw for GPU optimization with SetScreenClearedEveryFrame(false).
+	// Deprecated: as of v2.5. Use SetUpdateMode(UpdateModeOnEvent) instead.
+	// See also examples/skipdraw for GPU optimization with SetScreenClearedEveryFrame(false).
 	FPSModeVsyncOffMinimum FPSModeType = ui.FPSModeVsyncOffMinimum
 )
 
@@ -567,12 +970,63 @@ func SetFPSMode(mode FPSModeType) {
 //
 // ScheduleFrame is concurrent-safe.
 //
-// Deprecated: as of v2.5. Use SetScreenClearedEveryFrame(false) instead.
-// See examples/skipdraw for GPU optimization with SetScreenClearedEveryFrame(false).
+// Deprecated: as of v2.5. Use SetUpdateMode(UpdateModeOnEvent) and RequestRedraw instead.
 func ScheduleFrame() {
 	ui.Get().ScheduleFrame()
 }
 
+// UpdateModeType is a type of update modes.
+type UpdateModeType int
+
+const (
+	// UpdateModeContinuous indicates that the game's Update and Draw are called continuously based on TPS and FPS.
+	// UpdateModeContinuous is the default mode.
+	UpdateModeContinuous UpdateModeType = iota
+
+	// UpdateModeOnEvent indicates that the game's Update and Draw are called only when necessary,
+	// and the game loop sleeps otherwise.
+	//
+	// UpdateModeOnEvent is useful for non-animating applications like editors and turn-based games to save CPU, GPU and battery power.
+	//
+	// In UpdateModeOnEvent, the game's Update and Draw are called only when
+	// 1) new inputting except for gamepads is detected, 2) the window is resized, or 3) RequestRedraw is called.
+	// Each of such frames calls Update at least once regardless of TPS, unless TPS is 0.
+	// To wake up the game by a timer, call RequestRedraw from the timer like time.AfterFunc.
+	//
+	// Unlike SetScreenClearedEveryFrame(false), UpdateModeOnEvent doesn't only skip rendering but also stops the game loop itself.
+	UpdateModeOnEvent
+)
+
+// UpdateMode returns the current update mode.
+//
+// UpdateMode is concurrent-safe.
+func UpdateMode() UpdateModeType {
+	if ui.Get().IsUpdateOnEvent() {
+		return UpdateModeOnEvent
+	}
+	return UpdateModeContinuous
+}
+
+// SetUpdateMode sets the update mode.
+// The default update mode is UpdateModeContinuous.
+//
+// SetUpdateMode is concurrent-safe.
+func SetUpdateMode(mode UpdateModeType) {
+	ui.Get().SetUpdateOnEvent(mode == UpdateModeOnEvent)
+}
+
+// RequestRedraw requests the next frame to call the game's Update and Draw when the current update mode is UpdateModeOnEvent.
+// Update is called at least once in the requested frame regardless of TPS, unless TPS is 0.
+//
+// If the update mode is UpdateModeContinuous, RequestRedraw does nothing.
+//
+// RequestRedraw replaces the deprecated ScheduleFrame.
+//
+// RequestRedraw is concurrent-safe.
+func RequestRedraw() {
+	ui.Get().ScheduleFrame()
+}
+
 // TPS returns the current maximum TPS.
 //
 // TPS is concurrent-safe.
@@ -697,8 +1151,16 @@ func toUIRunOptions(options *RunGameOptions) *ui.RunOptions {
 	if options.X11InstanceName == "" {
 		options.X11InstanceName = defaultX11InstanceName
 	}
+	libraries := []ui.GraphicsLibrary{ui.GraphicsLibrary(options.GraphicsLibrary)}
+	if len(options.GraphicsLibraries) > 0 {
+		libraries = make([]ui.GraphicsLibrary, len(options.GraphicsLibraries))
+		for i, lib := range options.GraphicsLibraries {
+			libraries[i] = ui.GraphicsLibrary(lib)
+		}
+	}
+
 	return &ui.RunOptions{
-		GraphicsLibrary:   ui.GraphicsLibrary(options.GraphicsLibrary),
+		GraphicsLibraries: libraries,
 		InitUnfocused:     options.InitUnfocused,
 		ScreenTransparent: options.ScreenTransparent,
 		SkipTaskbar:       options.SkipTaskbar,
@@ -28,6 +28,9 @@ import (
 //
 // TODO: Remove this. In order to remove this, the gameForUI should be in another package.
 func RunGameWithoutMainLoop(game Game, options *RunGameOptions) {
+	if err := validateGameForUI(game, options); err != nil {
+		panic(err)
+	}
 	op := toUIRunOptions(options)
-	ui.Get().RunWithoutMainLoop(newGameForUI(game, op.ScreenTransparent), op)
+	ui.Get().RunWithoutMainLoop(newGameForUI(game, op.ScreenTransparent, options.onPanic(), options.concurrentUpdate()), op)
 }
@@ -30,6 +30,8 @@ import (
 type Shader struct {
 	shader *ui.Shader
 	unit   shaderir.Unit
+
+	vertexFloatCount int
 }
 
 // NewShader compiles a shader program in the shading language Kage, and returns the result.
@@ -43,8 +45,9 @@ func NewShader(src []byte) (*Shader, error) {
 		return nil, err
 	}
 	return &Shader{
-		shader: ui.NewShader(ir),
-		unit:   ir.Unit,
+		shader:           ui.NewShader(ir),
+		unit:             ir.Unit,
+		vertexFloatCount: graphics.ShaderVertexFloatCount(ir),
 	}, nil
 }
 
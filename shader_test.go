@@ -2595,3 +2595,238 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 		}
 	}
 }
+
+func TestShaderCustomValues(t *testing.T) {
+	const w, h = 16, 16
+
+	dst := ebiten.NewImage(w, h)
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4) vec4 {
+	return custom
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vs := []ebiten.Vertex{
+		{
+			DstX:    0,
+			DstY:    0,
+			Custom0: 0x20 / float32(0xff),
+			Custom1: 0x40 / float32(0xff),
+			Custom2: 0x60 / float32(0xff),
+			Custom3: 0x80 / float32(0xff),
+		},
+		{
+			DstX:    w,
+			DstY:    0,
+			Custom0: 0x20 / float32(0xff),
+			Custom1: 0x40 / float32(0xff),
+			Custom2: 0x60 / float32(0xff),
+			Custom3: 0x80 / float32(0xff),
+		},
+		{
+			DstX:    0,
+			DstY:    h,
+			Custom0: 0x20 / float32(0xff),
+			Custom1: 0x40 / float32(0xff),
+			Custom2: 0x60 / float32(0xff),
+			Custom3: 0x80 / float32(0xff),
+		},
+		{
+			DstX:    w,
+			DstY:    h,
+			Custom0: 0x20 / float32(0xff),
+			Custom1: 0x40 / float32(0xff),
+			Custom2: 0x60 / float32(0xff),
+			Custom3: 0x80 / float32(0xff),
+		},
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+	dst.DrawTrianglesShader(vs, is, s, nil)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{R: 0x20, G: 0x40, B: 0x60, A: 0x80}
+			if !sameColors(got, want, 1) {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestShaderImageArray(t *testing.T) {
+	const w, h = 16, 16
+
+	arr := ebiten.NewImageArray(w, h, 3)
+	clrs := []color.RGBA{
+		{R: 0xff, A: 0xff},
+		{G: 0xff, A: 0xff},
+		{B: 0xff, A: 0xff},
+	}
+	for i, clr := range clrs {
+		arr.Layer(i).Fill(clr)
+	}
+	if got, want := arr.LayerCount(), len(clrs); got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+var Layer int
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return imageSrc0LayerAt(srcPos, Layer)
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for layer, want := range clrs {
+		dst := ebiten.NewImage(w, h)
+		op := &ebiten.DrawRectShaderOptions{}
+		op.Images[0] = arr.Layer(0)
+		op.Uniforms = map[string]any{
+			"Layer": layer,
+		}
+		dst.DrawRectShader(w, h, s, op)
+
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				if got := dst.At(i, j).(color.RGBA); got != want {
+					t.Errorf("layer: %d, dst.At(%d, %d): got: %v, want: %v", layer, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestShaderCustomValuesWithDrawRectShader(t *testing.T) {
+	const w, h = 16, 16
+
+	dst := ebiten.NewImage(w, h)
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4) vec4 {
+	// The custom values are zero with DrawRectShader.
+	return color + custom
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst.DrawRectShader(w, h, s, nil)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestShaderImageSrcLinearAndBicubicAt(t *testing.T) {
+	const w, h = 4, 1
+
+	// The source is a horizontal gradient surrounded by white pixels.
+	// The white pixels must not be sampled as they are out of the sub-image.
+	src := ebiten.NewImage(w+2, h)
+	src.Fill(color.White)
+	for i := 0; i < w; i++ {
+		v := uint8(0x40 * i)
+		src.Set(i+1, 0, color.RGBA{R: v, G: v, B: v, A: 0xff})
+	}
+	subSrc := src.SubImage(image.Rect(1, 0, w+1, h)).(*ebiten.Image)
+
+	testCases := []struct {
+		Name   string
+		Func   string
+		Offset float32
+		Want   [w]uint8
+	}{
+		{
+			Name:   "linear at the pixel centers",
+			Func:   "imageSrc0LinearAt",
+			Offset: 0,
+			Want:   [w]uint8{0x00, 0x40, 0x80, 0xc0},
+		},
+		{
+			Name:   "linear at the pixel edges",
+			Func:   "imageSrc0LinearAt",
+			Offset: 0.5,
+			Want:   [w]uint8{0x20, 0x60, 0xa0, 0xc0},
+		},
+		{
+			Name:   "linear at a quarter",
+			Func:   "imageSrc0LinearAt",
+			Offset: 0.25,
+			Want:   [w]uint8{0x10, 0x50, 0x90, 0xc0},
+		},
+		{
+			Name:   "bicubic at the pixel centers",
+			Func:   "imageSrc0BicubicAt",
+			Offset: 0,
+			Want:   [w]uint8{0x00, 0x40, 0x80, 0xc0},
+		},
+		{
+			// The Catmull-Rom weights at the middle are (-1/16, 9/16, 9/16, -1/16).
+			// The edge pixels are repeated out of the region, so the spline overshoots around the edges.
+			Name:   "bicubic at the pixel edges",
+			Func:   "imageSrc0BicubicAt",
+			Offset: 0.5,
+			Want:   [w]uint8{0x1c, 0x60, 0xa4, 0xc4},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			s, err := ebiten.NewShader([]byte(fmt.Sprintf(`//kage:unit pixels
+
+package main
+
+var Offset float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return %s(srcPos + vec2(Offset, 0))
+}
+`, tc.Func)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Deallocate()
+
+			dst := ebiten.NewImage(w, h)
+			defer dst.Deallocate()
+
+			op := &ebiten.DrawRectShaderOptions{}
+			op.Images[0] = subSrc
+			op.Uniforms = map[string]any{
+				"Offset": tc.Offset,
+			}
+			dst.DrawRectShader(w, h, s, op)
+
+			for i := 0; i < w; i++ {
+				got := dst.At(i, 0).(color.RGBA)
+				v := tc.Want[i]
+				want := color.RGBA{R: v, G: v, B: v, A: 0xff}
+				if !sameColors(got, want, 2) {
+					t.Errorf("dst.At(%d, 0): got: %v, want: %v", i, got, want)
+				}
+			}
+		})
+	}
+}
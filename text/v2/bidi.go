@@ -0,0 +1,88 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"github.com/go-text/typesetting/di"
+	"github.com/go-text/typesetting/shaping"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// reorderInputsVisually reorders the shaping inputs in the logical order to the visual order from left to right.
+//
+// The inputs are split by the bidi runs, the scripts and the faces by a segmenter,
+// and each input has its resolved direction.
+// As the shaper outputs glyphs in the visual order in each input, this function reorders only the inputs,
+// based on the rule L2 of the Unicode Bidirectional Algorithm.
+func reorderInputsVisually(inputs []shaping.Input, direction Direction) {
+	if !direction.isHorizontal() {
+		return
+	}
+
+	levels := make([]int, len(inputs))
+	baseLevel := 0
+	if direction == DirectionRightToLeft {
+		baseLevel = 1
+	}
+	for i, input := range inputs {
+		rtl := input.Direction.Progression() == di.TowardTopLeft
+		switch {
+		case rtl && baseLevel == 0:
+			levels[i] = 1
+		case !rtl && baseLevel == 1:
+			levels[i] = 2
+		case !rtl && baseLevel == 0 && i > 0 && levels[i-1] > 0 && !hasStrongLeftToRight(input):
+			// Numbers following a right-to-left text are nested in the right-to-left text (rule I1).
+			levels[i] = 2
+		default:
+			levels[i] = baseLevel
+		}
+	}
+
+	// From the highest level to the lowest odd level, reverse any contiguous sequence of inputs at that level or higher.
+	// The glyphs in an input are already in the visual order, so an input itself is not reversed.
+	var maxLevel int
+	for _, l := range levels {
+		if maxLevel < l {
+			maxLevel = l
+		}
+	}
+	for level := maxLevel; level >= 1; level-- {
+		for i := 0; i < len(inputs); {
+			if levels[i] < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(inputs) && levels[j] >= level {
+				j++
+			}
+			for k, l := i, j-1; k < l; k, l = k+1, l-1 {
+				inputs[k], inputs[l] = inputs[l], inputs[k]
+				levels[k], levels[l] = levels[l], levels[k]
+			}
+			i = j
+		}
+	}
+}
+
+func hasStrongLeftToRight(input shaping.Input) bool {
+	for _, r := range input.Text[input.RunStart:input.RunEnd] {
+		if p, _ := bidi.LookupRune(r); p.Class() == bidi.L {
+			return true
+		}
+	}
+	return false
+}
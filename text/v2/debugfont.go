@@ -0,0 +1,66 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DebugFont is a font for ebitenutil.SetDebugFont, which renders a Face's glyphs one by one.
+//
+// DebugFont doesn't apply shaping across runes, e.g. kerning and ligatures.
+type DebugFont struct {
+	face Face
+
+	glyphs []Glyph
+	m      sync.Mutex
+}
+
+// NewDebugFont creates a new DebugFont from the given face.
+func NewDebugFont(face Face) *DebugFont {
+	return &DebugFont{
+		face: face,
+	}
+}
+
+// Glyph returns the image of the glyph for r, the position of the image relative to the pen position on the top of the line,
+// and the advance in pixels.
+//
+// If the face doesn't have an image for r, e.g. r is a space, the returned image is nil.
+//
+// Glyph is concurrent-safe.
+func (d *DebugFont) Glyph(r rune) (img *ebiten.Image, x, y, advance float64) {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	str := string(r)
+	d.glyphs = AppendGlyphs(d.glyphs[:0], str, d.face, nil)
+	advance = Advance(str, d.face)
+	if len(d.glyphs) == 0 {
+		return nil, 0, 0, advance
+	}
+	g := d.glyphs[0]
+	return g.Image, g.X, g.Y, advance
+}
+
+// LineHeight returns the height of a line in pixels, which is the face's HAscent + HDescent + HLineGap.
+//
+// LineHeight is concurrent-safe.
+func (d *DebugFont) LineHeight() float64 {
+	m := d.face.Metrics()
+	return m.HAscent + m.HDescent + m.HLineGap
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// EffectOptions represents options for outlines and shadows at Draw.
+//
+// An outline is rendered by dilating the glyph's coverage, and the outlined glyph images are cached
+// in the same way as the regular glyph images, so repeated frames cost one draw per glyph for an outline.
+// The outline and the shadow don't change the advances of glyphs and the size by Measure.
+// Use MeasureWithEffect to get the size including them.
+type EffectOptions struct {
+	// OutlineWidth is the width of the outline in pixels.
+	// If OutlineWidth is 0 or OutlineColor is nil, no outline is rendered.
+	//
+	// OutlineWidth is ignored for an SDFFace. Use SDFFace's OutlineWidth instead.
+	OutlineWidth int
+
+	// OutlineColor is the color of the outline.
+	OutlineColor color.Color
+
+	// ShadowOffsetX is the X offset of the shadow in pixels.
+	ShadowOffsetX float64
+
+	// ShadowOffsetY is the Y offset of the shadow in pixels.
+	ShadowOffsetY float64
+
+	// ShadowColor is the color of the shadow.
+	// If ShadowColor is nil, no shadow is rendered.
+	// If an outline is rendered, the shadow includes the outline.
+	ShadowColor color.Color
+}
+
+func (e *EffectOptions) outlineWidth() int {
+	if e.OutlineWidth <= 0 || e.OutlineColor == nil {
+		return 0
+	}
+	return e.OutlineWidth
+}
+
+// MeasureWithEffect measures the boundary size of the text with the given layout options,
+// including the outline and the shadow specified by effect.
+//
+// If effect is nil, MeasureWithEffect returns the same values as MeasureWithOptions.
+//
+// MeasureWithEffect is concurrent-safe.
+func MeasureWithEffect(text string, face Face, layout *LayoutOptions, effect *EffectOptions) (width, height float64) {
+	width, height = MeasureWithOptions(text, face, layout)
+	if effect == nil {
+		return width, height
+	}
+	if _, ok := face.(*SDFFace); !ok {
+		w := float64(effect.outlineWidth())
+		width += 2 * w
+		height += 2 * w
+	}
+	if effect.ShadowColor != nil {
+		width += math.Abs(effect.ShadowOffsetX)
+		height += math.Abs(effect.ShadowOffsetY)
+	}
+	return width, height
+}
+
+// effectColorScale returns a color scale to draw an outline or a shadow with the given color.
+// The alpha of the original color scale is also applied.
+func effectColorScale(colorScale ebiten.ColorScale, clr color.Color) ebiten.ColorScale {
+	var cs ebiten.ColorScale
+	cs.ScaleWithColor(clr)
+	cs.ScaleAlpha(colorScale.A())
+	return cs
+}
+
+type outlineImageCacheKey struct {
+	image *ebiten.Image
+	width int
+}
+
+var outlineImageCache glyphImageCache[outlineImageCacheKey]
+
+// outlineImage returns an image whose coverage is the given glyph image's coverage dilated by width pixels.
+// The returned image is extended by width pixels on each side.
+func outlineImage(face Face, img *ebiten.Image, width int) *ebiten.Image {
+	key := outlineImageCacheKey{
+		image: img,
+		width: width,
+	}
+	return outlineImageCache.getOrCreate(face, key, func() *ebiten.Image {
+		w, h := img.Bounds().Dx(), img.Bounds().Dy()
+		src := image.NewRGBA(image.Rect(0, 0, w, h))
+		img.ReadPixels(src.Pix)
+		return ebiten.NewImageFromImage(dilate(src, width))
+	})
+}
+
+// dilate returns a new image whose alpha values are dilated by width pixels.
+// The returned image is extended by width pixels on each side.
+//
+// The returned image is white, i.e. the alpha value represents the coverage.
+func dilate(src *image.RGBA, width int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, sw+2*width, sh+2*width))
+
+	// Use a disc kernel with an anti-aliased edge.
+	r := float64(width)
+	kernel := make([]float64, (2*width+1)*(2*width+1))
+	for j := -width; j <= width; j++ {
+		for i := -width; i <= width; i++ {
+			d := math.Hypot(float64(i), float64(j))
+			kernel[(j+width)*(2*width+1)+(i+width)] = math.Max(0, math.Min(1, r+0.5-d))
+		}
+	}
+
+	for sy := 0; sy < sh; sy++ {
+		for sx := 0; sx < sw; sx++ {
+			a := float64(src.Pix[src.PixOffset(sx, sy)+3])
+			if a == 0 {
+				continue
+			}
+			for j := -width; j <= width; j++ {
+				for i := -width; i <= width; i++ {
+					v := uint8(a * kernel[(j+width)*(2*width+1)+(i+width)])
+					idx := dst.PixOffset(sx+i+width, sy+j+width)
+					if dst.Pix[idx+3] >= v {
+						continue
+					}
+					dst.Pix[idx] = v
+					dst.Pix[idx+1] = v
+					dst.Pix[idx+2] = v
+					dst.Pix[idx+3] = v
+				}
+			}
+		}
+	}
+	return dst
+}
@@ -33,3 +33,8 @@ func Float32ToFixed26_6(x float32) fixed.Int26_6 {
 func Float64ToFixed26_6(x float64) fixed.Int26_6 {
 	return float64ToFixed26_6(x)
 }
+
+// AdvanceTickForTesting advances the clock for the glyph cache by one tick.
+func AdvanceTickForTesting() {
+	monotonicClock++
+}
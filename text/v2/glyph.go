@@ -16,6 +16,8 @@ package text
 
 import (
 	"math"
+	"runtime"
+	"sort"
 	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -40,56 +42,261 @@ func init() {
 type glyphImageCacheEntry struct {
 	image *ebiten.Image
 	atime int64
+
+	// size is the size of the image in bytes.
+	size int
+}
+
+var (
+	// glyphCacheGlyphs and glyphCacheBytes are the total number and the total size in bytes of the cached glyph images.
+	glyphCacheGlyphs int
+	glyphCacheBytes  int
+
+	// glyphCacheBudget is the budget of glyphCacheBytes in bytes. 0 means the default soft limit.
+	glyphCacheBudget int
+
+	glyphCacheM sync.Mutex
+)
+
+func addGlyphCacheUsage(glyphs, bytes int) {
+	glyphCacheM.Lock()
+	defer glyphCacheM.Unlock()
+	glyphCacheGlyphs += glyphs
+	glyphCacheBytes += bytes
+}
+
+// glyphCacheOverBudget reports whether the total size of the cached glyph images exceeds the budget.
+func glyphCacheOverBudget() bool {
+	glyphCacheM.Lock()
+	defer glyphCacheM.Unlock()
+	return glyphCacheBudget > 0 && glyphCacheBytes > glyphCacheBudget
+}
+
+// glyphImageCacheInterface is an interface of glyphImageCache for any key types.
+type glyphImageCacheInterface interface {
+	usage() (glyphs int, bytes int)
+	evict()
 }
 
 type glyphImageCache[Key comparable] struct {
+	entries *glyphImageCacheEntries[Key]
+	m       sync.Mutex
+}
+
+// glyphImageCacheEntries is separated from glyphImageCache so that the total usage can be updated
+// by a finalizer when the face owning the cache is GCed.
+type glyphImageCacheEntries[Key comparable] struct {
 	cache map[Key]*glyphImageCacheEntry
-	m     sync.Mutex
+	bytes int
+}
+
+func (g *glyphImageCache[Key]) ensureEntries() *glyphImageCacheEntries[Key] {
+	if g.entries == nil {
+		g.entries = &glyphImageCacheEntries[Key]{
+			cache: map[Key]*glyphImageCacheEntry{},
+		}
+		runtime.SetFinalizer(g.entries, func(e *glyphImageCacheEntries[Key]) {
+			addGlyphCacheUsage(-len(e.cache), -e.bytes)
+		})
+	}
+	return g.entries
 }
 
 func (g *glyphImageCache[Key]) getOrCreate(face Face, key Key, create func() *ebiten.Image) *ebiten.Image {
 	g.m.Lock()
 	defer g.m.Unlock()
 
-	e, ok := g.cache[key]
+	entries := g.ensureEntries()
+
+	e, ok := entries.cache[key]
 	if ok {
 		e.atime = now()
 		return e.image
 	}
 
-	if g.cache == nil {
-		g.cache = map[Key]*glyphImageCacheEntry{}
-	}
-
 	img := create()
 	e = &glyphImageCacheEntry{
 		image: img,
 	}
 	if img != nil {
 		e.atime = now()
+		e.size = 4 * img.Bounds().Dx() * img.Bounds().Dy()
 	} else {
 		// If the glyph image is nil, the entry doesn't have to be removed.
 		// Keep this until the face is GCed.
 		e.atime = infTime
 	}
-	g.cache[key] = e
+	entries.cache[key] = e
+	entries.bytes += e.size
+	addGlyphCacheUsage(1, e.size)
 
 	// Clean up old entries.
+	if glyphCacheOverBudget() {
+		g.evictOverBudget()
+		return img
+	}
 
 	// cacheSoftLimit indicates the soft limit of the number of glyphs in the cache.
 	// If the number of glyphs exceeds this soft limits, old glyphs are removed.
 	// Even after cleaning up the cache, the number of glyphs might still exceed the soft limit, but
 	// this is fine.
-	cacheSoftLimit := 128 * glyphVariationCount(face)
-	if len(g.cache) > cacheSoftLimit {
-		for key, e := range g.cache {
+	cacheSoftLimit := 128 * glyphVariationCount(face) * secondaryGlyphVariationCount(face)
+	if len(entries.cache) > cacheSoftLimit && GlyphCacheBudget() == 0 {
+		for key, e := range entries.cache {
 			// 60 is an arbitrary number.
 			if e.atime >= now()-60 {
 				continue
 			}
-			delete(g.cache, key)
+			g.remove(key, e)
 		}
 	}
 
 	return img
 }
+
+func (g *glyphImageCache[Key]) remove(key Key, e *glyphImageCacheEntry) {
+	delete(g.entries.cache, key)
+	g.entries.bytes -= e.size
+	addGlyphCacheUsage(-1, -e.size)
+}
+
+// evictOverBudget evicts the least recently used glyph images in this cache until the total size fits with the budget.
+// The glyph images used in the current tick are never evicted.
+func (g *glyphImageCache[Key]) evictOverBudget() {
+	type keyAndEntry struct {
+		key   Key
+		entry *glyphImageCacheEntry
+	}
+	var candidates []keyAndEntry
+	for k, e := range g.entries.cache {
+		if e.atime >= now() {
+			continue
+		}
+		candidates = append(candidates, keyAndEntry{
+			key:   k,
+			entry: e,
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].entry.atime < candidates[j].entry.atime
+	})
+	for _, c := range candidates {
+		if !glyphCacheOverBudget() {
+			break
+		}
+		g.remove(c.key, c.entry)
+	}
+}
+
+func (g *glyphImageCache[Key]) usage() (glyphs int, bytes int) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if g.entries == nil {
+		return 0, 0
+	}
+	return len(g.entries.cache), g.entries.bytes
+}
+
+func (g *glyphImageCache[Key]) evict() {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if g.entries == nil {
+		return
+	}
+	for key, e := range g.entries.cache {
+		if e.image != nil {
+			e.image.Deallocate()
+		}
+		g.remove(key, e)
+	}
+}
+
+// GlyphCacheUsage returns the number of the cached glyph images and their total size in bytes for the given face.
+//
+// A GoTextFace shares the cache with other GoTextFaces with the same source and the same size,
+// so the usage includes the glyph images for all of them.
+// For a MultiFace, the usage includes the glyph images for all the underlying faces.
+//
+// If face is nil, GlyphCacheUsage returns the usage for all the faces.
+//
+// GlyphCacheUsage is concurrent-safe.
+func GlyphCacheUsage(face Face) (glyphs int, bytes int) {
+	if face == nil {
+		glyphCacheM.Lock()
+		defer glyphCacheM.Unlock()
+		return glyphCacheGlyphs, glyphCacheBytes
+	}
+
+	for _, c := range uniqueGlyphImageCaches(face) {
+		g, b := c.usage()
+		glyphs += g
+		bytes += b
+	}
+	return glyphs, bytes
+}
+
+// EvictGlyphs removes all the cached glyph images for the given face, and deallocates the images.
+//
+// EvictGlyphs is useful to free the atlas space when the face is no longer used.
+// The face can still be used after EvictGlyphs, and the glyphs are created again when necessary.
+// As GlyphCacheUsage, EvictGlyphs for a GoTextFace affects other GoTextFaces with the same source and the same size.
+//
+// EvictGlyphs must not be called between drawing the glyphs of the face and the end of the frame,
+// as the glyph images are deallocated immediately.
+//
+// EvictGlyphs is concurrent-safe.
+func EvictGlyphs(face Face) {
+	for _, c := range uniqueGlyphImageCaches(face) {
+		c.evict()
+	}
+}
+
+func uniqueGlyphImageCaches(face Face) []glyphImageCacheInterface {
+	var caches []glyphImageCacheInterface
+	for _, c := range face.appendGlyphImageCaches(nil) {
+		var found bool
+		for _, c2 := range caches {
+			if c == c2 {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		caches = append(caches, c)
+	}
+	return caches
+}
+
+// GlyphCacheBudget returns the budget of the glyph cache in bytes.
+//
+// GlyphCacheBudget is concurrent-safe.
+func GlyphCacheBudget() int {
+	glyphCacheM.Lock()
+	defer glyphCacheM.Unlock()
+	return glyphCacheBudget
+}
+
+// SetGlyphCacheBudget sets the budget of the total size of the cached glyph images for all the faces in bytes.
+//
+// If bytes is 0 or less, the default behavior is used:
+// when a face has too many glyphs in the cache, the glyphs that have not been used for 60 ticks are evicted.
+//
+// If bytes is positive, when the total size exceeds the budget after a face creates a glyph image,
+// the least recently used glyph images of the face are evicted until the total size fits with the budget.
+// The glyph images used in the current tick are never evicted, and the glyph images of other faces are not evicted,
+// so the total size might still exceed the budget. Use EvictGlyphs to remove the glyph images of unused faces.
+//
+// SetGlyphCacheBudget is concurrent-safe.
+func SetGlyphCacheBudget(bytes int) {
+	glyphCacheM.Lock()
+	defer glyphCacheM.Unlock()
+
+	if bytes < 0 {
+		bytes = 0
+	}
+	glyphCacheBudget = bytes
+}
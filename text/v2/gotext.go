@@ -46,6 +46,12 @@ type GoTextFace struct {
 
 	// Direction is the rendering direction.
 	// The default (zero) value is left-to-right horizontal.
+	//
+	// For a horizontal direction, Direction is the base direction of a paragraph.
+	// Texts in mixed directions, like Arabic or Hebrew with numbers or Latin words, are reordered visually
+	// based on the Unicode Bidirectional Algorithm.
+	// Complex scripts like Arabic or Devanagari are shaped by the HarfBuzz shaper,
+	// so Draw, Measure and Wrap agree with each other.
 	Direction Direction
 
 	// Size is the font size in pixels.
@@ -58,6 +64,40 @@ type GoTextFace struct {
 	// If this is empty, the script is guessed from the specified language.
 	Script language.Script
 
+	// SubpixelPhases is the number of subpixel phases to quantize glyph positions in the primary direction.
+	// A glyph image is rasterized and cached for each phase, and the closest phase is picked to render a glyph.
+	//
+	// For example, 4 means quarter-pixel positioning, and 1 means that glyphs always snap to integer pixels.
+	// A value that is not a power of two like 3 is also available. A value more than 64 is treated as 64.
+	//
+	// More phases make small text at fractional positions, like a smoothly scrolling list, look smoother,
+	// at the cost of more glyph images in the cache.
+	// The number of cached glyph images is limited in proportion to the number of phases.
+	//
+	// The default (zero) value means that the number of phases is decided automatically based on the face size:
+	// the smaller the face is, the more phases are used.
+	SubpixelPhases int
+
+	// SecondarySubpixelPhases is the number of subpixel phases to quantize glyph positions in the secondary direction,
+	// i.e. the vertical direction for a horizontal-direction face.
+	// This is useful to move a text smoothly in the secondary direction, like vertically scrolling credits.
+	//
+	// A glyph image is rasterized and cached for each combination of the primary and secondary phases,
+	// so the number of cached glyph images can be multiplied by SecondarySubpixelPhases.
+	// For example, with SubpixelPhases 4 and SecondarySubpixelPhases 4, one glyph can have 16 images.
+	// The number of cached glyph images is limited in proportion to the product of the numbers of phases.
+	// A value more than 64 is treated as 64.
+	//
+	// SecondarySubpixelPhases is ignored with HintingVertical in a horizontal direction,
+	// as the hinting already snaps glyph outlines to the pixel grid vertically.
+	//
+	// The default (zero) value is 1, which means that glyphs always snap to integer pixels in the secondary direction.
+	SecondarySubpixelPhases int
+
+	// Hinting is the hinting mode used when rasterizing glyphs.
+	// The default (zero) value is HintingNone.
+	Hinting Hinting
+
 	variations []font.Variation
 	features   []shaping.FontFeature
 
@@ -65,6 +105,21 @@ type GoTextFace struct {
 	featuresString   string
 }
 
+// Hinting represents a hinting mode to rasterize glyphs.
+type Hinting int
+
+const (
+	// HintingNone indicates that glyph outlines are rasterized as they are.
+	HintingNone Hinting = iota
+
+	// HintingVertical indicates that the vertical coordinates of glyph outlines are snapped to the pixel grid.
+	// HintingVertical makes horizontal stems and the baseline sharper at small sizes, at the cost of the shape accuracy.
+	//
+	// HintingVertical is effective only for horizontal directions.
+	// With HintingVertical, glyphs always snap to integer pixels vertically regardless of SecondarySubpixelPhases.
+	HintingVertical
+)
+
 // SetVariation sets a variation value.
 // For font variations, see https://developer.mozilla.org/en-US/docs/Web/CSS/CSS_fonts/Variable_fonts_guide for more details.
 func (g *GoTextFace) SetVariation(tag Tag, value float32) {
@@ -304,7 +359,7 @@ func (g *GoTextFace) appendGlyphsForLine(glyphs []Glyph, line string, indexOffse
 	}
 	_, gs := g.Source.shape(line, g)
 	for _, glyph := range gs {
-		img, imgX, imgY := g.glyphImage(glyph, origin.Add(fixed.Point26_6{
+		img, imgX, imgY, isColor := g.glyphImage(glyph, origin.Add(fixed.Point26_6{
 			X: glyph.shapingGlyph.XOffset,
 			Y: -glyph.shapingGlyph.YOffset,
 		}))
@@ -315,8 +370,12 @@ func (g *GoTextFace) appendGlyphsForLine(glyphs []Glyph, line string, indexOffse
 			EndIndexInBytes:   indexOffset + glyph.endIndex,
 			GID:               uint32(glyph.shapingGlyph.GlyphID),
 			Image:             img,
+			IsColor:           isColor,
 			X:                 float64(imgX),
 			Y:                 float64(imgY),
+			OriginX:           fixed26_6ToFloat64(origin.X),
+			OriginY:           fixed26_6ToFloat64(origin.Y),
+			Advance:           g.glyphAdvance(glyph),
 		})
 		origin = origin.Add(fixed.Point26_6{
 			X: glyph.shapingGlyph.XAdvance,
@@ -327,16 +386,47 @@ func (g *GoTextFace) appendGlyphsForLine(glyphs []Glyph, line string, indexOffse
 	return glyphs
 }
 
-func (g *GoTextFace) glyphImage(glyph glyph, origin fixed.Point26_6) (*ebiten.Image, int, int) {
+// glyphAdvance returns the advance of the glyph in the primary direction.
+func (g *GoTextFace) glyphAdvance(glyph glyph) float64 {
+	if g.direction().isHorizontal() {
+		return fixed26_6ToFloat64(glyph.shapingGlyph.XAdvance)
+	}
+	return fixed26_6ToFloat64(-glyph.shapingGlyph.YAdvance)
+}
+
+func (g *GoTextFace) glyphImage(glyph glyph, origin fixed.Point26_6) (*ebiten.Image, int, int, bool) {
+	if glyph.bitmap != nil {
+		// A color bitmap doesn't have subpixel variations.
+		key := goTextGlyphImageCacheKey{
+			gid: glyph.shapingGlyph.GlyphID,
+		}
+		img := g.Source.getOrCreateGlyphImage(g, key, func() *ebiten.Image {
+			return bitmapToImage(glyph.bitmap, glyph.bounds)
+		})
+		imgX := (origin.X + glyph.bounds.Min.X).Round()
+		imgY := (origin.Y + glyph.bounds.Min.Y).Round()
+		return img, imgX, imgY, img != nil
+	}
+
 	if g.direction().isHorizontal() {
 		origin.X = adjustGranularity(origin.X, g)
-		origin.Y &^= ((1 << 6) - 1)
+		origin.Y = adjustSecondaryGranularity(origin.Y, g)
 	} else {
-		origin.X &^= ((1 << 6) - 1)
+		origin.X = adjustSecondaryGranularity(origin.X, g)
 		origin.Y = adjustGranularity(origin.Y, g)
 	}
 
 	b := glyph.bounds
+	hinting := g.Hinting
+	if !g.direction().isHorizontal() {
+		hinting = HintingNone
+	}
+	if hinting == HintingVertical {
+		// Snapped coordinates are always in the range between the floor of the minimum and the ceil of the maximum.
+		b.Min.Y = fixed.I(b.Min.Y.Floor())
+		b.Max.Y = fixed.I(b.Max.Y.Ceil())
+	}
+
 	subpixelOffset := fixed.Point26_6{
 		X: (origin.X + b.Min.X) & ((1 << 6) - 1),
 		Y: (origin.Y + b.Min.Y) & ((1 << 6) - 1),
@@ -346,25 +436,57 @@ func (g *GoTextFace) glyphImage(glyph glyph, origin fixed.Point26_6) (*ebiten.Im
 		xoffset:    subpixelOffset.X,
 		yoffset:    subpixelOffset.Y,
 		variations: g.ensureVariationsString(),
+		hinting:    hinting,
 	}
 	img := g.Source.getOrCreateGlyphImage(g, key, func() *ebiten.Image {
-		return segmentsToImage(glyph.scaledSegments, subpixelOffset, b)
+		segs := glyph.scaledSegments
+		if hinting == HintingVertical {
+			segs = snapSegmentsVertically(segs)
+		}
+		return segmentsToImage(segs, subpixelOffset, b)
 	})
 
 	imgX := (origin.X + b.Min.X).Floor()
 	imgY := (origin.Y + b.Min.Y).Floor()
-	return img, imgX, imgY
+	return img, imgX, imgY, false
 }
 
 // appendVectorPathForLine implements Face.
 func (g *GoTextFace) appendVectorPathForLine(path *vector.Path, line string, originX, originY float64) {
+	g.forEachGlyphOrigin(line, originX, originY, func(glyph glyph, x, y float32) {
+		appendVectorPathFromSegments(path, glyph.scaledSegments, x, y)
+	})
+}
+
+// appendGlyphVectorPathsForLine implements Face.
+func (g *GoTextFace) appendGlyphVectorPathsForLine(paths []GlyphVectorPath, line string, indexOffset int, originX, originY float64) []GlyphVectorPath {
+	g.forEachGlyphOrigin(line, originX, originY, func(glyph glyph, x, y float32) {
+		var path vector.Path
+		appendVectorPathFromSegments(&path, glyph.scaledSegments, x, y)
+		paths = append(paths, GlyphVectorPath{
+			StartIndexInBytes: indexOffset + glyph.startIndex,
+			EndIndexInBytes:   indexOffset + glyph.endIndex,
+			GID:               uint32(glyph.shapingGlyph.GlyphID),
+			Path:              &path,
+		})
+	})
+	return paths
+}
+
+// forEachGlyphOrigin calls f with each glyph and its origin position including the glyph offset.
+func (g *GoTextFace) forEachGlyphOrigin(line string, originX, originY float64, f func(glyph glyph, x, y float32)) {
 	origin := fixed.Point26_6{
 		X: float64ToFixed26_6(originX),
 		Y: float64ToFixed26_6(originY),
 	}
 	_, gs := g.Source.shape(line, g)
 	for _, glyph := range gs {
-		appendVectorPathFromSegments(path, glyph.scaledSegments, fixed26_6ToFloat32(origin.X), fixed26_6ToFloat32(origin.Y))
+		// Apply the offset in the same way as appendGlyphsForLine so that the path matches the rendered glyphs.
+		o := origin.Add(fixed.Point26_6{
+			X: glyph.shapingGlyph.XOffset,
+			Y: -glyph.shapingGlyph.YOffset,
+		})
+		f(glyph, fixed26_6ToFloat32(o.X), fixed26_6ToFloat32(o.Y))
 		origin = origin.Add(fixed.Point26_6{
 			X: glyph.shapingGlyph.XAdvance,
 			Y: -glyph.shapingGlyph.YAdvance,
@@ -378,5 +500,13 @@ func (g *GoTextFace) direction() Direction {
 }
 
 // private implements Face.
+// appendGlyphImageCaches implements Face.
+func (g *GoTextFace) appendGlyphImageCaches(caches []glyphImageCacheInterface) []glyphImageCacheInterface {
+	if c := g.Source.glyphImageCacheForSize(g.Size); c != nil {
+		caches = append(caches, c)
+	}
+	return caches
+}
+
 func (g *GoTextFace) private() {
 }
@@ -0,0 +1,101 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"bytes"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+
+	"github.com/go-text/typesetting/font"
+	"github.com/go-text/typesetting/opentype/api"
+	"github.com/go-text/typesetting/shaping"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// isColorBitmap reports whether the bitmap is a color bitmap like an emoji in CBDT or sbix tables.
+func isColorBitmap(bitmap *api.GlyphBitmap) bool {
+	switch bitmap.Format {
+	case api.PNG, api.JPG:
+		return bitmap.Width > 0 && bitmap.Height > 0
+	}
+	return false
+}
+
+// bitmapScale returns the scale to render a bitmap glyph of the face f in the given size.
+func bitmapScale(f font.Face, bitmap *api.GlyphBitmap, size float64, out *shaping.Output) float64 {
+	// The largest strike is used as XPpem and YPpem are not specified.
+	var ppem uint16
+	for _, s := range f.BitmapSizes() {
+		if ppem < s.YPpem {
+			ppem = s.YPpem
+		}
+	}
+	if ppem > 0 {
+		return size / float64(ppem)
+	}
+
+	// Fit the bitmap to the line height when the strike size is unknown.
+	if h := fixed26_6ToFloat64(out.LineBounds.Ascent - out.LineBounds.Descent); h > 0 {
+		return h / float64(bitmap.Height)
+	}
+	return 1
+}
+
+// bitmapBounds returns the bounds of a bitmap glyph relative to the glyph origin.
+//
+// Bitmap glyphs don't have subpixel positions, so the bounds are always aligned with pixels.
+func bitmapBounds(bitmap *api.GlyphBitmap, scale float64, out *shaping.Output) fixed.Rectangle26_6 {
+	w := int(math.Ceil(float64(bitmap.Width) * scale))
+	h := int(math.Ceil(float64(bitmap.Height) * scale))
+
+	var x, y int
+	if out.Direction.IsVertical() {
+		x = -w / 2
+	} else {
+		// Center the bitmap in the line vertically.
+		ascent := out.LineBounds.Ascent.Round()
+		lineHeight := (out.LineBounds.Ascent - out.LineBounds.Descent).Round()
+		y = -ascent + (lineHeight-h)/2
+	}
+
+	return fixed.Rectangle26_6{
+		Min: fixed.P(x, y),
+		Max: fixed.P(x+w, y+h),
+	}
+}
+
+// bitmapToImage decodes a color bitmap glyph and scales it to the given bounds.
+func bitmapToImage(bitmap *api.GlyphBitmap, bounds fixed.Rectangle26_6) *ebiten.Image {
+	src, _, err := image.Decode(bytes.NewReader(bitmap.Data))
+	if err != nil {
+		// A broken bitmap is treated as an empty glyph.
+		return nil
+	}
+
+	w, h := (bounds.Max.X - bounds.Min.X).Round(), (bounds.Max.Y - bounds.Min.Y).Round()
+	if w == 0 || h == 0 {
+		return nil
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	xdraw.BiLinear.Scale(dst, dst.Bounds(), src, src.Bounds(), xdraw.Src, nil)
+	return ebiten.NewImageFromImage(dst)
+}
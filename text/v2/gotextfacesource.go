@@ -16,7 +16,9 @@ package text
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"io/fs"
 	"sync"
 
 	"github.com/go-text/typesetting/font"
@@ -46,6 +48,9 @@ type glyph struct {
 	endIndex       int
 	scaledSegments []api.Segment
 	bounds         fixed.Rectangle26_6
+
+	// bitmap is a color bitmap like an emoji. If bitmap is not nil, scaledSegments is not used.
+	bitmap *api.GlyphBitmap
 }
 
 type goTextOutputCacheValue struct {
@@ -59,6 +64,7 @@ type goTextGlyphImageCacheKey struct {
 	xoffset    fixed.Int26_6
 	yoffset    fixed.Int26_6
 	variations string
+	hinting    Hinting
 }
 
 // GoTextFaceSource is a source of a GoTextFace. This can be shared by multiple GoTextFace objects.
@@ -120,6 +126,22 @@ func NewGoTextFaceSource(source io.Reader) (*GoTextFaceSource, error) {
 	return s, nil
 }
 
+// NewGoTextFaceSourceFromFS parses an OpenType or TrueType font file name in fsys and returns a GoTextFaceSource object.
+//
+// fsys can be an embed.FS, which works on any environments including browsers and mobiles.
+// The returned error includes name.
+func NewGoTextFaceSourceFromFS(fsys fs.FS, name string) (*GoTextFaceSource, error) {
+	bs, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("text: reading %s failed: %w", name, err)
+	}
+	s, err := NewGoTextFaceSource(bytes.NewReader(bs))
+	if err != nil {
+		return nil, fmt.Errorf("text: parsing %s failed: %w", name, err)
+	}
+	return s, nil
+}
+
 // NewGoTextFaceSourcesFromCollection parses an OpenType or TrueType font collection and returns a slice of GoTextFaceSource objects.
 func NewGoTextFaceSourcesFromCollection(source io.Reader) ([]*GoTextFaceSource, error) {
 	src, err := toFontResource(source)
@@ -197,12 +219,8 @@ func (g *GoTextFaceSource) shape(text string, face *GoTextFace) ([]shaping.Outpu
 	var seg shaping.Segmenter
 	inputs := seg.Split(input, &singleFontmap{face: f})
 
-	if face.Direction == DirectionRightToLeft {
-		// Reverse the input for RTL texts.
-		for i, j := 0, len(inputs)-1; i < j; i, j = i+1, j-1 {
-			inputs[i], inputs[j] = inputs[j], inputs[i]
-		}
-	}
+	// Put the inputs in the visual order for mixed-direction texts.
+	reorderInputsVisually(inputs, face.Direction)
 
 	outputs := make([]shaping.Output, len(inputs))
 	var gs []glyph
@@ -230,6 +248,17 @@ func (g *GoTextFaceSource) shape(text string, face *GoTextFace) ([]shaping.Outpu
 			case api.GlyphSVG:
 				segs = data.Outline.Segments
 			case api.GlyphBitmap:
+				if isColorBitmap(&data) {
+					scale := bitmapScale(g.f, &data, fixed26_6ToFloat64(out.Size), &out)
+					gs = append(gs, glyph{
+						shapingGlyph: &gl,
+						startIndex:   indices[gl.ClusterIndex],
+						endIndex:     indices[gl.ClusterIndex+gl.RuneCount],
+						bounds:       bitmapBounds(&data, scale, &out),
+						bitmap:       &data,
+					})
+					continue
+				}
 				if data.Outline != nil {
 					segs = data.Outline.Segments
 				}
@@ -283,13 +312,29 @@ func (g *GoTextFaceSource) scale(size float64) float64 {
 }
 
 func (g *GoTextFaceSource) getOrCreateGlyphImage(goTextFace *GoTextFace, key goTextGlyphImageCacheKey, create func() *ebiten.Image) *ebiten.Image {
+	g.m.Lock()
 	if g.glyphImageCache == nil {
 		g.glyphImageCache = map[float64]*glyphImageCache[goTextGlyphImageCacheKey]{}
 	}
 	if _, ok := g.glyphImageCache[goTextFace.Size]; !ok {
 		g.glyphImageCache[goTextFace.Size] = &glyphImageCache[goTextGlyphImageCacheKey]{}
 	}
-	return g.glyphImageCache[goTextFace.Size].getOrCreate(goTextFace, key, create)
+	c := g.glyphImageCache[goTextFace.Size]
+	g.m.Unlock()
+
+	return c.getOrCreate(goTextFace, key, create)
+}
+
+// glyphImageCacheForSize returns the glyph image cache for the given size, or nil if there is no cache yet.
+func (g *GoTextFaceSource) glyphImageCacheForSize(size float64) glyphImageCacheInterface {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	c, ok := g.glyphImageCache[size]
+	if !ok {
+		return nil
+	}
+	return c
 }
 
 type singleFontmap struct {
@@ -75,6 +75,18 @@ func segmentsToBounds(segs []api.Segment) fixed.Rectangle26_6 {
 	}
 }
 
+// snapSegmentsVertically returns a copy of the segments whose vertical coordinates are rounded to integers.
+func snapSegmentsVertically(segs []api.Segment) []api.Segment {
+	snapped := make([]api.Segment, len(segs))
+	for i, seg := range segs {
+		snapped[i] = seg
+		for j := range seg.Args {
+			snapped[i].Args[j].Y = float32(math.Round(float64(seg.Args[j].Y)))
+		}
+	}
+	return snapped
+}
+
 func segmentsToImage(segs []api.Segment, subpixelOffset fixed.Point26_6, glyphBounds fixed.Rectangle26_6) *ebiten.Image {
 	if len(segs) == 0 {
 		return nil
@@ -126,9 +138,16 @@ func segmentsToImage(segs []api.Segment, subpixelOffset fixed.Point26_6, glyphBo
 }
 
 func appendVectorPathFromSegments(path *vector.Path, segs []api.Segment, x, y float32) {
-	for _, seg := range segs {
+	if len(segs) == 0 {
+		return
+	}
+	for i, seg := range segs {
 		switch seg.Op {
 		case api.SegmentOpMoveTo:
+			// Close the previous contour so that each contour can be stroked as a closed shape.
+			if i > 0 {
+				path.Close()
+			}
 			path.MoveTo(seg.Args[0].X+x, seg.Args[0].Y+y)
 		case api.SegmentOpLineTo:
 			path.LineTo(seg.Args[0].X+x, seg.Args[0].Y+y)
@@ -45,6 +45,8 @@ type GoXFace struct {
 
 	glyphImageCache glyphImageCache[goXFaceGlyphImageCacheKey]
 
+	subpixelPhases int
+
 	addr *GoXFace
 }
 
@@ -59,6 +61,22 @@ func NewGoXFace(face font.Face) *GoXFace {
 	return s
 }
 
+// SubpixelPhases returns the number of subpixel phases to quantize glyph positions.
+// See GoTextFace's SubpixelPhases for the details.
+func (s *GoXFace) SubpixelPhases() int {
+	s.copyCheck()
+	return s.subpixelPhases
+}
+
+// SetSubpixelPhases sets the number of subpixel phases to quantize glyph positions.
+// See GoTextFace's SubpixelPhases for the details.
+//
+// The hinting mode of GoXFace is decided by the given font.Face, e.g. opentype.FaceOptions' Hinting.
+func (s *GoXFace) SetSubpixelPhases(phases int) {
+	s.copyCheck()
+	s.subpixelPhases = phases
+}
+
 func (s *GoXFace) copyCheck() {
 	if s.addr != s {
 		panic("text: illegal use of non-zero GoXFace copied by value")
@@ -124,6 +142,9 @@ func (s *GoXFace) appendGlyphsForLine(glyphs []Glyph, line string, indexOffset i
 			Image:             img,
 			X:                 float64(imgX),
 			Y:                 float64(imgY),
+			OriginX:           fixed26_6ToFloat64(origin.X),
+			OriginY:           fixed26_6ToFloat64(origin.Y),
+			Advance:           fixed26_6ToFloat64(a),
 		})
 		origin.X += a
 		prevR = r
@@ -190,6 +211,16 @@ func (s *GoXFace) direction() Direction {
 func (s *GoXFace) appendVectorPathForLine(path *vector.Path, line string, originX, originY float64) {
 }
 
+// appendGlyphVectorPathsForLine implements Face.
+func (s *GoXFace) appendGlyphVectorPathsForLine(paths []GlyphVectorPath, line string, indexOffset int, originX, originY float64) []GlyphVectorPath {
+	return paths
+}
+
 // Metrics implements Face.
+// appendGlyphImageCaches implements Face.
+func (s *GoXFace) appendGlyphImageCaches(caches []glyphImageCacheInterface) []glyphImageCacheInterface {
+	return append(caches, &s.glyphImageCache)
+}
+
 func (s *GoXFace) private() {
 }
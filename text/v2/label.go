@@ -0,0 +1,266 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// LabelOptions represents options for a Label.
+type LabelOptions struct {
+	LayoutOptions
+
+	// MaxAdvance is the maximum advance of a line in pixels to wrap the text.
+	// See WrapOptions for the details.
+	// If MaxAdvance is not positive, the text is split only by '\n'.
+	MaxAdvance float64
+
+	// Overflow is a policy for a word longer than MaxAdvance.
+	// The default (zero) value is OverflowVisible.
+	Overflow Overflow
+
+	// Rasterize specifies whether the label renders the whole text into one image in advance.
+	//
+	// If Rasterize is true, Draw draws the text with one DrawImage call, which is the cheapest.
+	// On the other hand, the image uses more texture memory, and the text is not rendered at subpixel positions.
+	// Also, color glyphs like emojis are tinted with the ColorScale as the other glyphs are.
+	//
+	// The default (zero) value is false, which means the glyphs are drawn one by one from the cached layout.
+	Rasterize bool
+}
+
+// Label is a text with the retained layout.
+//
+// Draw re-lays out the text at every call. A Label instead caches the laid-out glyphs,
+// and reuses them until the text, the face, or the options change.
+// This is useful for a static text like a dialogue box, especially with a long text.
+//
+// A Label is not concurrent-safe.
+type Label struct {
+	text    string
+	face    Face
+	options LabelOptions
+
+	valid   bool
+	wrapped string
+	glyphs  []Glyph
+	width   float64
+	height  float64
+
+	image        *ebiten.Image
+	imageOffsetX float64
+	imageOffsetY float64
+}
+
+// NewLabel creates a new Label.
+//
+// options can be nil.
+func NewLabel(text string, face Face, options *LabelOptions) *Label {
+	l := &Label{
+		text: text,
+		face: face,
+	}
+	if options != nil {
+		l.options = *options
+	}
+	return l
+}
+
+// Text returns the label's text.
+func (l *Label) Text() string {
+	return l.text
+}
+
+// SetText sets the label's text.
+// If the text is different from the current one, the layout is invalidated.
+func (l *Label) SetText(text string) {
+	if l.text == text {
+		return
+	}
+	l.text = text
+	l.Invalidate()
+}
+
+// Face returns the label's face.
+func (l *Label) Face() Face {
+	return l.face
+}
+
+// SetFace sets the label's face.
+// If the face is different from the current one, the layout is invalidated.
+//
+// As a face's properties are not tracked, call Invalidate explicitly after modifying the face's properties like Size.
+func (l *Label) SetFace(face Face) {
+	if l.face == face {
+		return
+	}
+	l.face = face
+	l.Invalidate()
+}
+
+// Options returns the label's options.
+func (l *Label) Options() LabelOptions {
+	return l.options
+}
+
+// SetOptions sets the label's options.
+// If the options are different from the current ones, the layout is invalidated.
+//
+// options can be nil.
+func (l *Label) SetOptions(options *LabelOptions) {
+	var op LabelOptions
+	if options != nil {
+		op = *options
+	}
+	if l.options == op {
+		return
+	}
+	l.options = op
+	l.Invalidate()
+}
+
+// Invalidate discards the cached layout, and the layout is recalculated at the next Size or Draw call.
+func (l *Label) Invalidate() {
+	l.valid = false
+	l.glyphs = l.glyphs[:0]
+	if l.image != nil {
+		l.image.Deallocate()
+		l.image = nil
+	}
+}
+
+// Size returns the boundary size of the label's text in the same way as Measure, with the tab stops if TabWidth is positive.
+func (l *Label) Size() (width, height float64) {
+	l.layout()
+	return l.width, l.height
+}
+
+func (l *Label) layout() {
+	if l.valid {
+		return
+	}
+	l.valid = true
+
+	l.wrapped = l.text
+	if l.options.MaxAdvance > 0 {
+		l.wrapped = strings.Join(Wrap(l.text, l.face, &WrapOptions{
+			MaxAdvance: l.options.MaxAdvance,
+			Overflow:   l.options.Overflow,
+			TabWidth:   l.options.TabWidth,
+		}), "\n")
+	}
+	l.width, l.height = measure(l.wrapped, l.face, l.options.LineSpacing, l.options.TabWidth)
+
+	// An SDFFace needs its own shader, so only the wrapped text is cached.
+	if _, ok := l.face.(*SDFFace); ok {
+		return
+	}
+
+	l.glyphs = AppendGlyphs(l.glyphs[:0], l.wrapped, l.face, &l.options.LayoutOptions)
+	if l.options.Rasterize {
+		l.rasterize()
+	}
+}
+
+func (l *Label) rasterize() {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, g := range l.glyphs {
+		if g.Image == nil {
+			continue
+		}
+		b := g.Image.Bounds()
+		minX = math.Min(minX, g.X)
+		minY = math.Min(minY, g.Y)
+		maxX = math.Max(maxX, g.X+float64(b.Dx()))
+		maxY = math.Max(maxY, g.Y+float64(b.Dy()))
+	}
+	if minX > maxX {
+		return
+	}
+
+	l.imageOffsetX, l.imageOffsetY = math.Floor(minX), math.Floor(minY)
+	w := int(math.Ceil(maxX) - l.imageOffsetX)
+	h := int(math.Ceil(maxY) - l.imageOffsetY)
+	l.image = ebiten.NewImage(w, h)
+
+	op := &ebiten.DrawImageOptions{}
+	for _, g := range l.glyphs {
+		if g.Image == nil {
+			continue
+		}
+		op.GeoM.Reset()
+		op.GeoM.Translate(g.X-l.imageOffsetX, g.Y-l.imageOffsetY)
+		l.image.DrawImage(g.Image, op)
+	}
+}
+
+// Draw draws the label's text on dst.
+//
+// The text is put in the same way as the function Draw with the label's LayoutOptions.
+// options.GeoM and options.ColorScale are applied as the function Draw does.
+// Unlike the function Draw, the glyphs are not put at subpixel positions based on GeoM,
+// as the layout is calculated without GeoM.
+//
+// options can be nil.
+func (l *Label) Draw(dst *ebiten.Image, options *ebiten.DrawImageOptions) {
+	l.layout()
+
+	var drawOp ebiten.DrawImageOptions
+	if options != nil {
+		drawOp = *options
+	}
+
+	if _, ok := l.face.(*SDFFace); ok {
+		Draw(dst, l.wrapped, l.face, &DrawOptions{
+			DrawImageOptions: drawOp,
+			LayoutOptions:    l.options.LayoutOptions,
+		})
+		return
+	}
+
+	geoM := drawOp.GeoM
+	colorScale := drawOp.ColorScale
+
+	if l.options.Rasterize {
+		if l.image == nil {
+			return
+		}
+		drawOp.GeoM.Reset()
+		drawOp.GeoM.Translate(l.imageOffsetX, l.imageOffsetY)
+		drawOp.GeoM.Concat(geoM)
+		dst.DrawImage(l.image, &drawOp)
+		return
+	}
+
+	for _, g := range l.glyphs {
+		if g.Image == nil {
+			continue
+		}
+		drawOp.GeoM.Reset()
+		drawOp.GeoM.Translate(g.X, g.Y)
+		drawOp.GeoM.Concat(geoM)
+		drawOp.ColorScale = colorScale
+		if g.IsColor {
+			// A color glyph is not tinted. Only the alpha is applied.
+			drawOp.ColorScale.Reset()
+			drawOp.ColorScale.ScaleAlpha(colorScale.A())
+		}
+		dst.DrawImage(g.Image, &drawOp)
+	}
+}
@@ -15,6 +15,8 @@
 package text
 
 import (
+	"fmt"
+	"math"
 	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -28,6 +30,11 @@ const (
 	AlignStart Align = iota
 	AlignCenter
 	AlignEnd
+
+	// AlignBaseline is an alignment to put the first line's baseline at the origin, like text v1.
+	// AlignBaseline is valid only for the secondary direction.
+	// For the primary direction, AlignBaseline is treated as AlignStart.
+	AlignBaseline
 )
 
 // DrawOptions represents options for the Draw function.
@@ -39,6 +46,7 @@ const (
 type DrawOptions struct {
 	ebiten.DrawImageOptions
 	LayoutOptions
+	EffectOptions
 }
 
 // LayoutOptions represents options for layouting texts.
@@ -61,6 +69,99 @@ type LayoutOptions struct {
 	// and the horizontal direction for a vertical-direction face.
 	// The meaning of the start and the end depends on the face direction.
 	SecondaryAlign Align
+
+	// TabWidth is a distance between two adjacent tab stops in pixels.
+	// A '\t' tab character advances the position to the next tab stop in the primary direction.
+	// The tab stops are measured from the start of each line.
+	// TabWidthInCells is useful to specify the width in character cells.
+	//
+	// If TabWidth is not positive, a tab character is treated as a regular character.
+	TabWidth float64
+}
+
+// TabWidthInCells returns a tab width for the given number of character cells with the given face.
+// The width of one cell is the advance of a space character.
+//
+// TabWidthInCells is useful for a monospace face.
+func TabWidthInCells(face Face, cells int) float64 {
+	return float64(cells) * face.advance(" ")
+}
+
+// JoinColumns joins the cells of the rows into a text with tab characters and '\n' newline characters,
+// and returns the text and a tab width to align the columns.
+//
+// The tab width is the longest advance of the cells except for the last column, plus gap in pixels.
+// Then, the columns are aligned by specifying the tab width as LayoutOptions' TabWidth.
+// As all the columns have the same width, JoinColumns is suitable for a monospace face or a simple table.
+//
+// A cell must not include a tab character nor a newline character.
+//
+// If gap is not positive, JoinColumns panics.
+func JoinColumns(rows [][]string, face Face, gap float64) (text string, tabWidth float64) {
+	if gap <= 0 {
+		panic(fmt.Sprintf("text: gap at JoinColumns must be positive but %f", gap))
+	}
+
+	var maxAdvance float64
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(row)-1 {
+				break
+			}
+			maxAdvance = math.Max(maxAdvance, face.advance(cell))
+		}
+	}
+
+	var sb strings.Builder
+	for j, row := range rows {
+		if j > 0 {
+			sb.WriteByte('\n')
+		}
+		for i, cell := range row {
+			if i > 0 {
+				sb.WriteByte('\t')
+			}
+			sb.WriteString(cell)
+		}
+	}
+	return sb.String(), maxAdvance + gap
+}
+
+// forEachTabSegment iterates the segments of the line split by tab characters, and returns the line's advance.
+// position is the distance from the start of the line to the segment in the primary direction.
+// f can be nil.
+func forEachTabSegment(line string, face Face, tabWidth float64, f func(segment string, indexOffset int, position, advance float64)) float64 {
+	if tabWidth <= 0 {
+		a := face.advance(line)
+		if f != nil {
+			f(line, 0, 0, a)
+		}
+		return a
+	}
+
+	var position float64
+	var indexOffset int
+	for t := line; ; {
+		segment, rest, found := strings.Cut(t, "\t")
+		a := face.advance(segment)
+		if f != nil {
+			f(segment, indexOffset, position, a)
+		}
+		position += a
+		if !found {
+			break
+		}
+		// Advance to the next tab stop.
+		position = (math.Floor(position/tabWidth) + 1) * tabWidth
+		t = rest
+		indexOffset += len(segment) + 1
+	}
+	return position
+}
+
+// lineAdvance returns the advance of the line with the tab stops.
+func lineAdvance(line string, face Face, tabWidth float64) float64 {
+	return forEachTabSegment(line, face, tabWidth, nil)
 }
 
 // Draw draws a given text on a given destination image dst.
@@ -74,6 +175,15 @@ type LayoutOptions struct {
 //
 // It is OK to call Draw with a same text and a same face at every frame in terms of performance.
 //
+// If GeoM in DrawOptions is a translation, glyphs are put at subpixel positions based on the fractional part of the translation.
+// See GoTextFace's SubpixelPhases and SecondarySubpixelPhases for the precision.
+//
+// Color glyphs like emojis (see Glyph's IsColor) are drawn without the tint of the ColorScale,
+// and only the alpha of the ColorScale is applied.
+// To render an emoji missing in the main face, use MultiFace with an emoji face as a fallback.
+//
+// The outline and the shadow in EffectOptions are rendered below the text. See EffectOptions for the details.
+//
 // Draw is concurrent-safe.
 //
 // # Rendering region
@@ -100,28 +210,234 @@ type LayoutOptions struct {
 // If the vertical alignment is top, the rendering region's top Y comes to the destination image's origin (0, 0).
 // If the vertical alignment is center, the rendering region's middle Y comes to the origin.
 // If the vertical alignment is bottom, the rendering region's bottom Y comes to the origin.
+//
+// If the secondary alignment is baseline, the first line's baseline comes to the origin.
+// For example, with a horizontal direction, PrimaryAlign AlignCenter and SecondaryAlign AlignBaseline put
+// the text centered horizontally on the origin with the first line's baseline at the origin's Y.
 func Draw(dst *ebiten.Image, text string, face Face, options *DrawOptions) {
 	var layoutOp LayoutOptions
 	var drawOp ebiten.DrawImageOptions
+	var effectOp EffectOptions
 
 	if options != nil {
 		layoutOp = options.LayoutOptions
 		drawOp = options.DrawImageOptions
+		effectOp = options.EffectOptions
+	}
+
+	if f, ok := face.(*SDFFace); ok {
+		if effectOp.ShadowColor != nil {
+			shadowOp := drawOp
+			shadowOp.GeoM.Reset()
+			shadowOp.GeoM.Translate(effectOp.ShadowOffsetX, effectOp.ShadowOffsetY)
+			shadowOp.GeoM.Concat(drawOp.GeoM)
+			shadowOp.ColorScale = effectColorScale(drawOp.ColorScale, effectOp.ShadowColor)
+			drawSDF(dst, text, f, &layoutOp, &shadowOp)
+		}
+		drawSDF(dst, text, f, &layoutOp, &drawOp)
+		return
 	}
 
 	geoM := drawOp.GeoM
+	colorScale := drawOp.ColorScale
+
+	// If GeoM is a translation, render glyphs at the fractional position with subpixel glyph images
+	// instead of snapping them to integer pixels.
+	var x, y float64
+	if geoM.Element(0, 0) == 1 && geoM.Element(0, 1) == 0 && geoM.Element(1, 0) == 0 && geoM.Element(1, 1) == 1 {
+		tx, ty := geoM.Element(0, 2), geoM.Element(1, 2)
+		x, y = tx-math.Floor(tx), ty-math.Floor(ty)
+		geoM.Translate(-x, -y)
+	}
+
+	glyphs := appendGlyphs(nil, text, face, x, y, &layoutOp)
+
+	// Draw the shadows, the outlines and the glyphs in this order so that a glyph's outline never covers its neighbor.
+	outlineWidth := effectOp.outlineWidth()
+	if effectOp.ShadowColor != nil {
+		cs := effectColorScale(colorScale, effectOp.ShadowColor)
+		for _, g := range glyphs {
+			if g.Image == nil {
+				continue
+			}
+			img := g.Image
+			if outlineWidth > 0 {
+				img = outlineImage(face, g.Image, outlineWidth)
+			}
+			drawOp.GeoM.Reset()
+			drawOp.GeoM.Translate(g.X-float64(outlineWidth)+effectOp.ShadowOffsetX, g.Y-float64(outlineWidth)+effectOp.ShadowOffsetY)
+			drawOp.GeoM.Concat(geoM)
+			drawOp.ColorScale = cs
+			dst.DrawImage(img, &drawOp)
+		}
+	}
+	if outlineWidth > 0 {
+		cs := effectColorScale(colorScale, effectOp.OutlineColor)
+		for _, g := range glyphs {
+			if g.Image == nil {
+				continue
+			}
+			drawOp.GeoM.Reset()
+			drawOp.GeoM.Translate(g.X-float64(outlineWidth), g.Y-float64(outlineWidth))
+			drawOp.GeoM.Concat(geoM)
+			drawOp.ColorScale = cs
+			dst.DrawImage(outlineImage(face, g.Image, outlineWidth), &drawOp)
+		}
+	}
 
-	for _, g := range AppendGlyphs(nil, text, face, &layoutOp) {
+	for _, g := range glyphs {
 		if g.Image == nil {
 			continue
 		}
 		drawOp.GeoM.Reset()
 		drawOp.GeoM.Translate(g.X, g.Y)
 		drawOp.GeoM.Concat(geoM)
+		drawOp.ColorScale = colorScale
+		if g.IsColor {
+			// A color glyph is not tinted. Only the alpha is applied.
+			drawOp.ColorScale.Reset()
+			drawOp.ColorScale.ScaleAlpha(colorScale.A())
+		}
 		dst.DrawImage(g.Image, &drawOp)
 	}
 }
 
+// DrawShaderOptions represents options for the DrawWithShader function.
+//
+// DrawShaderOptions embeds ebiten.DrawTrianglesShaderOptions.
+// DrawTrianglesShaderOptions.Images[0] is ignored, as each glyph image is used as the source image 0.
+// The other images are passed to the shader as they are.
+// As the other images' sizes don't match with the glyph images, the shader must use pixels as its unit
+// (//kage:unit pixels) to use the other images. Otherwise, DrawWithShader panics.
+type DrawShaderOptions struct {
+	ebiten.DrawTrianglesShaderOptions
+	LayoutOptions
+
+	// GeoM is a geometry transformation after putting the rendering region along with the specified alignments.
+	GeoM ebiten.GeoM
+
+	// ColorScale is passed to the shader as the vertex colors.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ebiten.ColorScale
+}
+
+// DrawWithShader draws a given text on a given destination image dst with a given shader.
+// face is the font for text rendering.
+//
+// DrawWithShader draws each glyph as a quad with the shader, where the source image 0 is the glyph image.
+// The glyph image is a grayscale image i.e. RGBA values are the same, except for color glyphs.
+// As each glyph has its own source image, DrawWithShader calls DrawTrianglesShader per glyph,
+// and Ebitengine batches the calls internally when possible.
+//
+// Unlike Draw, the custom vertex values are computed across the whole text, so that an effect like a gradient can span the text.
+// The shader's Fragment function can take the custom values as the fourth argument of type vec4:
+//
+//   - custom.xy is the position in the text's coordinate before GeoM is applied, in pixels.
+//     The origin is the same as Glyph's X and Y.
+//   - custom.zw is the normalized position in the bounding box of all the glyphs, in the range of [0, 1].
+//
+// For example, this Fragment function renders a horizontal gradient across the text:
+//
+//	func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4) vec4 {
+//		return imageSrc0At(srcPos).a * mix(vec4(1, 0, 0, 1), vec4(0, 0, 1, 1), custom.z)
+//	}
+//
+// A shader using DrawShaderOptions.Images[1] or later must use pixels as its unit.
+// srcPos is a position on the glyph image, so use custom.xy to sample the other images along the text.
+// For example, this shader fills the text with a texture given as Images[1]:
+//
+//	//kage:unit pixels
+//
+//	package main
+//
+//	func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4) vec4 {
+//		return imageSrc0At(srcPos).a * imageSrc1At(imageSrc1Origin() + custom.xy)
+//	}
+//
+// To build a mesh by yourself, use AppendGlyphs and the glyphs' positions and images.
+//
+// For the details of the layout, see Draw function.
+//
+// DrawWithShader is concurrent-safe.
+func DrawWithShader(dst *ebiten.Image, text string, face Face, shader *ebiten.Shader, options *DrawShaderOptions) {
+	var layoutOp LayoutOptions
+	var drawOp ebiten.DrawTrianglesShaderOptions
+	var geoM ebiten.GeoM
+	var colorScale ebiten.ColorScale
+
+	if options != nil {
+		layoutOp = options.LayoutOptions
+		drawOp = options.DrawTrianglesShaderOptions
+		geoM = options.GeoM
+		colorScale = options.ColorScale
+	}
+
+	glyphs := AppendGlyphs(nil, text, face, &layoutOp)
+
+	// Calculate the bounding box of all the glyphs to normalize the positions.
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, g := range glyphs {
+		if g.Image == nil {
+			continue
+		}
+		b := g.Image.Bounds()
+		minX = math.Min(minX, g.X)
+		minY = math.Min(minY, g.Y)
+		maxX = math.Max(maxX, g.X+float64(b.Dx()))
+		maxY = math.Max(maxY, g.Y+float64(b.Dy()))
+	}
+	if minX > maxX {
+		return
+	}
+	w, h := maxX-minX, maxY-minY
+
+	cr, cg, cb, ca := colorScale.R(), colorScale.G(), colorScale.B(), colorScale.A()
+	vs := make([]ebiten.Vertex, 4)
+	is := []uint16{0, 1, 2, 1, 2, 3}
+	for _, g := range glyphs {
+		if g.Image == nil {
+			continue
+		}
+		b := g.Image.Bounds()
+		for i := range vs {
+			sx, sy := b.Min.X, b.Min.Y
+			if i&1 != 0 {
+				sx = b.Max.X
+			}
+			if i&2 != 0 {
+				sy = b.Max.Y
+			}
+			x := g.X + float64(sx-b.Min.X)
+			y := g.Y + float64(sy-b.Min.Y)
+			dx, dy := geoM.Apply(x, y)
+			vs[i] = ebiten.Vertex{
+				DstX:    float32(dx),
+				DstY:    float32(dy),
+				SrcX:    float32(sx),
+				SrcY:    float32(sy),
+				ColorR:  cr,
+				ColorG:  cg,
+				ColorB:  cb,
+				ColorA:  ca,
+				Custom0: float32(x),
+				Custom1: float32(y),
+				Custom2: float32(normalize(x-minX, w)),
+				Custom3: float32(normalize(y-minY, h)),
+			}
+		}
+		drawOp.Images[0] = g.Image
+		dst.DrawTrianglesShader(vs, is, shader, &drawOp)
+	}
+}
+
+func normalize(v, size float64) float64 {
+	if size == 0 {
+		return 0
+	}
+	return v / size
+}
+
 // AppendGlyphs appends glyphs to the given slice and returns a slice.
 //
 // AppendGlyphs is a low-level API, and you can use AppendGlyphs to have more control than Draw.
@@ -136,6 +452,12 @@ func AppendGlyphs(glyphs []Glyph, text string, face Face, options *LayoutOptions
 
 // AppndVectorPath appends a vector path for glyphs to the given path.
 //
+// The glyphs are positioned in the same way as Draw with the same options without any GeoM,
+// so the path and the rendering result by Draw line up.
+// Both quadratic (TrueType) and cubic (CFF) outlines, including composite glyphs, are supported.
+// Each contour is closed, and the contours keep the font's winding directions so that counters become holes
+// with the fill rule NonZero.
+//
 // AppendVectorPath works only when the face is *GoTextFace or a composite face using *GoTextFace so far.
 // For other types, AppendVectorPath does nothing.
 func AppendVectorPath(path *vector.Path, text string, face Face, options *LayoutOptions) {
@@ -144,6 +466,20 @@ func AppendVectorPath(path *vector.Path, text string, face Face, options *Layout
 	})
 }
 
+// AppendGlyphVectorPaths appends a vector path for each glyph to the given slice and returns a slice.
+//
+// AppendGlyphVectorPaths is useful to treat glyphs separately, e.g. to animate each letter.
+// The positions are the same as AppendVectorPath.
+//
+// AppendGlyphVectorPaths works only when the face is *GoTextFace or a composite face using *GoTextFace so far.
+// For other types, AppendGlyphVectorPaths appends nothing.
+func AppendGlyphVectorPaths(paths []GlyphVectorPath, text string, face Face, options *LayoutOptions) []GlyphVectorPath {
+	forEachLine(text, face, options, func(line string, indexOffset int, originX, originY float64) {
+		paths = face.appendGlyphVectorPathsForLine(paths, line, indexOffset, originX, originY)
+	})
+	return paths
+}
+
 // appendGlyphs appends glyphs to the given slice and returns a slice.
 //
 // appendGlyphs assumes the text is rendered with the position (x, y).
@@ -172,7 +508,7 @@ func forEachLine(text string, face Face, options *LayoutOptions, f func(text str
 	for t := text; ; {
 		lineCount++
 		line, rest, found := strings.Cut(t, "\n")
-		a := face.advance(line)
+		a := lineAdvance(line, face, options.TabWidth)
 		advances = append(advances, a)
 		if longestAdvance < a {
 			longestAdvance = a
@@ -198,10 +534,17 @@ func forEachLine(text string, face Face, options *LayoutOptions, f func(text str
 
 	var offsetX, offsetY float64
 
+	primaryAlign := options.PrimaryAlign
+	if primaryAlign == AlignBaseline {
+		primaryAlign = AlignStart
+	}
+
 	// Adjust the offset based on the secondary alignments.
-	h, v := calcAligns(d, options.PrimaryAlign, options.SecondaryAlign)
-	switch d {
-	case DirectionLeftToRight, DirectionRightToLeft:
+	// With AlignBaseline, the origin is already on the baseline of the first line.
+	h, v := calcAligns(d, primaryAlign, options.SecondaryAlign)
+	switch {
+	case options.SecondaryAlign == AlignBaseline:
+	case d == DirectionLeftToRight || d == DirectionRightToLeft:
 		offsetY += m.HAscent
 		switch v {
 		case verticalAlignTop:
@@ -210,7 +553,7 @@ func forEachLine(text string, face Face, options *LayoutOptions, f func(text str
 		case verticalAlignBottom:
 			offsetY -= boundaryHeight
 		}
-	case DirectionTopToBottomAndLeftToRight:
+	case d == DirectionTopToBottomAndLeftToRight:
 		// TODO: Perhaps HDescent should be used for sideways glyphs.
 		offsetX += m.VDescent
 		switch h {
@@ -220,7 +563,7 @@ func forEachLine(text string, face Face, options *LayoutOptions, f func(text str
 		case horizontalAlignRight:
 			offsetX -= boundaryWidth
 		}
-	case DirectionTopToBottomAndRightToLeft:
+	case d == DirectionTopToBottomAndRightToLeft:
 		// TODO: Perhaps HAscent should be used for sideways glyphs.
 		offsetX -= m.VAscent
 		switch h {
@@ -260,7 +603,20 @@ func forEachLine(text string, face Face, options *LayoutOptions, f func(text str
 			}
 		}
 
-		f(line, indexOffset, originX+offsetX, originY+offsetY)
+		lineAdvance := advances[i]
+		forEachTabSegment(line, face, options.TabWidth, func(segment string, segmentIndexOffset int, position, advance float64) {
+			x, y := originX+offsetX, originY+offsetY
+			switch d {
+			case DirectionLeftToRight:
+				x += position
+			case DirectionRightToLeft:
+				// The first segment is at the right end.
+				x += lineAdvance - position - advance
+			case DirectionTopToBottomAndLeftToRight, DirectionTopToBottomAndRightToLeft:
+				y += position
+			}
+			f(segment, indexOffset+segmentIndexOffset, x, y)
+		})
 
 		if !found {
 			break
@@ -66,12 +66,22 @@ func (l *LimitedFace) appendVectorPathForLine(path *vector.Path, line string, or
 	l.face.appendVectorPathForLine(path, l.unicodeRanges.filter(line), originX, originY)
 }
 
+// appendGlyphVectorPathsForLine implements Face.
+func (l *LimitedFace) appendGlyphVectorPathsForLine(paths []GlyphVectorPath, line string, indexOffset int, originX, originY float64) []GlyphVectorPath {
+	return l.face.appendGlyphVectorPathsForLine(paths, l.unicodeRanges.filter(line), indexOffset, originX, originY)
+}
+
 // direction implements Face.
 func (l *LimitedFace) direction() Direction {
 	return l.face.direction()
 }
 
 // private implements Face.
+// appendGlyphImageCaches implements Face.
+func (l *LimitedFace) appendGlyphImageCaches(caches []glyphImageCacheInterface) []glyphImageCacheInterface {
+	return l.face.appendGlyphImageCaches(caches)
+}
+
 func (l *LimitedFace) private() {
 }
 
@@ -16,8 +16,11 @@ package text
 
 import (
 	"errors"
+	"unicode"
 	"unicode/utf8"
 
+	"github.com/go-text/typesetting/segmenter"
+
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
@@ -26,6 +29,17 @@ var _ Face = (*MultiFace)(nil)
 // MultiFace is a Face that consists of multiple Face objects.
 // The face in the first index is used in the highest priority, and the last the lowest priority.
 //
+// MultiFace works as a font fallback chain.
+// A face is selected per grapheme cluster, not per string, so that a text mixing multiple languages and emojis
+// is rendered seamlessly.
+// For each cluster, the first face that has all the glyphs in the cluster is used.
+// If no face has the glyphs, the last face is used.
+//
+// The glyphs from the faces with different metrics share the same baseline,
+// and MultiFace's Metrics is the maximum of the faces' metrics.
+// Measure, Wrap and Draw agree with each other, as all of them use the same face selection.
+// Each glyph image is cached by the face actually used for the glyph.
+//
 // There is a known issue: if the writing directions of the faces don't agree, the rendering result might be messed up.
 type MultiFace struct {
 	faces []Face
@@ -138,6 +152,25 @@ func (m *MultiFace) appendVectorPathForLine(path *vector.Path, line string, orig
 	}
 }
 
+// appendGlyphVectorPathsForLine implements Face.
+func (m *MultiFace) appendGlyphVectorPathsForLine(paths []GlyphVectorPath, line string, indexOffset int, originX, originY float64) []GlyphVectorPath {
+	for _, c := range m.splitText(line) {
+		if c.faceIndex == -1 {
+			continue
+		}
+		f := m.faces[c.faceIndex]
+		t := line[c.textStartIndex:c.textEndIndex]
+		paths = f.appendGlyphVectorPathsForLine(paths, t, indexOffset, originX, originY)
+		if a := f.advance(t); f.direction().isHorizontal() {
+			originX += a
+		} else {
+			originY += a
+		}
+		indexOffset += len(t)
+	}
+	return paths
+}
+
 // direction implements Face.
 func (m *MultiFace) direction() Direction {
 	if len(m.faces) == 0 {
@@ -147,6 +180,14 @@ func (m *MultiFace) direction() Direction {
 }
 
 // private implements Face.
+// appendGlyphImageCaches implements Face.
+func (m *MultiFace) appendGlyphImageCaches(caches []glyphImageCacheInterface) []glyphImageCacheInterface {
+	for _, f := range m.faces {
+		caches = f.appendGlyphImageCaches(caches)
+	}
+	return caches
+}
+
 func (m *MultiFace) private() {
 }
 
@@ -159,27 +200,27 @@ type textChunk struct {
 func (m *MultiFace) splitText(text string) []textChunk {
 	var chunks []textChunk
 
-	for ri, r := range text {
-		fi := -1
-		_, l := utf8.DecodeRuneInString(text[ri:])
-		for i, f := range m.faces {
-			if !f.hasGlyph(r) && i < len(m.faces)-1 {
-				continue
-			}
-			fi = i
-			break
-		}
-		if fi == -1 {
-			panic("text: a face was not selected correctly")
+	runes := []rune(text)
+	var seg segmenter.Segmenter
+	seg.Init(runes)
+
+	// byteIndex is the index in bytes corresponding to the grapheme's offset in runes.
+	var byteIndex int
+	for iter := seg.GraphemeIterator(); iter.Next(); {
+		// Select a face per grapheme cluster so that a base character and its combining marks,
+		// or an emoji sequence, are rendered with the same face.
+		cluster := iter.Grapheme().Text
+		var l int
+		for _, r := range cluster {
+			l += utf8.RuneLen(r)
 		}
+		fi := m.selectFace(cluster)
 
-		var s int
-		if len(chunks) > 0 {
-			if chunks[len(chunks)-1].faceIndex == fi {
-				chunks[len(chunks)-1].textEndIndex += l
-				continue
-			}
-			s = chunks[len(chunks)-1].textEndIndex
+		s := byteIndex
+		byteIndex += l
+		if len(chunks) > 0 && chunks[len(chunks)-1].faceIndex == fi {
+			chunks[len(chunks)-1].textEndIndex += l
+			continue
 		}
 		chunks = append(chunks, textChunk{
 			textStartIndex: s,
@@ -190,3 +231,33 @@ func (m *MultiFace) splitText(text string) []textChunk {
 
 	return chunks
 }
+
+// selectFace returns the index of the face to render the given grapheme cluster.
+//
+// The first face that has all the glyphs in the cluster is selected.
+// Default-ignorable runes like zero width joiners and variation selectors are not taken into account.
+// If there is no such face, the first face that has the glyph for the base rune is selected.
+// If there is still no such face, the last face is selected.
+func (m *MultiFace) selectFace(cluster []rune) int {
+	for i, f := range m.faces {
+		ok := true
+		for _, r := range cluster {
+			if unicode.In(r, unicode.Other_Default_Ignorable_Code_Point, unicode.Variation_Selector, unicode.Join_Control) {
+				continue
+			}
+			if !f.hasGlyph(r) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return i
+		}
+	}
+	for i, f := range m.faces {
+		if f.hasGlyph(cluster[0]) {
+			return i
+		}
+	}
+	return len(m.faces) - 1
+}
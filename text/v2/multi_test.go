@@ -61,3 +61,34 @@ func TestMultiFaceFallback(t *testing.T) {
 		t.Errorf("got: %d, want: %d", len(got), len(want))
 	}
 }
+
+func TestMultiFaceFallbackPerCluster(t *testing.T) {
+	enFaceSource, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	enFace := &text.GoTextFace{
+		Source: enFaceSource,
+		Size:   10,
+	}
+	jaFace := text.NewGoXFace(bitmapfont.Face)
+	multiFace, err := text.NewMultiFace(enFace, jaFace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each cluster should be rendered with the first face that has the glyph.
+	str := "aあb"
+	gs := text.AppendGlyphs(nil, str, multiFace, nil)
+	if got, want := len(gs), 3; got != want {
+		t.Fatalf("len(gs): got: %d, want: %d", got, want)
+	}
+	for i, want := range [][2]int{{0, 1}, {1, 4}, {4, 5}} {
+		if got := [2]int{gs[i].StartIndexInBytes, gs[i].EndIndexInBytes}; got != want {
+			t.Errorf("gs[%d] indices: got: %v, want: %v", i, got, want)
+		}
+	}
+	if got, want := text.Advance(str, multiFace), text.Advance("a", enFace)+text.Advance("あ", jaFace)+text.Advance("b", enFace); got != want {
+		t.Errorf("Advance: got: %f, want: %f", got, want)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Run is a span of a text with its own face and color for DrawRuns and MeasureRuns.
+type Run struct {
+	// Text is the text of this run.
+	Text string
+
+	// Face is the font face for this run.
+	// Face must be a horizontal-direction face.
+	Face Face
+
+	// ColorScale is the color scale for this run.
+	// The default (zero) value is white.
+	ColorScale ebiten.ColorScale
+
+	// Offset returns the offset in pixels to render a glyph, e.g. for shaky or wavy letters.
+	// indexInBytes is the start index of the glyph in Text.
+	// The offset affects only the rendering of the glyph and doesn't affect the positions of the following glyphs.
+	//
+	// If Offset is nil, no offset is applied.
+	Offset func(indexInBytes int) (dx, dy float64)
+}
+
+// DrawRuns draws the given runs on a given destination image dst as one sequence of text from left to right.
+//
+// The rendering region's upper-left position is (0, 0) in the same way as Draw with the default alignments.
+// options.GeoM is an additional geometry transformation, and options.ColorScale scales the runs' colors.
+//
+// All the runs share the same baseline, and the baseline of the first line is at the maximum HAscent of the runs' faces.
+// Adjacent runs with the same face are shaped together, so kerning and ligatures work even at the boundary of runs.
+//
+// The '\n' newline character puts the following text on the next line.
+// The distance between two adjacent lines's baselines is the maximum of HAscent + HDescent + HLineGap of the runs' faces.
+//
+// If a run's face is not a horizontal-direction face, DrawRuns panics.
+//
+// DrawRuns is concurrent-safe.
+func DrawRuns(dst *ebiten.Image, runs []Run, options *ebiten.DrawImageOptions) {
+	var drawOp ebiten.DrawImageOptions
+	if options != nil {
+		drawOp = *options
+	}
+
+	geoM := drawOp.GeoM
+	colorScale := drawOp.ColorScale
+
+	// If GeoM is a translation, render glyphs at the fractional position with subpixel glyph images
+	// in the same way as Draw.
+	var x, y float64
+	if geoM.Element(0, 0) == 1 && geoM.Element(0, 1) == 0 && geoM.Element(1, 0) == 0 && geoM.Element(1, 1) == 1 {
+		tx, ty := geoM.Element(0, 2), geoM.Element(1, 2)
+		x, y = tx-math.Floor(tx), ty-math.Floor(ty)
+		geoM.Translate(-x, -y)
+	}
+
+	layoutRuns(runs, x, y, func(run *Run, g Glyph) {
+		if g.Image == nil {
+			return
+		}
+		drawOp.GeoM.Reset()
+		drawOp.GeoM.Translate(g.X, g.Y)
+		if run.Offset != nil {
+			drawOp.GeoM.Translate(run.Offset(g.StartIndexInBytes))
+		}
+		drawOp.GeoM.Concat(geoM)
+		drawOp.ColorScale = run.ColorScale
+		if g.IsColor {
+			// A color glyph is not tinted. Only the alpha is applied.
+			drawOp.ColorScale.Reset()
+			drawOp.ColorScale.ScaleAlpha(run.ColorScale.A())
+		}
+		drawOp.ColorScale.ScaleWithColorScale(colorScale)
+		dst.DrawImage(g.Image, &drawOp)
+	})
+}
+
+// MeasureRuns measures the boundary size of the given runs drawn by DrawRuns.
+//
+// The offsets by Run.Offset are not taken into account.
+//
+// If a run's face is not a horizontal-direction face, MeasureRuns panics.
+//
+// MeasureRuns is concurrent-safe.
+func MeasureRuns(runs []Run) (width, height float64) {
+	return layoutRuns(runs, 0, 0, nil)
+}
+
+// runsMetrics returns the ascent, the descent and the line spacing for the runs.
+func runsMetrics(runs []Run) (ascent, descent, lineSpacing float64) {
+	for i := range runs {
+		f := runs[i].Face
+		if !f.direction().isHorizontal() {
+			panic(fmt.Sprintf("text: the face of runs[%d] must be a horizontal-direction face", i))
+		}
+		m := f.Metrics()
+		ascent = math.Max(ascent, m.HAscent)
+		descent = math.Max(descent, m.HDescent)
+		lineSpacing = math.Max(lineSpacing, m.HAscent+m.HDescent+m.HLineGap)
+	}
+	return
+}
+
+// layoutRuns calls f for each glyph in runs with the glyph's indices in the run's text,
+// and returns the boundary size.
+// (x, y) is the upper-left position of the rendering region.
+//
+// f can be nil.
+func layoutRuns(runs []Run, x, y float64, f func(run *Run, glyph Glyph)) (width, height float64) {
+	if len(runs) == 0 {
+		return 0, 0
+	}
+
+	ascent, descent, lineSpacing := runsMetrics(runs)
+
+	originX, originY := x, y+ascent
+	lineCount := 1
+
+	var glyphs []Glyph
+	var starts []int
+	var sb strings.Builder
+	for i := 0; i < len(runs); {
+		// Concatenate the adjacent runs with the same face so that they are shaped together.
+		face := runs[i].Face
+		j := i + 1
+		for j < len(runs) && runs[j].Face == face {
+			j++
+		}
+		sb.Reset()
+		starts = starts[:0]
+		for k := i; k < j; k++ {
+			starts = append(starts, sb.Len())
+			sb.WriteString(runs[k].Text)
+		}
+		text := sb.String()
+
+		var indexOffset int
+		for t := text; ; {
+			line, rest, found := strings.Cut(t, "\n")
+			if line != "" {
+				if f != nil {
+					glyphs = face.appendGlyphsForLine(glyphs[:0], line, indexOffset, originX, originY)
+					for _, g := range glyphs {
+						k := len(starts) - 1
+						for starts[k] > g.StartIndexInBytes {
+							k--
+						}
+						g.StartIndexInBytes -= starts[k]
+						g.EndIndexInBytes -= starts[k]
+						f(&runs[i+k], g)
+					}
+				}
+				originX += face.advance(line)
+			}
+			width = math.Max(width, originX-x)
+			if !found {
+				break
+			}
+			originX = x
+			originY += lineSpacing
+			lineCount++
+			indexOffset += len(line) + 1
+			t = rest
+		}
+
+		i = j
+	}
+
+	height = float64(lineCount-1)*lineSpacing + ascent + descent
+	return width, height
+}
@@ -0,0 +1,457 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sync"
+
+	"github.com/go-text/typesetting/opentype/api"
+	"golang.org/x/image/math/fixed"
+	gvector "golang.org/x/image/vector"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	defaultSDFFieldSize = 64
+
+	// SDFMinimumSize is the recommended minimum size of SDFFace in pixels.
+	// Below this size, a regular GoTextFace looks better, as its glyph images are rasterized for the exact size.
+	SDFMinimumSize = 12
+)
+
+var _ Face = (*SDFFace)(nil)
+
+// SDFFace is a Face that renders glyphs with signed distance fields (SDF).
+//
+// Glyphs of an SDFFace are rasterized once into distance fields at FieldSize,
+// and rendered with a shader that keeps the edges crisp at any scale.
+// Changing the underlying face's Size or scaling text with GeoM doesn't create new glyph images.
+// This is useful to zoom text smoothly, from tiny labels to full-screen titles.
+//
+// For small text below SDFMinimumSize pixels, a regular GoTextFace is recommended.
+//
+// Draw renders an SDFFace with the distance field shader.
+// The measurement functions like Measure and Wrap work in the same way as the underlying face.
+// AppendGlyphs returns glyphs whose Image is a distance field at FieldSize.
+// Such an image must be scaled by Face.Size / FieldSize to fit with the glyph position.
+// DrawWithShader doesn't support an SDFFace.
+//
+// Color bitmap glyphs like emojis are not rendered with an SDFFace.
+type SDFFace struct {
+	// Face is the underlying face.
+	// Face's Size is the rendering size.
+	Face *GoTextFace
+
+	// FieldSize is the font size in pixels to rasterize distance fields.
+	// The bigger FieldSize is, the more accurate the glyphs' corners are, at the cost of memory.
+	// The default (zero) value is 64.
+	FieldSize float64
+
+	// Spread is the maximum distance in pixels at FieldSize encoded in distance fields.
+	// Spread limits the maximum outline width.
+	// The default (zero) value is FieldSize / 8.
+	Spread float64
+
+	// OutlineWidth is the width in pixels of the outline at the rendering size.
+	// The outline is rendered from the same distance field.
+	// OutlineWidth is limited by Spread * Face.Size / FieldSize.
+	// The default (zero) value means no outline.
+	OutlineWidth float64
+
+	// OutlineColor is the color of the outline.
+	// If OutlineColor is nil, black is used.
+	OutlineColor color.Color
+
+	glyphImageCache glyphImageCache[sdfGlyphImageCacheKey]
+}
+
+type sdfGlyphImageCacheKey struct {
+	gid        api.GID
+	variations string
+	fieldSize  float64
+	spread     float64
+}
+
+func (s *SDFFace) fieldSize() float64 {
+	if s.FieldSize > 0 {
+		return s.FieldSize
+	}
+	return defaultSDFFieldSize
+}
+
+func (s *SDFFace) spread() float64 {
+	if s.Spread > 0 {
+		return s.Spread
+	}
+	return s.fieldSize() / 8
+}
+
+// Metrics implements Face.
+func (s *SDFFace) Metrics() Metrics {
+	return s.Face.Metrics()
+}
+
+// advance implements Face.
+func (s *SDFFace) advance(text string) float64 {
+	return s.Face.advance(text)
+}
+
+// hasGlyph implements Face.
+func (s *SDFFace) hasGlyph(r rune) bool {
+	return s.Face.hasGlyph(r)
+}
+
+// appendGlyphsForLine implements Face.
+func (s *SDFFace) appendGlyphsForLine(glyphs []Glyph, line string, indexOffset int, originX, originY float64) []Glyph {
+	f := s.Face
+	scale := f.Size / s.fieldSize()
+	pad := math.Ceil(s.spread())
+
+	origin := fixed.Point26_6{
+		X: float64ToFixed26_6(originX),
+		Y: float64ToFixed26_6(originY),
+	}
+	_, gs := f.Source.shape(line, f)
+	for _, glyph := range gs {
+		var img *ebiten.Image
+		if glyph.bitmap == nil {
+			img = s.glyphImage(glyph, scale)
+		}
+		pos := origin.Add(fixed.Point26_6{
+			X: glyph.shapingGlyph.XOffset,
+			Y: -glyph.shapingGlyph.YOffset,
+		})
+		// A distance field is not snapped to the pixel grid, as it is scalable.
+		glyphs = append(glyphs, Glyph{
+			StartIndexInBytes: indexOffset + glyph.startIndex,
+			EndIndexInBytes:   indexOffset + glyph.endIndex,
+			GID:               uint32(glyph.shapingGlyph.GlyphID),
+			Image:             img,
+			X:                 fixed26_6ToFloat64(pos.X+glyph.bounds.Min.X) - pad*scale,
+			Y:                 fixed26_6ToFloat64(pos.Y+glyph.bounds.Min.Y) - pad*scale,
+			OriginX:           fixed26_6ToFloat64(origin.X),
+			OriginY:           fixed26_6ToFloat64(origin.Y),
+			Advance:           f.glyphAdvance(glyph),
+		})
+		origin = origin.Add(fixed.Point26_6{
+			X: glyph.shapingGlyph.XAdvance,
+			Y: -glyph.shapingGlyph.YAdvance,
+		})
+	}
+	return glyphs
+}
+
+func (s *SDFFace) glyphImage(glyph glyph, scale float64) *ebiten.Image {
+	key := sdfGlyphImageCacheKey{
+		gid:        glyph.shapingGlyph.GlyphID,
+		variations: s.Face.ensureVariationsString(),
+		fieldSize:  s.fieldSize(),
+		spread:     s.spread(),
+	}
+	return s.glyphImageCache.getOrCreate(s, key, func() *ebiten.Image {
+		return segmentsToDistanceField(glyph.scaledSegments, glyph.bounds, 1/scale, s.spread())
+	})
+}
+
+// appendVectorPathForLine implements Face.
+func (s *SDFFace) appendVectorPathForLine(path *vector.Path, line string, originX, originY float64) {
+	s.Face.appendVectorPathForLine(path, line, originX, originY)
+}
+
+// appendGlyphVectorPathsForLine implements Face.
+func (s *SDFFace) appendGlyphVectorPathsForLine(paths []GlyphVectorPath, line string, indexOffset int, originX, originY float64) []GlyphVectorPath {
+	return s.Face.appendGlyphVectorPathsForLine(paths, line, indexOffset, originX, originY)
+}
+
+// direction implements Face.
+func (s *SDFFace) direction() Direction {
+	return s.Face.direction()
+}
+
+// private implements Face.
+// appendGlyphImageCaches implements Face.
+func (s *SDFFace) appendGlyphImageCaches(caches []glyphImageCacheInterface) []glyphImageCacheInterface {
+	return append(caches, &s.glyphImageCache)
+}
+
+func (s *SDFFace) private() {
+}
+
+// segmentsToDistanceField rasterizes the segments scaled by scale, and converts the result into a signed distance field.
+//
+// The field value at the edge is 0.5, and the value increases inside the glyph.
+// The value changes by 0.5 / spread per pixel. The field has a padding of ceil(spread) pixels on each side.
+func segmentsToDistanceField(segs []api.Segment, bounds fixed.Rectangle26_6, scale float64, spread float64) *ebiten.Image {
+	if len(segs) == 0 {
+		return nil
+	}
+
+	minX := fixed26_6ToFloat64(bounds.Min.X) * scale
+	minY := fixed26_6ToFloat64(bounds.Min.Y) * scale
+	maxX := fixed26_6ToFloat64(bounds.Max.X) * scale
+	maxY := fixed26_6ToFloat64(bounds.Max.Y) * scale
+	pad := math.Ceil(spread)
+	w := int(math.Ceil(maxX-minX) + 2*pad)
+	h := int(math.Ceil(maxY-minY) + 2*pad)
+	if w <= 0 || h <= 0 {
+		return nil
+	}
+
+	biasX := float32(pad - minX)
+	biasY := float32(pad - minY)
+	sc := float32(scale)
+
+	rast := gvector.NewRasterizer(w, h)
+	rast.DrawOp = draw.Src
+	for _, seg := range segs {
+		switch seg.Op {
+		case api.SegmentOpMoveTo:
+			rast.MoveTo(seg.Args[0].X*sc+biasX, seg.Args[0].Y*sc+biasY)
+		case api.SegmentOpLineTo:
+			rast.LineTo(seg.Args[0].X*sc+biasX, seg.Args[0].Y*sc+biasY)
+		case api.SegmentOpQuadTo:
+			rast.QuadTo(
+				seg.Args[0].X*sc+biasX, seg.Args[0].Y*sc+biasY,
+				seg.Args[1].X*sc+biasX, seg.Args[1].Y*sc+biasY,
+			)
+		case api.SegmentOpCubeTo:
+			rast.CubeTo(
+				seg.Args[0].X*sc+biasX, seg.Args[0].Y*sc+biasY,
+				seg.Args[1].X*sc+biasX, seg.Args[1].Y*sc+biasY,
+				seg.Args[2].X*sc+biasX, seg.Args[2].Y*sc+biasY,
+			)
+		}
+	}
+	rast.ClosePath()
+
+	mask := image.NewAlpha(image.Rect(0, 0, w, h))
+	rast.Draw(mask, mask.Bounds(), image.Opaque, image.Point{})
+
+	// Calculate the distances to the nearest inside pixels and the nearest outside pixels.
+	inside := make([]float64, w*h)
+	outside := make([]float64, w*h)
+	for i, a := range mask.Pix {
+		if a >= 0x80 {
+			inside[i] = math.Inf(1)
+		} else {
+			outside[i] = math.Inf(1)
+		}
+	}
+	distanceTransform(inside, w, h)
+	distanceTransform(outside, w, h)
+
+	pix := make([]byte, 4*w*h)
+	for i := range mask.Pix {
+		// The edge is at the middle of the pixels, then subtract 0.5.
+		var d float64
+		if mask.Pix[i] >= 0x80 {
+			d = math.Sqrt(inside[i]) - 0.5
+		} else {
+			d = -(math.Sqrt(outside[i]) - 0.5)
+		}
+		v := 0.5 + d/(2*spread)
+		v = math.Min(math.Max(v, 0), 1)
+		b := byte(math.Round(v * 0xff))
+		pix[4*i] = b
+		pix[4*i+1] = b
+		pix[4*i+2] = b
+		pix[4*i+3] = b
+	}
+
+	img := ebiten.NewImage(w, h)
+	img.WritePixels(pix)
+	return img
+}
+
+// distanceTransform calculates the squared Euclidean distance transform in place.
+// A zero value in grid indicates a feature pixel, and an infinity value indicates a non-feature pixel.
+//
+// See Pedro F. Felzenszwalb and Daniel P. Huttenlocher, "Distance Transforms of Sampled Functions".
+func distanceTransform(grid []float64, width, height int) {
+	n := width
+	if n < height {
+		n = height
+	}
+	f := make([]float64, n)
+	d := make([]float64, n)
+	v := make([]int, n)
+	z := make([]float64, n+1)
+
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			f[y] = grid[y*width+x]
+		}
+		distanceTransform1D(f[:height], d, v, z)
+		for y := 0; y < height; y++ {
+			grid[y*width+x] = d[y]
+		}
+	}
+	for y := 0; y < height; y++ {
+		copy(f, grid[y*width:(y+1)*width])
+		distanceTransform1D(f[:width], d, v, z)
+		copy(grid[y*width:(y+1)*width], d[:width])
+	}
+}
+
+func distanceTransform1D(f []float64, d []float64, v []int, z []float64) {
+	n := len(f)
+
+	// Compute the lower envelope of the parabolas rooted at the finite values.
+	k := -1
+	for q := 0; q < n; q++ {
+		if math.IsInf(f[q], 1) {
+			continue
+		}
+		if k == -1 {
+			k = 0
+			v[0] = q
+			z[0] = math.Inf(-1)
+			z[1] = math.Inf(1)
+			continue
+		}
+		s := intersectParabolas(f, q, v[k])
+		for s <= z[k] {
+			k--
+			s = intersectParabolas(f, q, v[k])
+		}
+		k++
+		v[k] = q
+		z[k] = s
+		z[k+1] = math.Inf(1)
+	}
+
+	// If there is no finite value, all the distances are infinite.
+	if k == -1 {
+		for q := 0; q < n; q++ {
+			d[q] = math.Inf(1)
+		}
+		return
+	}
+
+	k = 0
+	for q := 0; q < n; q++ {
+		for z[k+1] < float64(q) {
+			k++
+		}
+		p := v[k]
+		d[q] = float64((q-p)*(q-p)) + f[p]
+	}
+}
+
+func intersectParabolas(f []float64, q, p int) float64 {
+	return ((f[q] + float64(q*q)) - (f[p] + float64(p*p))) / float64(2*q-2*p)
+}
+
+var sdfShaderSrc = []byte(`//kage:unit pixels
+
+package main
+
+var Smoothing float
+var OutlineThreshold float
+var OutlineColor vec4
+
+func sampleField(pos vec2) float {
+	p := pos - 0.5
+	p0 := floor(p) + 0.5
+	p1 := p0 + 1
+	rate := fract(p)
+	c0 := imageSrc0At(p0).a
+	c1 := imageSrc0At(vec2(p1.x, p0.y)).a
+	c2 := imageSrc0At(vec2(p0.x, p1.y)).a
+	c3 := imageSrc0At(p1).a
+	return mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	d := sampleField(srcPos)
+	alpha := smoothstep(0.5-Smoothing, 0.5+Smoothing, d)
+	if OutlineThreshold < 0.5 {
+		outlineAlpha := smoothstep(OutlineThreshold-Smoothing, OutlineThreshold+Smoothing, d)
+		return mix(OutlineColor*outlineAlpha, color, alpha)
+	}
+	return color * alpha
+}
+`)
+
+var (
+	sdfShader     *ebiten.Shader
+	sdfShaderOnce sync.Once
+)
+
+func ensureSDFShader() *ebiten.Shader {
+	sdfShaderOnce.Do(func() {
+		s, err := ebiten.NewShader(sdfShaderSrc)
+		if err != nil {
+			panic(fmt.Sprintf("text: NewShader for the SDF shader failed: %v", err))
+		}
+		sdfShader = s
+	})
+	return sdfShader
+}
+
+// drawSDF draws a text with an SDFFace.
+func drawSDF(dst *ebiten.Image, text string, face *SDFFace, layoutOp *LayoutOptions, drawOp *ebiten.DrawImageOptions) {
+	scale := face.Face.Size / face.fieldSize()
+	spread := face.spread()
+
+	// Calculate the smoothing width from the size of one destination pixel in the distance field.
+	geoMScale := math.Sqrt(math.Abs(drawOp.GeoM.Element(0, 0)*drawOp.GeoM.Element(1, 1) - drawOp.GeoM.Element(0, 1)*drawOp.GeoM.Element(1, 0)))
+	if geoMScale == 0 {
+		return
+	}
+	pixelInField := 1 / (scale * geoMScale)
+	smoothing := math.Min(pixelInField/(4*spread), 0.5)
+
+	outlineThreshold := 0.5
+	if face.OutlineWidth > 0 {
+		outlineThreshold = math.Max(0.5-face.OutlineWidth/scale/(2*spread), 0)
+	}
+	oc := face.OutlineColor
+	if oc == nil {
+		oc = color.Black
+	}
+	or, og, ob, oa := oc.RGBA()
+
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	op.Blend = drawOp.Blend
+	op.Uniforms = map[string]any{
+		"Smoothing":        float32(smoothing),
+		"OutlineThreshold": float32(outlineThreshold),
+		"OutlineColor":     []float32{float32(or) / 0xffff, float32(og) / 0xffff, float32(ob) / 0xffff, float32(oa) / 0xffff},
+	}
+
+	shader := ensureSDFShader()
+	var vs []ebiten.Vertex
+	is := ebiten.AppendRectIndices(nil, 0)
+	for _, g := range AppendGlyphs(nil, text, face, layoutOp) {
+		if g.Image == nil {
+			continue
+		}
+		var geoM ebiten.GeoM
+		geoM.Scale(scale, scale)
+		geoM.Translate(g.X, g.Y)
+		geoM.Concat(drawOp.GeoM)
+		vs = ebiten.AppendRectVertices(vs[:0], g.Image.Bounds(), &geoM, &drawOp.ColorScale)
+		op.Images[0] = g.Image
+		dst.DrawTrianglesShader(vs, is, shader, op)
+	}
+}
@@ -38,9 +38,12 @@ type Face interface {
 
 	appendGlyphsForLine(glyphs []Glyph, line string, indexOffset int, originX, originY float64) []Glyph
 	appendVectorPathForLine(path *vector.Path, line string, originX, originY float64)
+	appendGlyphVectorPathsForLine(paths []GlyphVectorPath, line string, indexOffset int, originX, originY float64) []GlyphVectorPath
 
 	direction() Direction
 
+	appendGlyphImageCaches(caches []glyphImageCacheInterface) []glyphImageCacheInterface
+
 	// private is an unexported function preventing being implemented by other packages.
 	private()
 }
@@ -87,7 +90,24 @@ func float64ToFixed26_6(x float64) fixed.Int26_6 {
 	return fixed.Int26_6(x * (1 << 6))
 }
 
+// maxSubpixelPhases is the maximum number of subpixel phases, which is the precision of the 26.6 fixed-point numbers.
+const maxSubpixelPhases = 1 << 6
+
 func glyphVariationCount(face Face) int {
+	var phases int
+	switch f := face.(type) {
+	case *GoTextFace:
+		phases = f.SubpixelPhases
+	case *GoXFace:
+		phases = f.subpixelPhases
+	}
+	if phases > maxSubpixelPhases {
+		phases = maxSubpixelPhases
+	}
+	if phases > 0 {
+		return phases
+	}
+
 	var s float64
 	if m := face.Metrics(); face.direction().isHorizontal() {
 		s = m.HAscent + m.HDescent
@@ -108,9 +128,45 @@ func glyphVariationCount(face Face) int {
 }
 
 func adjustGranularity(x fixed.Int26_6, face Face) fixed.Int26_6 {
-	c := glyphVariationCount(face)
-	factor := (1 << 6) / fixed.Int26_6(c)
-	return x / factor * factor
+	return quantizeSubpixel(x, glyphVariationCount(face))
+}
+
+// quantizeSubpixel floors x to one of the given number of phases in a pixel.
+// The number of phases doesn't have to be a power of two.
+func quantizeSubpixel(x fixed.Int26_6, phases int) fixed.Int26_6 {
+	// Calculate with int64 values to avoid overflow.
+	c := int64(phases)
+	// The right shift floors also negative values.
+	q := (int64(x) * c) >> 6
+	v := (q << 6) / c
+	if (q<<6)%c < 0 {
+		v--
+	}
+	return fixed.Int26_6(v)
+}
+
+func secondaryGlyphVariationCount(face Face) int {
+	f, ok := face.(*GoTextFace)
+	if !ok {
+		return 1
+	}
+	// Hinting snaps the outlines to the pixel grid, and a secondary phase would shift them again.
+	if f.Hinting == HintingVertical && f.direction().isHorizontal() {
+		return 1
+	}
+	phases := f.SecondarySubpixelPhases
+	if phases > maxSubpixelPhases {
+		phases = maxSubpixelPhases
+	}
+	if phases < 1 {
+		phases = 1
+	}
+	return phases
+}
+
+// adjustSecondaryGranularity quantizes x in the secondary direction by flooring.
+func adjustSecondaryGranularity(x fixed.Int26_6, face Face) fixed.Int26_6 {
+	return quantizeSubpixel(x, secondaryGlyphVariationCount(face))
 }
 
 // Glyph represents one glyph to render.
@@ -125,10 +181,16 @@ type Glyph struct {
 	GID uint32
 
 	// Image is a rasterized glyph image.
-	// Image is a grayscale image i.e. RGBA values are the same.
+	// Image is a grayscale image i.e. RGBA values are the same, unless IsColor is true.
 	// Image should be used as a render source and should not be modified.
 	Image *ebiten.Image
 
+	// IsColor reports whether Image is a full-color image like an emoji.
+	//
+	// A color glyph comes from a color bitmap table (CBDT/CBLC or sbix) of a GoTextFace.
+	// COLR/CPAL layered glyphs are not supported so far and are rendered as grayscale outlines.
+	IsColor bool
+
 	// X is the X position to render this glyph.
 	// The position is determined in a sequence of characters given at AppendGlyphs.
 	// The position's origin is the first character's origin position.
@@ -138,6 +200,38 @@ type Glyph struct {
 	// The position is determined in a sequence of characters given at AppendGlyphs.
 	// The position's origin is the first character's origin position.
 	Y float64
+
+	// OriginX and OriginY are the position of this glyph's origin on the baseline,
+	// i.e. the caret position before this glyph.
+	// The position's origin is the same as X and Y.
+	//
+	// OriginX and OriginY are useful to place a caret and to hit-test a position to a character index.
+	// The bounding box of the glyph is the Image's bounds put at (X, Y).
+	OriginX float64
+	OriginY float64
+
+	// Advance is the advance of this glyph in the primary direction.
+	// The caret after this glyph is at the origin moved by Advance in the primary direction,
+	// unless the next glyph is kerned.
+	Advance float64
+}
+
+// GlyphVectorPath represents a vector path of one glyph.
+type GlyphVectorPath struct {
+	// StartIndexInBytes is the start index in bytes for the given string at AppendGlyphVectorPaths.
+	StartIndexInBytes int
+
+	// EndIndexInBytes is the end index in bytes for the given string at AppendGlyphVectorPaths.
+	EndIndexInBytes int
+
+	// GID is an ID for a glyph of TrueType or OpenType font.
+	GID uint32
+
+	// Path is the outline of the glyph.
+	// The position is the same as the glyph's outline in the path by AppendVectorPath for the same text and options.
+	//
+	// Path has no subpaths if the glyph doesn't have an outline, like a space or a color bitmap glyph.
+	Path *vector.Path
 }
 
 // Advance returns the advanced distance from the origin position when rendering the given text with the given face.
@@ -171,6 +265,11 @@ const (
 	// DirectionTopToBottomAndRightToLeft indicates that the primary direction is from top to bottom,
 	// and the secondary direction is from right to left.
 	// This is used e.g. for Japanese.
+	//
+	// With GoTextFace, glyphs are advanced downward with the font's vertical metrics,
+	// vertical alternates are substituted by the 'vert' feature when the font has it,
+	// and glyphs without vertical forms like Latin letters are rotated 90 degrees clockwise.
+	// To wrap a text into columns, use Wrap.
 	DirectionTopToBottomAndRightToLeft
 )
 
@@ -186,8 +285,28 @@ func (d Direction) isHorizontal() bool {
 // With a horizontal direction face, the width is the longest line's advance, and the height is the total of line heights.
 // With a vertical direction face, the width and the height are calculated in an opposite manner.
 //
+// Measure treats a tab character as a regular character. To measure a text with tab stops, use MeasureWithOptions.
+//
 // Measure is concurrent-safe.
 func Measure(text string, face Face, lineSpacingInPixels float64) (width, height float64) {
+	return measure(text, face, lineSpacingInPixels, 0)
+}
+
+// MeasureWithOptions measures the boundary size of the text with the given layout options.
+// The alignments in options don't affect the size.
+//
+// options can be nil.
+//
+// MeasureWithOptions is concurrent-safe.
+func MeasureWithOptions(text string, face Face, options *LayoutOptions) (width, height float64) {
+	if options == nil {
+		return measure(text, face, 0, 0)
+	}
+	return measure(text, face, options.LineSpacing, options.TabWidth)
+}
+
+// measure measures the boundary size of the text with the tab stops.
+func measure(text string, face Face, lineSpacingInPixels float64, tabWidth float64) (width, height float64) {
 	if text == "" {
 		return 0, 0
 	}
@@ -197,7 +316,7 @@ func Measure(text string, face Face, lineSpacingInPixels float64) (width, height
 	for t := text; ; {
 		lineCount++
 		line, rest, found := strings.Cut(t, "\n")
-		a := face.advance(line)
+		a := lineAdvance(line, face, tabWidth)
 		if primary < a {
 			primary = a
 		}
@@ -224,6 +343,8 @@ func Measure(text string, face Face, lineSpacingInPixels float64) (width, height
 // Glyphs used for rendering are cached in the least-recently-used way.
 // Then old glyphs might be evicted from the cache.
 // As the cache capacity has limitations, it is not guaranteed that all the glyphs for runes given at CacheGlyphs are cached.
+// To keep the pre-cached glyphs e.g. from a loading screen, set a big enough budget by SetGlyphCacheBudget.
+// GlyphCacheUsage and EvictGlyphs are useful to inspect and free the cache.
 // The cache is shared with Draw and AppendGlyphs.
 //
 // One rune can have multiple variations of glyphs due to sub-pixels in X or Y direction.
@@ -240,17 +361,19 @@ func CacheGlyphs(text string, face Face) {
 	var x, y float64
 
 	c := glyphVariationCount(face)
+	sc := secondaryGlyphVariationCount(face)
 
 	var buf []Glyph
 	// Create all the possible variations (#2528).
-	for i := 0; i < c; i++ {
-		buf = appendGlyphs(buf, text, face, x, y, nil)
-		buf = buf[:0]
-
-		if face.direction().isHorizontal() {
-			x += 1.0 / float64(c)
-		} else {
-			y += 1.0 / float64(c)
+	for j := 0; j < sc; j++ {
+		for i := 0; i < c; i++ {
+			if face.direction().isHorizontal() {
+				x, y = float64(i)/float64(c), float64(j)/float64(sc)
+			} else {
+				x, y = float64(j)/float64(sc), float64(i)/float64(c)
+			}
+			buf = appendGlyphs(buf, text, face, x, y, nil)
+			buf = buf[:0]
 		}
 	}
 }
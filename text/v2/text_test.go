@@ -15,19 +15,25 @@
 package text_test
 
 import (
+	"bytes"
 	"image"
 	"image/color"
+	"math"
+	"reflect"
 	"regexp"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/hajimehoshi/bitmapfont/v3"
 	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
 	"golang.org/x/image/math/fixed"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 func TestMain(m *testing.M) {
@@ -172,6 +178,28 @@ func TestNegativeKern(t *testing.T) {
 	}
 }
 
+func TestGlyphOrigin(t *testing.T) {
+	f := text.NewGoXFace(&testGoXFace{})
+	gs := text.AppendGlyphs(nil, "abc", f, nil)
+	if got, want := len(gs), 3; got != want {
+		t.Fatalf("len(gs): got: %d, want: %d", got, want)
+	}
+
+	// With testGoXFace, 'b' is kerned back to the origin of 'a'.
+	wantOriginXs := []float64{0, 0, testGoXFaceSize}
+	for i, g := range gs {
+		if got, want := g.OriginX, wantOriginXs[i]; got != want {
+			t.Errorf("gs[%d].OriginX: got: %f, want: %f", i, got, want)
+		}
+		if got, want := g.OriginY, float64(0); got != want {
+			t.Errorf("gs[%d].OriginY: got: %f, want: %f", i, got, want)
+		}
+		if got, want := g.Advance, float64(testGoXFaceSize); got != want {
+			t.Errorf("gs[%d].Advance: got: %f, want: %f", i, got, want)
+		}
+	}
+}
+
 type unhashableGoXFace func()
 
 const unhashableGoXFaceSize = 10
@@ -371,3 +399,602 @@ func TestDrawOptionsNotModified(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestDrawWithShader(t *testing.T) {
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4) vec4 {
+	return vec4(custom.z, custom.w, 0, 1)
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const str = "Hello"
+	f := text.NewGoXFace(bitmapfont.Face)
+	glyphs := text.AppendGlyphs(nil, str, f, nil)
+	first, last := glyphs[0], glyphs[len(glyphs)-1]
+
+	dst := ebiten.NewImage(100, 100)
+	op := &text.DrawShaderOptions{}
+	op.GeoM.Translate(10, 10)
+	text.DrawWithShader(dst, str, f, s, op)
+
+	// The left end of the first glyph and the right end of the last glyph correspond to the both ends of the gradient.
+	x0 := 10 + int(first.X)
+	y0 := 10 + int(first.Y) + first.Image.Bounds().Dy()/2
+	if got := dst.At(x0, y0).(color.RGBA); got.R > 0x10 || got.A != 0xff {
+		t.Errorf("dst.At(%d, %d): got: %v, want: R <= 0x10 and A == 0xff", x0, y0, got)
+	}
+	x1 := 10 + int(last.X) + last.Image.Bounds().Dx() - 1
+	y1 := 10 + int(last.Y) + last.Image.Bounds().Dy()/2
+	if got := dst.At(x1, y1).(color.RGBA); got.R < 0xef || got.A != 0xff {
+		t.Errorf("dst.At(%d, %d): got: %v, want: R >= 0xef and A == 0xff", x1, y1, got)
+	}
+}
+
+func TestDrawWithShaderExtraImage(t *testing.T) {
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4) vec4 {
+	return vec4(imageSrc1At(imageSrc1Origin() + custom.xy).rgb, 1)
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const str = "Hello"
+	f := text.NewGoXFace(bitmapfont.Face)
+	glyphs := text.AppendGlyphs(nil, str, f, nil)
+	first := glyphs[0]
+
+	// The texture's size differs from the glyph images' sizes.
+	texture := ebiten.NewImage(100, 100)
+	texture.Fill(color.RGBA{G: 0xff, A: 0xff})
+
+	dst := ebiten.NewImage(100, 100)
+	op := &text.DrawShaderOptions{}
+	op.GeoM.Translate(10, 10)
+	op.Images[1] = texture
+	text.DrawWithShader(dst, str, f, s, op)
+
+	x := 10 + int(first.X)
+	y := 10 + int(first.Y) + first.Image.Bounds().Dy()/2
+	if got, want := dst.At(x, y).(color.RGBA), (color.RGBA{G: 0xff, A: 0xff}); got != want {
+		t.Errorf("dst.At(%d, %d): got: %v, want: %v", x, y, got, want)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	// bitmapfont.Face's ASCII glyphs are 6 pixels wide and CJK glyphs are 12 pixels wide.
+	f := text.NewGoXFace(bitmapfont.Face)
+
+	testCases := []struct {
+		In      string
+		Options *text.WrapOptions
+		Out     []string
+	}{
+		{
+			In:      "Hello World",
+			Options: nil,
+			Out:     []string{"Hello World"},
+		},
+		{
+			In:      "Hello World\nFoo",
+			Options: &text.WrapOptions{MaxAdvance: 48},
+			Out:     []string{"Hello", "World", "Foo"},
+		},
+		{
+			In:      "こんにちは、世界",
+			Options: &text.WrapOptions{MaxAdvance: 48},
+			Out:     []string{"こんにち", "は、世界"},
+		},
+		{
+			In:      "Supercalifragilistic",
+			Options: &text.WrapOptions{MaxAdvance: 48, Overflow: text.OverflowBreak},
+			Out:     []string{"Supercal", "ifragili", "stic"},
+		},
+		{
+			In:      "aa   bb",
+			Options: &text.WrapOptions{MaxAdvance: 12},
+			Out:     []string{"aa", "bb"},
+		},
+		{
+			In:      "a\n\nbb cc",
+			Options: &text.WrapOptions{MaxAdvance: 12},
+			Out:     []string{"a", "", "bb", "cc"},
+		},
+		{
+			In:      "abcdefghij xy",
+			Options: &text.WrapOptions{MaxAdvance: 30},
+			Out:     []string{"abcdefghij", "xy"},
+		},
+		{
+			In:      "「あいう」えお",
+			Options: &text.WrapOptions{MaxAdvance: 36},
+			Out:     []string{"「あい", "う」え", "お"},
+		},
+	}
+	for _, tc := range testCases {
+		if got, want := text.Wrap(tc.In, f, tc.Options), tc.Out; !reflect.DeepEqual(got, want) {
+			t.Errorf("Wrap(%q, %v): got: %q, want: %q", tc.In, tc.Options, got, want)
+		}
+	}
+}
+
+func TestAppendGlyphVectorPaths(t *testing.T) {
+	source, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	face := &text.GoTextFace{
+		Source: source,
+		Size:   64,
+	}
+
+	const str = "o o"
+	paths := text.AppendGlyphVectorPaths(nil, str, face, nil)
+	if got, want := len(paths), 3; got != want {
+		t.Fatalf("len(paths): got: %d, want: %d", got, want)
+	}
+	for i, p := range paths {
+		if got, want := p.StartIndexInBytes, i; got != want {
+			t.Errorf("paths[%d].StartIndexInBytes: got: %d, want: %d", i, got, want)
+		}
+		if got, want := p.EndIndexInBytes, i+1; got != want {
+			t.Errorf("paths[%d].EndIndexInBytes: got: %d, want: %d", i, got, want)
+		}
+	}
+
+	// A space doesn't have an outline.
+	if x0, y0, x1, y1 := paths[1].Path.Bounds(); x0 != 0 || y0 != 0 || x1 != 0 || y1 != 0 {
+		t.Errorf("paths[1].Path.Bounds(): got: (%v, %v, %v, %v), want: zeros", x0, y0, x1, y1)
+	}
+
+	// The glyph 'o' has a counter, which must be a hole with the fill rule NonZero.
+	for _, i := range []int{0, 2} {
+		p := paths[i].Path
+		x0, y0, x1, y1 := p.Bounds()
+		cx, cy := (x0+x1)/2, (y0+y1)/2
+		if p.Contains(cx, cy, vector.FillRuleNonZero) {
+			t.Errorf("paths[%d].Path.Contains(%v, %v): got: true, want: false", i, cx, cy)
+		}
+		if !p.Contains(x0+2, cy, vector.FillRuleNonZero) {
+			t.Errorf("paths[%d].Path.Contains(%v, %v): got: false, want: true", i, x0+2, cy)
+		}
+		// The glyph is above the baseline and below the top of the line.
+		if y0 < 0 || y1 > float32(face.Metrics().HAscent)+2 {
+			t.Errorf("paths[%d].Path.Bounds(): got: y0 = %v, y1 = %v", i, y0, y1)
+		}
+	}
+
+	// The second 'o' is positioned after the first 'o' and the space.
+	x00, _, _, _ := paths[0].Path.Bounds()
+	x20, _, _, _ := paths[2].Path.Bounds()
+	if got, want := float64(x20-x00), text.Advance("o ", face); math.Abs(got-want) > 0.1 {
+		t.Errorf("distance between the glyphs: got: %v, want: %v", got, want)
+	}
+
+	// The paths match the path by AppendVectorPath.
+	var path vector.Path
+	text.AppendVectorPath(&path, str, face, nil)
+	gx0, gy0, gx1, gy1 := path.Bounds()
+	_, py0, _, py1 := paths[0].Path.Bounds()
+	_, _, px1, _ := paths[2].Path.Bounds()
+	if gx0 != x00 || gy0 != py0 || gx1 != px1 || gy1 != py1 {
+		t.Errorf("path.Bounds(): got: (%v, %v, %v, %v), want: (%v, %v, %v, %v)", gx0, gy0, gx1, gy1, x00, py0, px1, py1)
+	}
+}
+
+func TestAlignBaseline(t *testing.T) {
+	source, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	face := &text.GoTextFace{
+		Source: source,
+		Size:   32,
+	}
+
+	const str = "a\nb"
+	top := text.AppendGlyphs(nil, str, face, &text.LayoutOptions{
+		LineSpacing: 40,
+	})
+	baseline := text.AppendGlyphs(nil, str, face, &text.LayoutOptions{
+		LineSpacing:    40,
+		SecondaryAlign: text.AlignBaseline,
+	})
+	// AlignBaseline as the primary alignment is treated as AlignStart.
+	baseline2 := text.AppendGlyphs(nil, str, face, &text.LayoutOptions{
+		LineSpacing:    40,
+		PrimaryAlign:   text.AlignBaseline,
+		SecondaryAlign: text.AlignBaseline,
+	})
+	if len(top) != len(baseline) || len(top) != len(baseline2) {
+		t.Fatalf("the numbers of glyphs must be the same: %d, %d, %d", len(top), len(baseline), len(baseline2))
+	}
+
+	ascent := face.Metrics().HAscent
+	for i := range top {
+		// The glyphs move up by the ascent. The positions might be rounded.
+		if got, want := baseline[i].Y, top[i].Y-ascent; math.Abs(got-want) > 1 {
+			t.Errorf("baseline[%d].Y: got: %v, want: %v", i, got, want)
+		}
+		if got, want := baseline[i].X, top[i].X; got != want {
+			t.Errorf("baseline[%d].X: got: %v, want: %v", i, got, want)
+		}
+		if got, want := baseline2[i].X, baseline[i].X; got != want {
+			t.Errorf("baseline2[%d].X: got: %v, want: %v", i, got, want)
+		}
+	}
+
+	// 'a' sits on the baseline, which is at the origin.
+	if g := baseline[0]; g.Image != nil {
+		if bottom := g.Y + float64(g.Image.Bounds().Dy()); math.Abs(bottom) > 2 {
+			t.Errorf("the bottom of 'a': got: %v, want: around 0", bottom)
+		}
+	}
+}
+
+func TestLabel(t *testing.T) {
+	source, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	face := &text.GoTextFace{
+		Source: source,
+		Size:   16,
+	}
+
+	const str = "Hello, World!\nThis is a label."
+	layoutOp := text.LayoutOptions{
+		LineSpacing: 20,
+	}
+
+	for _, rasterize := range []bool{false, true} {
+		label := text.NewLabel(str, face, &text.LabelOptions{
+			LayoutOptions: layoutOp,
+			Rasterize:     rasterize,
+		})
+		w, h := label.Size()
+		if gotW, gotH := text.Measure(str, face, layoutOp.LineSpacing); w != gotW || h != gotH {
+			t.Errorf("rasterize: %t: Size: got: (%v, %v), want: (%v, %v)", rasterize, w, h, gotW, gotH)
+		}
+
+		dst0 := ebiten.NewImage(200, 60)
+		dst1 := ebiten.NewImage(200, 60)
+		text.Draw(dst0, str, face, &text.DrawOptions{LayoutOptions: layoutOp})
+		label.Draw(dst1, nil)
+
+		for j := 0; j < 60; j++ {
+			for i := 0; i < 200; i++ {
+				got := dst1.At(i, j).(color.RGBA)
+				want := dst0.At(i, j).(color.RGBA)
+				// Rasterizing might cause a tiny error at blending.
+				if math.Abs(float64(got.A)-float64(want.A)) > 1 {
+					t.Fatalf("rasterize: %t: At(%d, %d): got: %v, want: %v", rasterize, i, j, got, want)
+				}
+			}
+		}
+	}
+
+	// Changing the options invalidates the layout.
+	label := text.NewLabel(str, face, nil)
+	w0, _ := label.Size()
+	label.SetOptions(&text.LabelOptions{
+		MaxAdvance: w0 / 2,
+	})
+	w1, _ := label.Size()
+	if w1 > w0/2 {
+		t.Errorf("wrapped width: got: %v, want: <= %v", w1, w0/2)
+	}
+	label.SetText("")
+	if w, h := label.Size(); w != 0 || h != 0 {
+		t.Errorf("empty text size: got: (%v, %v), want: (0, 0)", w, h)
+	}
+}
+
+func TestTabStops(t *testing.T) {
+	source, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	face := &text.GoTextFace{
+		Source: source,
+		Size:   16,
+	}
+
+	const tabWidth = 100
+	op := &text.LayoutOptions{
+		TabWidth: tabWidth,
+	}
+	b := text.AppendGlyphs(nil, "b", face, nil)
+	for _, tc := range []struct {
+		str  string
+		want float64
+	}{
+		{str: "a\tb", want: tabWidth},
+		{str: "\tb", want: tabWidth},
+		{str: "a\t\tb", want: 2 * tabWidth},
+	} {
+		gs := text.AppendGlyphs(nil, tc.str, face, op)
+		g := gs[len(gs)-1]
+		if got, want := g.X, b[0].X+tc.want; math.Abs(got-want) > 1 {
+			t.Errorf("%q: the last glyph's X: got: %v, want: %v", tc.str, got, want)
+		}
+		if got, want := g.StartIndexInBytes, len(tc.str)-1; got != want {
+			t.Errorf("%q: the last glyph's StartIndexInBytes: got: %d, want: %d", tc.str, got, want)
+		}
+	}
+
+	if got, _ := text.MeasureWithOptions("a\tb", face, op); math.Abs(got-(tabWidth+text.Advance("b", face))) > 1e-9 {
+		t.Errorf("MeasureWithOptions: got: %v, want: %v", got, tabWidth+text.Advance("b", face))
+	}
+
+	// A tab is measured with the tab stops at wrapping.
+	lines := text.Wrap("a\tb c", face, &text.WrapOptions{
+		MaxAdvance: tabWidth + text.Advance("b", face) + 1,
+		TabWidth:   tabWidth,
+	})
+	if got, want := lines, []string{"a\tb", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrap: got: %q, want: %q", got, want)
+	}
+}
+
+func TestJoinColumns(t *testing.T) {
+	source, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	face := &text.GoTextFace{
+		Source: source,
+		Size:   16,
+	}
+
+	str, tabWidth := text.JoinColumns([][]string{
+		{"name", "score"},
+		{"Ebitengine", "100"},
+	}, face, 8)
+	if got, want := str, "name\tscore\nEbitengine\t100"; got != want {
+		t.Errorf("text: got: %q, want: %q", got, want)
+	}
+	if got, want := tabWidth, text.Advance("Ebitengine", face)+8; got != want {
+		t.Errorf("tab width: got: %v, want: %v", got, want)
+	}
+}
+
+func TestSecondarySubpixelPhases(t *testing.T) {
+	source, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	draw := func(face text.Face, y float64) []byte {
+		dst := ebiten.NewImage(32, 32)
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(0, y)
+		text.Draw(dst, "a", face, op)
+		pix := make([]byte, 4*32*32)
+		dst.ReadPixels(pix)
+		return pix
+	}
+
+	// By default, glyphs snap to integer pixels in the secondary direction.
+	face := &text.GoTextFace{
+		Source: source,
+		Size:   16,
+	}
+	if !bytes.Equal(draw(face, 0), draw(face, 0.5)) {
+		t.Errorf("the results must be the same without SecondarySubpixelPhases")
+	}
+
+	face = &text.GoTextFace{
+		Source:                  source,
+		Size:                    16,
+		SecondarySubpixelPhases: 4,
+	}
+	if bytes.Equal(draw(face, 0), draw(face, 0.5)) {
+		t.Errorf("the results must be different with SecondarySubpixelPhases")
+	}
+}
+
+func TestSubpixelPhasesNotPowerOfTwo(t *testing.T) {
+	source, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Face     *text.GoTextFace
+		Vertical bool
+		Want     int
+	}{
+		{
+			Face: &text.GoTextFace{SubpixelPhases: 3},
+			Want: 3,
+		},
+		{
+			Face: &text.GoTextFace{SubpixelPhases: 4},
+			Want: 4,
+		},
+		{
+			Face: &text.GoTextFace{SubpixelPhases: 5},
+			Want: 5,
+		},
+		{
+			Face:     &text.GoTextFace{SubpixelPhases: 1, SecondarySubpixelPhases: 3},
+			Vertical: true,
+			Want:     3,
+		},
+		{
+			// SecondarySubpixelPhases is ignored with HintingVertical.
+			Face:     &text.GoTextFace{SubpixelPhases: 1, SecondarySubpixelPhases: 3, Hinting: text.HintingVertical},
+			Vertical: true,
+			Want:     1,
+		},
+	}
+	for _, tc := range testCases {
+		face := tc.Face
+		face.Source = source
+		// Use a unique size so that the glyph cache is not shared with the other tests.
+		face.Size = 17
+		text.EvictGlyphs(face)
+
+		// Render a glyph at all the possible subpixel positions.
+		dst := ebiten.NewImage(32, 32)
+		for i := 0; i < 64; i++ {
+			op := &text.DrawOptions{}
+			if tc.Vertical {
+				op.GeoM.Translate(0, float64(i)/64)
+			} else {
+				op.GeoM.Translate(float64(i)/64, 0)
+			}
+			text.Draw(dst, "a", face, op)
+		}
+		if got, _ := text.GlyphCacheUsage(face); got != tc.Want {
+			t.Errorf("SubpixelPhases: %d, SecondarySubpixelPhases: %d, Hinting: %d: glyphs: got: %d, want: %d", face.SubpixelPhases, face.SecondarySubpixelPhases, face.Hinting, got, tc.Want)
+		}
+		text.EvictGlyphs(face)
+	}
+}
+
+func TestGlyphCacheBudget(t *testing.T) {
+	// Collect the faces of the other tests so that the total usage is stable.
+	runtime.GC()
+	_, base := text.GlyphCacheUsage(nil)
+
+	f := text.NewGoXFace(&testGoXFace{})
+	text.CacheGlyphs("a", f)
+	runeGlyphs, runeSize := text.GlyphCacheUsage(f)
+	if runeGlyphs == 0 || runeSize == 0 {
+		t.Fatalf("GlyphCacheUsage: got: (%d, %d), want: positive values", runeGlyphs, runeSize)
+	}
+
+	budget := base + 2*runeSize
+	text.SetGlyphCacheBudget(budget)
+	defer text.SetGlyphCacheBudget(0)
+
+	text.AdvanceTickForTesting()
+	text.CacheGlyphs("b", f)
+	text.AdvanceTickForTesting()
+	text.CacheGlyphs("c", f)
+
+	// The least recently used glyphs for 'a' should be evicted.
+	glyphs, bytes := text.GlyphCacheUsage(f)
+	if got, want := glyphs, 2*runeGlyphs; got != want {
+		t.Errorf("glyphs: got: %d, want: %d", got, want)
+	}
+	if got, want := bytes, 2*runeSize; got != want {
+		t.Errorf("bytes: got: %d, want: %d", got, want)
+	}
+	if _, total := text.GlyphCacheUsage(nil); total > budget {
+		t.Errorf("total bytes: got: %d, want: <= %d", total, budget)
+	}
+
+	text.EvictGlyphs(f)
+	glyphs, bytes = text.GlyphCacheUsage(f)
+	if glyphs != 0 || bytes != 0 {
+		t.Errorf("after EvictGlyphs: got: (%d, %d), want: (0, 0)", glyphs, bytes)
+	}
+}
+
+func TestDrawOutline(t *testing.T) {
+	f := text.NewGoXFace(&testGoXFace{})
+	dst := ebiten.NewImage(testGoXFaceSize*2, testGoXFaceSize*2)
+
+	// With testGoXFace, 'b' is rendered as a filled square.
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(testGoXFaceSize/2, testGoXFaceSize/2)
+	op.OutlineWidth = 1
+	op.OutlineColor = color.RGBA{R: 0xff, A: 0xff}
+	text.Draw(dst, "b", f, op)
+
+	// The glyph itself is not affected by the outline.
+	if got, want := dst.At(testGoXFaceSize/2, testGoXFaceSize/2), (color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}); got != want {
+		t.Errorf("glyph: got: %v, want: %v", got, want)
+	}
+
+	// The pixel next to the glyph is the outline.
+	if got := dst.At(testGoXFaceSize/2-1, testGoXFaceSize/2).(color.RGBA); got.R == 0 || got.G != 0 || got.A == 0 {
+		t.Errorf("outline: got: %v, want: red", got)
+	}
+
+	// The pixel far from the glyph is transparent.
+	if got, want := dst.At(0, 0), (color.RGBA{}); got != want {
+		t.Errorf("background: got: %v, want: %v", got, want)
+	}
+
+	w, h := text.Measure("b", f, 0)
+	gotW, gotH := text.MeasureWithEffect("b", f, nil, &op.EffectOptions)
+	if gotW != w+2 || gotH != h+2 {
+		t.Errorf("MeasureWithEffect: got: (%f, %f), want: (%f, %f)", gotW, gotH, w+2, h+2)
+	}
+}
+
+func TestDrawShadow(t *testing.T) {
+	f := text.NewGoXFace(&testGoXFace{})
+	dst := ebiten.NewImage(testGoXFaceSize*2, testGoXFaceSize*2)
+
+	op := &text.DrawOptions{}
+	op.ShadowOffsetX = 2
+	op.ShadowOffsetY = 2
+	op.ShadowColor = color.RGBA{B: 0xff, A: 0xff}
+	text.Draw(dst, "b", f, op)
+
+	// The glyph is rendered above the shadow.
+	if got, want := dst.At(testGoXFaceSize-1, testGoXFaceSize-1), (color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}); got != want {
+		t.Errorf("glyph: got: %v, want: %v", got, want)
+	}
+	if got, want := dst.At(testGoXFaceSize+1, testGoXFaceSize+1), (color.RGBA{B: 0xff, A: 0xff}); got != want {
+		t.Errorf("shadow: got: %v, want: %v", got, want)
+	}
+}
+
+func TestDrawRuns(t *testing.T) {
+	const w, h = 128, 64
+	const str = "Hello,\nWorld!"
+
+	f := text.NewGoXFace(bitmapfont.Face)
+	runs := []text.Run{
+		{Text: "Hel", Face: f},
+		{Text: "lo,\nWo", Face: f},
+		{Text: "rld!", Face: f},
+	}
+
+	// Runs with the same face should be the same as one text.
+	m := f.Metrics()
+	lineSpacing := m.HAscent + m.HDescent + m.HLineGap
+
+	dst0 := ebiten.NewImage(w, h)
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(8, 16)
+	op.LineSpacing = lineSpacing
+	text.Draw(dst0, str, f, op)
+
+	dst1 := ebiten.NewImage(w, h)
+	runOp := &ebiten.DrawImageOptions{}
+	runOp.GeoM.Translate(8, 16)
+	text.DrawRuns(dst1, runs, runOp)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst1.At(i, j)
+			want := dst0.At(i, j)
+			if got != want {
+				t.Errorf("At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	gotW, gotH := text.MeasureRuns(runs)
+	wantW, wantH := text.Measure(str, f, lineSpacing)
+	if gotW != wantW || gotH != wantH {
+		t.Errorf("MeasureRuns: got: (%f, %f), want: (%f, %f)", gotW, gotH, wantW, wantH)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Overflow represents how to treat a word that doesn't fit with the maximum advance at wrapping.
+type Overflow int
+
+const (
+	// OverflowVisible puts a word longer than the maximum advance on its own line without breaking the word.
+	// The line exceeds the maximum advance.
+	OverflowVisible Overflow = iota
+
+	// OverflowBreak breaks a word longer than the maximum advance at character boundaries.
+	// A line never exceeds the maximum advance unless one character is longer than the maximum advance.
+	OverflowBreak
+)
+
+// WrapOptions represents options for Wrap.
+type WrapOptions struct {
+	// MaxAdvance is the maximum advance of a line in pixels.
+	// The advance is measured in the primary direction of the face,
+	// i.e. MaxAdvance is the maximum width for a horizontal-direction face,
+	// and the maximum height of a column for a vertical-direction face.
+	// If MaxAdvance is not positive, texts are not wrapped.
+	MaxAdvance float64
+
+	// Overflow is a policy for a word longer than MaxAdvance.
+	// The default (zero) value is OverflowVisible.
+	Overflow Overflow
+
+	// TabWidth is a distance between two adjacent tab stops in pixels.
+	// This should be the same value as LayoutOptions' TabWidth to render the wrapped lines.
+	// If TabWidth is not positive, a tab character is treated as a regular character.
+	TabWidth float64
+}
+
+// Wrap splits a given text into lines so that each line fits with the maximum advance in options.
+//
+// For a vertical-direction face like GoTextFace with DirectionTopToBottomAndRightToLeft,
+// the lines are the columns, and the maximum advance is the maximum height of a column.
+//
+// A line can be broken after spaces, and before or after CJK characters.
+// A line is never broken before closing punctuations like '、' or '」', or after opening punctuations like '「'.
+// The '\n' newline character always breaks a line.
+//
+// The spaces at the end of a wrapped line are removed.
+// The advances of lines are measured in the same way as Advance, with the tab stops if options.TabWidth is positive.
+//
+// To render the wrapped lines, join them with '\n' and pass the result to Draw.
+//
+// If options is nil or options.MaxAdvance is not positive, Wrap splits the text only by '\n'.
+//
+// Wrap is concurrent-safe.
+func Wrap(text string, face Face, options *WrapOptions) []string {
+	if options == nil || options.MaxAdvance <= 0 {
+		return strings.Split(text, "\n")
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		lines = appendWrappedParagraph(lines, face, paragraph, options.MaxAdvance, options.Overflow, options.TabWidth)
+	}
+	return lines
+}
+
+func appendWrappedParagraph(lines []string, face Face, paragraph string, maxAdvance float64, overflow Overflow, tabWidth float64) []string {
+	if paragraph == "" {
+		return append(lines, "")
+	}
+
+	// start is the start index of the current line.
+	// end is the end index of the current line, which is a break opportunity.
+	start, end := 0, 0
+	for end < len(paragraph) {
+		next := nextBreak(paragraph, end)
+
+		if lineAdvance(trimRightSpaces(paragraph[start:next]), face, tabWidth) <= maxAdvance {
+			end = next
+			continue
+		}
+
+		if end > start {
+			// The current line is full. Put the segment [end, next) on the next line.
+			lines = append(lines, trimRightSpaces(paragraph[start:end]))
+			start = end
+			continue
+		}
+
+		// The segment [start, next) is longer than the maximum advance by itself.
+		if overflow == OverflowBreak {
+			var w int
+			for i, r := range paragraph[start:next] {
+				if i > 0 && lineAdvance(paragraph[start:start+i+utf8.RuneLen(r)], face, tabWidth) > maxAdvance {
+					break
+				}
+				w = i + utf8.RuneLen(r)
+			}
+			if start+w < next {
+				lines = append(lines, paragraph[start:start+w])
+				start += w
+				end = start
+				continue
+			}
+		}
+		lines = append(lines, trimRightSpaces(paragraph[start:next]))
+		start, end = next, next
+	}
+	if start < len(paragraph) {
+		lines = append(lines, trimRightSpaces(paragraph[start:]))
+	}
+	return lines
+}
+
+// nextBreak returns the next break opportunity index after the index i.
+// The returned index can be len(s).
+func nextBreak(s string, i int) int {
+	prev, n := utf8.DecodeRuneInString(s[i:])
+	i += n
+	for i < len(s) {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		if canBreakBetween(prev, r) {
+			return i
+		}
+		prev = r
+		i += n
+	}
+	return i
+}
+
+func isBreakSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '　'
+}
+
+func trimRightSpaces(s string) string {
+	return strings.TrimRightFunc(s, isBreakSpace)
+}
+
+func isCJK(r rune) bool {
+	if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+		return true
+	}
+	// CJK Symbols and Punctuation, and Halfwidth and Fullwidth Forms.
+	return (0x3000 <= r && r <= 0x303f) || (0xff00 <= r && r <= 0xffef)
+}
+
+// canBreakBetween reports whether a line can be broken between r0 and r1.
+func canBreakBetween(r0, r1 rune) bool {
+	if isBreakSpace(r1) {
+		return false
+	}
+	if isBreakSpace(r0) {
+		return true
+	}
+	if !isCJK(r0) && !isCJK(r1) {
+		return false
+	}
+	if strings.ContainsRune(noBreakBefore, r1) {
+		return false
+	}
+	if strings.ContainsRune(noBreakAfter, r0) {
+		return false
+	}
+	return true
+}
+
+const (
+	// noBreakBefore is a set of characters that cannot be at the beginning of a line.
+	noBreakBefore = ",.:;!?)]}、。，．：；？！）」』】〕〉》ーぁぃぅぇぉっゃゅょゎァィゥェォッャュョヮ"
+
+	// noBreakAfter is a set of characters that cannot be at the end of a line.
+	noBreakAfter = "([{（「『【〔〈《"
+)
@@ -0,0 +1,343 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vector
+
+import (
+	"math"
+	"sort"
+)
+
+// BooleanTolerance is the tolerance in pixels for the boolean operations of paths.
+//
+// The vertices of the paths and the intersection points are snapped to a grid whose cell size is BooleanTolerance.
+// Thus, points and edges closer than BooleanTolerance might be merged.
+const BooleanTolerance = 1.0 / 128
+
+// Union returns a new path representing the union of p and q.
+// See the documentation of Intersect for details.
+func (p *Path) Union(q *Path, fillRule FillRule) *Path {
+	return booleanOperation(p, q, fillRule, func(a, b bool) bool { return a || b })
+}
+
+// Intersect returns a new path representing the intersection of p and q.
+//
+// The paths are flattened polygons, and every subpath is treated as closed in the same way as filling.
+// fillRule specifies whether a region is inside of a path.
+// The vertices are snapped with BooleanTolerance, so shared edges and near-degenerate intersections are merged.
+//
+// The returned path consists of closed subpaths without curves.
+// The subpaths are oriented so that the result is the same with either FillRuleNonZero or FillRuleEvenOdd.
+// The returned path can be also stroked.
+//
+// The computation cost is O(n²) where n is the number of the edges, so the boolean operations are not intended for
+// very complex paths every frame.
+func (p *Path) Intersect(q *Path, fillRule FillRule) *Path {
+	return booleanOperation(p, q, fillRule, func(a, b bool) bool { return a && b })
+}
+
+// Subtract returns a new path representing p minus q.
+// See the documentation of Intersect for details.
+func (p *Path) Subtract(q *Path, fillRule FillRule) *Path {
+	return booleanOperation(p, q, fillRule, func(a, b bool) bool { return a && !b })
+}
+
+// Xor returns a new path representing the regions that are inside of exactly one of p and q.
+// See the documentation of Intersect for details.
+func (p *Path) Xor(q *Path, fillRule FillRule) *Path {
+	return booleanOperation(p, q, fillRule, func(a, b bool) bool { return a != b })
+}
+
+// gridPoint is a point snapped to the grid of BooleanTolerance.
+type gridPoint struct {
+	x int64
+	y int64
+}
+
+// gridEdge is a directed edge on the grid.
+type gridEdge struct {
+	p0 gridPoint
+	p1 gridPoint
+}
+
+func toGridPoint(x, y float64) gridPoint {
+	return gridPoint{
+		x: int64(math.Round(x / BooleanTolerance)),
+		y: int64(math.Round(y / BooleanTolerance)),
+	}
+}
+
+func crossGrid(o, a, b gridPoint) int64 {
+	return (a.x-o.x)*(b.y-o.y) - (a.y-o.y)*(b.x-o.x)
+}
+
+func (p *Path) gridEdges() []gridEdge {
+	var edges []gridEdge
+	for _, s := range p.subpaths {
+		// Treat every subpath as closed as AppendVerticesAndIndicesForFilling does.
+		n := len(s.points)
+		if n < 3 {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			p0 := s.points[i]
+			p1 := s.points[(i+1)%n]
+			e := gridEdge{
+				p0: toGridPoint(float64(p0.x), float64(p0.y)),
+				p1: toGridPoint(float64(p1.x), float64(p1.y)),
+			}
+			if e.p0 == e.p1 {
+				continue
+			}
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// appendSplitPoints appends the points where e0 should be split by e1 and returns them.
+func appendSplitPoints(points []gridPoint, e0, e1 gridEdge) []gridPoint {
+	a, b := e0.p0, e0.p1
+	c, d := e1.p0, e1.p1
+
+	d0 := crossGrid(a, b, c)
+	d1 := crossGrid(a, b, d)
+	d2 := crossGrid(c, d, a)
+	d3 := crossGrid(c, d, b)
+
+	// A proper intersection.
+	if ((d0 > 0 && d1 < 0) || (d0 < 0 && d1 > 0)) && ((d2 > 0 && d3 < 0) || (d2 < 0 && d3 > 0)) {
+		t := float64(d2) / float64(d2-d3)
+		pt := gridPoint{
+			x: int64(math.Round(float64(a.x) + t*float64(b.x-a.x))),
+			y: int64(math.Round(float64(a.y) + t*float64(b.y-a.y))),
+		}
+		if pt != a && pt != b {
+			points = append(points, pt)
+		}
+		return points
+	}
+
+	// The end points of e1 on e0, including collinear overlaps.
+	for _, pt := range [...]gridPoint{c, d} {
+		if pt == a || pt == b {
+			continue
+		}
+		if isGridPointOnEdge(pt, a, b) {
+			points = append(points, pt)
+		}
+	}
+	return points
+}
+
+// isGridPointOnEdge reports whether pt is on the edge (a, b) within the half of the grid size, excluding the end points.
+func isGridPointOnEdge(pt, a, b gridPoint) bool {
+	dx := float64(b.x - a.x)
+	dy := float64(b.y - a.y)
+	l2 := dx*dx + dy*dy
+	t := (float64(pt.x-a.x)*dx + float64(pt.y-a.y)*dy) / l2
+	if t <= 0 || t >= 1 {
+		return false
+	}
+	// The distance from pt to the line is |cross| / |ab|.
+	c := float64(crossGrid(a, b, pt))
+	return c*c <= 0.25*l2
+}
+
+// splitEdges splits the edges at their intersections so that the edges don't cross each other.
+// The edges are divided into the groups, and the group indices are kept.
+func splitEdges(edges []gridEdge, groups []int) ([]gridEdge, []int) {
+	// Snapping an intersection point to the grid might cause a new intersection.
+	// Iterate a few times until the edges become stable.
+	for iter := 0; iter < 8; iter++ {
+		splitPoints := make([][]gridPoint, len(edges))
+		var split bool
+		for i := range edges {
+			for j := range edges {
+				if i == j {
+					continue
+				}
+				n := len(splitPoints[i])
+				splitPoints[i] = appendSplitPoints(splitPoints[i], edges[i], edges[j])
+				if len(splitPoints[i]) > n {
+					split = true
+				}
+			}
+		}
+		if !split {
+			break
+		}
+
+		var newEdges []gridEdge
+		var newGroups []int
+		for i, e := range edges {
+			pts := splitPoints[i]
+			if len(pts) == 0 {
+				newEdges = append(newEdges, e)
+				newGroups = append(newGroups, groups[i])
+				continue
+			}
+			dx := float64(e.p1.x - e.p0.x)
+			dy := float64(e.p1.y - e.p0.y)
+			sort.Slice(pts, func(i, j int) bool {
+				ti := float64(pts[i].x-e.p0.x)*dx + float64(pts[i].y-e.p0.y)*dy
+				tj := float64(pts[j].x-e.p0.x)*dx + float64(pts[j].y-e.p0.y)*dy
+				return ti < tj
+			})
+			prev := e.p0
+			for _, pt := range append(pts, e.p1) {
+				if pt == prev {
+					continue
+				}
+				newEdges = append(newEdges, gridEdge{p0: prev, p1: pt})
+				newGroups = append(newGroups, groups[i])
+				prev = pt
+			}
+		}
+		edges = newEdges
+		groups = newGroups
+	}
+	return edges, groups
+}
+
+// windings returns the winding numbers of the given group at the right side and the left side of the edge e.
+func windings(e gridEdge, edges []gridEdge, groups []int, group int) (right, left int) {
+	// Cast a ray from the midpoint of e to the right side of e.
+	// As the edges don't cross each other, only the edges coincident with e pass the midpoint.
+	dx := float64(e.p1.x - e.p0.x)
+	dy := float64(e.p1.y - e.p0.y)
+	// Use doubled coordinates to keep the midpoint on the grid.
+	mx := float64(e.p0.x + e.p1.x)
+	my := float64(e.p0.y + e.p1.y)
+	// (rx, ry) is the direction of the ray, and the coordinates (u, v) are along the ray and along e.
+	rx, ry := dy, -dx
+
+	var coincident int
+	for i, e1 := range edges {
+		if groups[i] != group {
+			continue
+		}
+		if e1 == e {
+			coincident++
+			continue
+		}
+		if e1.p0 == e.p1 && e1.p1 == e.p0 {
+			coincident--
+			continue
+		}
+
+		px := 2*float64(e1.p0.x) - mx
+		py := 2*float64(e1.p0.y) - my
+		qx := 2*float64(e1.p1.x) - mx
+		qy := 2*float64(e1.p1.y) - my
+		pv := px*dx + py*dy
+		qv := qx*dx + qy*dy
+		if (pv > 0) == (qv > 0) {
+			continue
+		}
+		pu := px*rx + py*ry
+		qu := qx*rx + qy*ry
+		// u at the crossing point is pu + (qu - pu) * pv / (pv - qv).
+		if (pu*(pv-qv)+(qu-pu)*pv)*(pv-qv) <= 0 {
+			continue
+		}
+		if qv > pv {
+			right++
+		} else {
+			right--
+		}
+	}
+	return right, right + coincident
+}
+
+func isInside(winding int, fillRule FillRule) bool {
+	if fillRule == FillRuleEvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+func booleanOperation(p, q *Path, fillRule FillRule, op func(a, b bool) bool) *Path {
+	var edges []gridEdge
+	var groups []int
+	for _, e := range p.gridEdges() {
+		edges = append(edges, e)
+		groups = append(groups, 0)
+	}
+	for _, e := range q.gridEdges() {
+		edges = append(edges, e)
+		groups = append(groups, 1)
+	}
+	edges, groups = splitEdges(edges, groups)
+
+	// Pick the edges on the boundary of the result, oriented so that the inside is on the left side.
+	visited := map[gridEdge]struct{}{}
+	var result []gridEdge
+	for _, e := range edges {
+		if _, ok := visited[e]; ok {
+			continue
+		}
+		visited[e] = struct{}{}
+		visited[gridEdge{p0: e.p1, p1: e.p0}] = struct{}{}
+
+		ar, al := windings(e, edges, groups, 0)
+		br, bl := windings(e, edges, groups, 1)
+		r := op(isInside(ar, fillRule), isInside(br, fillRule))
+		l := op(isInside(al, fillRule), isInside(bl, fillRule))
+		switch {
+		case l && !r:
+			result = append(result, e)
+		case r && !l:
+			result = append(result, gridEdge{p0: e.p1, p1: e.p0})
+		}
+	}
+
+	// Link the edges into closed subpaths.
+	// Every vertex has the same number of incoming and outgoing edges, so walking the edges always returns to the start.
+	outgoing := map[gridPoint][]int{}
+	for i, e := range result {
+		outgoing[e.p0] = append(outgoing[e.p0], i)
+	}
+	used := make([]bool, len(result))
+	var path Path
+	for i := range result {
+		if used[i] {
+			continue
+		}
+		start := result[i].p0
+		path.MoveTo(float32(float64(start.x)*BooleanTolerance), float32(float64(start.y)*BooleanTolerance))
+		idx := i
+		for {
+			used[idx] = true
+			end := result[idx].p1
+			if end == start {
+				break
+			}
+			path.LineTo(float32(float64(end.x)*BooleanTolerance), float32(float64(end.y)*BooleanTolerance))
+			next := -1
+			for _, j := range outgoing[end] {
+				if !used[j] {
+					next = j
+					break
+				}
+			}
+			if next < 0 {
+				break
+			}
+			idx = next
+		}
+		path.Close()
+	}
+	return &path
+}
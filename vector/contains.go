@@ -0,0 +1,92 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vector
+
+// Contains reports whether the point (x, y) is inside of the path with the given fill rule.
+//
+// Curves are flattened into line segments when they are added to the path, with a tolerance of 0.5 pixels.
+// Contains evaluates the same flattened polygons as AppendVerticesAndIndicesForFilling,
+// so the result agrees with DrawFilledPath for the same fill rule, except for points on or very close to the edges.
+// Every subpath is treated as closed in the same way as filling.
+func (p *Path) Contains(x, y float32, fillRule FillRule) bool {
+	if len(p.subpaths) == 0 {
+		return false
+	}
+	minX, minY, maxX, maxY := p.Bounds()
+	if x < minX || x > maxX || y < minY || y > maxY {
+		return false
+	}
+	return isInside(p.winding(float64(x), float64(y)), fillRule)
+}
+
+// winding returns the winding number of the path around the point (x, y).
+func (p *Path) winding(x, y float64) int {
+	var w int
+	for _, s := range p.subpaths {
+		n := len(s.points)
+		if n < 3 {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			p0 := s.points[i]
+			p1 := s.points[(i+1)%n]
+			x0, y0 := float64(p0.x), float64(p0.y)
+			x1, y1 := float64(p1.x), float64(p1.y)
+			// The cross product is positive when (x, y) is on the left side of the edge in the y-down coordinate.
+			c := (x1-x0)*(y-y0) - (y1-y0)*(x-x0)
+			switch {
+			case y0 <= y && y < y1:
+				if c > 0 {
+					w++
+				}
+			case y1 <= y && y < y0:
+				if c < 0 {
+					w--
+				}
+			}
+		}
+	}
+	return w
+}
+
+// Bounds returns the bounding box of all the points of the path.
+//
+// Bounds is useful as a cheap pre-check before Contains.
+// If the path is empty, Bounds returns zeros.
+func (p *Path) Bounds() (minX, minY, maxX, maxY float32) {
+	first := true
+	for _, s := range p.subpaths {
+		for _, pt := range s.points {
+			if first {
+				minX, minY, maxX, maxY = pt.x, pt.y, pt.x, pt.y
+				first = false
+				continue
+			}
+			if minX > pt.x {
+				minX = pt.x
+			}
+			if minY > pt.y {
+				minY = pt.y
+			}
+			if maxX < pt.x {
+				maxX = pt.x
+			}
+			if maxY < pt.y {
+				maxY = pt.y
+			}
+		}
+	}
+	return
+}
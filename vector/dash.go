@@ -0,0 +1,143 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vector
+
+import (
+	"math"
+)
+
+// minDashLength is the length of a segment to represent a zero-length dash.
+// A zero-length dash still needs a direction to render its line caps.
+const minDashLength = 1e-3
+
+// normalizeDashes returns the dash pattern with an even number of elements.
+// normalizeDashes returns false if the pattern should be treated as a solid stroke.
+func normalizeDashes(dashes []float32) ([]float32, bool) {
+	if len(dashes) == 0 {
+		return nil, false
+	}
+	var sum float32
+	for _, d := range dashes {
+		if d < 0 || math.IsNaN(float64(d)) || math.IsInf(float64(d), 0) {
+			return nil, false
+		}
+		sum += d
+	}
+	if sum == 0 {
+		return nil, false
+	}
+	if len(dashes)%2 == 0 {
+		return dashes, true
+	}
+	ds := make([]float32, 0, len(dashes)*2)
+	ds = append(ds, dashes...)
+	ds = append(ds, dashes...)
+	return ds, true
+}
+
+// appendDashedSubpaths splits the subpath into open subpaths for each dash, appends them to dst and returns it.
+// dashes must be a normalized pattern by normalizeDashes.
+func appendDashedSubpaths(dst []*subpath, s *subpath, dashes []float32, offset float32) []*subpath {
+	if s.pointCount() < 2 {
+		return dst
+	}
+
+	var total float32
+	for _, d := range dashes {
+		total += d
+	}
+
+	// Find the position in the pattern at the start of the subpath.
+	// The pattern restarts for each subpath.
+	offset = float32(math.Mod(float64(offset), float64(total)))
+	if offset < 0 {
+		offset += total
+	}
+	var idx int
+	for offset > 0 && offset >= dashes[idx] {
+		offset -= dashes[idx]
+		idx = (idx + 1) % len(dashes)
+	}
+	remaining := dashes[idx] - offset
+
+	var current *subpath
+	var dirX, dirY float32
+	finish := func() {
+		if current == nil {
+			return
+		}
+		if current.pointCount() < 2 {
+			pt := current.points[0]
+			current.points = append(current.points, point{
+				x: pt.x + dirX*minDashLength,
+				y: pt.y + dirY*minDashLength,
+			})
+		}
+		dst = append(dst, current)
+		current = nil
+	}
+
+	for i := 0; i < s.pointCount()-1; i++ {
+		p0, p1 := s.points[i], s.points[i+1]
+		dx, dy := p1.x-p0.x, p1.y-p0.y
+		l := float32(math.Hypot(float64(dx), float64(dy)))
+		if l == 0 {
+			continue
+		}
+		dirX, dirY = dx/l, dy/l
+
+		var t float32
+		for {
+			if remaining <= 0 {
+				if idx%2 == 0 {
+					// A zero-length dash is rendered as a tiny segment.
+					if current == nil {
+						current = &subpath{
+							points: []point{{x: p0.x + dirX*t, y: p0.y + dirY*t}},
+						}
+					}
+					finish()
+				}
+				idx = (idx + 1) % len(dashes)
+				remaining = dashes[idx]
+				continue
+			}
+			if t >= l {
+				break
+			}
+
+			step := remaining
+			if step > l-t {
+				step = l - t
+			}
+			if idx%2 == 0 {
+				if current == nil {
+					current = &subpath{
+						points: []point{{x: p0.x + dirX*t, y: p0.y + dirY*t}},
+					}
+				}
+				pt := point{x: p0.x + dirX*(t+step), y: p0.y + dirY*(t+step)}
+				if lp := current.lastPoint(); abs(lp.x-pt.x) >= 1e-2 || abs(lp.y-pt.y) >= 1e-2 {
+					current.points = append(current.points, pt)
+				}
+			}
+			t += step
+			remaining -= step
+		}
+	}
+	finish()
+
+	return dst
+}
@@ -0,0 +1,333 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vector
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// MaxGradientStops is the maximum number of the stops of a gradient.
+const MaxGradientStops = 16
+
+// Fill represents a way to paint a region.
+//
+// Fill is implemented by ColorFill, LinearGradient, and RadialGradient.
+type Fill interface {
+	// private prevents other packages from implementing this interface.
+	private()
+}
+
+// ColorFill is a Fill with a solid color.
+type ColorFill struct {
+	Color color.Color
+}
+
+func (ColorFill) private() {}
+
+// Spread represents how a gradient is painted outside of the range between the first and the last stops.
+type Spread int
+
+const (
+	// SpreadPad extends the colors of the first and the last stops.
+	SpreadPad Spread = iota
+
+	// SpreadRepeat repeats the gradient.
+	SpreadRepeat
+
+	// SpreadReflect repeats the gradient, reflecting it at every other repetition.
+	SpreadReflect
+)
+
+// GradientStop is a color at a position of a gradient.
+type GradientStop struct {
+	// Offset is the position of the stop in [0, 1].
+	// Offset is clamped to [0, 1], and to be equal to or greater than the previous stop's offset.
+	Offset float32
+
+	// Color is the color of the stop.
+	Color color.Color
+}
+
+// LinearGradient is a Fill with a linear gradient from (X0, Y0) to (X1, Y1).
+//
+// The colors are interpolated in the premultiplied-alpha space.
+type LinearGradient struct {
+	X0, Y0 float32
+	X1, Y1 float32
+
+	// Stops are the stops of the gradient.
+	// The number of the stops must be in [1, MaxGradientStops].
+	Stops []GradientStop
+
+	// Spread is the way to paint outside of the gradient.
+	//
+	// The default (zero) value is SpreadPad.
+	Spread Spread
+}
+
+func (LinearGradient) private() {}
+
+// RadialGradient is a Fill with a radial gradient with the center (CX, CY) and the radius Radius.
+// The offset 0 is at the center and the offset 1 is on the circle.
+//
+// The colors are interpolated in the premultiplied-alpha space.
+type RadialGradient struct {
+	CX, CY float32
+	Radius float32
+
+	// Stops are the stops of the gradient.
+	// The number of the stops must be in [1, MaxGradientStops].
+	Stops []GradientStop
+
+	// Spread is the way to paint outside of the gradient.
+	//
+	// The default (zero) value is SpreadPad.
+	Spread Spread
+}
+
+func (RadialGradient) private() {}
+
+// FillOptions represents options for FillPath.
+type FillOptions struct {
+	// GeoM is a geometry matrix applied to the path.
+	// Gradients are defined in the path's coordinate space and are transformed with the path.
+	//
+	// The default (zero) value is identity.
+	GeoM ebiten.GeoM
+
+	// ColorScale is a scale of color applied to the fill.
+	//
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ebiten.ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	//
+	// The default (zero) value is the regular alpha blending.
+	Blend ebiten.Blend
+
+	// FillRule is the rule whether an overlapped region is rendered or not.
+	//
+	// The default (zero) value is FillRuleNonZero.
+	FillRule FillRule
+
+	// AntiAlias indicates whether the rendering uses anti-alias or not.
+	//
+	// The default (zero) value is false.
+	AntiAlias bool
+
+	// Tolerance is the tolerance in the destination pixels to flatten the curves of the path.
+	// Tolerance is interpreted after GeoM is applied, so a scaled path is flattened finely enough.
+	// A smaller value makes curves smoother with more vertices, and a bigger value makes fewer vertices with visible facets.
+	//
+	// The default (zero) value is 0, which means the path's curves are used as they are flattened with DefaultTolerance
+	// regardless of GeoM.
+	Tolerance float32
+}
+
+var gradientShaderSrc = []byte(fmt.Sprintf(`//kage:unit pixels
+
+package main
+
+var Radial int
+var Start vec2
+var End vec2
+var Radius float
+var Spread int
+var StopCount int
+var StopOffsets [%[1]d]float
+var StopColors [%[1]d]vec4
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	// srcPos is the position in the path's coordinate space.
+	t := 0.0
+	if Radial != 0 {
+		t = length(srcPos-Start) / Radius
+	} else {
+		d := End - Start
+		t = dot(srcPos-Start, d) / dot(d, d)
+	}
+
+	if Spread == 1 {
+		t = fract(t)
+	} else if Spread == 2 {
+		t = 1 - abs(mod(t, 2)-1)
+	} else {
+		t = clamp(t, 0, 1)
+	}
+
+	clr := StopColors[0]
+	for i := 1; i < %[1]d; i++ {
+		if i >= StopCount {
+			break
+		}
+		o0 := StopOffsets[i-1]
+		o1 := StopOffsets[i]
+		if t <= o0 {
+			break
+		}
+		if t >= o1 {
+			clr = StopColors[i]
+			continue
+		}
+		clr = mix(StopColors[i-1], StopColors[i], (t-o0)/(o1-o0))
+		break
+	}
+	return clr * color
+}
+`, MaxGradientStops))
+
+var (
+	gradientShader     *ebiten.Shader
+	gradientShaderOnce sync.Once
+)
+
+func ensureGradientShader() *ebiten.Shader {
+	gradientShaderOnce.Do(func() {
+		s, err := ebiten.NewShader(gradientShaderSrc)
+		if err != nil {
+			panic(fmt.Sprintf("vector: NewShader for the gradient shader failed: %v", err))
+		}
+		gradientShader = s
+	})
+	return gradientShader
+}
+
+// FillPath fills the specified path with the specified fill.
+//
+// The path is transformed by options.GeoM.
+// A gradient is computed in the path's coordinate space, so the gradient is transformed together with the path.
+//
+// options can be nil. In this case, the default options are used.
+//
+// FillPath panics if the number of the gradient stops is 0 or more than MaxGradientStops.
+func FillPath(dst *ebiten.Image, path *Path, fill Fill, options *FillOptions) {
+	if options == nil {
+		options = &FillOptions{}
+	}
+
+	if options.Tolerance > 0 {
+		path = path.flatten(options.Tolerance / geoMScale(&options.GeoM))
+	}
+	vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	if len(vs) == 0 {
+		return
+	}
+
+	cr, cg, cb, ca := options.ColorScale.R(), options.ColorScale.G(), options.ColorScale.B(), options.ColorScale.A()
+	for i := range vs {
+		x, y := options.GeoM.Apply(float64(vs[i].DstX), float64(vs[i].DstY))
+		// Keep the position in the path's coordinate space as the source position for gradients.
+		vs[i].SrcX = vs[i].DstX
+		vs[i].SrcY = vs[i].DstY
+		vs[i].DstX = float32(x)
+		vs[i].DstY = float32(y)
+		vs[i].ColorR = cr
+		vs[i].ColorG = cg
+		vs[i].ColorB = cb
+		vs[i].ColorA = ca
+	}
+
+	var uniforms map[string]any
+	switch fill := fill.(type) {
+	case ColorFill:
+		r, g, b, a := fill.Color.RGBA()
+		for i := range vs {
+			vs[i].SrcX = 1
+			vs[i].SrcY = 1
+			vs[i].ColorR *= float32(r) / 0xffff
+			vs[i].ColorG *= float32(g) / 0xffff
+			vs[i].ColorB *= float32(b) / 0xffff
+			vs[i].ColorA *= float32(a) / 0xffff
+		}
+		op := &ebiten.DrawTrianglesOptions{}
+		op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+		op.Blend = options.Blend
+		op.FillRule = options.FillRule.ebitenFillRule()
+		op.AntiAlias = options.AntiAlias
+		dst.DrawTriangles(vs, is, whiteSubImage, op)
+		return
+
+	case LinearGradient:
+		uniforms = gradientUniforms(fill.Stops, fill.Spread)
+		uniforms["Radial"] = 0
+		uniforms["Start"] = []float32{fill.X0, fill.Y0}
+		uniforms["End"] = []float32{fill.X1, fill.Y1}
+
+	case RadialGradient:
+		uniforms = gradientUniforms(fill.Stops, fill.Spread)
+		uniforms["Radial"] = 1
+		uniforms["Start"] = []float32{fill.CX, fill.CY}
+		uniforms["Radius"] = fill.Radius
+
+	default:
+		panic(fmt.Sprintf("vector: unexpected fill: %T", fill))
+	}
+
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	op.Uniforms = uniforms
+	op.Blend = options.Blend
+	op.FillRule = options.FillRule.ebitenFillRule()
+	op.AntiAlias = options.AntiAlias
+	dst.DrawTrianglesShader(vs, is, ensureGradientShader(), op)
+}
+
+func gradientUniforms(stops []GradientStop, spread Spread) map[string]any {
+	if len(stops) == 0 || len(stops) > MaxGradientStops {
+		panic(fmt.Sprintf("vector: the number of the gradient stops must be in [1, %d] but %d", MaxGradientStops, len(stops)))
+	}
+
+	offsets := make([]float32, MaxGradientStops)
+	colors := make([]float32, 4*MaxGradientStops)
+	var prev float32
+	for i, s := range stops {
+		o := s.Offset
+		if o < prev {
+			o = prev
+		}
+		if o > 1 {
+			o = 1
+		}
+		offsets[i] = o
+		prev = o
+
+		r, g, b, a := s.Color.RGBA()
+		colors[4*i] = float32(r) / 0xffff
+		colors[4*i+1] = float32(g) / 0xffff
+		colors[4*i+2] = float32(b) / 0xffff
+		colors[4*i+3] = float32(a) / 0xffff
+	}
+
+	return map[string]any{
+		"Spread":      int(spread),
+		"StopCount":   len(stops),
+		"StopOffsets": offsets,
+		"StopColors":  colors,
+	}
+}
+
+// geoMScale returns the maximum scale of the lengths by the geometry matrix approximately.
+func geoMScale(geoM *ebiten.GeoM) float32 {
+	a, b, c, d := geoM.Element(0, 0), geoM.Element(0, 1), geoM.Element(1, 0), geoM.Element(1, 1)
+	s := math.Max(math.Hypot(a, c), math.Hypot(b, d))
+	if s == 0 {
+		return 1
+	}
+	return float32(s)
+}
@@ -92,10 +92,77 @@ func (s *subpath) close() {
 // Path represents a collection of path subpathments.
 type Path struct {
 	subpaths []*subpath
+
+	// ops is the recorded operations to flatten the curves again with a different tolerance.
+	ops []pathOp
+
+	// tolerance is the tolerance to flatten the curves. If tolerance is 0, DefaultTolerance is used.
+	tolerance float32
+}
+
+// DefaultTolerance is the default tolerance in pixels to flatten curves into line segments.
+//
+// A curve is approximated by line segments so that the distance between the curve and the segments is
+// roughly within the tolerance.
+const DefaultTolerance = 0.5
+
+type pathOpType int
+
+const (
+	pathOpMoveTo pathOpType = iota
+	pathOpLineTo
+	pathOpQuadTo
+	pathOpCubicTo
+	pathOpClose
+)
+
+type pathOp struct {
+	typ pathOpType
+	p1  point
+	p2  point
+	p3  point
+}
+
+func (p *Path) flatteningTolerance() float32 {
+	if p.tolerance > 0 {
+		return p.tolerance
+	}
+	return DefaultTolerance
+}
+
+// flatten returns a path whose curves are flattened with the given tolerance.
+// If tolerance is 0 or the same as p's tolerance, flatten returns p itself.
+func (p *Path) flatten(tolerance float32) *Path {
+	if tolerance <= 0 || tolerance == p.flatteningTolerance() {
+		return p
+	}
+
+	q := &Path{
+		tolerance: tolerance,
+	}
+	for _, op := range p.ops {
+		switch op.typ {
+		case pathOpMoveTo:
+			q.MoveTo(op.p1.x, op.p1.y)
+		case pathOpLineTo:
+			q.LineTo(op.p1.x, op.p1.y)
+		case pathOpQuadTo:
+			q.QuadTo(op.p1.x, op.p1.y, op.p2.x, op.p2.y)
+		case pathOpCubicTo:
+			q.CubicTo(op.p1.x, op.p1.y, op.p2.x, op.p2.y, op.p3.x, op.p3.y)
+		case pathOpClose:
+			q.Close()
+		}
+	}
+	return q
 }
 
 // MoveTo starts a new subpath with the given position (x, y) without adding a subpath,
 func (p *Path) MoveTo(x, y float32) {
+	p.ops = append(p.ops, pathOp{
+		typ: pathOpMoveTo,
+		p1:  point{x: x, y: y},
+	})
 	p.subpaths = append(p.subpaths, &subpath{
 		points: []point{
 			{x: x, y: y},
@@ -107,6 +174,14 @@ func (p *Path) MoveTo(x, y float32) {
 // and ends to the given position (x, y).
 // If p doesn't have any subpaths or the last subpath is closed, LineTo sets (x, y) as the start position of a new subpath.
 func (p *Path) LineTo(x, y float32) {
+	p.ops = append(p.ops, pathOp{
+		typ: pathOpLineTo,
+		p1:  point{x: x, y: y},
+	})
+	p.lineTo(x, y)
+}
+
+func (p *Path) lineTo(x, y float32) {
 	if len(p.subpaths) == 0 || p.subpaths[len(p.subpaths)-1].closed {
 		p.subpaths = append(p.subpaths, &subpath{
 			points: []point{
@@ -121,7 +196,15 @@ func (p *Path) LineTo(x, y float32) {
 
 // QuadTo adds a quadratic Bézier curve to the path.
 // (x1, y1) is the control point, and (x2, y2) is the destination.
+//
+// The curve is flattened into line segments with DefaultTolerance.
+// The tolerance can be changed at rendering, e.g. by StrokeOptions.Tolerance or FillOptions.Tolerance.
 func (p *Path) QuadTo(x1, y1, x2, y2 float32) {
+	p.ops = append(p.ops, pathOp{
+		typ: pathOpQuadTo,
+		p1:  point{x: x1, y: y1},
+		p2:  point{x: x2, y: y2},
+	})
 	p.quadTo(point{x: x1, y: y1}, point{x: x2, y: y2}, 0)
 }
 
@@ -170,8 +253,8 @@ func (p *Path) quadTo(p1, p2 point, level int) {
 	if !ok {
 		p0 = p1
 	}
-	if isPointCloseToSegment(p1, p0, p2, 0.5) {
-		p.LineTo(p2.x, p2.y)
+	if isPointCloseToSegment(p1, p0, p2, p.flatteningTolerance()) {
+		p.lineTo(p2.x, p2.y)
 		return
 	}
 
@@ -193,7 +276,16 @@ func (p *Path) quadTo(p1, p2 point, level int) {
 
 // CubicTo adds a cubic Bézier curve to the path.
 // (x1, y1) and (x2, y2) are the control points, and (x3, y3) is the destination.
+//
+// The curve is flattened into line segments with DefaultTolerance.
+// The tolerance can be changed at rendering, e.g. by StrokeOptions.Tolerance or FillOptions.Tolerance.
 func (p *Path) CubicTo(x1, y1, x2, y2, x3, y3 float32) {
+	p.ops = append(p.ops, pathOp{
+		typ: pathOpCubicTo,
+		p1:  point{x: x1, y: y1},
+		p2:  point{x: x2, y: y2},
+		p3:  point{x: x3, y: y3},
+	})
 	p.cubicTo(point{x: x1, y: y1}, point{x: x2, y: y2}, point{x: x3, y: y3}, 0)
 }
 
@@ -206,8 +298,8 @@ func (p *Path) cubicTo(p1, p2, p3 point, level int) {
 	if !ok {
 		p0 = p1
 	}
-	if isPointCloseToSegment(p1, p0, p3, 0.5) && isPointCloseToSegment(p2, p0, p3, 0.5) {
-		p.LineTo(p3.x, p3.y)
+	if tol := p.flatteningTolerance(); isPointCloseToSegment(p1, p0, p3, tol) && isPointCloseToSegment(p2, p0, p3, tol) {
+		p.lineTo(p3.x, p3.y)
 		return
 	}
 
@@ -271,6 +363,12 @@ func (p *Path) ArcTo(x1, y1, x2, y2, radius float32) {
 	d0 = normalize(d0)
 	d1 = normalize(d1)
 
+	// If the two lines are parallel, the arc is degenerated to a line.
+	if abs(cross(d0, d1)) < 1e-6 {
+		p.LineTo(x1, y1)
+		return
+	}
+
 	// theta is the angle between two vectors d0 and d1.
 	theta := math.Acos(float64(d0.x*d1.x + d0.y*d1.y))
 	// TODO: When theta is bigger than π/2, the arc should be split into two.
@@ -379,10 +477,107 @@ func (p *Path) Arc(x, y, radius, startAngle, endAngle float32, dir Direction) {
 	p.CubicTo(cx0, cy0, cx1, cy1, x1, y1)
 }
 
+// EllipticalArcTo adds an elliptical arc to the path in the same way as SVG's elliptical arc command.
+// The arc starts from the last position of the current subpath and ends at (x, y).
+//
+// rx and ry are the radii of the ellipse, and xAxisRotation is the rotation of the ellipse's x-axis in radians.
+// largeArc and sweep select one of the four candidate arcs.
+// If largeArc is true, the arc spanning more than 180 degrees is selected.
+// If sweep is true, the arc is drawn in the clockwise direction, i.e. the positive angle direction.
+// For details, see https://www.w3.org/TR/SVG11/paths.html#PathDataEllipticalArcCommands.
+//
+// If rx or ry is 0, EllipticalArcTo adds a line to (x, y).
+// If the radii are too small to connect the two points, the radii are scaled up as SVG does.
+// If the start and end positions are the same, EllipticalArcTo does nothing.
+// To draw a full ellipse, split it into two arcs.
+func (p *Path) EllipticalArcTo(rx, ry, xAxisRotation float32, largeArc, sweep bool, x, y float32) {
+	p0, ok := p.currentPosition()
+	if !ok {
+		p.LineTo(x, y)
+		return
+	}
+	if p0.x == x && p0.y == y {
+		return
+	}
+	if rx == 0 || ry == 0 {
+		p.LineTo(x, y)
+		return
+	}
+
+	// See https://www.w3.org/TR/SVG11/implnote.html#ArcConversionEndpointToCenter.
+	frx := math.Abs(float64(rx))
+	fry := math.Abs(float64(ry))
+	sinPhi, cosPhi := math.Sincos(float64(xAxisRotation))
+
+	dx := float64(p0.x-x) / 2
+	dy := float64(p0.y-y) / 2
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	// Scale up the radii if there is no solution.
+	if lambda := x1*x1/(frx*frx) + y1*y1/(fry*fry); lambda > 1 {
+		s := math.Sqrt(lambda)
+		frx *= s
+		fry *= s
+	}
+
+	num := frx*frx*fry*fry - frx*frx*y1*y1 - fry*fry*x1*x1
+	den := frx*frx*y1*y1 + fry*fry*x1*x1
+	coef := math.Sqrt(math.Max(0, num/den))
+	if largeArc == sweep {
+		coef = -coef
+	}
+	cx1 := coef * frx * y1 / fry
+	cy1 := -coef * fry * x1 / frx
+
+	cx := cosPhi*cx1 - sinPhi*cy1 + float64(p0.x+x)/2
+	cy := sinPhi*cx1 + cosPhi*cy1 + float64(p0.y+y)/2
+
+	theta := math.Atan2((y1-cy1)/fry, (x1-cx1)/frx)
+	dtheta := math.Atan2((-y1-cy1)/fry, (-x1-cx1)/frx) - theta
+	if sweep && dtheta < 0 {
+		dtheta += 2 * math.Pi
+	} else if !sweep && dtheta > 0 {
+		dtheta -= 2 * math.Pi
+	}
+
+	// Approximate the arc with cubic Bézier curves, each of which spans at most 90 degrees.
+	// The error of each curve is less than 0.03% of the radius.
+	n := int(math.Ceil(math.Abs(dtheta)/(math.Pi/2) - 1e-9))
+	if n < 1 {
+		n = 1
+	}
+	delta := dtheta / float64(n)
+	k := 4.0 / 3.0 * math.Tan(delta/4)
+
+	transform := func(ux, uy float64) (float32, float32) {
+		ex := frx * ux
+		ey := fry * uy
+		return float32(cosPhi*ex - sinPhi*ey + cx), float32(sinPhi*ex + cosPhi*ey + cy)
+	}
+
+	for i := 0; i < n; i++ {
+		a0 := theta + delta*float64(i)
+		a1 := a0 + delta
+		sin0, cos0 := math.Sincos(a0)
+		sin1, cos1 := math.Sincos(a1)
+		c0x, c0y := transform(cos0-k*sin0, sin0+k*cos0)
+		c1x, c1y := transform(cos1+k*sin1, sin1-k*cos1)
+		ex, ey := transform(cos1, sin1)
+		if i == n-1 {
+			ex, ey = x, y
+		}
+		p.CubicTo(c0x, c0y, c1x, c1y, ex, ey)
+	}
+}
+
 // Close adds a new line from the last position of the current subpath to the first position of the current subpath,
 // and marks the current subpath closed.
 // Following operations for this path will start with a new subpath.
 func (p *Path) Close() {
+	p.ops = append(p.ops, pathOp{
+		typ: pathOpClose,
+	})
 	if len(p.subpaths) == 0 {
 		return
 	}
@@ -471,6 +666,31 @@ type StrokeOptions struct {
 	//
 	// The default (zero) value is 0.
 	MiterLimit float32
+
+	// Dashes is the dash pattern of the stroke, alternating the lengths of dashes and gaps in pixels.
+	// If the number of the elements is odd, the elements are repeated to make it even.
+	// Line caps are applied to each dash.
+	// For details, see https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/stroke-dasharray.
+	//
+	// A zero-length dash is rendered only with its line caps, e.g. a dot with LineCapRound.
+	// If Dashes includes a negative value or the sum of the elements is 0, the stroke is rendered as solid.
+	//
+	// The default (zero) value is nil, which means a solid stroke.
+	Dashes []float32
+
+	// DashOffset is the distance into the dash pattern at which the stroke starts.
+	// Changing DashOffset every frame animates the dashes without rebuilding the path.
+	// For details, see https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/stroke-dashoffset.
+	//
+	// The default (zero) value is 0.
+	DashOffset float32
+
+	// Tolerance is the tolerance in pixels to flatten the curves of the path and the round joins and caps.
+	// A smaller value makes curves smoother with more vertices, and a bigger value makes fewer vertices with visible facets.
+	// For example, a huge curve might need a smaller value, and a tiny curve can use a bigger value for performance.
+	//
+	// The default (zero) value is 0, which means the path's curves are used as they are flattened with DefaultTolerance.
+	Tolerance float32
 }
 
 // AppendVerticesAndIndicesForStroke appends vertices and indices to render a stroke of this path and returns them.
@@ -486,7 +706,16 @@ func (p *Path) AppendVerticesAndIndicesForStroke(vertices []ebiten.Vertex, indic
 		return vertices, indices
 	}
 
-	for _, subpath := range p.subpaths {
+	p = p.flatten(op.Tolerance)
+	subpaths := p.subpaths
+	if dashes, ok := normalizeDashes(op.Dashes); ok {
+		subpaths = nil
+		for _, subpath := range p.subpaths {
+			subpaths = appendDashedSubpaths(subpaths, subpath, dashes, op.DashOffset)
+		}
+	}
+
+	for _, subpath := range subpaths {
 		if subpath.pointCount() < 2 {
 			continue
 		}
@@ -603,7 +832,7 @@ func (p *Path) AppendVerticesAndIndicesForStroke(vertices []ebiten.Vertex, indic
 				vertices, indices = tri.AppendVerticesAndIndicesForFilling(vertices, indices)
 
 			case LineJoinRound:
-				var arc Path
+				arc := Path{tolerance: op.Tolerance}
 				arc.MoveTo(c.x, c.y)
 				if da < math.Pi {
 					arc.Arc(c.x, c.y, op.Width/2, a0, a1, Clockwise)
@@ -635,7 +864,7 @@ func (p *Path) AppendVerticesAndIndicesForStroke(vertices []ebiten.Vertex, indic
 					y: (startR[0].y + startR[2].y) / 2,
 				}
 				a := float32(math.Atan2(float64(startR[0].y-startR[2].y), float64(startR[0].x-startR[2].x)))
-				var arc Path
+				arc := Path{tolerance: op.Tolerance}
 				arc.MoveTo(startR[0].x, startR[0].y)
 				arc.Arc(c.x, c.y, op.Width/2, a, a+math.Pi, CounterClockwise)
 				vertices, indices = arc.AppendVerticesAndIndicesForFilling(vertices, indices)
@@ -646,7 +875,7 @@ func (p *Path) AppendVerticesAndIndicesForStroke(vertices []ebiten.Vertex, indic
 					y: (endR[1].y + endR[3].y) / 2,
 				}
 				a := float32(math.Atan2(float64(endR[1].y-endR[3].y), float64(endR[1].x-endR[3].x)))
-				var arc Path
+				arc := Path{tolerance: op.Tolerance}
 				arc.MoveTo(endR[1].x, endR[1].y)
 				arc.Arc(c.x, c.y, op.Width/2, a, a+math.Pi, Clockwise)
 				vertices, indices = arc.AppendVerticesAndIndicesForFilling(vertices, indices)
@@ -0,0 +1,345 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vector
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// SVGPathError represents an error of ParseSVGPath.
+type SVGPathError struct {
+	// Offset is the byte offset in the path data where the error happens.
+	Offset int
+
+	// Message is the description of the error.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *SVGPathError) Error() string {
+	return fmt.Sprintf("vector: invalid SVG path data at offset %d: %s", e.Offset, e.Message)
+}
+
+// ParseSVGPath parses the SVG path data, e.g. the value of the d attribute of an SVG path element, and returns a new Path.
+//
+// ParseSVGPath supports the full grammar of the path data including absolute and relative commands,
+// implicit repetitions of commands, elliptical arcs, and numbers in scientific notation.
+// For details, see https://www.w3.org/TR/SVG11/paths.html#PathData.
+//
+// If the path data is invalid, ParseSVGPath returns an *SVGPathError with the position of the error.
+// An empty path data results in an empty Path.
+func ParseSVGPath(d string) (*Path, error) {
+	p := &svgPathParser{
+		src: d,
+	}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return &p.path, nil
+}
+
+type svgPathParser struct {
+	src string
+	pos int
+
+	path Path
+
+	// (x, y) is the current point, and (startX, startY) is the start point of the current subpath.
+	x, y           float32
+	startX, startY float32
+
+	// (ctrlX, ctrlY) is the last control point for the smooth curve commands.
+	ctrlX, ctrlY float32
+
+	// closed reports whether the current subpath is closed by Z.
+	// A drawing command after Z starts a new subpath at the start point of the closed subpath.
+	closed bool
+}
+
+func (p *svgPathParser) errorf(offset int, format string, args ...any) error {
+	return &SVGPathError{
+		Offset:  offset,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func isSVGWhitespace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func (p *svgPathParser) skipWhitespaces() {
+	for p.pos < len(p.src) && isSVGWhitespace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+// skipSeparator skips whitespaces and at most one comma.
+func (p *svgPathParser) skipSeparator() {
+	p.skipWhitespaces()
+	if p.pos < len(p.src) && p.src[p.pos] == ',' {
+		p.pos++
+		p.skipWhitespaces()
+	}
+}
+
+// hasNumber reports whether the next token is a number.
+func (p *svgPathParser) hasNumber() bool {
+	if p.pos >= len(p.src) {
+		return false
+	}
+	c := p.src[p.pos]
+	return c == '+' || c == '-' || c == '.' || ('0' <= c && c <= '9')
+}
+
+func (p *svgPathParser) number() (float32, error) {
+	start := p.pos
+	i := p.pos
+	if i < len(p.src) && (p.src[i] == '+' || p.src[i] == '-') {
+		i++
+	}
+	var digits int
+	for i < len(p.src) && '0' <= p.src[i] && p.src[i] <= '9' {
+		i++
+		digits++
+	}
+	if i < len(p.src) && p.src[i] == '.' {
+		i++
+		for i < len(p.src) && '0' <= p.src[i] && p.src[i] <= '9' {
+			i++
+			digits++
+		}
+	}
+	if digits == 0 {
+		return 0, p.errorf(start, "a number is expected")
+	}
+	if i < len(p.src) && (p.src[i] == 'e' || p.src[i] == 'E') {
+		j := i + 1
+		if j < len(p.src) && (p.src[j] == '+' || p.src[j] == '-') {
+			j++
+		}
+		var expDigits int
+		for j < len(p.src) && '0' <= p.src[j] && p.src[j] <= '9' {
+			j++
+			expDigits++
+		}
+		if expDigits == 0 {
+			return 0, p.errorf(i, "an exponent is expected")
+		}
+		i = j
+	}
+
+	v, err := strconv.ParseFloat(p.src[start:i], 32)
+	if err != nil {
+		return 0, p.errorf(start, "invalid number %q", p.src[start:i])
+	}
+	p.pos = i
+	p.skipSeparator()
+	return float32(v), nil
+}
+
+func (p *svgPathParser) flag() (bool, error) {
+	if p.pos >= len(p.src) || (p.src[p.pos] != '0' && p.src[p.pos] != '1') {
+		return false, p.errorf(p.pos, "a flag (0 or 1) is expected")
+	}
+	v := p.src[p.pos] == '1'
+	p.pos++
+	p.skipSeparator()
+	return v, nil
+}
+
+// numbers reads n numbers into vs.
+func (p *svgPathParser) numbers(vs []float32) error {
+	for i := range vs {
+		v, err := p.number()
+		if err != nil {
+			return err
+		}
+		vs[i] = v
+	}
+	return nil
+}
+
+func (p *svgPathParser) parse() error {
+	p.skipWhitespaces()
+	if p.pos >= len(p.src) {
+		return nil
+	}
+	if c := p.src[p.pos]; c != 'M' && c != 'm' {
+		return p.errorf(p.pos, "the path data must start with M or m, got %q", c)
+	}
+
+	var prev byte
+	for {
+		p.skipWhitespaces()
+		if p.pos >= len(p.src) {
+			return nil
+		}
+		offset := p.pos
+		cmd := p.src[p.pos]
+		p.pos++
+		p.skipWhitespaces()
+
+		if cmd == 'Z' || cmd == 'z' {
+			p.path.Close()
+			p.x, p.y = p.startX, p.startY
+			p.closed = true
+			prev = cmd
+			continue
+		}
+
+		// Execute the command at least once, and repeat it while numbers follow.
+		for first := true; first || p.hasNumber(); first = false {
+			if err := p.command(cmd, offset, prev); err != nil {
+				return err
+			}
+			prev = cmd
+			// An implicit command after M or m is L or l.
+			if cmd == 'M' {
+				cmd = 'L'
+			} else if cmd == 'm' {
+				cmd = 'l'
+			}
+		}
+	}
+}
+
+// ensureSubpath starts a new subpath at the start point of the closed subpath if needed.
+func (p *svgPathParser) ensureSubpath() {
+	if !p.closed {
+		return
+	}
+	p.path.MoveTo(p.x, p.y)
+	p.closed = false
+}
+
+func (p *svgPathParser) command(cmd byte, offset int, prev byte) error {
+	var ox, oy float32
+	relative := 'a' <= cmd && cmd <= 'z'
+	if relative {
+		ox, oy = p.x, p.y
+	}
+
+	var vs [6]float32
+	switch cmd {
+	case 'M', 'm':
+		if err := p.numbers(vs[:2]); err != nil {
+			return err
+		}
+		p.x, p.y = ox+vs[0], oy+vs[1]
+		p.startX, p.startY = p.x, p.y
+		p.path.MoveTo(p.x, p.y)
+		p.closed = false
+
+	case 'L', 'l':
+		if err := p.numbers(vs[:2]); err != nil {
+			return err
+		}
+		p.ensureSubpath()
+		p.x, p.y = ox+vs[0], oy+vs[1]
+		p.path.LineTo(p.x, p.y)
+
+	case 'H', 'h':
+		if err := p.numbers(vs[:1]); err != nil {
+			return err
+		}
+		p.ensureSubpath()
+		p.x = ox + vs[0]
+		p.path.LineTo(p.x, p.y)
+
+	case 'V', 'v':
+		if err := p.numbers(vs[:1]); err != nil {
+			return err
+		}
+		p.ensureSubpath()
+		p.y = oy + vs[0]
+		p.path.LineTo(p.x, p.y)
+
+	case 'C', 'c':
+		if err := p.numbers(vs[:6]); err != nil {
+			return err
+		}
+		p.ensureSubpath()
+		p.cubicTo(ox+vs[0], oy+vs[1], ox+vs[2], oy+vs[3], ox+vs[4], oy+vs[5])
+
+	case 'S', 's':
+		if err := p.numbers(vs[:4]); err != nil {
+			return err
+		}
+		p.ensureSubpath()
+		// The first control point is the reflection of the previous second control point.
+		x1, y1 := p.x, p.y
+		if prev == 'C' || prev == 'c' || prev == 'S' || prev == 's' {
+			x1, y1 = 2*p.x-p.ctrlX, 2*p.y-p.ctrlY
+		}
+		p.cubicTo(x1, y1, ox+vs[0], oy+vs[1], ox+vs[2], oy+vs[3])
+
+	case 'Q', 'q':
+		if err := p.numbers(vs[:4]); err != nil {
+			return err
+		}
+		p.ensureSubpath()
+		p.quadTo(ox+vs[0], oy+vs[1], ox+vs[2], oy+vs[3])
+
+	case 'T', 't':
+		if err := p.numbers(vs[:2]); err != nil {
+			return err
+		}
+		p.ensureSubpath()
+		// The control point is the reflection of the previous control point.
+		x1, y1 := p.x, p.y
+		if prev == 'Q' || prev == 'q' || prev == 'T' || prev == 't' {
+			x1, y1 = 2*p.x-p.ctrlX, 2*p.y-p.ctrlY
+		}
+		p.quadTo(x1, y1, ox+vs[0], oy+vs[1])
+
+	case 'A', 'a':
+		if err := p.numbers(vs[:3]); err != nil {
+			return err
+		}
+		largeArc, err := p.flag()
+		if err != nil {
+			return err
+		}
+		sweep, err := p.flag()
+		if err != nil {
+			return err
+		}
+		if err := p.numbers(vs[3:5]); err != nil {
+			return err
+		}
+		p.ensureSubpath()
+		p.x, p.y = ox+vs[3], oy+vs[4]
+		p.path.EllipticalArcTo(vs[0], vs[1], vs[2]*math.Pi/180, largeArc, sweep, p.x, p.y)
+
+	default:
+		return p.errorf(offset, "unknown command %q", cmd)
+	}
+
+	return nil
+}
+
+func (p *svgPathParser) cubicTo(x1, y1, x2, y2, x3, y3 float32) {
+	p.path.CubicTo(x1, y1, x2, y2, x3, y3)
+	p.ctrlX, p.ctrlY = x2, y2
+	p.x, p.y = x3, y3
+}
+
+func (p *svgPathParser) quadTo(x1, y1, x2, y2 float32) {
+	p.path.QuadTo(x1, y1, x2, y2)
+	p.ctrlX, p.ctrlY = x1, y1
+	p.x, p.y = x2, y2
+}
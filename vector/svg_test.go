@@ -0,0 +1,121 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vector_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+func pathVertices(t *testing.T, d string) []float32 {
+	t.Helper()
+	path, err := vector.ParseSVGPath(d)
+	if err != nil {
+		t.Fatalf("ParseSVGPath(%q): %v", d, err)
+	}
+	vs, _ := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	var r []float32
+	for _, v := range vs {
+		r = append(r, v.DstX, v.DstY)
+	}
+	return r
+}
+
+func TestParseSVGPath(t *testing.T) {
+	// Each pair has the same path in different notations, e.g. a compact path exported by an editor and
+	// its canonical absolute form.
+	testCases := []struct {
+		In   string
+		Want string
+	}{
+		{
+			// A circle icon with relative and smooth curves.
+			In:   "M12 2C6.48 2 2 6.48 2 12s4.48 10 10 10 10-4.48 10-10S17.52 2 12 2z",
+			Want: "M12,2 C6.48,2 2,6.48 2,12 C2,17.52 6.48,22 12,22 C17.52,22 22,17.52 22,12 C22,6.48 17.52,2 12,2 Z",
+		},
+		{
+			// Relative moves and implicit line commands.
+			In:   "m10 10h5v5h-5z m10 0 l5 0 0 5z",
+			Want: "M10 10L15 10L15 15L10 15Z M20 10L25 10L25 15Z",
+		},
+		{
+			// Smooth quadratic curves.
+			In:   "M0 0q5 10 10 0t10 0",
+			Want: "M0 0Q5 10 10 0Q15 -10 20 0",
+		},
+		{
+			// Arcs with compact flags.
+			In:   "M0,0a5,5 0 1,0 10,0a5 5 0 105e0-0",
+			Want: "M0 0A5 5 0 1 0 10 0A5 5 0 1 0 15 0",
+		},
+		{
+			// Numbers in scientific notation and without separators.
+			In:   "M1e1.5L-.5 0 2E+1-1e-1",
+			Want: "M10 0.5L-0.5 0L20 -0.1",
+		},
+		{
+			// A line after Z starts at the start point of the closed subpath.
+			In:   "M0 0L10 0L10 10ZL5 5",
+			Want: "M0 0L10 0L10 10Z M0 0L5 5",
+		},
+	}
+	for _, tc := range testCases {
+		got := pathVertices(t, tc.In)
+		want := pathVertices(t, tc.Want)
+		if len(got) != len(want) {
+			t.Errorf("ParseSVGPath(%q): len(vertices): got: %d, want: %d", tc.In, len(got), len(want))
+			continue
+		}
+		for i := range got {
+			if math.Abs(float64(got[i]-want[i])) > 1e-3 {
+				t.Errorf("ParseSVGPath(%q): got: %v, want: %v", tc.In, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestParseSVGPathError(t *testing.T) {
+	testCases := []struct {
+		In     string
+		Offset int
+	}{
+		{In: "L0 0", Offset: 0},
+		{In: "M0 0 L", Offset: 6},
+		{In: "M0 0 X 1", Offset: 5},
+		{In: "M0 0 L1e 2", Offset: 7},
+		{In: "M 0 0 A 1 1 0 2 0 1 1", Offset: 14},
+		{In: "M0 0z1", Offset: 5},
+		{In: "M0 0 L 1 ,, 2", Offset: 10},
+	}
+	for _, tc := range testCases {
+		_, err := vector.ParseSVGPath(tc.In)
+		var svgErr *vector.SVGPathError
+		if !errors.As(err, &svgErr) {
+			t.Errorf("ParseSVGPath(%q): got: %v, want: *SVGPathError", tc.In, err)
+			continue
+		}
+		if got, want := svgErr.Offset, tc.Offset; got != want {
+			t.Errorf("ParseSVGPath(%q): offset: got: %d, want: %d (%v)", tc.In, got, want, err)
+		}
+	}
+
+	if _, err := vector.ParseSVGPath(" \n"); err != nil {
+		t.Errorf("ParseSVGPath with an empty path: got: %v, want: nil", err)
+	}
+}
@@ -37,7 +37,27 @@ func init() {
 	whiteImage.WritePixels(pix)
 }
 
-func drawVerticesForUtil(dst *ebiten.Image, vs []ebiten.Vertex, is []uint16, clr color.Color, antialias bool) {
+// FillRule is the rule whether an overlapped region is rendered or not.
+type FillRule int
+
+const (
+	// FillRuleNonZero means that triangles are rendered based on the non-zero rule.
+	// If and only if the number of overlaps is not 0, the region is rendered.
+	FillRuleNonZero FillRule = iota
+
+	// FillRuleEvenOdd means that triangles are rendered based on the even-odd rule.
+	// If and only if the number of overlaps is odd, the region is rendered.
+	FillRuleEvenOdd
+)
+
+func (f FillRule) ebitenFillRule() ebiten.FillRule {
+	if f == FillRuleEvenOdd {
+		return ebiten.EvenOdd
+	}
+	return ebiten.NonZero
+}
+
+func drawVerticesForUtil(dst *ebiten.Image, vs []ebiten.Vertex, is []uint16, clr color.Color, antialias bool, fillRule ebiten.FillRule) {
 	r, g, b, a := clr.RGBA()
 	for i := range vs {
 		vs[i].SrcX = 1
@@ -51,6 +71,7 @@ func drawVerticesForUtil(dst *ebiten.Image, vs []ebiten.Vertex, is []uint16, clr
 	op := &ebiten.DrawTrianglesOptions{}
 	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
 	op.AntiAlias = antialias
+	op.FillRule = fillRule
 	dst.DrawTriangles(vs, is, whiteSubImage, op)
 }
 
@@ -65,7 +86,7 @@ func StrokeLine(dst *ebiten.Image, x0, y0, x1, y1 float32, strokeWidth float32,
 	strokeOp.Width = strokeWidth
 	vs, is := path.AppendVerticesAndIndicesForStroke(nil, nil, strokeOp)
 
-	drawVerticesForUtil(dst, vs, is, clr, antialias)
+	drawVerticesForUtil(dst, vs, is, clr, antialias, ebiten.FillAll)
 }
 
 // DrawFilledRect fills a rectangle with the specified width and color.
@@ -77,7 +98,7 @@ func DrawFilledRect(dst *ebiten.Image, x, y, width, height float32, clr color.Co
 	path.LineTo(x+width, y)
 	vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
 
-	drawVerticesForUtil(dst, vs, is, clr, antialias)
+	drawVerticesForUtil(dst, vs, is, clr, antialias, ebiten.FillAll)
 }
 
 // StrokeRect strokes a rectangle with the specified width and color.
@@ -96,7 +117,7 @@ func StrokeRect(dst *ebiten.Image, x, y, width, height float32, strokeWidth floa
 	strokeOp.MiterLimit = 10
 	vs, is := path.AppendVerticesAndIndicesForStroke(nil, nil, strokeOp)
 
-	drawVerticesForUtil(dst, vs, is, clr, antialias)
+	drawVerticesForUtil(dst, vs, is, clr, antialias, ebiten.FillAll)
 }
 
 // DrawFilledCircle fills a circle with the specified center position (cx, cy), the radius (r), width and color.
@@ -105,7 +126,7 @@ func DrawFilledCircle(dst *ebiten.Image, cx, cy, r float32, clr color.Color, ant
 	path.Arc(cx, cy, r, 0, 2*math.Pi, Clockwise)
 	vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
 
-	drawVerticesForUtil(dst, vs, is, clr, antialias)
+	drawVerticesForUtil(dst, vs, is, clr, antialias, ebiten.FillAll)
 }
 
 // StrokeCircle strokes a circle with the specified center position (cx, cy), the radius (r), width and color.
@@ -120,5 +141,29 @@ func StrokeCircle(dst *ebiten.Image, cx, cy, r float32, strokeWidth float32, clr
 	strokeOp.Width = strokeWidth
 	vs, is := path.AppendVerticesAndIndicesForStroke(nil, nil, strokeOp)
 
-	drawVerticesForUtil(dst, vs, is, clr, antialias)
+	drawVerticesForUtil(dst, vs, is, clr, antialias, ebiten.FillAll)
+}
+
+// DrawFilledPath fills the specified path with the specified color.
+//
+// fillRule specifies how the overlapped regions of the path, e.g. holes or self-intersections, are rendered.
+// With FillRuleNonZero, a hole is rendered as a hole only when its subpath winds in the opposite direction of the outer subpath.
+// With FillRuleEvenOdd, a hole is rendered as a hole regardless of the winding directions.
+//
+// The curves are flattened with DefaultTolerance. To specify the tolerance, use FillPath with FillOptions.Tolerance.
+func DrawFilledPath(dst *ebiten.Image, path *Path, clr color.Color, antialias bool, fillRule FillRule) {
+	vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	drawVerticesForUtil(dst, vs, is, clr, antialias, fillRule.ebitenFillRule())
+}
+
+// DrawStrokedPath strokes the specified path with the specified color and the stroke options.
+//
+// If antialias is true, the edges are anti-aliased at the cost of more rendering work. Otherwise, the edges are hard.
+// The curves are flattened with options.Tolerance.
+// A smaller tolerance makes more vertices, which increases the cost on CPU.
+//
+// clr has be to be a solid (non-transparent) color.
+func DrawStrokedPath(dst *ebiten.Image, path *Path, clr color.Color, antialias bool, options *StrokeOptions) {
+	vs, is := path.AppendVerticesAndIndicesForStroke(nil, nil, options)
+	drawVerticesForUtil(dst, vs, is, clr, antialias, ebiten.FillAll)
 }
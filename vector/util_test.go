@@ -16,6 +16,7 @@ package vector_test
 
 import (
 	"image/color"
+	"math"
 	"testing"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -35,3 +36,398 @@ func TestLine0(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestStrokeDashes(t *testing.T) {
+	var path vector.Path
+	path.MoveTo(0, 0)
+	path.LineTo(100, 0)
+
+	testCases := []struct {
+		Dashes      []float32
+		DashOffset  float32
+		VertexCount int
+	}{
+		{
+			Dashes:      nil,
+			VertexCount: 4,
+		},
+		{
+			Dashes:      []float32{10, 10},
+			VertexCount: 4 * 5,
+		},
+		{
+			Dashes:      []float32{10, 10},
+			DashOffset:  5,
+			VertexCount: 4 * 6,
+		},
+		{
+			Dashes:      []float32{10},
+			VertexCount: 4 * 5,
+		},
+		{
+			Dashes:      []float32{0, 25},
+			VertexCount: 4 * 5,
+		},
+		{
+			Dashes:      []float32{0, 0},
+			VertexCount: 4,
+		},
+		{
+			Dashes:      []float32{10, -1},
+			VertexCount: 4,
+		},
+	}
+	for _, tc := range testCases {
+		op := &vector.StrokeOptions{
+			Width:      2,
+			Dashes:     tc.Dashes,
+			DashOffset: tc.DashOffset,
+		}
+		vs, is := path.AppendVerticesAndIndicesForStroke(nil, nil, op)
+		if got, want := len(vs), tc.VertexCount; got != want {
+			t.Errorf("len(vertices) for %v (offset: %f): got: %d, want: %d", tc.Dashes, tc.DashOffset, got, want)
+		}
+		if got, want := len(is), tc.VertexCount/4*6; got != want {
+			t.Errorf("len(indices) for %v (offset: %f): got: %d, want: %d", tc.Dashes, tc.DashOffset, got, want)
+		}
+	}
+}
+
+func TestEllipticalArcTo(t *testing.T) {
+	// An ellipse centered at (100, 50) with the radii (40, 20).
+	var path vector.Path
+	path.MoveTo(60, 50)
+	path.EllipticalArcTo(40, 20, 0, false, true, 140, 50)
+	path.EllipticalArcTo(40, 20, 0, false, true, 60, 50)
+	path.Close()
+
+	vs, _ := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	if len(vs) < 8 {
+		t.Fatalf("len(vertices): got: %d, want: >= 8", len(vs))
+	}
+	for _, v := range vs {
+		dx := (float64(v.DstX) - 100) / 40
+		dy := (float64(v.DstY) - 50) / 20
+		if d := math.Hypot(dx, dy); math.Abs(d-1) > 0.01 {
+			t.Errorf("vertex (%f, %f) is not on the ellipse", v.DstX, v.DstY)
+		}
+	}
+
+	// With sweep, the arc from the left to the right goes through the top.
+	path = vector.Path{}
+	path.MoveTo(0, 0)
+	path.EllipticalArcTo(50, 50, 0, false, true, 100, 0)
+	vs, _ = path.AppendVerticesAndIndicesForFilling(nil, nil)
+	for _, v := range vs {
+		if v.DstY > 0.01 {
+			t.Errorf("vertex (%f, %f) must not be below the chord", v.DstX, v.DstY)
+		}
+	}
+}
+
+func TestDrawFilledPathFillRule(t *testing.T) {
+	// A donut with two subpaths in the same winding direction.
+	var donut vector.Path
+	donut.Arc(32, 32, 24, 0, 2*math.Pi, vector.Clockwise)
+	donut.Close()
+	donut.Arc(32, 32, 12, 0, 2*math.Pi, vector.Clockwise)
+	donut.Close()
+
+	// A five-pointed star with self-intersections.
+	var star vector.Path
+	for i := 0; i < 5; i++ {
+		a := float64(i)*4*math.Pi/5 - math.Pi/2
+		x := float32(32 + 28*math.Cos(a))
+		y := float32(32 + 28*math.Sin(a))
+		if i == 0 {
+			star.MoveTo(x, y)
+			continue
+		}
+		star.LineTo(x, y)
+	}
+	star.Close()
+
+	testCases := []struct {
+		Name     string
+		Path     *vector.Path
+		FillRule vector.FillRule
+		Center   bool
+	}{
+		{
+			Name:     "donut",
+			Path:     &donut,
+			FillRule: vector.FillRuleNonZero,
+			Center:   true,
+		},
+		{
+			Name:     "donut",
+			Path:     &donut,
+			FillRule: vector.FillRuleEvenOdd,
+			Center:   false,
+		},
+		{
+			Name:     "star",
+			Path:     &star,
+			FillRule: vector.FillRuleNonZero,
+			Center:   true,
+		},
+		{
+			Name:     "star",
+			Path:     &star,
+			FillRule: vector.FillRuleEvenOdd,
+			Center:   false,
+		},
+	}
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	for _, tc := range testCases {
+		dst := ebiten.NewImage(64, 64)
+		vector.DrawFilledPath(dst, tc.Path, white, false, tc.FillRule)
+
+		want := color.RGBA{}
+		if tc.Center {
+			want = white
+		}
+		if got := dst.At(32, 32); got != want {
+			t.Errorf("%s (fill rule: %d): center: got: %v, want: %v", tc.Name, tc.FillRule, got, want)
+		}
+		if got := dst.At(0, 0); got != (color.RGBA{}) {
+			t.Errorf("%s (fill rule: %d): corner: got: %v, want: %v", tc.Name, tc.FillRule, got, color.RGBA{})
+		}
+	}
+
+	// The donut's ring and the star's points are rendered regardless of the fill rule.
+	for _, fillRule := range []vector.FillRule{vector.FillRuleNonZero, vector.FillRuleEvenOdd} {
+		dst := ebiten.NewImage(64, 64)
+		vector.DrawFilledPath(dst, &donut, white, false, fillRule)
+		if got := dst.At(32+18, 32); got != white {
+			t.Errorf("donut (fill rule: %d): ring: got: %v, want: %v", fillRule, got, white)
+		}
+
+		dst.Clear()
+		vector.DrawFilledPath(dst, &star, white, false, fillRule)
+		if got := dst.At(32, 8); got != white {
+			t.Errorf("star (fill rule: %d): point: got: %v, want: %v", fillRule, got, white)
+		}
+	}
+}
+
+func rectPath(x, y, width, height float32) *vector.Path {
+	var path vector.Path
+	path.MoveTo(x, y)
+	path.LineTo(x+width, y)
+	path.LineTo(x+width, y+height)
+	path.LineTo(x, y+height)
+	path.Close()
+	return &path
+}
+
+func filledArea(path *vector.Path) float64 {
+	vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	var area float64
+	for i := 0; i < len(is); i += 3 {
+		v0, v1, v2 := vs[is[i]], vs[is[i+1]], vs[is[i+2]]
+		area += float64((v1.DstX-v0.DstX)*(v2.DstY-v0.DstY)-(v1.DstY-v0.DstY)*(v2.DstX-v0.DstX)) / 2
+	}
+	return math.Abs(area)
+}
+
+func TestPathBooleanOperations(t *testing.T) {
+	a := rectPath(0, 0, 10, 10)
+	testCases := []struct {
+		Name string
+		Path *vector.Path
+		Area float64
+	}{
+		{
+			Name: "union",
+			Path: a.Union(rectPath(5, 5, 10, 10), vector.FillRuleNonZero),
+			Area: 175,
+		},
+		{
+			Name: "intersect",
+			Path: a.Intersect(rectPath(5, 5, 10, 10), vector.FillRuleNonZero),
+			Area: 25,
+		},
+		{
+			Name: "subtract",
+			Path: a.Subtract(rectPath(5, 5, 10, 10), vector.FillRuleNonZero),
+			Area: 75,
+		},
+		{
+			Name: "xor",
+			Path: a.Xor(rectPath(5, 5, 10, 10), vector.FillRuleNonZero),
+			Area: 150,
+		},
+		{
+			Name: "union with a shared edge",
+			Path: a.Union(rectPath(10, 0, 10, 10), vector.FillRuleNonZero),
+			Area: 200,
+		},
+		{
+			Name: "union with a nearly shared edge",
+			Path: a.Union(rectPath(10.001, 0, 10, 10), vector.FillRuleNonZero),
+			Area: 200,
+		},
+		{
+			Name: "intersect with a shared edge",
+			Path: a.Intersect(rectPath(10, 0, 10, 10), vector.FillRuleNonZero),
+			Area: 0,
+		},
+		{
+			Name: "hole",
+			Path: a.Subtract(rectPath(3, 3, 4, 4), vector.FillRuleNonZero),
+			Area: 84,
+		},
+		{
+			Name: "xor with itself",
+			Path: a.Xor(a, vector.FillRuleNonZero),
+			Area: 0,
+		},
+	}
+	for _, tc := range testCases {
+		if got, want := filledArea(tc.Path), tc.Area; math.Abs(got-want) > 1e-3 {
+			t.Errorf("%s: area: got: %f, want: %f", tc.Name, got, want)
+		}
+	}
+
+	// The result of a hole is rendered with the non-zero rule as expected.
+	dst := ebiten.NewImage(16, 16)
+	vector.DrawFilledPath(dst, a.Subtract(rectPath(3, 3, 4, 4), vector.FillRuleNonZero), color.White, false, vector.FillRuleNonZero)
+	if got, want := dst.At(5, 5), (color.RGBA{}); got != want {
+		t.Errorf("hole: got: %v, want: %v", got, want)
+	}
+	if got, want := dst.At(1, 1), (color.RGBA{0xff, 0xff, 0xff, 0xff}); got != want {
+		t.Errorf("ring: got: %v, want: %v", got, want)
+	}
+}
+
+func TestFillPathGradient(t *testing.T) {
+	path := rectPath(0, 0, 16, 16)
+	stops := []vector.GradientStop{
+		{Offset: 0, Color: color.RGBA{0, 0, 0, 0xff}},
+		{Offset: 1, Color: color.RGBA{0xff, 0xff, 0xff, 0xff}},
+	}
+
+	dst := ebiten.NewImage(32, 16)
+	vector.FillPath(dst, path, vector.LinearGradient{X0: 0, Y0: 0, X1: 16, Y1: 0, Stops: stops}, nil)
+	var prev uint8
+	for x := 0; x < 16; x++ {
+		r := dst.At(x, 8).(color.RGBA).R
+		if x > 0 && r < prev {
+			t.Errorf("linear: dst.At(%d, 8).R: got: %d, want: >= %d", x, r, prev)
+		}
+		prev = r
+	}
+	if got := dst.At(0, 8).(color.RGBA).R; got > 0x10 {
+		t.Errorf("linear: dst.At(0, 8).R: got: %d, want: <= 0x10", got)
+	}
+	if got := dst.At(15, 8).(color.RGBA).R; got < 0xe0 {
+		t.Errorf("linear: dst.At(15, 8).R: got: %d, want: >= 0xe0", got)
+	}
+
+	// The gradient is transformed with the path.
+	dst.Clear()
+	op := &vector.FillOptions{}
+	op.GeoM.Translate(16, 0)
+	vector.FillPath(dst, path, vector.LinearGradient{X0: 0, Y0: 0, X1: 16, Y1: 0, Stops: stops}, op)
+	if got := dst.At(16, 8).(color.RGBA).R; got > 0x10 {
+		t.Errorf("translated: dst.At(16, 8).R: got: %d, want: <= 0x10", got)
+	}
+	if got, want := dst.At(8, 8), (color.RGBA{}); got != want {
+		t.Errorf("translated: dst.At(8, 8): got: %v, want: %v", got, want)
+	}
+
+	// With SpreadRepeat, the gradient repeats.
+	dst.Clear()
+	vector.FillPath(dst, path, vector.LinearGradient{X0: 0, Y0: 0, X1: 8, Y1: 0, Stops: stops, Spread: vector.SpreadRepeat}, nil)
+	if got := dst.At(8, 8).(color.RGBA).R; got > 0x20 {
+		t.Errorf("repeat: dst.At(8, 8).R: got: %d, want: <= 0x20", got)
+	}
+
+	// A radial gradient is dark at the center and bright at the edge.
+	dst.Clear()
+	vector.FillPath(dst, path, vector.RadialGradient{CX: 8, CY: 8, Radius: 8, Stops: stops}, nil)
+	if center, edge := dst.At(8, 8).(color.RGBA).R, dst.At(8, 0).(color.RGBA).R; center >= edge {
+		t.Errorf("radial: center: %d, edge: %d, want: center < edge", center, edge)
+	}
+
+	// A solid color.
+	dst.Clear()
+	vector.FillPath(dst, path, vector.ColorFill{Color: color.RGBA{0xff, 0, 0, 0xff}}, nil)
+	if got, want := dst.At(8, 8), (color.RGBA{0xff, 0, 0, 0xff}); got != want {
+		t.Errorf("color: dst.At(8, 8): got: %v, want: %v", got, want)
+	}
+}
+
+func TestPathContains(t *testing.T) {
+	// A shape with curves and a hole in the same winding direction.
+	var path vector.Path
+	path.MoveTo(8, 32)
+	path.CubicTo(8, 0, 56, 0, 56, 32)
+	path.QuadTo(56, 60, 32, 60)
+	path.LineTo(8, 60)
+	path.Close()
+	path.Arc(32, 36, 10, 0, 2*math.Pi, vector.Clockwise)
+	path.Close()
+
+	if got, want := path.Contains(32, 36, vector.FillRuleNonZero), true; got != want {
+		t.Errorf("Contains(32, 36, FillRuleNonZero): got: %v, want: %v", got, want)
+	}
+	if got, want := path.Contains(32, 36, vector.FillRuleEvenOdd), false; got != want {
+		t.Errorf("Contains(32, 36, FillRuleEvenOdd): got: %v, want: %v", got, want)
+	}
+	if got, want := path.Contains(2, 2, vector.FillRuleNonZero), false; got != want {
+		t.Errorf("Contains(2, 2, FillRuleNonZero): got: %v, want: %v", got, want)
+	}
+
+	minX, minY, maxX, maxY := path.Bounds()
+	if minX != 8 || maxX != 56 || maxY != 60 || minY < 8 || minY > 12 {
+		t.Errorf("Bounds(): got: (%v, %v, %v, %v)", minX, minY, maxX, maxY)
+	}
+
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	for _, fillRule := range []vector.FillRule{vector.FillRuleNonZero, vector.FillRuleEvenOdd} {
+		dst := ebiten.NewImage(64, 64)
+		vector.DrawFilledPath(dst, &path, white, false, fillRule)
+
+		for j := 0; j < 64; j++ {
+			for i := 0; i < 64; i++ {
+				// Skip pixels on the edges.
+				c := path.Contains(float32(i), float32(j), fillRule)
+				if c != path.Contains(float32(i+1), float32(j), fillRule) ||
+					c != path.Contains(float32(i), float32(j+1), fillRule) ||
+					c != path.Contains(float32(i+1), float32(j+1), fillRule) {
+					continue
+				}
+				got := dst.At(i, j) == white
+				if want := path.Contains(float32(i)+0.5, float32(j)+0.5, fillRule); got != want {
+					t.Errorf("fill rule: %d, (%d, %d): got: %v, want: %v", fillRule, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestStrokeTolerance(t *testing.T) {
+	var path vector.Path
+	path.MoveTo(0, 0)
+	path.CubicTo(0, 1000, 1000, 1000, 1000, 0)
+
+	count := func(tolerance float32) int {
+		vs, _ := path.AppendVerticesAndIndicesForStroke(nil, nil, &vector.StrokeOptions{
+			Width:     2,
+			Tolerance: tolerance,
+		})
+		return len(vs)
+	}
+
+	if got, want := count(vector.DefaultTolerance), count(0); got != want {
+		t.Errorf("the number of vertices with DefaultTolerance: got: %d, want: %d", got, want)
+	}
+	if fine, def := count(0.05), count(0); fine <= def {
+		t.Errorf("the number of vertices with a smaller tolerance must be more: got: %d, default: %d", fine, def)
+	}
+	if coarse, def := count(5), count(0); coarse >= def {
+		t.Errorf("the number of vertices with a bigger tolerance must be less: got: %d, default: %d", coarse, def)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image"
+)
+
+// AppendRectVertices appends four vertices for a rectangle to the given slice and returns the extended slice.
+//
+// srcRegion is the region of the source image in the source image's coordinate.
+// The rectangle's upper-left position is at (0, 0) and its size is the same as srcRegion, and then geoM is applied.
+// The vertex colors are colorScale's values, which are premultiplied-alpha colors.
+// Use ColorScaleModePremultipliedAlpha at DrawTriangles or DrawTrianglesShader with these vertices.
+// If geoM or colorScale is nil, the identity is used.
+//
+// The vertices are in the order of the upper-left, the upper-right, the lower-left and the lower-right,
+// which matches with AppendRectIndices.
+//
+// AppendRectVertices is useful to build a mesh of many sprites for one DrawTriangles call reusing a buffer
+// without allocations.
+// For example:
+//
+//	vs = vs[:0]
+//	is = is[:0]
+//	for _, s := range sprites {
+//		is = ebiten.AppendRectIndices(is, uint16(len(vs)))
+//		vs = ebiten.AppendRectVertices(vs, s.region, &s.geoM, nil)
+//	}
+//	op := &ebiten.DrawTrianglesOptions{}
+//	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+//	screen.DrawTriangles(vs, is, spriteSheet, op)
+func AppendRectVertices(vertices []Vertex, srcRegion image.Rectangle, geoM *GeoM, colorScale *ColorScale) []Vertex {
+	var g GeoM
+	if geoM != nil {
+		g = *geoM
+	}
+	var c ColorScale
+	if colorScale != nil {
+		c = *colorScale
+	}
+	cr, cg, cb, ca := c.R(), c.G(), c.B(), c.A()
+
+	sx0, sy0 := float32(srcRegion.Min.X), float32(srcRegion.Min.Y)
+	sx1, sy1 := float32(srcRegion.Max.X), float32(srcRegion.Max.Y)
+	w, h := float64(srcRegion.Dx()), float64(srcRegion.Dy())
+
+	x0, y0 := g.Apply(0, 0)
+	x1, y1 := g.Apply(w, 0)
+	x2, y2 := g.Apply(0, h)
+	x3, y3 := g.Apply(w, h)
+
+	return append(vertices,
+		Vertex{DstX: float32(x0), DstY: float32(y0), SrcX: sx0, SrcY: sy0, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+		Vertex{DstX: float32(x1), DstY: float32(y1), SrcX: sx1, SrcY: sy0, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+		Vertex{DstX: float32(x2), DstY: float32(y2), SrcX: sx0, SrcY: sy1, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+		Vertex{DstX: float32(x3), DstY: float32(y3), SrcX: sx1, SrcY: sy1, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca})
+}
+
+// AppendRectIndices appends six indices for a rectangle to the given slice and returns the extended slice.
+//
+// base is the index of the first vertex of the rectangle, which is usually the length of the vertices
+// before AppendRectVertices is called.
+func AppendRectIndices(indices []uint16, base uint16) []uint16 {
+	return append(indices, base, base+1, base+2, base+1, base+2, base+3)
+}
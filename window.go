@@ -213,6 +213,37 @@ func SetWindowSizeLimits(minw, minh, maxw, maxh int) {
 	ui.Get().Window().SetSizeLimits(minw, minh, maxw, maxh)
 }
 
+// WindowAspectRatio returns the aspect ratio of the window's client area that is kept when a user resizes the window.
+// A negative value indicates the aspect ratio is not limited.
+//
+// WindowAspectRatio returns (-1, -1) if the platform is not a desktop.
+//
+// WindowAspectRatio is concurrent-safe.
+func WindowAspectRatio() (numer, denom int) {
+	return ui.Get().Window().AspectRatio()
+}
+
+// SetWindowAspectRatio sets the aspect ratio of the window's client area on desktops.
+// When the aspect ratio is set, the window's client area keeps the ratio numer:denom while a user resizes the window.
+// For example, the common 16:9 aspect ratio is specified as 16 and 9.
+//
+// If numer or denom is not a positive number, the aspect ratio is not limited.
+// The aspect ratio is not limited by default.
+//
+// The aspect ratio affects only resizing by a user. SetWindowSize still sets the given size as it is.
+// The aspect ratio doesn't affect the fullscreen mode.
+//
+// Regardless of the window's aspect ratio, the game screen is always scaled with keeping the aspect ratio of
+// the size that Game.Layout returns, and is centered in the window (letterboxing).
+// Thus, you don't have to adjust the aspect ratio in Game.Layout.
+//
+// SetWindowAspectRatio does nothing if the platform is not a desktop.
+//
+// SetWindowAspectRatio is concurrent-safe.
+func SetWindowAspectRatio(numer, denom int) {
+	ui.Get().Window().SetAspectRatio(numer, denom)
+}
+
 // IsWindowFloating reports whether the window is always shown above all the other windows.
 //
 // IsWindowFloating returns false if the platform is not a desktop.